@@ -111,6 +111,11 @@ export namespace App {
     }
   }
 
+  // reset drops the state of a service so the next use initializes it again
+  export function reset(key: any) {
+    ctx.use().services.delete(key)
+  }
+
   export function info() {
     return ctx.use().info
   }
@@ -2,6 +2,7 @@ import { App } from "../../app/app"
 import { Provider } from "../../provider/provider"
 import { Server } from "../../server/server"
 import { Share } from "../../share/share"
+import { Permission } from "../../permission"
 import { cmd } from "./cmd"
 
 export const ServeCommand = cmd({
@@ -19,6 +20,11 @@ export const ServeCommand = cmd({
         type: "string",
         describe: "hostname to listen on",
         default: "127.0.0.1",
+      })
+      .option("token", {
+        type: "string",
+        describe: "bearer token clients must send",
+        default: process.env["OPENCODE_SERVER_TOKEN"],
       }),
   describe: "starts a headless opencode server",
   handler: async (args) => {
@@ -36,7 +42,10 @@ export const ServeCommand = cmd({
       const server = Server.listen({
         port,
         hostname,
+        token: args.token,
       })
+      // tool calls wait for an attached client to approve them
+      Permission.prompt()
 
       console.log(
         `opencode server listening on http://${server.hostname}:${server.port}`,
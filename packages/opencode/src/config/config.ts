@@ -136,6 +136,12 @@ export namespace Config {
         .optional()
         .describe("Theme name to use for the interface"),
       keybinds: Keybinds.optional().describe("Custom keybind configurations"),
+      aliases: z
+        .record(z.string(), z.string())
+        .optional()
+        .describe(
+          "Slash command aliases, such as c for compact, arguments typed after an alias are appended to its expansion",
+        ),
       autoshare: z
         .boolean()
         .optional()
@@ -23,6 +23,7 @@ import { Config } from "./config/config"
 import { NamedError } from "./util/error"
 import { FormatError } from "./cli/error"
 import { ServeCommand } from "./cli/cmd/serve"
+import { Permission } from "./permission"
 
 const cancel = new AbortController()
 
@@ -83,6 +84,7 @@ const cli = yargs(hideBin(process.argv))
             port: 0,
             hostname: "127.0.0.1",
           })
+          Permission.prompt()
 
           let cmd = ["go", "run", "./main.go"]
           let cwd = url.fileURLToPath(
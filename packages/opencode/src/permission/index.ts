@@ -31,6 +31,7 @@ export namespace Permission {
       const pending: {
         [sessionID: string]: {
           [permissionID: string]: {
+            scope: string
             info: Info
             resolve: () => void
             reject: (e: any) => void
@@ -38,15 +39,19 @@ export namespace Permission {
         }
       } = {}
 
+      // approvals for the rest of a session, by the command or file they
+      // were given for
       const approved: {
         [sessionID: string]: {
-          [permissionID: string]: Info
+          [scope: string]: Info
         }
       } = {}
 
       return {
         pending,
         approved,
+        interactive: false,
+        counter: 0,
       }
     },
     async (state) => {
@@ -58,48 +63,63 @@ export namespace Permission {
     },
   )
 
+  // prompt makes ask wait for a client to answer through respond, without an
+  // attached client every permission is granted
+  export function prompt() {
+    state().interactive = true
+  }
+
+  // scope is what an approval for the session covers: the command or the
+  // file of the call, never the whole tool
+  function scope(input: {
+    id: string
+    title: string
+    metadata: Info["metadata"]
+  }) {
+    const target =
+      input.metadata.command ?? input.metadata.filePath ?? input.title
+    return `${input.id}:${target}`
+  }
+
   export function ask(input: {
     id: Info["id"]
     sessionID: Info["sessionID"]
     title: Info["title"]
     metadata: Info["metadata"]
   }) {
-    return
-    const { pending, approved } = state()
+    const { pending, approved, interactive } = state()
+    if (!interactive) return
     log.info("asking", {
       sessionID: input.sessionID,
       permissionID: input.id,
     })
-    if (approved[input.sessionID]?.[input.id]) {
+    const key = scope(input)
+    if (approved[input.sessionID]?.[key]) {
       log.info("previously approved", {
         sessionID: input.sessionID,
         permissionID: input.id,
       })
       return
     }
+    // several calls of the same tool can wait at once, the tool is kept in
+    // the metadata for clients choosing what to approve
     const info: Info = {
-      id: input.id,
+      id: `${input.id}_${++state().counter}`,
       sessionID: input.sessionID,
       title: input.title,
-      metadata: input.metadata,
+      metadata: { ...input.metadata, tool: input.id },
       time: {
         created: Date.now(),
       },
     }
     pending[input.sessionID] = pending[input.sessionID] || {}
     return new Promise<void>((resolve, reject) => {
-      pending[input.sessionID][input.id] = {
+      pending[input.sessionID][info.id] = {
+        scope: key,
         info,
         resolve,
         reject,
       }
-      setTimeout(() => {
-        respond({
-          sessionID: input.sessionID,
-          permissionID: input.id,
-          response: "always",
-        })
-      }, 1000)
       Bus.publish(Event.Updated, info)
     })
   }
@@ -121,7 +141,7 @@ export namespace Permission {
     match.resolve()
     if (input.response === "always") {
       approved[input.sessionID] = approved[input.sessionID] || {}
-      approved[input.sessionID][input.permissionID] = match.info
+      approved[input.sessionID][match.scope] = match.info
     }
   }
 
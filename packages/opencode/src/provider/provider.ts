@@ -294,6 +294,50 @@ export namespace Provider {
     return state().then((state) => state.providers)
   }
 
+  // reload loads the providers again, picking up credentials saved since
+  export async function reload() {
+    App.reset("provider")
+    return list()
+  }
+
+  // verify lists the models of a provider with key, a cheap authenticated
+  // request telling whether the key works before it is saved. Providers
+  // without a known models endpoint are not checked.
+  export async function verify(providerID: string, key: string) {
+    const provider = (await ModelsDev.get())[providerID]
+    const request = (() => {
+      switch (providerID) {
+        case "anthropic":
+          return new Request("https://api.anthropic.com/v1/models", {
+            headers: { "x-api-key": key, "anthropic-version": "2023-06-01" },
+          })
+        case "google":
+          return new Request(
+            "https://generativelanguage.googleapis.com/v1beta/models",
+            { headers: { "x-goog-api-key": key } },
+          )
+        case "openai":
+          return new Request("https://api.openai.com/v1/models", {
+            headers: { authorization: `Bearer ${key}` },
+          })
+      }
+      // providers with an api are OpenAI compatible
+      if (provider?.api)
+        return new Request(provider.api.replace(/\/$/, "") + "/models", {
+          headers: { authorization: `Bearer ${key}` },
+        })
+    })()
+    if (!request) return
+    const response = await fetch(request).catch((e) => {
+      throw new AuthError({ providerID, message: e.toString() })
+    })
+    if (!response.ok)
+      throw new AuthError({
+        providerID,
+        message: `The key was rejected: ${response.status} ${response.statusText}`,
+      })
+  }
+
   async function getSDK(provider: ModelsDev.Provider) {
     return (async () => {
       using _ = log.time("getSDK", {
@@ -22,4 +22,36 @@ export namespace ProviderTransform {
     }
     return msgs
   }
+
+  const REASONING_BUDGET = {
+    low: 4_000,
+    medium: 16_000,
+    high: 32_000,
+  }
+
+  export function reasoning(
+    providerID: string,
+    modelID: string,
+    effort: "low" | "medium" | "high",
+  ): Record<string, any> {
+    if (providerID === "anthropic" || modelID.includes("anthropic")) {
+      return {
+        anthropic: {
+          thinking: { type: "enabled", budgetTokens: REASONING_BUDGET[effort] },
+        },
+      }
+    }
+    if (providerID === "google") {
+      return {
+        google: {
+          thinkingConfig: { thinkingBudget: REASONING_BUDGET[effort] },
+        },
+      }
+    }
+    return {
+      openai: {
+        reasoningEffort: effort,
+      },
+    }
+  }
 }
@@ -3,11 +3,16 @@ import { Bus } from "../bus"
 import { describeRoute, generateSpecs, openAPISpecs } from "hono-openapi"
 import { Hono } from "hono"
 import { streamSSE } from "hono/streaming"
+import { bearerAuth } from "hono/bearer-auth"
+import { HTTPException } from "hono/http-exception"
 import { Session } from "../session"
 import { resolver, validator as zValidator } from "hono-openapi/zod"
 import { z } from "zod"
 import { Message } from "../session/message"
+import { Audit } from "../session/audit"
+import { Snapshot } from "../session/snapshot"
 import { Provider } from "../provider/provider"
+import { Auth } from "../auth"
 import { App } from "../app/app"
 import { Global } from "../global"
 import { mapValues } from "remeda"
@@ -16,6 +21,10 @@ import { ModelsDev } from "../provider/models"
 import { Ripgrep } from "../external/ripgrep"
 import { Installation } from "../installation"
 import { Config } from "../config/config"
+import { Permission } from "../permission"
+import { Filesystem } from "../util/filesystem"
+import path from "path"
+import fs from "fs/promises"
 
 const ERRORS = {
   400: {
@@ -39,13 +48,48 @@ const ERRORS = {
 export namespace Server {
   const log = Log.create({ service: "server" })
 
+  // Requests retried by a client after a network error carry the key of the
+  // first attempt, and share its result instead of running again
+  const idempotent = new Map<string, Promise<unknown>>()
+  const IDEMPOTENCY_TTL = 10 * 60 * 1000
+
+  function once<T>(key: string | undefined, run: () => Promise<T>) {
+    if (!key) return run()
+    const existing = idempotent.get(key)
+    if (existing) {
+      log.info("idempotent replay", { key })
+      return existing as Promise<T>
+    }
+    const pending = run()
+    idempotent.set(key, pending)
+    const forget = () =>
+      setTimeout(() => idempotent.delete(key), IDEMPOTENCY_TTL)
+    pending.then(forget, forget)
+    return pending
+  }
+
+  // contextFile is the AGENTS.md of the project, the one file clients can
+  // read and write, refused when a symlink points it outside of the project
+  async function contextFile() {
+    const root = await fs.realpath(App.info().path.root)
+    const file = path.join(root, "AGENTS.md")
+    const result = await fs.realpath(file).catch(() => file)
+    if (!Filesystem.contains(root, result))
+      throw new Error("AGENTS.md points outside of the project: " + result)
+    return result
+  }
+
   export type Routes = ReturnType<typeof app>
 
-  function app() {
+  // app builds the routes, requests must carry token as a bearer token when
+  // one is set
+  function app(token?: string) {
     const app = new Hono()
+    const auth = token ? bearerAuth({ token }) : undefined
 
     const result = app
       .onError((err, c) => {
+        if (err instanceof HTTPException) return err.getResponse()
         if (err instanceof NamedError) {
           return c.json(err.toObject(), {
             status: 400,
@@ -69,6 +113,7 @@ export namespace Server {
           duration: Date.now() - start,
         })
       })
+      .use((c, next) => (auth ? auth(c, next) : next()))
       .get(
         "/openapi",
         openAPISpecs(app, {
@@ -180,6 +225,66 @@ export namespace Server {
           return c.json(true)
         },
       )
+      .get(
+        "/app_context",
+        describeRoute({
+          description: "Read the AGENTS.md of the project",
+          responses: {
+            200: {
+              description: "Content of AGENTS.md, left out when there is none",
+              content: {
+                "application/json": {
+                  schema: resolver(
+                    z.object({
+                      path: z.string(),
+                      content: z.string().optional(),
+                    }),
+                  ),
+                },
+              },
+            },
+          },
+        }),
+        async (c) => {
+          const target = await contextFile()
+          const file = Bun.file(target)
+          if (!(await file.exists())) return c.json({ path: target })
+          return c.json({ path: target, content: await file.text() })
+        },
+      )
+      .put(
+        "/app_context",
+        describeRoute({
+          description: "Write or remove the AGENTS.md of the project",
+          responses: {
+            200: {
+              description: "Written AGENTS.md",
+              content: {
+                "application/json": {
+                  schema: resolver(z.boolean()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            content: z
+              .string()
+              .optional()
+              .describe("The file is removed when left out"),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          const target = await contextFile()
+          if (body.content === undefined)
+            await fs.unlink(target).catch(() => {})
+          else await Bun.write(target, body.content)
+          return c.json(true)
+        },
+      )
       .post(
         "/session_initialize",
         describeRoute({
@@ -201,6 +306,12 @@ export namespace Server {
             sessionID: z.string(),
             providerID: z.string(),
             modelID: z.string(),
+            review: z
+              .boolean()
+              .optional()
+              .describe(
+                "Leave the app uninitialized until the client approves the file with /app_initialize",
+              ),
           }),
         ),
         async (c) => {
@@ -344,6 +455,98 @@ export namespace Server {
           return c.json(messages)
         },
       )
+      .post(
+        "/session_audit",
+        describeRoute({
+          description:
+            "Get the audit log of the tool calls of a session, oldest first",
+          responses: {
+            200: {
+              description: "Audit log entries",
+              content: {
+                "application/json": {
+                  schema: resolver(Audit.Entry.array()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+          }),
+        ),
+        async (c) => {
+          return c.json(await Audit.list(c.req.valid("json").sessionID))
+        },
+      )
+      .post(
+        "/session_changes",
+        describeRoute({
+          description:
+            "List the files the agent changed that an undo would restore",
+          responses: {
+            200: {
+              description: "Absolute paths of the changed files",
+              content: {
+                "application/json": {
+                  schema: resolver(z.string().array()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            messageID: z
+              .string()
+              .optional()
+              .describe(
+                "Changes of this message and later ones, the latest message with changes when left out",
+              ),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          return c.json(await Snapshot.changes(body.sessionID, body.messageID))
+        },
+      )
+      .post(
+        "/session_undo",
+        describeRoute({
+          description:
+            "Restore the files the agent changed to their content from before",
+          responses: {
+            200: {
+              description: "Absolute paths of the restored files",
+              content: {
+                "application/json": {
+                  schema: resolver(z.string().array()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            messageID: z
+              .string()
+              .optional()
+              .describe(
+                "Undo the changes of this message and later ones, the latest message with changes when left out",
+              ),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          return c.json(await Snapshot.undo(body.sessionID, body.messageID))
+        },
+      )
       .post(
         "/session_list",
         describeRoute({
@@ -383,13 +586,75 @@ export namespace Server {
           "json",
           z.object({
             sessionID: z.string(),
+            toolCallID: z
+              .string()
+              .optional()
+              .describe("Abort only this tool call, the turn continues"),
           }),
         ),
         async (c) => {
           const body = c.req.valid("json")
+          if (body.toolCallID)
+            return c.json(Session.abortTool(body.sessionID, body.toolCallID))
           return c.json(Session.abort(body.sessionID))
         },
       )
+      .post(
+        "/permission_respond",
+        describeRoute({
+          description: "Answer a permission request of a session",
+          responses: {
+            200: {
+              description: "Permission answered",
+              content: {
+                "application/json": {
+                  schema: resolver(z.boolean()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            permissionID: z.string(),
+            response: z.enum(["once", "always", "reject"]),
+          }),
+        ),
+        async (c) => {
+          Permission.respond(c.req.valid("json"))
+          return c.json(true)
+        },
+      )
+      .post(
+        "/session_feedback",
+        describeRoute({
+          description: "Rate an assistant message",
+          responses: {
+            200: {
+              description: "Rated message",
+              content: {
+                "application/json": {
+                  schema: resolver(Message.Info),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            messageID: z.string(),
+            feedback: z.enum(["up", "down"]).optional(),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          return c.json(await Session.feedback(body))
+        },
+      )
       .post(
         "/session_delete",
         describeRoute({
@@ -442,10 +707,139 @@ export namespace Server {
         ),
         async (c) => {
           const body = c.req.valid("json")
-          await Session.summarize(body)
+          await once(c.req.header("idempotency-key"), () =>
+            Session.summarize(body),
+          )
           return c.json(true)
         },
       )
+      .post(
+        "/session_merge",
+        describeRoute({
+          description:
+            "Append the history of another session to the session, optionally summarized",
+          responses: {
+            200: {
+              description: "Appended messages",
+              content: {
+                "application/json": {
+                  schema: resolver(Message.Info.array()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            sourceID: z.string(),
+            summarize: z.boolean().optional(),
+            providerID: z.string(),
+            modelID: z.string(),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          return c.json(await Session.merge(body))
+        },
+      )
+      .post(
+        "/session_fork",
+        describeRoute({
+          description:
+            "Create a session with the history of the session up to a message",
+          responses: {
+            200: {
+              description: "Forked session",
+              content: {
+                "application/json": {
+                  schema: resolver(Session.Info),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            messageID: z.string(),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          return c.json(await Session.fork(body))
+        },
+      )
+      .post(
+        "/session_trim",
+        describeRoute({
+          description:
+            "Create a session with a trimmed history of a session, some messages left out and ranges of them summarized",
+          responses: {
+            200: {
+              description: "Session with the trimmed history",
+              content: {
+                "application/json": {
+                  schema: resolver(Session.Info),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            exclude: z.string().array().describe("Messages left out"),
+            collapse: z
+              .object({
+                from: z.string(),
+                to: z.string(),
+              })
+              .array()
+              .describe("Ranges of messages replaced by a summary"),
+            providerID: z.string(),
+            modelID: z.string(),
+          }),
+        ),
+        async (c) => {
+          return c.json(await Session.trim(c.req.valid("json")))
+        },
+      )
+      .post(
+        "/session_revert",
+        describeRoute({
+          description:
+            "Drop the messages of a session after a message and restore the files the agent changed in them",
+          responses: {
+            200: {
+              description: "Absolute paths of the restored files",
+              content: {
+                "application/json": {
+                  schema: resolver(z.string().array()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            sessionID: z.string(),
+            messageID: z
+              .string()
+              .optional()
+              .describe(
+                "The last message kept, every message is dropped when left out",
+              ),
+          }),
+        ),
+        async (c) => {
+          return c.json(await Session.revert(c.req.valid("json")))
+        },
+      )
       .post(
         "/session_chat",
         describeRoute({
@@ -468,11 +862,31 @@ export namespace Server {
             providerID: z.string(),
             modelID: z.string(),
             parts: Message.Part.array(),
+            temperature: z.number().min(0).max(2).optional(),
+            topP: z.number().min(0).max(1).optional(),
+            maxTokens: z.number().int().positive().optional(),
+            reasoningEffort: z.enum(["low", "medium", "high"]).optional(),
+            root: z
+              .string()
+              .optional()
+              .describe("The workspace root the user is working in"),
+            mode: Session.Mode.optional().describe(
+              "The agent mode, plan and ask leave out the tools that change files",
+            ),
+            system: z
+              .string()
+              .optional()
+              .describe("Replaces the default system prompt of the provider"),
           }),
         ),
         async (c) => {
           const body = c.req.valid("json")
-          const msg = await Session.chat(body)
+          const msg = await once(c.req.header("idempotency-key"), () =>
+            Session.chat({
+              ...body,
+              system: body.system ? [body.system] : undefined,
+            }),
+          )
           return c.json(msg)
         },
       )
@@ -509,6 +923,38 @@ export namespace Server {
           })
         },
       )
+      .post(
+        "/provider_auth",
+        describeRoute({
+          description:
+            "Check an API key with the provider, then save it and reload the providers with it",
+          responses: {
+            ...ERRORS,
+            200: {
+              description: "Whether the provider is available with the key",
+              content: {
+                "application/json": {
+                  schema: resolver(z.boolean()),
+                },
+              },
+            },
+          },
+        }),
+        zValidator(
+          "json",
+          z.object({
+            providerID: z.string(),
+            key: z.string(),
+          }),
+        ),
+        async (c) => {
+          const body = c.req.valid("json")
+          await Provider.verify(body.providerID, body.key)
+          await Auth.set(body.providerID, { type: "api", key: body.key })
+          const providers = await Provider.reload()
+          return c.json(body.providerID in providers)
+        },
+      )
       .post(
         "/file_search",
         describeRoute({
@@ -528,13 +974,17 @@ export namespace Server {
           "json",
           z.object({
             query: z.string(),
+            root: z
+              .string()
+              .optional()
+              .describe("The directory to search instead of the working directory"),
           }),
         ),
         async (c) => {
           const body = c.req.valid("json")
           const app = App.info()
           const result = await Ripgrep.files({
-            cwd: app.path.cwd,
+            cwd: body.root ?? app.path.cwd,
             query: body.query,
             limit: 10,
           })
@@ -579,12 +1029,16 @@ export namespace Server {
     return result
   }
 
-  export function listen(opts: { port: number; hostname: string }) {
+  export function listen(opts: {
+    port: number
+    hostname: string
+    token?: string
+  }) {
     const server = Bun.serve({
       port: opts.port,
       hostname: opts.hostname,
       idleTimeout: 0,
-      fetch: app().fetch,
+      fetch: app(opts.token).fetch,
     })
     return server
   }
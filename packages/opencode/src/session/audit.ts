@@ -0,0 +1,116 @@
+import path from "path"
+import fs from "fs/promises"
+import z from "zod"
+import { App } from "../app/app"
+import { Log } from "../util/log"
+
+// Audit keeps an append-only log per session of the tool calls the agent
+// made, with the hashes of the files they touched before and after. The log
+// outlives the session so autonomous runs can be reviewed after cleanup.
+export namespace Audit {
+  const log = Log.create({ service: "audit" })
+
+  export const File = z
+    .object({
+      path: z.string(),
+      before: z.string().optional(),
+      after: z.string().optional(),
+    })
+    .openapi({
+      ref: "audit.file",
+    })
+
+  export const Entry = z
+    .object({
+      time: z.object({
+        start: z.number().int(),
+        end: z.number().int(),
+      }),
+      sessionID: z.string(),
+      messageID: z.string(),
+      toolCallID: z.string(),
+      tool: z.string(),
+      args: z.any(),
+      exit: z.number().int().optional(),
+      error: z.string().optional(),
+      files: File.array(),
+    })
+    .openapi({
+      ref: "audit.entry",
+    })
+  export type Entry = z.infer<typeof Entry>
+
+  function file(sessionID: string) {
+    return path.join(App.info().path.data, "audit", sessionID + ".jsonl")
+  }
+
+  // paths returns the files a tool call names in its arguments, absolute
+  function paths(args: any) {
+    const result: string[] = []
+    if (typeof args?.filePath === "string") result.push(args.filePath)
+    if (typeof args?.patchText === "string") {
+      for (const line of args.patchText.split("\n")) {
+        const match = line.match(/^\*\*\* (?:Add|Update|Delete) File:(.+)$/)
+        if (match) result.push(match[1].trim())
+      }
+    }
+    const cwd = App.info().path.cwd
+    return [...new Set(result.map((p) => path.resolve(cwd, p)))]
+  }
+
+  // hash is the sha256 of a file, undefined when it does not exist
+  async function hash(target: string) {
+    const content = await fs.readFile(target).catch(() => undefined)
+    if (!content) return
+    return new Bun.CryptoHasher("sha256").update(content).digest("hex")
+  }
+
+  // begin hashes the files a tool call is about to touch, the returned
+  // function appends the call to the log once it finished
+  export async function begin(input: {
+    sessionID: string
+    messageID: string
+    toolCallID: string
+    tool: string
+    args: any
+  }) {
+    const start = Date.now()
+    const targets = paths(input.args)
+    const before = await Promise.all(targets.map(hash))
+    return async (result: { exit?: number; error?: string }) => {
+      const entry: Entry = {
+        ...input,
+        ...result,
+        time: { start, end: Date.now() },
+        files: await Promise.all(
+          targets.map(async (p, i) => ({
+            path: p,
+            before: before[i],
+            after: await hash(p),
+          })),
+        ),
+      }
+      const target = file(input.sessionID)
+      await fs.mkdir(path.dirname(target), { recursive: true })
+      await fs
+        .appendFile(target, JSON.stringify(entry) + "\n")
+        .catch((e) => log.error("failed to append", { error: e }))
+    }
+  }
+
+  export async function list(sessionID: string) {
+    const content = await Bun.file(file(sessionID))
+      .text()
+      .catch(() => "")
+    const entries: Entry[] = []
+    for (const line of content.split("\n")) {
+      if (!line.trim()) continue
+      try {
+        entries.push(JSON.parse(line))
+      } catch {
+        log.warn("skipping a corrupt line", { sessionID })
+      }
+    }
+    return entries
+  }
+}
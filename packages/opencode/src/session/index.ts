@@ -17,6 +17,7 @@ import {
   wrapLanguageModel,
 } from "ai"
 import { z, ZodSchema } from "zod"
+import { mergeDeep } from "remeda"
 import { Decimal } from "decimal.js"
 
 import PROMPT_INITIALIZE from "../session/prompt/initialize.txt"
@@ -34,10 +35,19 @@ import type { ModelsDev } from "../provider/models"
 import { Installation } from "../installation"
 import { Config } from "../config/config"
 import { ProviderTransform } from "../provider/transform"
+import { Audit } from "./audit"
+import { Snapshot } from "./snapshot"
 
 export namespace Session {
   const log = Log.create({ service: "session" })
 
+  export const Mode = z.enum(["build", "plan", "ask"])
+  export type Mode = z.infer<typeof Mode>
+
+  // tools that change the workspace, left out in plan and ask modes. task is
+  // too since its sub-agents run with every tool.
+  const WRITE_TOOLS = ["bash", "edit", "multiedit", "patch", "write", "task"]
+
   export const Info = z
     .object({
       id: Identifier.schema("session"),
@@ -92,11 +102,13 @@ export namespace Session {
       const sessions = new Map<string, Info>()
       const messages = new Map<string, Message.Info[]>()
       const pending = new Map<string, AbortController>()
+      const tools = new Map<string, AbortController>()
 
       return {
         sessions,
         messages,
         pending,
+        tools,
       }
     },
     async (state) => {
@@ -231,6 +243,38 @@ export namespace Session {
     return true
   }
 
+  export function abortTool(sessionID: string, toolCallID: string) {
+    const controller = state().tools.get(toolCallID)
+    if (!controller || !state().pending.has(sessionID)) return false
+    controller.abort()
+    state().tools.delete(toolCallID)
+    return true
+  }
+
+  // cancellable runs a tool call that can be aborted on its own with
+  // abortTool, the turn then continues with the error as the tool result
+  async function cancellable<T>(
+    toolCallID: string,
+    signal: AbortSignal,
+    fn: (signal: AbortSignal) => Promise<T>,
+  ): Promise<T> {
+    const controller = new AbortController()
+    state().tools.set(toolCallID, controller)
+    const cancelled = new Promise<never>((_, reject) =>
+      controller.signal.addEventListener("abort", () =>
+        reject(new Error("tool call was cancelled by the user")),
+      ),
+    )
+    try {
+      return await Promise.race([
+        fn(AbortSignal.any([signal, controller.signal])),
+        cancelled,
+      ])
+    } finally {
+      state().tools.delete(toolCallID)
+    }
+  }
+
   export async function remove(sessionID: string, emitEvent = true) {
     try {
       abort(sessionID)
@@ -241,6 +285,7 @@ export namespace Session {
       await unshare(sessionID).catch(() => {})
       await Storage.remove(`session/info/${sessionID}`).catch(() => {})
       await Storage.removeDir(`session/message/${sessionID}/`).catch(() => {})
+      await Storage.removeDir(`session/snapshot/${sessionID}/`).catch(() => {})
       state().sessions.delete(sessionID)
       state().messages.delete(sessionID)
       if (emitEvent) {
@@ -253,6 +298,17 @@ export namespace Session {
     }
   }
 
+  export async function feedback(input: {
+    sessionID: string
+    messageID: string
+    feedback?: "up" | "down"
+  }) {
+    const msg = await getMessage(input.sessionID, input.messageID)
+    msg.metadata.feedback = input.feedback
+    await updateMessage(msg)
+    return msg
+  }
+
   async function updateMessage(msg: Message.Info) {
     await Storage.writeJSON(
       "session/message/" + msg.metadata.sessionID + "/" + msg.id,
@@ -270,6 +326,12 @@ export namespace Session {
     parts: Message.Part[]
     system?: string[]
     tools?: Tool.Info[]
+    temperature?: number
+    topP?: number
+    maxTokens?: number
+    reasoningEffort?: "low" | "medium" | "high"
+    root?: string
+    mode?: Mode
   }) {
     const l = log.clone().tag("session", input.sessionID)
     l.info("chatting")
@@ -355,7 +417,8 @@ export namespace Session {
     msgs.push(msg)
 
     const system = input.system ?? SystemPrompt.provider(input.providerID)
-    system.push(...(await SystemPrompt.environment()))
+    system.push(...SystemPrompt.mode(input.mode))
+    system.push(...(await SystemPrompt.environment(input.root)))
     system.push(...(await SystemPrompt.custom()))
 
     const next: Message.Info = {
@@ -388,30 +451,52 @@ export namespace Session {
     }
     await updateMessage(next)
     const tools: Record<string, AITool> = {}
+    // the files are snapshotted once per message, before the first tool
+    // that can change them runs
+    let snapshot: Promise<void> | undefined
+    const track = () =>
+      (snapshot ??= Snapshot.create(input.sessionID, next.id).catch((e) =>
+        log.error("snapshot", e),
+      ))
 
+    const readOnly = input.mode === "plan" || input.mode === "ask"
     for (const item of await Provider.tools(input.providerID)) {
+      if (readOnly && WRITE_TOOLS.includes(item.id)) continue
       tools[item.id.replaceAll(".", "_")] = tool({
         id: item.id as any,
         description: item.description,
         parameters: item.parameters as ZodSchema,
         async execute(args, opts) {
           const start = Date.now()
+          const audit = await Audit.begin({
+            sessionID: input.sessionID,
+            messageID: next.id,
+            toolCallID: opts.toolCallId,
+            tool: item.id,
+            args,
+          })
           try {
-            const result = await item.execute(args, {
-              sessionID: input.sessionID,
-              abort: abort.signal,
-              messageID: next.id,
-              metadata: async (val) => {
-                next.metadata.tool[opts.toolCallId] = {
-                  ...val,
-                  time: {
-                    start: 0,
-                    end: 0,
+            if (Snapshot.TOOLS.includes(item.id)) await track()
+            const result = await cancellable(
+              opts.toolCallId,
+              abort.signal,
+              (signal) =>
+                item.execute(args, {
+                  sessionID: input.sessionID,
+                  abort: signal,
+                  messageID: next.id,
+                  metadata: async (val) => {
+                    next.metadata.tool[opts.toolCallId] = {
+                      ...val,
+                      time: {
+                        start: 0,
+                        end: 0,
+                      },
+                    }
+                    await updateMessage(next)
                   },
-                }
-                await updateMessage(next)
-              },
-            })
+                }),
+            )
             next.metadata!.tool![opts.toolCallId] = {
               ...result.metadata,
               time: {
@@ -420,6 +505,12 @@ export namespace Session {
               },
             }
             await updateMessage(next)
+            await audit({
+              exit:
+                typeof result.metadata?.exit === "number"
+                  ? result.metadata.exit
+                  : undefined,
+            })
             return result.output
           } catch (e: any) {
             next.metadata!.tool![opts.toolCallId] = {
@@ -432,19 +523,35 @@ export namespace Session {
               },
             }
             await updateMessage(next)
+            await audit({ error: e.toString() })
             return e.toString()
           }
         },
       })
     }
 
-    for (const [key, item] of Object.entries(await MCP.tools())) {
+    // MCP tools can change files as well, the read-only modes leave them out
+    for (const [key, item] of Object.entries(
+      readOnly ? {} : await MCP.tools(),
+    )) {
       const execute = item.execute
       if (!execute) continue
       item.execute = async (args, opts) => {
         const start = Date.now()
+        const audit = await Audit.begin({
+          sessionID: input.sessionID,
+          messageID: next.id,
+          toolCallID: opts.toolCallId,
+          tool: key,
+          args,
+        })
         try {
-          const result = await execute(args, opts)
+          await track()
+          const result = await cancellable(
+            opts.toolCallId,
+            opts.abortSignal ?? abort.signal,
+            (signal) => execute(args, { ...opts, abortSignal: signal }),
+          )
           next.metadata!.tool![opts.toolCallId] = {
             ...result.metadata,
             time: {
@@ -453,6 +560,7 @@ export namespace Session {
             },
           }
           await updateMessage(next)
+          await audit({})
           return result.content
             .filter((x: any) => x.type === "text")
             .map((x: any) => x.text)
@@ -468,6 +576,7 @@ export namespace Session {
             },
           }
           await updateMessage(next)
+          await audit({ error: e.toString() })
           return e.toString()
         }
       }
@@ -475,6 +584,7 @@ export namespace Session {
     }
 
     let text: Message.TextPart | undefined
+    let reasoning: Message.ReasoningPart | undefined
     const result = streamText({
       onStepFinish: async (step) => {
         log.info("step finish", { finishReason: step.finishReason })
@@ -491,6 +601,7 @@ export namespace Session {
           })
         }
         text = undefined
+        reasoning = undefined
       },
       async onFinish(input) {
         log.info("message finish", {
@@ -537,10 +648,19 @@ export namespace Session {
       //   return step
       // },
       toolCallStreaming: true,
-      maxTokens: model.info.limit.output || undefined,
+      maxTokens: input.maxTokens ?? (model.info.limit.output || undefined),
       abortSignal: abort.signal,
       maxSteps: 1000,
-      providerOptions: model.info.options,
+      providerOptions: input.reasoningEffort
+        ? mergeDeep(
+            model.info.options ?? {},
+            ProviderTransform.reasoning(
+              input.providerID,
+              input.modelID,
+              input.reasoningEffort,
+            ),
+          )
+        : model.info.options,
       messages: [
         ...system.map(
           (x): CoreMessage => ({
@@ -552,7 +672,9 @@ export namespace Session {
           msgs.map(toUIMessage).filter((x) => x.parts.length > 0),
         ),
       ],
-      temperature: model.info.temperature ? 0 : undefined,
+      temperature:
+        input.temperature ?? (model.info.temperature ? 0 : undefined),
+      topP: input.topP,
       tools: model.info.tool_call === false ? undefined : tools,
       model: wrapLanguageModel({
         model: model.language,
@@ -594,6 +716,17 @@ export namespace Session {
             } else text.text += value.textDelta
             break
 
+          case "reasoning":
+            if (!reasoning) {
+              reasoning = {
+                type: "reasoning",
+                text: value.textDelta,
+              }
+              next.parts.push(reasoning)
+              break
+            } else reasoning.text += value.textDelta
+            break
+
           case "tool-call": {
             const [match] = next.parts.flatMap((p) =>
               p.type === "tool-invocation" &&
@@ -717,6 +850,12 @@ export namespace Session {
         error: next.metadata.error,
       })
     }
+    // the tree after the message tells the files it changed, the only ones
+    // undoing it restores
+    if (snapshot)
+      await snapshot
+        .then(() => Snapshot.finish(input.sessionID, next.id))
+        .catch((e) => log.error("snapshot", e))
     next.metadata!.time.completed = Date.now()
     for (const part of next.parts) {
       if (
@@ -844,7 +983,242 @@ export namespace Session {
     }
   }
 
-  function lock(sessionID: string) {
+  export async function fork(input: { sessionID: string; messageID: string }) {
+    const source = await get(input.sessionID)
+    const msgs = await messages(input.sessionID)
+    const index = msgs.findIndex((msg) => msg.id === input.messageID)
+    if (index === -1) throw new Error("Message not found in session")
+    const session = await create()
+    for (const msg of msgs.slice(0, index + 1)) {
+      const copy: Message.Info = structuredClone(msg)
+      copy.id = Identifier.ascending("message")
+      copy.metadata.sessionID = session.id
+      await updateMessage(copy)
+    }
+    return update(session.id, (draft) => {
+      draft.title = "Fork of " + source.title
+    })
+  }
+
+  // trim creates a session with the history of another one, leaving out the
+  // excluded messages and replacing each collapsed range, given by its first
+  // and last message, with a summary the model writes
+  export async function trim(input: {
+    sessionID: string
+    exclude: string[]
+    collapse: { from: string; to: string }[]
+    providerID: string
+    modelID: string
+  }) {
+    const source = await get(input.sessionID)
+    const msgs = await messages(input.sessionID)
+    const model = await Provider.getModel(input.providerID, input.modelID)
+    const app = App.info()
+    const session = await create()
+    for (let i = 0; i < msgs.length; i++) {
+      const range = input.collapse.find((r) => r.from === msgs[i].id)
+      if (range) {
+        const end = msgs.findIndex((msg) => msg.id === range.to)
+        if (end < i) throw new Error("Collapsed range ends before it starts")
+        const collapsed = msgs.slice(i, end + 1)
+        const system = SystemPrompt.summarize(input.providerID)
+        const result = await generateText({
+          model: model.language,
+          providerOptions: model.info.options,
+          messages: [
+            ...system.map(
+              (x): CoreMessage => ({
+                role: "system",
+                content: x,
+              }),
+            ),
+            ...convertToCoreMessages(
+              collapsed.map(toUIMessage).filter((x) => x.parts.length > 0),
+            ),
+            {
+              role: "user",
+              content:
+                "Summarize the part of our conversation above in a few lines. Keep what later messages may refer to: decisions, files and identifiers.",
+            },
+          ],
+        })
+        const usage = getUsage(
+          model.info,
+          result.usage,
+          result.providerMetadata,
+        )
+        await updateMessage({
+          id: Identifier.ascending("message"),
+          role: "assistant",
+          parts: [
+            {
+              type: "text",
+              text: `Summary of ${collapsed.length} earlier messages:\n\n${result.text}`,
+            },
+          ],
+          metadata: {
+            tool: {},
+            sessionID: session.id,
+            assistant: {
+              system,
+              path: {
+                cwd: app.path.cwd,
+                root: app.path.root,
+              },
+              cost: usage.cost,
+              modelID: input.modelID,
+              providerID: input.providerID,
+              tokens: usage.tokens,
+            },
+            time: {
+              created: Date.now(),
+              completed: Date.now(),
+            },
+          },
+        })
+        i = end
+        continue
+      }
+      if (input.exclude.includes(msgs[i].id)) continue
+      const copy: Message.Info = structuredClone(msgs[i])
+      copy.id = Identifier.ascending("message")
+      copy.metadata.sessionID = session.id
+      await updateMessage(copy)
+    }
+    return update(session.id, (draft) => {
+      draft.title = source.title
+    })
+  }
+
+  // revert drops the messages after messageID, every message without it,
+  // and restores the files the agent changed in them. It returns the
+  // restored files.
+  export async function revert(input: {
+    sessionID: string
+    messageID?: string
+  }) {
+    using _ = lock(input.sessionID, { hooks: false })
+    const msgs = await messages(input.sessionID)
+    const index = input.messageID
+      ? msgs.findIndex((msg) => msg.id === input.messageID)
+      : -1
+    if (input.messageID && index === -1)
+      throw new Error("Message not found in session")
+    const later = msgs.slice(index + 1)
+    if (later.length === 0) return []
+    const files = await Snapshot.undo(input.sessionID, later[0].id)
+    for (const msg of later)
+      await Storage.remove("session/message/" + input.sessionID + "/" + msg.id)
+    await update(input.sessionID, () => {})
+    return files
+  }
+
+  export async function merge(input: {
+    sessionID: string
+    sourceID: string
+    summarize?: boolean
+    providerID: string
+    modelID: string
+  }) {
+    if (input.sessionID === input.sourceID)
+      throw new Error("Cannot merge a session into itself")
+    using _ = lock(input.sessionID, { hooks: false })
+    const source = await get(input.sourceID)
+    let msgs = await messages(input.sourceID)
+    const lastSummary = msgs.findLast(
+      (msg) => msg.metadata.assistant?.summary === true,
+    )
+    if (lastSummary) msgs = msgs.filter((msg) => msg.id >= lastSummary.id)
+    const merged: Message.Info[] = []
+
+    if (!input.summarize) {
+      for (const msg of msgs) {
+        const copy: Message.Info = structuredClone(msg)
+        copy.id = Identifier.ascending("message")
+        copy.metadata.sessionID = input.sessionID
+        // a copied summary would hide the history before the merge
+        if (copy.metadata.assistant) delete copy.metadata.assistant.summary
+        await updateMessage(copy)
+        merged.push(copy)
+      }
+      return merged
+    }
+
+    const model = await Provider.getModel(input.providerID, input.modelID)
+    const app = App.info()
+    const system = SystemPrompt.summarize(input.providerID)
+    const result = await generateText({
+      model: model.language,
+      messages: [
+        ...system.map(
+          (x): CoreMessage => ({
+            role: "system",
+            content: x,
+          }),
+        ),
+        ...convertToCoreMessages(msgs.map(toUIMessage)),
+        {
+          role: "user",
+          content: [
+            {
+              type: "text",
+              text: "Provide a detailed but concise summary of our conversation above. Focus on information that would be helpful for continuing the conversation, including what we did, what we found, which files we worked on, and what was left to do.",
+            },
+          ],
+        },
+      ],
+    })
+    const usage = getUsage(model.info, result.usage, result.providerMetadata)
+    const now = Date.now()
+    merged.push(
+      {
+        id: Identifier.ascending("message"),
+        role: "user",
+        parts: [
+          {
+            type: "text",
+            text: `Summarize the session "${source.title}" so we can continue its work here.`,
+          },
+        ],
+        metadata: {
+          time: { created: now },
+          sessionID: input.sessionID,
+          tool: {},
+        },
+      },
+      {
+        id: Identifier.ascending("message"),
+        role: "assistant",
+        parts: [{ type: "text", text: result.text }],
+        metadata: {
+          tool: {},
+          sessionID: input.sessionID,
+          assistant: {
+            system,
+            path: {
+              cwd: app.path.cwd,
+              root: app.path.root,
+            },
+            cost: usage.cost,
+            modelID: input.modelID,
+            providerID: input.providerID,
+            tokens: usage.tokens,
+          },
+          time: {
+            created: now,
+            completed: Date.now(),
+          },
+        },
+      },
+    )
+    for (const msg of merged) await updateMessage(msg)
+    return merged
+  }
+
+  // lock marks a session busy until disposed, which runs the
+  // session_completed hooks unless hooks is false, for the operations that
+  // do not complete a turn
+  function lock(sessionID: string, opts: { hooks?: boolean } = {}) {
     log.info("locking", { sessionID })
     if (state().pending.has(sessionID)) throw new BusyError(sessionID)
     const controller = new AbortController()
@@ -854,6 +1228,7 @@ export namespace Session {
       [Symbol.dispose]() {
         log.info("unlocking", { sessionID })
         state().pending.delete(sessionID)
+        if (opts.hooks === false) return
         Config.get().then((cfg) => {
           if (cfg.experimental?.hook?.session_completed) {
             for (const item of cfg.experimental.hook.session_completed) {
@@ -916,6 +1291,7 @@ export namespace Session {
     sessionID: string
     modelID: string
     providerID: string
+    review?: boolean
   }) {
     const app = App.info()
     await Session.chat({
@@ -929,7 +1305,7 @@ export namespace Session {
         },
       ],
     })
-    await App.initialize()
+    if (!input.review) await App.initialize()
   }
 }
 
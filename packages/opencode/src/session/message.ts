@@ -188,6 +188,7 @@ export namespace Message {
               }),
             })
             .optional(),
+          feedback: z.enum(["up", "down"]).optional(),
         })
         .openapi({ ref: "Message.Metadata" }),
     })
@@ -0,0 +1,165 @@
+import path from "path"
+import fs from "fs/promises"
+import { $ } from "bun"
+import { App } from "../app/app"
+import { Storage } from "../storage/storage"
+import { Log } from "../util/log"
+
+// Snapshot records the project files before an assistant message first
+// changes them, so the changes can be undone whichever tool made them. The
+// snapshots are trees of a git repository kept apart from the project's, in
+// the data directory, and only git projects are snapshotted.
+export namespace Snapshot {
+  const log = Log.create({ service: "snapshot" })
+
+  // tools that can change files, MCP tools are assumed to as well
+  export const TOOLS = ["bash", "edit", "multiedit", "patch", "write"]
+
+  type Record = {
+    messageID: string
+    tree: string
+    // after is the tree once the message finished, unset while it runs or
+    // when it was interrupted
+    after?: string
+  }
+
+  function gitdir() {
+    return path.join(App.info().path.data, "snapshot")
+  }
+
+  function git(args: string[]) {
+    const app = App.info()
+    return $`git --git-dir ${gitdir()} --work-tree ${app.path.root} ${args}`
+      .cwd(app.path.root)
+      .quiet()
+      .nothrow()
+  }
+
+  // stage adds the current project files to the index of the snapshot
+  // repository, gitignored files are left out
+  async function stage() {
+    if (!(await Bun.file(path.join(gitdir(), "HEAD")).exists()))
+      await git(["init", "--quiet"])
+    await git(["add", "--all", "."])
+  }
+
+  // writeTree records the current project files as a tree
+  async function writeTree() {
+    await stage()
+    const result = await git(["write-tree"])
+    const tree = result.stdout.toString().trim()
+    if (result.exitCode !== 0 || !tree) {
+      log.error("failed to snapshot", { stderr: result.stderr.toString() })
+      return
+    }
+    return tree
+  }
+
+  export async function create(sessionID: string, messageID: string) {
+    if (!App.info().git) return
+    const tree = await writeTree()
+    if (!tree) return
+    log.info("created", { sessionID, messageID, tree })
+    await Storage.writeJSON<Record>(
+      "session/snapshot/" + sessionID + "/" + messageID,
+      { messageID, tree },
+    )
+  }
+
+  // finish records the files as the message left them, so that undoing it
+  // restores only the files it changed
+  export async function finish(sessionID: string, messageID: string) {
+    const key = "session/snapshot/" + sessionID + "/" + messageID
+    const record = await Storage.readJSON<Record>(key).catch(() => undefined)
+    if (!record) return
+    const after = await writeTree()
+    if (!after) return
+    await Storage.writeJSON<Record>(key, { ...record, after })
+  }
+
+  // records returns the snapshots to undo: those of messageID and later
+  // messages, or only the latest one without messageID. Oldest first.
+  async function records(sessionID: string, messageID?: string) {
+    const result: Record[] = []
+    for await (const key of Storage.list("session/snapshot/" + sessionID)) {
+      result.push(await Storage.readJSON<Record>(key))
+    }
+    result.sort((a, b) => (a.messageID < b.messageID ? -1 : 1))
+    if (!messageID) return result.slice(-1)
+    return result.filter((x) => x.messageID >= messageID)
+  }
+
+  // names returns the files listed by a git command, relative to the root
+  async function names(args: string[]) {
+    const result = await git(args)
+    return result.stdout.toString().split("\n").filter(Boolean)
+  }
+
+  // touched returns the files the messages of the snapshots changed,
+  // relative to the root. A message without a tree after it, still running
+  // or interrupted, may have changed any file that differs now.
+  async function touched(undone: Record[]) {
+    const files = new Set<string>()
+    await stage()
+    for (const record of undone) {
+      const changed = record.after
+        ? await names(["diff", "--name-only", record.tree, record.after])
+        : await names(["diff", "--cached", "--name-only", record.tree])
+      for (const file of changed) files.add(file)
+    }
+    return [...files]
+  }
+
+  // changes returns the files an undo would restore
+  export async function changes(sessionID: string, messageID?: string) {
+    const root = App.info().path.root
+    const files = await touched(await records(sessionID, messageID))
+    return files.map((file) => path.join(root, file))
+  }
+
+  // undo restores the files the messages of the snapshots to undo changed
+  // as they were in the oldest one, removing those created since, and drops
+  // the snapshots. Files changed otherwise are left alone. It returns the
+  // files that were restored.
+  export async function undo(sessionID: string, messageID?: string) {
+    const undone = await records(sessionID, messageID)
+    const [first] = undone
+    if (!first) return []
+    const files = await touched(undone)
+    const root = App.info().path.root
+    if (files.length > 0) {
+      const existing = new Set(
+        await names([
+          "ls-tree",
+          "-r",
+          "--name-only",
+          first.tree,
+          "--",
+          ...files,
+        ]),
+      )
+      for (const file of files.filter((file) => !existing.has(file)))
+        await fs.unlink(path.join(root, file)).catch(() => {})
+      const restore = files.filter((file) => existing.has(file))
+      if (restore.length > 0) {
+        await git(["read-tree", first.tree])
+        const result = await git([
+          "checkout-index",
+          "--force",
+          "--",
+          ...restore,
+        ])
+        if (result.exitCode !== 0)
+          throw new Error(
+            "failed to restore files: " + result.stderr.toString(),
+          )
+      }
+    }
+    for (const record of undone)
+      await Storage.remove(
+        "session/snapshot/" + sessionID + "/" + record.messageID,
+      )
+    log.info("undone", { sessionID, tree: first.tree, files: files.length })
+    return files.map((file) => path.join(root, file))
+  }
+}
@@ -9,6 +9,8 @@ import PROMPT_ANTHROPIC from "./prompt/anthropic.txt"
 import PROMPT_ANTHROPIC_SPOOF from "./prompt/anthropic_spoof.txt"
 import PROMPT_SUMMARIZE from "./prompt/summarize.txt"
 import PROMPT_TITLE from "./prompt/title.txt"
+import PROMPT_PLAN from "./prompt/plan.txt"
+import PROMPT_ASK from "./prompt/ask.txt"
 
 export namespace SystemPrompt {
   export function provider(providerID: string) {
@@ -25,7 +27,18 @@ export namespace SystemPrompt {
     return result
   }
 
-  export async function environment() {
+  export function mode(mode?: string) {
+    switch (mode) {
+      case "plan":
+        return [PROMPT_PLAN]
+      case "ask":
+        return [PROMPT_ASK]
+      default:
+        return []
+    }
+  }
+
+  export async function environment(root?: string) {
     const app = App.info()
 
     ;async () => {
@@ -81,6 +94,9 @@ export namespace SystemPrompt {
         `Here is some useful information about the environment you are running in:`,
         `<env>`,
         `  Working directory: ${app.path.cwd}`,
+        ...(root && root !== app.path.cwd
+          ? [`  Active workspace root: ${root}`]
+          : []),
         `  Is directory a git repo: ${app.git ? "yes" : "no"}`,
         `  Platform: ${process.platform}`,
         `  Today's date: ${new Date().toDateString()}`,
@@ -1,6 +1,7 @@
 import { z } from "zod"
 import { Tool } from "./tool"
 import DESCRIPTION from "./bash.txt"
+import { Permission } from "../permission"
 
 const MAX_OUTPUT_LENGTH = 30000
 const BANNED_COMMANDS = [
@@ -47,6 +48,16 @@ export const BashTool = Tool.define({
     if (BANNED_COMMANDS.some((item) => params.command.startsWith(item)))
       throw new Error(`Command '${params.command}' is not allowed`)
 
+    await Permission.ask({
+      id: "bash",
+      sessionID: ctx.sessionID,
+      title: "Run this command: " + params.command,
+      metadata: {
+        command: params.command,
+        description: params.description,
+      },
+    })
+
     const process = Bun.spawn({
       cmd: ["bash", "-c", params.command],
       maxBuffer: MAX_OUTPUT_LENGTH,
@@ -1,5 +1,5 @@
 import { exists } from "fs/promises"
-import { dirname, join } from "path"
+import { dirname, isAbsolute, join, relative } from "path"
 
 export namespace Filesystem {
   export async function findUp(target: string, start: string, stop?: string) {
@@ -15,4 +15,10 @@ export namespace Filesystem {
     }
     return result
   }
+
+  // contains reports whether child is parent or a path inside of it
+  export function contains(parent: string, child: string) {
+    const rel = relative(parent, child)
+    return !rel.startsWith("..") && !isAbsolute(rel)
+  }
 }
@@ -3,99 +3,297 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	neturl "net/url"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/image"
+	"github.com/sst/opencode/internal/logging"
+	"github.com/sst/opencode/internal/remote"
+	"github.com/sst/opencode/internal/script"
 	"github.com/sst/opencode/internal/tui"
 	"github.com/sst/opencode/pkg/client"
 )
 
 var Version = "dev"
 
+var sse = logging.For(logging.SSE)
+
 func main() {
 	version := Version
 	if version != "dev" && !strings.HasPrefix(Version, "v") {
 		version = "v" + Version
 	}
 
-	url := os.Getenv("OPENCODE_SERVER")
+	// Create main context for the application
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	appInfoStr := os.Getenv("OPENCODE_APP_INFO")
-	var appInfo client.AppInfo
-	err := json.Unmarshal([]byte(appInfoStr), &appInfo)
+	options := remote.ParseOptions(os.Args[1:])
+	if statePath, err := remote.StatePath(); err == nil {
+		options.ApplyState(statePath)
+	}
+	url := options.Server
+	if target, err := neturl.Parse(url); err == nil && target.Scheme == "ssh" {
+		local, closeTunnel, err := remote.Tunnel(ctx, target)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer closeTunnel()
+		url = local
+	}
+
+	var program atomic.Pointer[tea.Program]
+	var unauthorized atomic.Bool
+	connection := options.Connection(func() {
+		unauthorized.Store(true)
+		if p := program.Load(); p != nil {
+			p.Send(app.AuthFailedMsg{})
+		}
+	})
+	clientOptions, err := connection.ClientOptions()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	httpClient, err := client.NewClientWithResponses(url, clientOptions...)
 	if err != nil {
-		slog.Error("Failed to unmarshal app info", "error", err)
+		fmt.Fprintln(os.Stderr, "Failed to create client:", err)
 		os.Exit(1)
 	}
 
-	logfile := filepath.Join(appInfo.Path.Data, "log", "tui.log")
-	if _, err := os.Stat(filepath.Dir(logfile)); os.IsNotExist(err) {
-		err := os.MkdirAll(filepath.Dir(logfile), 0755)
+	var appInfo client.AppInfo
+	if appInfoStr := os.Getenv("OPENCODE_APP_INFO"); appInfoStr != "" {
+		if err := json.Unmarshal([]byte(appInfoStr), &appInfo); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to unmarshal app info:", err)
+			os.Exit(1)
+		}
+	} else {
+		// Connected to a remote server, ask it for the app info
+		response, err := httpClient.PostAppInfoWithResponse(ctx)
+		if errors.Is(err, client.ErrUnauthorized) {
+			fmt.Fprintln(os.Stderr, "Authentication failed, check --token or OPENCODE_SERVER_TOKEN")
+			os.Exit(1)
+		}
 		if err != nil {
-			slog.Error("Failed to create log directory", "error", err)
+			fmt.Fprintln(os.Stderr, "Failed to connect to server:", err)
+			os.Exit(1)
+		}
+		if response.JSON200 == nil {
+			fmt.Fprintf(os.Stderr, "Failed to get app info: %d\n", response.StatusCode())
+			os.Exit(1)
+		}
+		appInfo = *response.JSON200
+		if err := remote.UseLocalPaths(&appInfo); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to create local state directory:", err)
 			os.Exit(1)
 		}
 	}
-	file, err := os.Create(logfile)
+
+	file, err := logging.Open(logging.Path(appInfo.Path.State))
 	if err != nil {
-		slog.Error("Failed to create log file", "error", err)
+		fmt.Fprintln(os.Stderr, "Failed to open log file:", err)
 		os.Exit(1)
 	}
 	defer file.Close()
-	logger := slog.New(slog.NewTextHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug}))
-	slog.SetDefault(logger)
+	logging.Setup(file)
 
 	slog.Debug("TUI launched", "app", appInfo)
+	app.StartupStep("app info")
 
-	httpClient, err := client.NewClientWithResponses(url)
+	app_, err := app.New(ctx, version, appInfo, httpClient)
 	if err != nil {
-		slog.Error("Failed to create client", "error", err)
-		os.Exit(1)
+		panic(err)
+	}
+	if err := logging.SetLevels(app_.State.LogLevels); err != nil {
+		slog.Warn("Ignoring log levels", "error", err)
+	}
+	app_.Inspector = connection.Inspector
+	app_.Credentials = connection.Credentials
+	if slices.Contains(os.Args[1:], "--screen-reader") || os.Getenv("OPENCODE_SCREEN_READER") == "1" {
+		app_.ScreenReader = true
 	}
+	app_.Graphics = image.DetectProtocol()
 
-	// Create main context for the application
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// the program applies the config after its first frame, the other modes
+	// wait for it
+	interactive := !slices.Contains(os.Args[1:], "--script")
+	if _, ok := subcommandArg(os.Args[1:], "import"); ok {
+		interactive = false
+	}
+	if !interactive {
+		if err := app_.AwaitConfig(); err != nil {
+			if unauthorized.Load() {
+				fmt.Fprintln(os.Stderr, "Authentication failed, check --token or OPENCODE_SERVER_TOKEN")
+			} else {
+				fmt.Fprintln(os.Stderr, "Failed to load config:", err)
+			}
+			os.Exit(1)
+		}
+	}
 
-	app_, err := app.New(ctx, version, appInfo, httpClient)
-	if err != nil {
-		panic(err)
+	// opencode import <file>: recreate exported sessions and exit
+	if path, ok := subcommandArg(os.Args[1:], "import"); ok {
+		sessions, err := app_.ImportSessions(ctx, path)
+		app_.AdoptSessions(sessions)
+		for _, session := range sessions {
+			fmt.Println("Imported", session.Id)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to import sessions:", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	program := tea.NewProgram(
-		tui.NewModel(app_),
+	// Non-interactive mode: read commands from stdin, write events to stdout
+	if slices.Contains(os.Args[1:], "--script") {
+		eventClient, err := client.NewClient(url, clientOptions...)
+		if err != nil {
+			slog.Error("Failed to create event client", "error", err)
+			os.Exit(1)
+		}
+		evts, err := eventClient.Event(ctx)
+		if err != nil {
+			slog.Error("Failed to subscribe to events", "error", err)
+			os.Exit(1)
+		}
+		if err := script.Run(ctx, app_, evts, os.Stdin, os.Stdout, flagList(os.Args[1:], "--allow")); err != nil {
+			slog.Error("Script error", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	tuiProgram := tea.NewProgram(
+		tui.Guard(tui.NewModel(app_)),
 		tea.WithAltScreen(),
 		tea.WithKeyboardEnhancements(),
 		tea.WithMouseCellMotion(),
 	)
+	program.Store(tuiProgram)
 
-	eventClient, err := client.NewClient(url)
+	eventClient, err := client.NewClient(url, clientOptions...)
 	if err != nil {
 		slog.Error("Failed to create event client", "error", err)
 		os.Exit(1)
 	}
 
-	evts, err := eventClient.Event(ctx)
+	// Subscribe while the first frame renders, sending the events waits for
+	// the program to run
+	go forwardEvents(ctx, eventClient, tuiProgram, app_.Events)
+
+	// Run the TUI
+	result, err := tuiProgram.Run()
+	if errors.Is(err, tea.ErrProgramPanic) {
+		reportCrash(appInfo.Path.State, version)
+	}
 	if err != nil {
-		slog.Error("Failed to subscribe to events", "error", err)
-		os.Exit(1)
+		slog.Error("TUI error", "error", err)
 	}
 
-	go func() {
+	slog.Info("TUI exited", "result", result)
+}
+
+// forwardEvents sends server events to the program and records them for
+// bug reports, subscribing again with a backoff whenever the stream drops,
+// e.g. after the token was rejected
+func forwardEvents(ctx context.Context, eventClient *client.Client, program *tea.Program, history *app.EventHistory) {
+	backoff := time.Second
+	evts, err := eventClient.Event(ctx)
+	if err != nil {
+		sse.Error("Failed to subscribe to events", "error", err)
+		evts = closed()
+	} else {
+		app.StartupStep("events")
+	}
+	for {
 		for item := range evts {
+			backoff = time.Second
+			sse.Debug("Event", "type", fmt.Sprintf("%T", item))
+			history.Record(item)
 			program.Send(item)
 		}
-	}()
+		sse.Info("Event stream closed")
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, 30*time.Second)
+			evts, err = eventClient.Event(ctx)
+			if err == nil {
+				sse.Info("Reconnected to the event stream")
+				break
+			}
+			sse.Debug("Failed to resubscribe to events", "error", err)
+		}
+	}
+}
 
-	// Run the TUI
-	result, err := program.Run()
+// reportCrash writes the dump of a panic of the program, which restored the
+// terminal, and exits
+func reportCrash(stateDir string, version string) {
+	crash := tui.LastCrash()
+	if crash == nil {
+		// the panic happened in a command the guard did not wrap
+		crash = &app.Crash{Time: time.Now(), Panic: "panic in a command, see the output above"}
+	}
+	crash.Version = version
+	slog.Error("TUI crashed", "panic", crash.Panic, "stack", crash.Stack)
+	path, err := app.WriteCrash(stateDir, *crash)
 	if err != nil {
-		slog.Error("TUI error", "error", err)
+		fmt.Fprintln(os.Stderr, "opencode crashed and failed to write a crash dump:", err)
+		os.Exit(1)
 	}
+	fmt.Fprintln(os.Stderr, "opencode crashed, the crash dump was written to", path)
+	fmt.Fprintln(os.Stderr, "Launch it again to restore your draft and reopen the session")
+	os.Exit(1)
+}
 
-	slog.Info("TUI exited", "result", result)
+// closed returns a closed channel, to subscribe again after the backoff
+func closed() <-chan any {
+	evts := make(chan any)
+	close(evts)
+	return evts
+}
+
+// flagList collects the comma separated values of a flag that can be
+// repeated, as in --allow read,grep --allow bash
+func flagList(args []string, name string) []string {
+	values := []string{}
+	for i, arg := range args {
+		value, ok := strings.CutPrefix(arg, name+"=")
+		if !ok && arg == name && i+1 < len(args) {
+			value, ok = args[i+1], true
+		}
+		if !ok {
+			continue
+		}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+// subcommandArg returns the argument following a subcommand
+func subcommandArg(args []string, name string) (string, bool) {
+	if len(args) < 2 || args[0] != name {
+		return "", false
+	}
+	return args[1], true
 }
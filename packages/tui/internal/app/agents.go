@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// projectContextFile is the file the project initialization writes
+const projectContextFile = "AGENTS.md"
+
+// ProjectContextMsg is sent when the project initialization wrote the
+// context file, to review it before the project is marked initialized
+type ProjectContextMsg struct {
+	Path    string
+	Content string
+	// Previous is the content of the file before the initialization, nil
+	// when there was none
+	Previous *string
+	Err      error
+}
+
+// ApproveProjectContextMsg saves the reviewed context file and marks the
+// project initialized
+type ApproveProjectContextMsg struct {
+	Context ProjectContextMsg
+	Content string
+}
+
+// DiscardProjectContextMsg restores the context file as it was before the
+// initialization
+type DiscardProjectContextMsg struct {
+	Context ProjectContextMsg
+}
+
+// generateProjectContext asks the agent to write the context file in the
+// current session and reads it back for review, through the server since it
+// may run on another machine
+func (a *App) generateProjectContext(ctx context.Context) tea.Cmd {
+	review := true
+	body := client.PostSessionInitializeJSONRequestBody{
+		SessionID:  a.Session.Id,
+		ProviderID: a.Provider.Id,
+		ModelID:    a.Model.Id,
+		Review:     &review,
+	}
+	return func() tea.Msg {
+		path, previous, err := a.readProjectContext(ctx)
+		if err != nil {
+			return ProjectContextMsg{Path: path, Err: err}
+		}
+		response, err := a.Client.PostSessionInitialize(ctx, body)
+		if err != nil {
+			slog.Error("Failed to initialize project", "error", err)
+			return ProjectContextMsg{Path: path, Previous: previous, Err: err}
+		}
+		response.Body.Close()
+		if response.StatusCode != 200 {
+			slog.Error("Failed to initialize project", "error", response.StatusCode)
+			err := fmt.Errorf("failed to initialize project: %d", response.StatusCode)
+			return ProjectContextMsg{Path: path, Previous: previous, Err: err}
+		}
+		_, content, err := a.readProjectContext(ctx)
+		if err == nil && content == nil {
+			err = fmt.Errorf("the agent did not write %s", projectContextFile)
+		}
+		if err != nil {
+			return ProjectContextMsg{Path: path, Previous: previous, Err: err}
+		}
+		return ProjectContextMsg{Path: path, Content: *content, Previous: previous}
+	}
+}
+
+// readProjectContext reads the context file from the server, with its path
+// there, nil when there is none
+func (a *App) readProjectContext(ctx context.Context) (string, *string, error) {
+	response, err := a.Client.GetAppContextWithResponse(ctx)
+	if err != nil {
+		return projectContextFile, nil, err
+	}
+	if response.StatusCode() != 200 || response.JSON200 == nil {
+		return projectContextFile, nil, fmt.Errorf("failed to read %s: %d", projectContextFile, response.StatusCode())
+	}
+	return response.JSON200.Path, response.JSON200.Content, nil
+}
+
+// writeProjectContext writes the context file through the server, removing
+// it when content is nil
+func (a *App) writeProjectContext(ctx context.Context, content *string) error {
+	response, err := a.Client.PutAppContextWithResponse(ctx, client.PutAppContextJSONRequestBody{Content: content})
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("failed to write %s: %d", projectContextFile, response.StatusCode())
+	}
+	return nil
+}
+
+// ApproveProjectContext saves the reviewed content of the context file and
+// marks the project initialized
+func (a *App) ApproveProjectContext(ctx context.Context, project ProjectContextMsg, content string) error {
+	if content != project.Content {
+		if err := a.writeProjectContext(ctx, &content); err != nil {
+			return err
+		}
+	}
+	return a.MarkProjectInitialized(ctx)
+}
+
+// DiscardProjectContext puts back the context file as it was before the
+// initialization, removing it when there was none
+func (a *App) DiscardProjectContext(ctx context.Context, project ProjectContextMsg) error {
+	return a.writeProjectContext(ctx, project.Previous)
+}
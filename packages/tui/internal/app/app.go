@@ -2,19 +2,27 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"log/slog"
+	"net/http"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/bus"
 	"github.com/sst/opencode/internal/commands"
 	"github.com/sst/opencode/internal/components/toast"
 	"github.com/sst/opencode/internal/config"
-	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/image"
+	"github.com/sst/opencode/internal/spell"
 	"github.com/sst/opencode/internal/theme"
 	"github.com/sst/opencode/internal/util"
 	"github.com/sst/opencode/pkg/client"
@@ -23,17 +31,68 @@ import (
 var RootPath string
 
 type App struct {
-	Info      client.AppInfo
-	Version   string
-	StatePath string
-	Config    *client.ConfigInfo
-	Client    *client.ClientWithResponses
-	State     *config.State
-	Provider  *client.ProviderInfo
-	Model     *client.ModelInfo
-	Session   *client.SessionInfo
-	Messages  []client.MessageInfo
-	Commands  commands.CommandRegistry
+	Info        client.AppInfo
+	Version     string
+	StatePath   string
+	Config      *client.ConfigInfo
+	Client      *client.ClientWithResponses
+	State       *config.State
+	Provider    *client.ProviderInfo
+	Model       *client.ModelInfo
+	Session     *client.SessionInfo
+	Messages    []client.MessageInfo
+	Commands    commands.CommandRegistry
+	Comparison  *Comparison
+	RateLimit   *RateLimit
+	Steering    *Steering
+	Chunks      *Chunks
+	Outputs     []CommandOutput
+	RecentFiles *RecentFiles
+	Inspector   *client.Inspector
+	Credentials *client.Credentials
+	// Permissions are the permission requests waiting for a dialog
+	Permissions []client.PermissionInfo
+	// Bus delivers the messages handled by the root model to subscribers
+	Bus *bus.Bus
+	// Events are the recent server events, for bug reports
+	Events *EventHistory
+	config *pendingConfig
+	// messageCache writes the messages of the sessions opened to disk
+	messageCache messageCache
+	// quickReplies are the replies suggested to the last response
+	quickReplies quickReplyCache
+	truncated    map[string]client.MessageInfo
+	fallback     *fallback
+	failed       map[string]bool
+	statuses     map[string]SessionStatus
+	runs         map[string]*Run
+	timings      map[string]*timing
+	metrics      map[string]MessageMetrics
+	sending      map[string]string
+	watcher      *fileWatcher
+	external     map[string]bool
+	// stateBase is the state as last read from or written to StatePath, the
+	// changes since are merged with the ones of other instances on save
+	stateBase *config.State
+	// crash is the crash of the last run, read at launch
+	crash *Crash
+	// stateRecovery tells how a corrupt state file was recovered at launch
+	stateRecovery string
+	dictionary    *spell.Dictionary
+	title         string
+	mode          string
+	system        string
+	parameters    config.ModelParameters
+
+	// ScreenReader is set from the state or the --screen-reader flag
+	ScreenReader bool
+	// Graphics is the protocol images are drawn with, detected at launch
+	Graphics image.Protocol
+
+	reauthenticating atomic.Bool
+	loadingProviders atomic.Bool
+	// rateLimitRetries counts the turns retried in a row after rate limits
+	rateLimitRetries int
 }
 
 type SessionSelectedMsg = *client.SessionInfo
@@ -46,6 +105,8 @@ type CompactSessionMsg struct{}
 type SendMsg struct {
 	Text        string
 	Attachments []Attachment
+	// Oversize is how the prompt is fit when it is over the attachment limit
+	Oversize OversizeAction
 }
 type CompletionDialogTriggeredMsg struct {
 	InitialValue string
@@ -62,77 +123,159 @@ func New(
 ) (*App, error) {
 	RootPath = appInfo.Path.Root
 
-	configResponse, err := httpClient.PostConfigGetWithResponse(ctx)
-	if err != nil {
-		return nil, err
-	}
-	if configResponse.StatusCode() != 200 || configResponse.JSON200 == nil {
-		return nil, fmt.Errorf("failed to get config: %d", configResponse.StatusCode())
-	}
-	configInfo := configResponse.JSON200
-	if configInfo.Keybinds == nil {
-		leader := "ctrl+x"
-		keybinds := client.ConfigKeybinds{
-			Leader: &leader,
-		}
-		configInfo.Keybinds = &keybinds
-	}
+	// The themes are read while the state is, the config is fetched in the
+	// background and applied after the first frame
+	var themesErr error
+	var loading sync.WaitGroup
+	loading.Add(1)
+	go func() {
+		defer loading.Done()
+		themesErr = theme.LoadThemesFromDirectories(
+			appInfo.Path.Config,
+			appInfo.Path.Root,
+			appInfo.Path.Cwd,
+		)
+		StartupStep("themes")
+	}()
+	pending := &pendingConfig{ready: make(chan struct{})}
+	go pending.fetch(ctx, httpClient)
 
 	appStatePath := filepath.Join(appInfo.Path.State, "tui")
 	appState, err := config.LoadState(appStatePath)
+	stateRecovery := ""
+	if errors.Is(err, config.ErrStateCorrupt) {
+		slog.Error("State file is corrupt", "error", err)
+		appState, err = config.RecoverState(appStatePath)
+		stateRecovery = "Settings were damaged and restored from a backup"
+		if err != nil {
+			slog.Error("Failed to recover state", "error", err)
+			stateRecovery = "Settings were damaged and reset"
+		}
+		stateRecovery += ", the damaged file was kept as " + config.CorruptPath(appStatePath)
+	}
 	if err != nil {
 		appState = config.NewState()
 		config.SaveState(appStatePath, appState)
 	}
-
-	if configInfo.Theme != nil {
-		appState.Theme = *configInfo.Theme
+	stateBase, _ := config.LoadState(appStatePath)
+	if appState.SessionDirectories == nil {
+		appState.SessionDirectories = map[string]string{}
 	}
-	if configInfo.Model != nil {
-		splits := strings.Split(*configInfo.Model, "/")
-		appState.Provider = splits[0]
-		appState.Model = strings.Join(splits[1:], "/")
+	if appState.SessionModes == nil {
+		appState.SessionModes = map[string]string{}
 	}
-
-	// Load themes from all directories
-	if err := theme.LoadThemesFromDirectories(
-		appInfo.Path.Config,
-		appInfo.Path.Root,
-		appInfo.Path.Cwd,
-	); err != nil {
-		slog.Warn("Failed to load themes from directories", "error", err)
+	if appState.SessionParameters == nil {
+		appState.SessionParameters = map[string]config.ModelParameters{}
+	}
+	if appState.LayoutPresets == nil {
+		appState.LayoutPresets = map[string]config.LayoutPreset{}
+	}
+	if appState.PinnedContext == nil {
+		appState.PinnedContext = map[string][]config.PinnedContext{}
+	}
+	if appState.Redaction == nil {
+		appState.Redaction = map[string]config.RedactionRules{}
+	}
+	if appState.Workspaces == nil {
+		appState.Workspaces = map[string]config.Workspace{}
+	}
+	if appState.Checkpoints == nil {
+		appState.Checkpoints = map[string][]config.Checkpoint{}
+	}
+	if appState.Fallbacks == nil {
+		appState.Fallbacks = map[string]map[string]string{}
+	}
+	if appState.SystemPrompts == nil {
+		appState.SystemPrompts = map[string]string{}
+	}
+	if appState.Drafts == nil {
+		appState.Drafts = map[string]string{}
+	}
+	if appState.ArchivedSessions == nil {
+		appState.ArchivedSessions = map[string]bool{}
+	}
+	if appState.PinnedSessions == nil {
+		appState.PinnedSessions = map[string]bool{}
+	}
+	if appState.PinnedMessages == nil {
+		appState.PinnedMessages = map[string]bool{}
+	}
+	if appState.ProjectDefaults == nil {
+		appState.ProjectDefaults = map[string]config.ProjectDefault{}
+	}
+	applyProjectDefaults(appState, appInfo.Path.Root)
+	if appState.KeptSessions == nil {
+		appState.KeptSessions = map[string]bool{}
 	}
+	if appState.SessionTags == nil {
+		appState.SessionTags = map[string][]string{}
+	}
+	if appState.SkipConfirmations == nil {
+		appState.SkipConfirmations = map[string]bool{}
+	}
+	for level, seconds := range appState.ToastDurations {
+		toast.Durations[toast.Level(level)] = time.Duration(seconds) * time.Second
+	}
+	if err := i18n.SetLocale(i18n.Detect(appState.Locale)); err != nil {
+		slog.Warn("Failed to set the locale", "error", err)
+	}
+	StartupStep("state")
 
-	if appState.Theme != "" {
-		if appState.Theme == "system" && styles.Terminal != nil {
-			theme.UpdateSystemTheme(
-				styles.Terminal.Background,
-				styles.Terminal.BackgroundIsDark,
-			)
-		}
-		theme.SetTheme(appState.Theme)
+	loading.Wait()
+	if themesErr != nil {
+		slog.Warn("Failed to load themes from directories", "error", themesErr)
 	}
+	applyTheme(appState.Theme)
 
-	slog.Debug("Loaded config", "config", configInfo)
+	// the default keybinds until the config is loaded
+	leader := "ctrl+x"
+	configInfo := &client.ConfigInfo{Keybinds: &client.ConfigKeybinds{Leader: &leader}}
 
 	app := &App{
 		Info:      appInfo,
 		Version:   version,
 		StatePath: appStatePath,
+		stateBase: stateBase,
 		Config:    configInfo,
 		State:     appState,
 		Client:    httpClient,
 		Session:   &client.SessionInfo{},
 		Messages:  []client.MessageInfo{},
 		Commands:  commands.LoadFromConfig(configInfo),
-	}
+		Bus:       bus.New(),
+		Events:    NewEventHistory(),
+		config:    pending,
+		truncated: map[string]client.MessageInfo{},
+		failed:    map[string]bool{},
+		statuses:  map[string]SessionStatus{},
+		runs:      map[string]*Run{},
+		timings:   map[string]*timing{},
+		metrics:   map[string]MessageMetrics{},
+		sending:   map[string]string{},
+		external:  map[string]bool{},
+
+		stateRecovery: stateRecovery,
+		crash:         takeCrash(appInfo.Path.State),
+
+		ScreenReader: appState.ScreenReader,
+	}
+	app.RecentFiles = newRecentFiles(app.ActiveRoot())
+	app.loadingProviders.Store(true)
+	app.trackStatuses()
 
 	return app, nil
 }
 
 func (a *App) InitializeProvider() tea.Cmd {
 	return func() tea.Msg {
+		defer a.loadingProviders.Store(false)
+		defer StartupStep("providers")
 		providersResponse, err := a.Client.PostProviderListWithResponse(context.Background())
+		// the model of the config is preferred, it is listed along with it
+		preferredProvider, preferredModel := a.State.Provider, a.State.Model
+		if configInfo, _ := a.config.wait(); configInfo != nil && configInfo.Model != nil {
+			preferredProvider, preferredModel, _ = strings.Cut(*configInfo.Model, "/")
+		}
 		if err != nil {
 			slog.Error("Failed to list providers", "error", err)
 			// TODO: notify user
@@ -168,17 +311,21 @@ func (a *App) InitializeProvider() tea.Cmd {
 		}
 		if len(providers) == 0 {
 			slog.Error("No providers configured")
-			return nil
+			return NoProvidersMsg{}
+		}
+		if provider, model := a.topFavorite(providers); provider != nil {
+			defaultProvider = provider
+			defaultModel = model
 		}
 
 		var currentProvider *client.ProviderInfo
 		var currentModel *client.ModelInfo
 		for _, provider := range providers {
-			if provider.Id == a.State.Provider {
+			if provider.Id == preferredProvider {
 				currentProvider = &provider
 
 				for _, model := range provider.Models {
-					if model.Id == a.State.Model {
+					if model.Id == preferredModel {
 						currentModel = &model
 					}
 				}
@@ -217,22 +364,42 @@ type Attachment struct {
 }
 
 func (a *App) IsBusy() bool {
-	if len(a.Messages) == 0 {
-		return false
+	if a.IsComparing() {
+		return a.Comparison.Busy()
 	}
-
-	lastMessage := a.Messages[len(a.Messages)-1]
-	return lastMessage.Metadata.Time.Completed == nil
+	return a.Run().State != RunIdle
 }
 
 func (a *App) SaveState() {
-	err := config.SaveState(a.StatePath, a.State)
+	base, err := config.SyncState(a.StatePath, a.stateBase, a.State)
 	if err != nil {
 		slog.Error("Failed to save state", "error", err)
+		return
 	}
+	a.stateBase = base
 }
 
+// StateRecovery warns that the state file was corrupt at launch and how it
+// was recovered
+func (a *App) StateRecovery() tea.Cmd {
+	if a.stateRecovery == "" {
+		return nil
+	}
+	return toast.NewWarningToast(a.stateRecovery)
+}
+
+// InitializeProject creates a session to write AGENTS.md in, offering to
+// resume the latest session first when its last response did not finish
 func (a *App) InitializeProject(ctx context.Context) tea.Cmd {
+	if session, ok := a.UnfinishedSession(ctx); ok && session.Id != a.Session.Id {
+		return util.CmdHandler(ResumePromptMsg{Session: *session, Decline: InitializeProjectMsg{}})
+	}
+	return a.InitializeNewSession(ctx)
+}
+
+// InitializeNewSession initializes the project in a new session without
+// looking for unfinished work
+func (a *App) InitializeNewSession(ctx context.Context) tea.Cmd {
 	cmds := []tea.Cmd{}
 
 	session, err := a.CreateSession(ctx)
@@ -244,40 +411,32 @@ func (a *App) InitializeProject(ctx context.Context) tea.Cmd {
 	a.Session = session
 	cmds = append(cmds, util.CmdHandler(SessionSelectedMsg(session)))
 
-	go func() {
-		response, err := a.Client.PostSessionInitialize(ctx, client.PostSessionInitializeJSONRequestBody{
-			SessionID:  a.Session.Id,
-			ProviderID: a.Provider.Id,
-			ModelID:    a.Model.Id,
-		})
-		if err != nil {
-			slog.Error("Failed to initialize project", "error", err)
-			// status.Error(err.Error())
-		}
-		if response != nil && response.StatusCode != 200 {
-			slog.Error("Failed to initialize project", "error", response.StatusCode)
-			// status.Error(fmt.Sprintf("failed to initialize project: %d", response.StatusCode))
-		}
-	}()
-
+	cmds = append(cmds, a.generateProjectContext(ctx))
 	return tea.Batch(cmds...)
 }
 
 func (a *App) CompactSession(ctx context.Context) tea.Cmd {
-	go func() {
-		response, err := a.Client.PostSessionSummarizeWithResponse(ctx, client.PostSessionSummarizeJSONRequestBody{
-			SessionID:  a.Session.Id,
-			ProviderID: a.Provider.Id,
-			ModelID:    a.Model.Id,
-		})
+	body := client.PostSessionSummarizeJSONRequestBody{
+		SessionID:  a.Session.Id,
+		ProviderID: a.Provider.Id,
+		ModelID:    a.Model.Id,
+	}
+	key := fmt.Sprintf("summarize-%s-%d", body.SessionID, time.Now().UnixNano())
+	send := func() (*http.Response, error) {
+		return a.Client.PostSessionSummarize(ctx, body, idempotencyKey(key))
+	}
+	return a.retried("", send, func(response *http.Response, err error) tea.Msg {
 		if err != nil {
 			slog.Error("Failed to compact session", "error", err)
 		}
-		if response != nil && response.StatusCode() != 200 {
-			slog.Error("Failed to compact session", "error", response.StatusCode)
+		if response != nil {
+			response.Body.Close()
+			if response.StatusCode != 200 {
+				slog.Error("Failed to compact session", "error", response.StatusCode)
+			}
 		}
-	}()
-	return nil
+		return nil
+	})
 }
 
 func (a *App) MarkProjectInitialized(ctx context.Context) error {
@@ -301,10 +460,34 @@ func (a *App) CreateSession(ctx context.Context) (*client.SessionInfo, error) {
 		return nil, fmt.Errorf("failed to create session: %d", resp.StatusCode())
 	}
 	session := resp.JSON200
+	a.State.SessionDirectories[session.Id] = a.Info.Path.Cwd
+	a.SaveState()
 	return session, nil
 }
 
 func (a *App) SendChatMessage(ctx context.Context, text string, attachments []Attachment) tea.Cmd {
+	return a.sendChatMessage(ctx, a.attachOutputs(text), a.Provider, a.Model, a.promptParts(attachments)...)
+}
+
+// promptParts are the parts sent after the text of a prompt: its
+// attachments and the context added to every prompt
+func (a *App) promptParts(attachments []Attachment) []client.MessagePart {
+	parts := append(pasteParts(attachments), imageParts(attachments)...)
+	parts = append(parts, a.externalChangesPart()...)
+	return append(parts, a.pinnedContextParts()...)
+}
+
+func (a *App) sendChatMessage(ctx context.Context, text string, provider *client.ProviderInfo, model *client.ModelInfo, extra ...client.MessagePart) tea.Cmd {
+	part := client.MessagePart{}
+	part.FromMessagePartText(client.MessagePartText{
+		Type: "text",
+		Text: text,
+	})
+	return a.sendParts(ctx, append([]client.MessagePart{part}, extra...), provider, model)
+}
+
+// sendParts sends a prompt to the model, its text part first
+func (a *App) sendParts(ctx context.Context, parts []client.MessagePart, provider *client.ProviderInfo, model *client.ModelInfo) tea.Cmd {
 	var cmds []tea.Cmd
 	if a.Session.Id == "" {
 		session, err := a.CreateSession(ctx)
@@ -312,15 +495,14 @@ func (a *App) SendChatMessage(ctx context.Context, text string, attachments []At
 			return toast.NewErrorToast(err.Error())
 		}
 		a.Session = session
+		a.adoptPinnedContext(session.Id)
+		a.adoptWorkspace(session.Id)
 		cmds = append(cmds, util.CmdHandler(SessionSelectedMsg(session)))
 	}
 
-	part := client.MessagePart{}
-	part.FromMessagePartText(client.MessagePartText{
-		Type: "text",
-		Text: text,
-	})
-	parts := []client.MessagePart{part}
+	parts, masked := a.RedactParts(parts)
+	cmds = append(cmds, redactionWarning(masked))
+	turn := FailedTurn{SessionID: a.Session.Id, After: a.lastServerMessage(), Parts: parts}
 
 	optimisticMessage := client.MessageInfo{
 		Id:    fmt.Sprintf("optimistic-%d", time.Now().UnixNano()),
@@ -339,27 +521,47 @@ func (a *App) SendChatMessage(ctx context.Context, text string, attachments []At
 	}
 
 	a.Messages = append(a.Messages, optimisticMessage)
+	a.queueRun(a.Session.Id)
 	cmds = append(cmds, util.CmdHandler(OptimisticMessageAddedMsg{Message: optimisticMessage}))
 
-	cmds = append(cmds, func() tea.Msg {
-		response, err := a.Client.PostSessionChat(ctx, client.PostSessionChatJSONRequestBody{
-			SessionID:  a.Session.Id,
+	mode := a.rememberMode(a.Session.Id)
+	system := a.rememberSystemPrompt(a.Session.Id)
+	parameters := a.rememberParameters(a.Session.Id)
+	root := a.RootParam()
+	sessionID := a.Session.Id
+	send := func() (*http.Response, error) {
+		return a.postChat(ctx, withParameters(client.PostSessionChatJSONBody{
+			SessionID:  sessionID,
 			Parts:      parts,
-			ProviderID: a.Provider.Id,
-			ModelID:    a.Model.Id,
-		})
+			ProviderID: provider.Id,
+			ModelID:    model.Id,
+			Root:       root,
+		}, parameters), mode, system, idempotencyKey(optimisticMessage.Id))
+	}
+	cmds = append(cmds, a.retried(optimisticMessage.Id, send, func(response *http.Response, err error) tea.Msg {
 		if err != nil {
 			errormsg := fmt.Sprintf("failed to send message: %v", err)
 			slog.Error(errormsg)
 			return toast.NewErrorToast(errormsg)()
 		}
+		if response != nil && response.StatusCode == http.StatusTooManyRequests {
+			return RateLimitedMsg{RetryAfter: ParseRetryAfter(response.Header), MessageID: optimisticMessage.Id, Turn: turn}
+		}
+		if response != nil && response.StatusCode >= 500 {
+			return ProviderFailedMsg{
+				Turn:       turn,
+				ProviderID: provider.Id,
+				ModelID:    model.Id,
+				Error:      fmt.Sprintf("failed to send message: %d", response.StatusCode),
+			}
+		}
 		if response != nil && response.StatusCode != 200 {
 			errormsg := fmt.Sprintf("failed to send message: %d", response.StatusCode)
 			slog.Error(errormsg)
 			return toast.NewErrorToast(errormsg)()
 		}
 		return nil
-	})
+	}))
 
 	// The actual response will come through SSE
 	// For now, just return success
@@ -367,6 +569,10 @@ func (a *App) SendChatMessage(ctx context.Context, text string, attachments []At
 }
 
 func (a *App) Cancel(ctx context.Context, sessionID string) error {
+	// the parts left of a chunked prompt are dropped along with the turn
+	if a.Chunks != nil && a.Chunks.SessionID == sessionID {
+		a.Chunks = nil
+	}
 	response, err := a.Client.PostSessionAbort(ctx, client.PostSessionAbortJSONRequestBody{
 		SessionID: sessionID,
 	})
@@ -383,6 +589,71 @@ func (a *App) Cancel(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// StopAndRetain aborts the current request but keeps the partially streamed
+// assistant message, finalizing it locally and marking it as truncated
+func (a *App) StopAndRetain(ctx context.Context) error {
+	if len(a.Messages) > 0 {
+		last := a.Messages[len(a.Messages)-1]
+		if last.Role == client.Assistant && last.Metadata.Time.Completed == nil {
+			completed := float32(time.Now().UnixMilli())
+			last.Metadata.Time.Completed = &completed
+			a.Messages[len(a.Messages)-1] = last
+			a.truncated[last.Id] = last
+		}
+	}
+	return a.Cancel(ctx, a.Session.Id)
+}
+
+// SetMessages replaces the messages of the current session, forgetting the
+// truncated messages that are not among them, such as those of the session
+// switched away from
+func (a *App) SetMessages(messages []client.MessageInfo) {
+	a.Messages = messages
+	for id := range a.truncated {
+		if !slices.ContainsFunc(messages, func(message client.MessageInfo) bool { return message.Id == id }) {
+			delete(a.truncated, id)
+		}
+	}
+}
+
+// IsTruncated reports whether the message was stopped with StopAndRetain
+func (a *App) IsTruncated(messageID string) bool {
+	_, ok := a.truncated[messageID]
+	return ok
+}
+
+// ApplyRetained keeps the locally retained partial output of a truncated
+// message when the server sends an update that would discard it
+func (a *App) ApplyRetained(message client.MessageInfo) client.MessageInfo {
+	retained, ok := a.truncated[message.Id]
+	if !ok {
+		return message
+	}
+	if len(message.Parts) < len(retained.Parts) {
+		message.Parts = retained.Parts
+	}
+	if message.Metadata.Time.Completed == nil {
+		message.Metadata.Time.Completed = retained.Metadata.Time.Completed
+	}
+	message.Metadata.Error = nil
+	return message
+}
+
+// MessageText joins all text parts of a message
+func MessageText(message client.MessageInfo) string {
+	texts := []string{}
+	for _, p := range message.Parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		if text, ok := part.(client.MessagePartText); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
 func (a *App) ListSessions(ctx context.Context) ([]client.SessionInfo, error) {
 	resp, err := a.Client.PostSessionListWithResponse(ctx)
 	if err != nil {
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// PreviewAttachmentMsg previews a pending attachment of the editor
+type PreviewAttachmentMsg struct {
+	Index int
+}
+
+// RemoveAttachmentMsg drops a pending attachment of the editor before the
+// prompt is sent
+type RemoveAttachmentMsg struct {
+	Index int
+}
+
+// FormatSize formats a number of bytes, such as 12.4 KB
+func FormatSize(bytes int) string {
+	switch {
+	case bytes < 1024:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+}
+
+// AttachmentSummary describes an attachment by its name, size and type
+func AttachmentSummary(attachment Attachment) string {
+	return fmt.Sprintf("%s · %s · %s", attachment.FileName, FormatSize(len(attachment.Content)), attachment.MimeType)
+}
+
+// IsImage reports whether an attachment holds an image
+func IsImage(attachment Attachment) bool {
+	return strings.HasPrefix(attachment.MimeType, "image/")
+}
+
+// imageParts returns the file parts sending the attached images along with
+// the prompt, as data URLs
+func imageParts(attachments []Attachment) []client.MessagePart {
+	parts := []client.MessagePart{}
+	for _, attachment := range attachments {
+		if !IsImage(attachment) {
+			continue
+		}
+		filename := attachment.FileName
+		part := client.MessagePart{}
+		part.FromMessagePartFile(client.MessagePartFile{
+			Type:      "file",
+			Filename:  &filename,
+			MediaType: attachment.MimeType,
+			Url:       "data:" + attachment.MimeType + ";base64," + base64.StdEncoding.EncodeToString(attachment.Content),
+		})
+		parts = append(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// AuditLogMsg carries the audit log of the current session, Export writes it
+// to a file instead of showing it
+type AuditLogMsg struct {
+	Entries []client.AuditEntry
+	Export  bool
+	Err     error
+}
+
+// ExportAuditMsg writes the audit log entries to a file
+type ExportAuditMsg struct {
+	Entries []client.AuditEntry
+}
+
+// AuditLog fetches the tool calls the agent made in the current session,
+// oldest first
+func (a *App) AuditLog(ctx context.Context, export bool) tea.Cmd {
+	sessionID := a.Session.Id
+	return func() tea.Msg {
+		if sessionID == "" {
+			return AuditLogMsg{Export: export, Err: errors.New("no session to audit")}
+		}
+		response, err := a.Client.PostSessionAuditWithResponse(ctx, client.PostSessionAuditJSONRequestBody{SessionID: sessionID})
+		if err != nil {
+			return AuditLogMsg{Export: export, Err: err}
+		}
+		if response.StatusCode() != 200 || response.JSON200 == nil {
+			return AuditLogMsg{Export: export, Err: fmt.Errorf("failed to fetch the audit log: %d", response.StatusCode())}
+		}
+		return AuditLogMsg{Entries: *response.JSON200, Export: export}
+	}
+}
+
+// ExportAudit writes audit log entries to the working directory, one JSON
+// object per line, and returns the path of the file
+func (a *App) ExportAudit(entries []client.AuditEntry) (string, error) {
+	var out strings.Builder
+	encoder := json.NewEncoder(&out)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return "", err
+		}
+	}
+	name := fmt.Sprintf("opencode-audit-%s-%s.jsonl", a.Session.Id, time.Now().Format("20060102-150405"))
+	path := filepath.Join(a.Info.Path.Cwd, name)
+	if err := os.WriteFile(path, []byte(out.String()), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// AuditChange describes what a tool call did to a file from its hashes
+func AuditChange(file client.AuditFile) string {
+	switch {
+	case file.Before == nil && file.After == nil:
+		return "missing"
+	case file.Before == nil:
+		return "created"
+	case file.After == nil:
+		return "deleted"
+	case *file.Before == *file.After:
+		return "unchanged"
+	}
+	return "modified"
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// ProviderOption is a provider that can be set up with an API key
+type ProviderOption struct {
+	ID   string
+	Name string
+}
+
+// SupportedProviders are offered by the onboarding wizard
+var SupportedProviders = []ProviderOption{
+	{ID: "anthropic", Name: "Anthropic"},
+	{ID: "openai", Name: "OpenAI"},
+	{ID: "google", Name: "Google"},
+	{ID: "openrouter", Name: "OpenRouter"},
+	{ID: "groq", Name: "Groq"},
+	{ID: "mistral", Name: "Mistral"},
+	{ID: "xai", Name: "xAI"},
+}
+
+// NoProvidersMsg is sent when the server has no configured providers
+type NoProvidersMsg struct{}
+
+// ProviderCredentialsMsg carries an API key entered in the onboarding wizard
+type ProviderCredentialsMsg struct {
+	ProviderID string
+	Key        string
+}
+
+// SaveCredentials stores an API key through the server, which checks it with
+// the provider and reloads its providers with it, and selects the provider
+// once it is available
+func (a *App) SaveCredentials(ctx context.Context, providerID, key string) tea.Cmd {
+	return func() tea.Msg {
+		if key == "" {
+			return toast.NewErrorToast(i18n.T("toast.api_key_empty"), toast.WithTitle(i18n.T("toast.provider_setup_title")))()
+		}
+		response, err := a.Client.PostProviderAuthWithResponse(ctx, client.PostProviderAuthJSONRequestBody{
+			ProviderID: providerID,
+			Key:        key,
+		})
+		if err != nil {
+			return toast.NewErrorToast(i18n.T("toast.credentials_failed", err.Error()), toast.WithTitle(i18n.T("toast.provider_setup_title")))()
+		}
+		if response.JSON400 != nil {
+			return toast.NewErrorToast(
+				i18n.T("toast.api_key_rejected", providerID, response.JSON400.Data["message"]),
+				toast.WithTitle(i18n.T("toast.provider_setup_title")),
+			)()
+		}
+		if response.StatusCode() != 200 || response.JSON200 == nil {
+			return toast.NewErrorToast(
+				i18n.T("toast.credentials_failed", strconv.Itoa(response.StatusCode())),
+				toast.WithTitle(i18n.T("toast.provider_setup_title")),
+			)()
+		}
+		if !*response.JSON200 {
+			return toast.NewWarningToast(
+				i18n.T("toast.credentials_not_loaded", providerID),
+				toast.WithTitle(i18n.T("toast.provider_setup_title")),
+			)()
+		}
+		return a.InitializeProvider()()
+	}
+}
+
+// AuthFailedMsg is sent when the server rejects the bearer token
+type AuthFailedMsg struct{}
+
+// AuthPromptMsg asks for a new server token, Retry is set when the last one
+// was rejected too
+type AuthPromptMsg struct {
+	Retry bool
+}
+
+// AuthTokenMsg carries a server token entered by the user
+type AuthTokenMsg struct {
+	Token string
+}
+
+// AuthCancelledMsg is sent when the user dismisses the token prompt
+type AuthCancelledMsg struct{}
+
+// AuthRestoredMsg is sent once the server accepts the credentials again
+type AuthRestoredMsg struct{}
+
+// Reauthenticating reports whether the credentials were rejected and not yet
+// replaced, failures caused by the rejection should not be reported meanwhile
+func (a *App) Reauthenticating() bool {
+	return a.reauthenticating.Load()
+}
+
+// Reauthenticate starts the re-authentication flow, re-reading the token
+// file before asking for a new token. It is a no-op while one is running.
+func (a *App) Reauthenticate() tea.Cmd {
+	if !a.reauthenticating.CompareAndSwap(false, true) {
+		return nil
+	}
+	return func() tea.Msg {
+		if a.Credentials != nil && a.Credentials.Refresh() {
+			return a.verifyCredentials()
+		}
+		return AuthPromptMsg{}
+	}
+}
+
+// CancelReauthentication ends the re-authentication flow without new
+// credentials, the next rejection starts it again
+func (a *App) CancelReauthentication() {
+	a.reauthenticating.Store(false)
+}
+
+// UseToken replaces the server token and checks that it is accepted
+func (a *App) UseToken(token string) tea.Cmd {
+	if a.Credentials != nil {
+		a.Credentials.SetToken(token)
+	}
+	return a.verifyCredentials
+}
+
+func (a *App) verifyCredentials() tea.Msg {
+	_, err := a.Client.PostAppInfoWithResponse(context.Background())
+	if errors.Is(err, client.ErrUnauthorized) {
+		return AuthPromptMsg{Retry: true}
+	}
+	a.reauthenticating.Store(false)
+	if err != nil {
+		return toast.NewErrorToast(i18n.T("toast.server_unreachable", err.Error()), toast.WithTitle(i18n.T("toast.auth_title")))()
+	}
+	return AuthRestoredMsg{}
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/logging"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	// bugReportEvents is the number of recent server events kept for bug
+	// reports
+	bugReportEvents = 200
+	// bugReportLogLines is the number of log lines a bug report includes
+	bugReportLogLines = 2000
+)
+
+// BugReportMsg reports where the bug report archive was written
+type BugReportMsg struct {
+	Path string
+	Err  error
+}
+
+// recordedEvent is a server event as it arrived, its content is stripped
+// when a bug report includes it
+type recordedEvent struct {
+	Time  time.Time `json:"time"`
+	Event any       `json:"event"`
+}
+
+// EventHistory keeps the most recent server events, to include them in
+// bug reports
+type EventHistory struct {
+	mu     sync.Mutex
+	events []recordedEvent
+}
+
+func NewEventHistory() *EventHistory {
+	return &EventHistory{}
+}
+
+// Record adds an event, dropping the oldest beyond bugReportEvents
+func (h *EventHistory) Record(event any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, recordedEvent{Time: time.Now(), Event: event})
+	if len(h.events) > bugReportEvents {
+		h.events = h.events[len(h.events)-bugReportEvents:]
+	}
+}
+
+func (h *EventHistory) recorded() []recordedEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events := make([]recordedEvent, len(h.events))
+	for i, event := range h.events {
+		events[i] = recordedEvent{Time: event.Time, Event: stripContent(event.Event)}
+	}
+	return events
+}
+
+// BugReport bundles the recent logs, the redacted config, the state and the
+// recent server events into a zip under the state directory. What the user
+// wrote is left out: the drafts, prompts, pinned context and snippets of the
+// state, and the text of the events.
+func (a *App) BugReport() tea.Cmd {
+	// the state is encoded now, it is only safe to read from the update loop
+	var state bytes.Buffer
+	stateCopy := *a.State
+	stateCopy.Proxy = client.RedactURL(stateCopy.Proxy)
+	stateCopy.Drafts = nil
+	stateCopy.SystemPrompts = nil
+	stateCopy.PinnedContext = nil
+	stateCopy.Snippets = nil
+	stateCopy.Redaction = nil
+	stateErr := toml.NewEncoder(&state).Encode(stateCopy)
+	info := map[string]any{
+		"version":  a.Version,
+		"os":       runtime.GOOS,
+		"arch":     runtime.GOARCH,
+		"go":       runtime.Version(),
+		"term":     os.Getenv("TERM"),
+		"program":  os.Getenv("TERM_PROGRAM"),
+		"graphics": string(a.Graphics),
+		"app":      a.Info,
+		"session":  a.Session.Id,
+		"messages": len(a.Messages),
+		"run":      a.Run().Label(),
+	}
+	if a.Provider != nil && a.Model != nil {
+		info["model"] = a.Provider.Id + "/" + a.Model.Id
+	}
+	config := client.Redact(a.Config)
+	events := []recordedEvent{}
+	if a.Events != nil {
+		events = a.Events.recorded()
+	}
+	dir := filepath.Join(a.Info.Path.State, "bug-reports")
+
+	return func() tea.Msg {
+		if stateErr != nil {
+			return BugReportMsg{Err: fmt.Errorf("failed to encode state: %w", stateErr)}
+		}
+		logs, err := logging.Tail(logging.Path(a.Info.Path.State), bugReportLogLines)
+		if err != nil && !os.IsNotExist(err) {
+			logs = []string{"failed to read the log file: " + err.Error()}
+		}
+		files := []struct {
+			name    string
+			content any
+		}{
+			{"info.json", info},
+			{"config.json", config},
+			{"state.toml", state.Bytes()},
+			{"events.json", events},
+			{"tui.log", []byte(strings.Join(logs, "\n") + "\n")},
+		}
+
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return BugReportMsg{Err: err}
+		}
+		path := filepath.Join(dir, "opencode-bug-report-"+time.Now().Format("20060102-150405")+".zip")
+		file, err := os.Create(path)
+		if err != nil {
+			return BugReportMsg{Err: err}
+		}
+		defer file.Close()
+		archive := zip.NewWriter(file)
+		for _, entry := range files {
+			content, ok := entry.content.([]byte)
+			if !ok {
+				content, err = json.MarshalIndent(entry.content, "", "  ")
+				if err != nil {
+					return BugReportMsg{Err: fmt.Errorf("failed to encode %s: %w", entry.name, err)}
+				}
+			}
+			w, err := archive.Create(entry.name)
+			if err != nil {
+				return BugReportMsg{Err: err}
+			}
+			if _, err := w.Write(content); err != nil {
+				return BugReportMsg{Err: err}
+			}
+		}
+		if err := archive.Close(); err != nil {
+			return BugReportMsg{Err: err}
+		}
+		return BugReportMsg{Path: path}
+	}
+}
+
+// eventKeys are the keys of server events whose text a bug report keeps,
+// the identifiers and states that tell what happened in which order
+var eventKeys = []string{"type", "id", "sessionid", "messageid", "toolcallid", "parentid", "role", "status", "state", "tool", "toolname", "providerid", "modelid", "name", "version"}
+
+// stripContent returns an event encoded as JSON with its text replaced,
+// but for the keys in eventKeys, numbers and booleans are kept
+func stripContent(event any) any {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Sprintf("%T", event)
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Sprintf("%T", event)
+	}
+	return stripValue(decoded, false)
+}
+
+func stripValue(value any, keep bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			value[key] = stripValue(child, slices.Contains(eventKeys, strings.ToLower(key)))
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = stripValue(child, keep)
+		}
+		return value
+	case string:
+		if !keep && value != "" {
+			return fmt.Sprintf("[%d chars]", len([]rune(value)))
+		}
+	}
+	return value
+}
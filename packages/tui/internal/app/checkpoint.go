@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/pkg/client"
+)
+
+type CheckpointNamedMsg struct {
+	Name string
+}
+
+// RestoreCheckpointMsg restores a checkpoint, asking first unless Confirmed
+type RestoreCheckpointMsg struct {
+	Name      string
+	Confirmed bool
+}
+
+// CheckpointRestoredMsg carries the messages a session was rolled back to
+// and the files restored with them
+type CheckpointRestoredMsg struct {
+	Name      string
+	SessionID string
+	Messages  []client.MessageInfo
+	Files     []string
+	Err       error
+}
+
+// CreateCheckpoint records the last message of the current session under
+// name, replacing an existing checkpoint with the same name, and saves it
+func (a *App) CreateCheckpoint(name string) error {
+	if a.Session.Id == "" {
+		return errors.New("no active session")
+	}
+	if name == "" {
+		return errors.New("checkpoint name is empty")
+	}
+	checkpoint := config.Checkpoint{Name: name, Created: time.Now()}
+	for i := len(a.Messages) - 1; i >= 0; i-- {
+		// optimistic messages are not on the server yet
+		if !strings.HasPrefix(a.Messages[i].Id, "optimistic-") {
+			checkpoint.MessageID = a.Messages[i].Id
+			break
+		}
+	}
+	checkpoints := slices.DeleteFunc(slices.Clone(a.State.Checkpoints[a.Session.Id]), func(c config.Checkpoint) bool {
+		return c.Name == name
+	})
+	a.State.Checkpoints[a.Session.Id] = append(checkpoints, checkpoint)
+	a.SaveState()
+	return nil
+}
+
+// Checkpoints returns the checkpoints of the current session, newest first
+func (a *App) Checkpoints() []config.Checkpoint {
+	checkpoints := slices.Clone(a.State.Checkpoints[a.Session.Id])
+	slices.Reverse(checkpoints)
+	return checkpoints
+}
+
+// RestoreCheckpoint drops the messages of the current session after the
+// checkpoint on the server, which restores the files the agent changed in
+// them. Checkpoints created later are dropped.
+func (a *App) RestoreCheckpoint(ctx context.Context, name string) tea.Cmd {
+	sessionID := a.Session.Id
+	checkpoints := a.State.Checkpoints[sessionID]
+	idx := slices.IndexFunc(checkpoints, func(c config.Checkpoint) bool {
+		return c.Name == name
+	})
+	if idx < 0 {
+		return func() tea.Msg {
+			return CheckpointRestoredMsg{Name: name, Err: fmt.Errorf("checkpoint not found: %s", name)}
+		}
+	}
+	checkpoint := checkpoints[idx]
+	return func() tea.Msg {
+		body := client.PostSessionRevertJSONRequestBody{SessionID: sessionID}
+		if checkpoint.MessageID != "" {
+			body.MessageID = &checkpoint.MessageID
+		}
+		response, err := a.Client.PostSessionRevertWithResponse(ctx, body)
+		if err != nil {
+			return CheckpointRestoredMsg{Name: name, Err: err}
+		}
+		if response.StatusCode() != 200 || response.JSON200 == nil {
+			return CheckpointRestoredMsg{Name: name, Err: fmt.Errorf("failed to restore checkpoint: %d", response.StatusCode())}
+		}
+		messages, err := a.ListMessages(ctx, sessionID)
+		if err != nil {
+			return CheckpointRestoredMsg{Name: name, Err: err}
+		}
+		return CheckpointRestoredMsg{
+			Name:      name,
+			SessionID: sessionID,
+			Messages:  messages,
+			Files:     *response.JSON200,
+		}
+	}
+}
+
+// CheckpointRestored drops the checkpoints created after a restored one
+func (a *App) CheckpointRestored(msg CheckpointRestoredMsg) {
+	checkpoints := a.State.Checkpoints[msg.SessionID]
+	idx := slices.IndexFunc(checkpoints, func(c config.Checkpoint) bool {
+		return c.Name == msg.Name
+	})
+	if idx < 0 || idx == len(checkpoints)-1 {
+		return
+	}
+	a.State.Checkpoints[msg.SessionID] = slices.Clone(checkpoints[:idx+1])
+	a.SaveState()
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	// cleanupInterval is how often the retention policy looks for sessions
+	// to delete while the app runs
+	cleanupInterval = 6 * time.Hour
+	// defaultCleanupDays is the age reviewed by /cleanup without a policy
+	defaultCleanupDays = 30
+)
+
+// CleanupTickMsg runs the retention policy
+type CleanupTickMsg struct{}
+
+// CleanupCandidate is a session the retention policy would delete
+type CleanupCandidate struct {
+	Session  client.SessionInfo
+	Messages int
+}
+
+// CleanupCandidatesMsg lists the sessions to review before deleting them,
+// Requested is set when the review was asked for with /cleanup
+type CleanupCandidatesMsg struct {
+	Candidates []CleanupCandidate
+	Days       int
+	Requested  bool
+	Err        error
+}
+
+// CleanupReviewedMsg deletes the reviewed sessions and keeps the other ones
+// from being proposed again
+type CleanupReviewedMsg struct {
+	Delete []string
+	Keep   []string
+}
+
+// RunCleanup looks for sessions to delete and schedules the next run of the
+// retention policy, it returns nil when no policy is set
+func (a *App) RunCleanup() tea.Cmd {
+	if a.State.CleanupDays <= 0 {
+		return nil
+	}
+	return tea.Batch(
+		a.FindCleanup(a.State.CleanupDays, false),
+		tea.Tick(cleanupInterval, func(time.Time) tea.Msg {
+			return CleanupTickMsg{}
+		}),
+	)
+}
+
+// FindCleanup lists the unarchived top-level sessions untouched for days,
+// only the empty ones unless the state also cleans up non-empty sessions.
+// The current session, pinned sessions and the ones kept in an earlier
+// review are skipped.
+func (a *App) FindCleanup(days int, requested bool) tea.Cmd {
+	current := a.Session.Id
+	kept := map[string]bool{}
+	for id := range a.State.KeptSessions {
+		kept[id] = true
+	}
+	for id := range a.State.PinnedSessions {
+		kept[id] = true
+	}
+	archived := map[string]bool{}
+	for id := range a.State.ArchivedSessions {
+		archived[id] = true
+	}
+	nonEmpty := a.State.CleanupNonEmpty
+	return func() tea.Msg {
+		ctx := context.Background()
+		sessions, err := a.ListSessions(ctx)
+		if err != nil {
+			return CleanupCandidatesMsg{Days: days, Requested: requested, Err: err}
+		}
+		cutoff := time.Now().AddDate(0, 0, -days).UnixMilli()
+		candidates := []CleanupCandidate{}
+		for _, session := range sessions {
+			if session.Id == current || session.ParentID != nil || kept[session.Id] || archived[session.Id] ||
+				int64(session.Time.Updated) > cutoff {
+				continue
+			}
+			messages, err := a.ListMessages(ctx, session.Id)
+			if err != nil {
+				return CleanupCandidatesMsg{Days: days, Requested: requested, Err: err}
+			}
+			if len(messages) > 0 && !nonEmpty {
+				continue
+			}
+			candidates = append(candidates, CleanupCandidate{Session: session, Messages: len(messages)})
+		}
+		return CleanupCandidatesMsg{Candidates: candidates, Days: days, Requested: requested}
+	}
+}
+
+// CleanupDays returns the age in days of the sessions the retention policy
+// deletes, /cleanup reviews sessions of a month without a policy
+func (a *App) CleanupDays() int {
+	if a.State.CleanupDays > 0 {
+		return a.State.CleanupDays
+	}
+	return defaultCleanupDays
+}
+
+// KeepSessions stops the retention policy from proposing sessions again
+func (a *App) KeepSessions(sessionIDs []string) {
+	for _, id := range sessionIDs {
+		a.State.KeptSessions[id] = true
+	}
+	a.SaveState()
+}
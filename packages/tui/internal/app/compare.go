@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// CompareSide is one provider/model pair taking part in a comparison
+type CompareSide struct {
+	Provider client.ProviderInfo
+	Model    client.ModelInfo
+	Session  *client.SessionInfo
+	Messages []client.MessageInfo
+	// Failed is set when the prompt could not be sent to the side, it gets
+	// no messages then
+	Failed bool
+}
+
+// Comparison sends a single prompt to two models in parallel sessions
+type Comparison struct {
+	Sides [2]*CompareSide
+	Sent  bool
+}
+
+type CompareModelSelectedMsg struct {
+	Provider client.ProviderInfo
+	Model    client.ModelInfo
+}
+type CompareWinnerSelectedMsg struct {
+	Side int
+}
+type ComparisonClearedMsg struct{}
+
+// CompareFailedMsg is sent when the prompt could not be sent to a side
+type CompareFailedMsg struct {
+	SessionID string
+	Err       error
+}
+
+// StartComparison pairs the current model with the given one
+func (a *App) StartComparison(provider client.ProviderInfo, model client.ModelInfo) {
+	a.Comparison = &Comparison{
+		Sides: [2]*CompareSide{
+			{Provider: *a.Provider, Model: *a.Model},
+			{Provider: provider, Model: model},
+		},
+	}
+}
+
+// IsComparing reports whether a comparison prompt has been sent
+func (a *App) IsComparing() bool {
+	return a.Comparison != nil && a.Comparison.Sent
+}
+
+// Side returns the comparison side that owns the given session, if any
+func (c *Comparison) Side(sessionID string) *CompareSide {
+	for _, side := range c.Sides {
+		if side.Session != nil && side.Session.Id == sessionID {
+			return side
+		}
+	}
+	return nil
+}
+
+// Busy reports whether either side is still generating a response
+func (c *Comparison) Busy() bool {
+	for _, side := range c.Sides {
+		if side.Failed {
+			continue
+		}
+		if len(side.Messages) == 0 {
+			return true
+		}
+		last := side.Messages[len(side.Messages)-1]
+		if last.Role == client.User || last.Metadata.Time.Completed == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SendComparison sends a prompt to both sides of the comparison, with the
+// same parts SendChatMessage sends
+func (a *App) SendComparison(ctx context.Context, text string, attachments []Attachment) tea.Cmd {
+	part := client.MessagePart{}
+	part.FromMessagePartText(client.MessagePartText{
+		Type: "text",
+		Text: a.attachOutputs(text),
+	})
+	parts, masked := a.RedactParts(append([]client.MessagePart{part}, a.promptParts(attachments)...))
+
+	for i, side := range a.Comparison.Sides {
+		session, err := a.CreateSession(ctx)
+		if err != nil {
+			// drop the sessions of the sides already set up, the comparison
+			// can be sent again
+			for _, side := range a.Comparison.Sides[:i] {
+				a.DeleteSession(ctx, side.Session.Id)
+				side.Session = nil
+			}
+			return toast.NewErrorToast(err.Error())
+		}
+		side.Session = session
+		side.Messages = []client.MessageInfo{}
+		side.Failed = false
+	}
+
+	cmds := []tea.Cmd{redactionWarning(masked)}
+	root := a.RootParam()
+	for _, side := range a.Comparison.Sides {
+		session := side.Session
+		mode := a.rememberMode(session.Id)
+		system := a.rememberSystemPrompt(session.Id)
+		parameters := a.rememberParameters(session.Id)
+		cmds = append(cmds, func() tea.Msg {
+			response, err := a.postChat(ctx, withParameters(client.PostSessionChatJSONBody{
+				SessionID:  session.Id,
+				Parts:      parts,
+				ProviderID: side.Provider.Id,
+				ModelID:    side.Model.Id,
+				Root:       root,
+			}, parameters), mode, system)
+			if err == nil && response != nil && response.StatusCode != 200 {
+				err = fmt.Errorf("%d", response.StatusCode)
+			}
+			if err != nil {
+				return CompareFailedMsg{SessionID: session.Id, Err: err}
+			}
+			return nil
+		})
+	}
+	a.Comparison.Sent = true
+
+	return tea.Batch(cmds...)
+}
+
+// ComparisonFailed marks the side a prompt could not be sent to as done, so
+// the comparison can finish with the other side
+func (a *App) ComparisonFailed(msg CompareFailedMsg) tea.Cmd {
+	if a.Comparison == nil {
+		return nil
+	}
+	side := a.Comparison.Side(msg.SessionID)
+	if side == nil {
+		return nil
+	}
+	side.Failed = true
+	slog.Error("Failed to send comparison message", "model", side.Model.Name, "error", msg.Err)
+	return toast.NewErrorToast(i18n.T("toast.compare_failed", side.Model.Name, msg.Err))
+}
+
+// CancelComparison aborts any in-flight comparison sessions
+func (a *App) CancelComparison(ctx context.Context) {
+	if a.Comparison == nil {
+		return
+	}
+	for _, side := range a.Comparison.Sides {
+		if side.Session != nil {
+			a.Cancel(ctx, side.Session.Id)
+		}
+	}
+}
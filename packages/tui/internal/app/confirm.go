@@ -0,0 +1,57 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/util"
+)
+
+// Destructive actions asking for confirmation, the keys of
+// State.SkipConfirmations
+const (
+	ConfirmDeleteSessions    = "delete_sessions"
+	ConfirmRestoreCheckpoint = "restore_checkpoint"
+	ConfirmClearInput        = "clear_input"
+)
+
+// ConfirmMsg asks before sending Confirm, the message running a destructive
+// action
+type ConfirmMsg struct {
+	Action  string
+	Title   string
+	Message string
+	Confirm tea.Msg
+}
+
+// SkipConfirmationMsg stops asking for confirmation before Action
+type SkipConfirmationMsg struct {
+	Action string
+}
+
+// ClearInputMsg clears the editor once confirmed
+type ClearInputMsg struct{}
+
+// Confirms reports whether the user is asked before the action
+func (a *App) Confirms(action string) bool {
+	return !a.State.SkipConfirmations[action]
+}
+
+// Confirm asks before sending msg, unless the user chose not to be asked
+// again for the action
+func (a *App) Confirm(action, title, message string, msg tea.Msg) tea.Cmd {
+	if !a.Confirms(action) {
+		return util.CmdHandler(msg)
+	}
+	return util.CmdHandler(ConfirmMsg{Action: action, Title: title, Message: message, Confirm: msg})
+}
+
+// SkipConfirmation stops asking before the action
+func (a *App) SkipConfirmation(action string) {
+	a.State.SkipConfirmations[action] = true
+	a.SaveState()
+}
+
+// ResetConfirmations asks before every destructive action again
+func (a *App) ResetConfirmations() {
+	a.State.SkipConfirmations = map[string]bool{}
+	a.SaveState()
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+const contextExcerptWidth = 120
+
+// contextSummaryTokens is the estimated size of the summary the server
+// writes for a collapsed range
+const contextSummaryTokens = 200
+
+// ContextEntry is a prior message of the session as it is carried into the
+// next request. Tokens is estimated from the text the model reads.
+type ContextEntry struct {
+	Message client.MessageInfo
+	Tokens  int
+}
+
+// ContextRange is an inclusive range of message indexes
+type ContextRange struct {
+	From int
+	To   int
+}
+
+// ContextPlan is the history picked in the context manager. Excluded
+// messages are left out and each collapsed range is replaced by a summary
+// the model writes.
+type ContextPlan struct {
+	Excluded  map[string]bool
+	Collapsed []ContextRange
+}
+
+// ApplyContextMsg continues the session with the history of Plan
+type ApplyContextMsg struct {
+	Plan ContextPlan
+}
+
+// ContextTrimmedMsg is sent once the trimmed history was replayed into a new
+// session
+type ContextTrimmedMsg struct {
+	Session *client.SessionInfo
+	Tokens  int
+	Err     error
+}
+
+// EstimateTokens approximates the number of tokens of text, about four
+// characters each
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// ContextEntries returns the messages of the current session with their
+// estimated size
+func (a *App) ContextEntries() []ContextEntry {
+	entries := make([]ContextEntry, 0, len(a.Messages))
+	for _, message := range a.Messages {
+		entries = append(entries, ContextEntry{
+			Message: message,
+			Tokens:  a.TextTokens(contextText(message)),
+		})
+	}
+	return entries
+}
+
+// contextText returns the text of a message and the results of its tools
+func contextText(message client.MessageInfo) string {
+	texts := []string{}
+	for _, p := range message.Parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		switch part := part.(type) {
+		case client.MessagePartText:
+			texts = append(texts, part.Text)
+		case client.MessagePartToolInvocation:
+			if result, err := part.ToolInvocation.AsMessageToolInvocationToolResult(); err == nil {
+				texts = append(texts, result.Result)
+			}
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// RangeAt returns the collapsed range containing the message at index
+func (p ContextPlan) RangeAt(index int) (ContextRange, bool) {
+	for _, r := range p.Collapsed {
+		if index >= r.From && index <= r.To {
+			return r, true
+		}
+	}
+	return ContextRange{}, false
+}
+
+// Changed reports whether the plan leaves out or collapses any message
+func (p ContextPlan) Changed() bool {
+	return len(p.Excluded) > 0 || len(p.Collapsed) > 0
+}
+
+// Tokens estimates the size of the history the plan keeps
+func (p ContextPlan) Tokens(entries []ContextEntry) int {
+	tokens := 0
+	for i, entry := range entries {
+		if r, ok := p.RangeAt(i); ok {
+			if i == r.From {
+				tokens += contextSummaryTokens
+			}
+			continue
+		}
+		if !p.Excluded[entry.Message.Id] {
+			tokens += entry.Tokens
+		}
+	}
+	return tokens
+}
+
+// ContextExcerpt returns the first line of the text of a message
+func ContextExcerpt(message client.MessageInfo) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(MessageText(message)), "\n")
+	if utf8.RuneCountInString(line) > contextExcerptWidth {
+		line = string([]rune(line)[:contextExcerptWidth]) + "…"
+	}
+	return line
+}
+
+// TrimContext creates a session with the history of the plan on the server,
+// since it always sends every message of a session along with a prompt. The
+// session is returned for AdoptSessions, which must run on the UI thread.
+func (a *App) TrimContext(ctx context.Context, entries []ContextEntry, plan ContextPlan) (*client.SessionInfo, error) {
+	if a.Provider == nil || a.Model == nil {
+		return nil, errors.New("select a model before trimming the context")
+	}
+	body := client.PostSessionTrimJSONRequestBody{
+		SessionID:  a.Session.Id,
+		Exclude:    []string{},
+		ProviderID: a.Provider.Id,
+		ModelID:    a.Model.Id,
+	}
+	for _, entry := range entries {
+		if plan.Excluded[entry.Message.Id] {
+			body.Exclude = append(body.Exclude, entry.Message.Id)
+		}
+	}
+	body.Collapse = make([]struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}, len(plan.Collapsed))
+	for i, r := range plan.Collapsed {
+		body.Collapse[i].From = entries[r.From].Message.Id
+		body.Collapse[i].To = entries[r.To].Message.Id
+	}
+	response, err := a.Client.PostSessionTrimWithResponse(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 || response.JSON200 == nil {
+		return nil, fmt.Errorf("failed to trim the context: %d", response.StatusCode())
+	}
+	return response.JSON200, nil
+}
+
+// CarrySettings gives a session the agent mode, system prompt and model
+// parameters of the current one
+func (a *App) CarrySettings(sessionID string) {
+	a.rememberMode(sessionID)
+	a.rememberSystemPrompt(sessionID)
+	a.rememberParameters(sessionID)
+}
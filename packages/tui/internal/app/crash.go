@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/util"
+)
+
+// crashFile marks that the last run crashed, it is removed once the next
+// launch read it while the crash dumps are kept
+const crashFile = "crash.json"
+
+// Crash describes a panic of the TUI along with the work in progress when it
+// happened
+type Crash struct {
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+	Panic   string    `json:"panic"`
+	Stack   string    `json:"stack,omitempty"`
+	// SessionID is the session that was open, empty for a new session
+	SessionID string `json:"sessionID,omitempty"`
+	Draft     string `json:"draft,omitempty"`
+}
+
+// CrashRecoveryMsg offers to restore the work lost in a crash
+type CrashRecoveryMsg struct {
+	Crash Crash
+}
+
+// RestoreCrashMsg restores the draft and reopens the session of a crash
+type RestoreCrashMsg struct {
+	Crash Crash
+}
+
+// DraftRestoredMsg reloads the draft of the current session into the editor
+type DraftRestoredMsg struct{}
+
+// WriteCrash writes a crash dump to the state directory and marks the crash
+// for the next launch, it returns the path of the dump
+func WriteCrash(stateDir string, crash Crash) (string, error) {
+	var dump strings.Builder
+	fmt.Fprintf(&dump, "opencode %s crashed at %s\n\n", crash.Version, crash.Time.Format(time.RFC3339))
+	fmt.Fprintf(&dump, "panic: %s\n\n%s\n", crash.Panic, crash.Stack)
+	if crash.SessionID != "" {
+		fmt.Fprintf(&dump, "\nsession: %s\n", crash.SessionID)
+	}
+	path := filepath.Join(stateDir, "crash-"+crash.Time.Format("20060102-150405")+".log")
+	if err := os.WriteFile(path, []byte(dump.String()), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write crash dump: %w", err)
+	}
+
+	data, err := json.Marshal(crash)
+	if err != nil {
+		return path, err
+	}
+	if err := os.WriteFile(filepath.Join(stateDir, crashFile), data, 0o600); err != nil {
+		return path, fmt.Errorf("failed to mark the crash: %w", err)
+	}
+	return path, nil
+}
+
+// takeCrash reads and removes the crash marker of the last run
+func takeCrash(stateDir string) *Crash {
+	path := filepath.Join(stateDir, crashFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if removeErr := os.Remove(path); removeErr != nil {
+		slog.Warn("Failed to remove the crash marker", "error", removeErr)
+	}
+	if err != nil {
+		slog.Warn("Failed to read the crash marker", "error", err)
+		return nil
+	}
+	var crash Crash
+	if err := json.Unmarshal(data, &crash); err != nil {
+		slog.Warn("Failed to parse the crash marker", "error", err)
+		return nil
+	}
+	return &crash
+}
+
+// CrashRecovery offers to restore the work of the last run when it crashed
+// with a draft or a session open
+func (a *App) CrashRecovery() tea.Cmd {
+	if a.crash == nil || (a.crash.Draft == "" && a.crash.SessionID == "") {
+		return nil
+	}
+	return util.CmdHandler(CrashRecoveryMsg{Crash: *a.crash})
+}
+
+// RestoreCrash saves the draft of a crash for its session and reopens it
+func (a *App) RestoreCrash(ctx context.Context, crash Crash) tea.Cmd {
+	if crash.Draft != "" {
+		a.SaveDraft(crash.SessionID, crash.Draft)
+	}
+	if crash.SessionID == "" || crash.SessionID == a.Session.Id {
+		return util.CmdHandler(DraftRestoredMsg{})
+	}
+	return func() tea.Msg {
+		sessions, err := a.ListSessions(ctx)
+		if err != nil {
+			slog.Error("Failed to list sessions", "error", err)
+			return nil
+		}
+		for _, session := range sessions {
+			if session.Id == crash.SessionID {
+				return SessionSelectedMsg(&session)
+			}
+		}
+		slog.Warn("The session of the crash no longer exists", "session", crash.SessionID)
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+const defaultDraftInterval = 3 * time.Second
+
+type DraftTickMsg struct{}
+
+// Draft returns the saved editor content for a session
+func (a *App) Draft(sessionID string) string {
+	return a.State.Drafts[sessionID]
+}
+
+// SaveDraft persists the editor content for a session, writing the state
+// only when the draft changed
+func (a *App) SaveDraft(sessionID, text string) {
+	if a.State.Drafts[sessionID] == text {
+		return
+	}
+	if text == "" {
+		delete(a.State.Drafts, sessionID)
+	} else {
+		a.State.Drafts[sessionID] = text
+	}
+	a.SaveState()
+}
+
+// TickDraft schedules the next draft save, it returns nil when saving
+// drafts is disabled
+func (a *App) TickDraft() tea.Cmd {
+	interval := defaultDraftInterval
+	switch {
+	case a.State.DraftInterval < 0:
+		return nil
+	case a.State.DraftInterval > 0:
+		interval = time.Duration(a.State.DraftInterval) * time.Second
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return DraftTickMsg{}
+	})
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// ProviderFailedMsg is sent when a turn failed because of its provider
+type ProviderFailedMsg struct {
+	Turn       FailedTurn
+	ProviderID string
+	ModelID    string
+	Error      string
+}
+
+// FallbackMsg retries a failed turn with a fallback model. From is the
+// "provider/model" ID that failed.
+type FallbackMsg struct {
+	Turn     FailedTurn
+	From     string
+	Provider client.ProviderInfo
+	Model    client.ModelInfo
+}
+
+// fallback is a turn retried with a fallback model, waiting for the first
+// assistant message of the answer
+type fallback struct {
+	sessionID string
+	from      string
+	known     map[string]bool
+}
+
+// ProviderError returns the error of an assistant message that failed
+// because of its provider. Rate limits and aborted or truncated responses
+// are not provider errors.
+func ProviderError(message client.MessageInfo) (string, bool) {
+	if message.Role != client.Assistant || message.Metadata.Error == nil {
+		return "", false
+	}
+	if _, ok := RateLimitError(message); ok {
+		return "", false
+	}
+	value, err := message.Metadata.Error.ValueByDiscriminator()
+	if err != nil {
+		return "", false
+	}
+	switch value := value.(type) {
+	case client.ProviderAuthError:
+		return value.Data.Message, true
+	case client.UnknownError:
+		if strings.Contains(strings.ToLower(value.Data.Message), "abort") {
+			return "", false
+		}
+		return value.Data.Message, true
+	}
+	return "", false
+}
+
+// FailedPrompt returns the turn of an assistant message that failed because
+// of its provider, once per message
+func (a *App) FailedPrompt(message client.MessageInfo) (ProviderFailedMsg, bool) {
+	reason, ok := ProviderError(message)
+	if !ok || message.Metadata.Assistant == nil || a.failed[message.Id] {
+		return ProviderFailedMsg{}, false
+	}
+	turn, ok := a.failedTurn(message)
+	if !ok {
+		return ProviderFailedMsg{}, false
+	}
+	a.failed[message.Id] = true
+	return ProviderFailedMsg{
+		Turn:       turn,
+		ProviderID: message.Metadata.Assistant.ProviderID,
+		ModelID:    message.Metadata.Assistant.ModelID,
+		Error:      reason,
+	}, true
+}
+
+// NextFallback returns the model of the fallback chain that follows the
+// failed one, or the first model of the chain when the failed model is not
+// part of it
+func (a *App) NextFallback(ctx context.Context, providerID, modelID string) (*client.ProviderInfo, *client.ModelInfo, bool) {
+	chain := a.State.FallbackModels
+	next := slices.Index(chain, favoriteID(providerID, modelID)) + 1
+	if next >= len(chain) {
+		return nil, nil, false
+	}
+	providers, err := a.ListProviders(ctx)
+	if err != nil {
+		return nil, nil, false
+	}
+	for _, id := range chain[next:] {
+		for _, provider := range providers {
+			for _, model := range provider.Models {
+				if favoriteID(provider.Id, model.Id) == id {
+					return &provider, &model, true
+				}
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// SendFallback retries a failed turn with a fallback model, the answer is
+// annotated with the model that failed
+func (a *App) SendFallback(ctx context.Context, msg FallbackMsg) tea.Cmd {
+	known := map[string]bool{}
+	for _, message := range a.Messages {
+		known[message.Id] = true
+	}
+	a.fallback = &fallback{sessionID: msg.Turn.SessionID, from: msg.From, known: known}
+	return a.RetryTurn(ctx, msg.Turn, &msg.Provider, &msg.Model)
+}
+
+// TrackFallback records the first assistant message answering a fallback
+// turn and saves it
+func (a *App) TrackFallback(message client.MessageInfo) {
+	f := a.fallback
+	if f == nil || message.Role != client.Assistant || message.Metadata.SessionID != f.sessionID || f.known[message.Id] {
+		return
+	}
+	if a.State.Fallbacks[f.sessionID] == nil {
+		a.State.Fallbacks[f.sessionID] = map[string]string{}
+	}
+	a.State.Fallbacks[f.sessionID][message.Id] = f.from
+	a.SaveState()
+	a.fallback = nil
+}
+
+// FallbackFrom returns the model that failed before a fallback model wrote
+// the message of the current session
+func (a *App) FallbackFrom(messageID string) (string, bool) {
+	from, ok := a.State.Fallbacks[a.Session.Id][messageID]
+	return from, ok
+}
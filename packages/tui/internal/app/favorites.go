@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+func favoriteID(providerID, modelID string) string {
+	return providerID + "/" + modelID
+}
+
+// IsFavorite reports whether a model is starred
+func (a *App) IsFavorite(providerID, modelID string) bool {
+	return slices.Contains(a.State.FavoriteModels, favoriteID(providerID, modelID))
+}
+
+// ToggleFavorite stars or unstars a model, returning whether it is now starred
+func (a *App) ToggleFavorite(providerID, modelID string) bool {
+	id := favoriteID(providerID, modelID)
+	favorite := !slices.Contains(a.State.FavoriteModels, id)
+	if favorite {
+		a.State.FavoriteModels = append(a.State.FavoriteModels, id)
+	} else {
+		a.State.FavoriteModels = slices.DeleteFunc(a.State.FavoriteModels, func(f string) bool { return f == id })
+	}
+	a.SaveState()
+	return favorite
+}
+
+// topFavorite returns the first starred model that is still available
+func (a *App) topFavorite(providers []client.ProviderInfo) (*client.ProviderInfo, *client.ModelInfo) {
+	for _, id := range a.State.FavoriteModels {
+		for _, provider := range providers {
+			for _, model := range provider.Models {
+				if favoriteID(provider.Id, model.Id) == id {
+					return &provider, &model
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// FindModel finds the model a query such as "sonnet" or
+// "anthropic/claude-sonnet-4" names: the model with that exact ID first,
+// then the first whose ID or name contains the query
+func (a *App) FindModel(ctx context.Context, query string) (*client.ProviderInfo, *client.ModelInfo, error) {
+	providers, err := a.ListProviders(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	for _, provider := range providers {
+		for _, model := range provider.Models {
+			if query == strings.ToLower(favoriteID(provider.Id, model.Id)) || query == strings.ToLower(model.Id) {
+				return &provider, &model, nil
+			}
+		}
+	}
+	for _, provider := range providers {
+		ids := []string{}
+		for id := range provider.Models {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		for _, id := range ids {
+			model := provider.Models[id]
+			if strings.Contains(strings.ToLower(favoriteID(provider.Id, model.Id)), query) ||
+				strings.Contains(strings.ToLower(model.Name), query) {
+				return &provider, &model, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("no model matches %q", query)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// RateMessage records a thumbs up or down on an assistant message in its
+// metadata, rating it again the same way clears the rating
+func (a *App) RateMessage(ctx context.Context, message client.MessageInfo, feedback client.MessageMetadataFeedback) (*client.MessageInfo, error) {
+	if message.Role != client.Assistant {
+		return nil, errors.New("only assistant messages can be rated")
+	}
+	body := client.PostSessionFeedbackJSONRequestBody{
+		SessionID: message.Metadata.SessionID,
+		MessageID: message.Id,
+	}
+	if message.Metadata.Feedback == nil || *message.Metadata.Feedback != feedback {
+		rating := client.PostSessionFeedbackJSONBodyFeedback(feedback)
+		body.Feedback = &rating
+	}
+	response, err := a.Client.PostSessionFeedbackWithResponse(ctx, body)
+	if err != nil {
+		slog.Error("Failed to rate message", "error", err)
+		return nil, err
+	}
+	if response.StatusCode() != 200 || response.JSON200 == nil {
+		return nil, fmt.Errorf("failed to rate message: %d", response.StatusCode())
+	}
+	return response.JSON200, nil
+}
+
+// RatedMessages returns the messages of the session rated with feedback, in
+// the order they were sent
+func (a *App) RatedMessages(feedback client.MessageMetadataFeedback) []client.MessageInfo {
+	rated := []client.MessageInfo{}
+	for _, message := range a.Messages {
+		if message.Metadata.Feedback != nil && *message.Metadata.Feedback == feedback {
+			rated = append(rated, message)
+		}
+	}
+	return rated
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// SessionForkedMsg is sent once a session was created with the history of
+// the current one up to a message
+type SessionForkedMsg struct {
+	Session client.SessionInfo
+	Err     error
+}
+
+// ForkSession copies the messages of the current session up to messageID,
+// included, into a new session
+func (a *App) ForkSession(ctx context.Context, messageID string) tea.Cmd {
+	sessionID := a.Session.Id
+	return func() tea.Msg {
+		response, err := a.Client.PostSessionForkWithResponse(ctx, client.PostSessionForkJSONRequestBody{
+			SessionID: sessionID,
+			MessageID: messageID,
+		})
+		if err != nil {
+			return SessionForkedMsg{Err: err}
+		}
+		if response.StatusCode() != 200 || response.JSON200 == nil {
+			return SessionForkedMsg{Err: fmt.Errorf("failed to fork session: %d", response.StatusCode())}
+		}
+		return SessionForkedMsg{Session: *response.JSON200}
+	}
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sst/opencode/internal/config"
+)
+
+// SaveLayoutMsg saves the current layout settings under a name
+type SaveLayoutMsg struct {
+	Name string
+}
+
+// ApplyLayoutMsg switches to the layout saved under a name
+type ApplyLayoutMsg struct {
+	Name string
+}
+
+// BuiltinLayouts are the presets available until a layout of the same name
+// is saved
+var BuiltinLayouts = map[string]config.LayoutPreset{
+	// review uses the whole terminal for diffs, with the session list
+	"review": {
+		Density:      config.DensityCompact,
+		ContentWidth: -1,
+		CodeWrap:     config.CodeWrapScroll,
+	},
+	// pairing keeps the conversation readable on a shared screen
+	"pairing": {
+		Density:          config.DensityComfortable,
+		SplitLayoutWidth: -1,
+		ContentWidth:     120,
+		CodeWrap:         config.CodeWrapSoft,
+		Reasoning:        config.ReasoningExpanded,
+	},
+	// presentation shows only the conversation, in a narrow column
+	"presentation": {
+		Density:          config.DensityComfortable,
+		SplitLayoutWidth: -1,
+		ContentWidth:     100,
+		CodeWrap:         config.CodeWrapSoft,
+		Reasoning:        config.ReasoningHidden,
+	},
+}
+
+// Layouts lists the names of the saved and builtin layouts, sorted
+func (a *App) Layouts() []string {
+	names := slices.Collect(maps.Keys(a.State.LayoutPresets))
+	for name := range BuiltinLayouts {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// LayoutPreset returns the layout of a name, the saved one before the
+// builtin one
+func (a *App) LayoutPreset(name string) (config.LayoutPreset, bool) {
+	if preset, ok := a.State.LayoutPresets[name]; ok {
+		return preset, true
+	}
+	preset, ok := BuiltinLayouts[name]
+	return preset, ok
+}
+
+// SaveLayout saves the current layout settings under a name, it returns
+// false for an empty name
+func (a *App) SaveLayout(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	a.State.LayoutPresets[name] = config.LayoutPreset{
+		Theme:            a.State.Theme,
+		Density:          a.State.Density,
+		SplitLayoutWidth: a.State.SplitLayoutWidth,
+		ContentWidth:     a.State.ContentWidth,
+		CodeWrap:         a.State.CodeWrap,
+		Reasoning:        a.State.Reasoning,
+	}
+	a.State.Layout = name
+	a.SaveState()
+	return true
+}
+
+// ApplyLayout switches the layout settings to the ones of a name, the caller
+// applies the theme of the returned preset
+func (a *App) ApplyLayout(name string) (config.LayoutPreset, bool) {
+	preset, ok := a.LayoutPreset(name)
+	if !ok {
+		return preset, false
+	}
+	a.State.Density = preset.Density
+	a.State.SplitLayoutWidth = preset.SplitLayoutWidth
+	a.State.ContentWidth = preset.ContentWidth
+	a.State.CodeWrap = preset.CodeWrap
+	a.State.Reasoning = preset.Reasoning
+	a.State.Layout = name
+	a.SaveState()
+	return preset, true
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"log/slog"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+)
+
+// LinkPattern matches the URLs in message text, escape sequences end a match
+// so it can run over rendered text too
+var LinkPattern = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `\x1b]+`)
+
+// OpenLinkMsg opens a URL in the desktop browser
+type OpenLinkMsg struct {
+	URL string
+}
+
+// TrimLink drops the punctuation that ends the sentence around a URL, and
+// a closing bracket without its opening one
+func TrimLink(url string) string {
+	for url != "" {
+		last := url[len(url)-1]
+		switch {
+		case strings.ContainsRune(".,;:!?*_", rune(last)):
+		case last == ')' && strings.Count(url, "(") < strings.Count(url, ")"):
+		case last == ']' && strings.Count(url, "[") < strings.Count(url, "]"):
+		default:
+			return url
+		}
+		url = url[:len(url)-1]
+	}
+	return url
+}
+
+// FindLinks returns the URLs of text in order, without duplicates
+func FindLinks(text string) []string {
+	links := []string{}
+	for _, match := range LinkPattern.FindAllString(text, -1) {
+		if link := TrimLink(match); !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// OpenLink opens a URL with the desktop opener, xdg-open on Linux and open on
+// macOS, without waiting for the browser
+func OpenLink(url string) tea.Cmd {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		c = exec.Command("open", url)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		c = exec.Command("xdg-open", url)
+	}
+	if err := c.Start(); err != nil {
+		slog.Error("Failed to open link", "url", url, "error", err)
+		return toast.NewErrorToast(i18n.T("toast.open_failed", url, err.Error()))
+	}
+	go c.Wait()
+	return toast.NewInfoToast(i18n.T("toast.opened", url))
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FileReference is a file mentioned in the prompt or in a message, with an
+// optional 1-based line range
+type FileReference struct {
+	Path       string
+	Start, End int
+}
+
+// FilePreviewMsg opens a preview of a referenced file
+type FilePreviewMsg struct {
+	Reference FileReference
+}
+
+// lineRange matches the line suffixes of a mention: path:10, path:10-20,
+// path#L10 and path#L10-L20
+var lineRange = regexp.MustCompile(`(?::|#L)(\d+)(?:-L?(\d+))?$`)
+
+// ResolveFileReference parses a mention such as @src/main.go:10-20 and
+// reports whether it names an existing file in the workspace
+func (a *App) ResolveFileReference(token string) (FileReference, bool) {
+	token = strings.TrimLeft(token, "`'\"([<{")
+	token = strings.TrimPrefix(token, "@")
+	token = strings.TrimRight(token, "`'\")]>},;!?.:")
+	if token == "" {
+		return FileReference{}, false
+	}
+
+	reference := FileReference{Path: token}
+	if match := lineRange.FindStringSubmatchIndex(token); match != nil {
+		reference.Path = token[:match[0]]
+		reference.Start, _ = strconv.Atoi(token[match[2]:match[3]])
+		reference.End = reference.Start
+		if match[4] >= 0 {
+			reference.End, _ = strconv.Atoi(token[match[4]:match[5]])
+		}
+		if reference.End < reference.Start {
+			reference.Start, reference.End = reference.End, reference.Start
+		}
+	}
+
+	path := reference.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.Info.Path.Cwd, path)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return FileReference{}, false
+	}
+	reference.Path = path
+	return reference, true
+}
+
+// FindFileReference returns the first existing file mentioned in text
+func (a *App) FindFileReference(text string) (FileReference, bool) {
+	for _, token := range strings.Fields(text) {
+		if reference, ok := a.ResolveFileReference(token); ok {
+			return reference, true
+		}
+	}
+	return FileReference{}, false
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// SessionMergedMsg is sent once the history of another session was appended
+// to the current one
+type SessionMergedMsg struct {
+	Source   client.SessionInfo
+	Messages int
+	Err      error
+}
+
+// ParseMergeArgs splits the arguments of /merge into the session to merge
+// and whether to summarize it, as in "/merge --summarize refactor"
+func ParseMergeArgs(args string) (string, bool) {
+	fields := strings.Fields(args)
+	summarize := false
+	rest := []string{}
+	for _, field := range fields {
+		if field == "--summarize" || field == "-s" {
+			summarize = true
+			continue
+		}
+		rest = append(rest, field)
+	}
+	return strings.Join(rest, " "), summarize
+}
+
+// FindSession resolves a session by its id, or by a part of its title when
+// that matches a single other session
+func (a *App) FindSession(ctx context.Context, query string) (client.SessionInfo, error) {
+	sessions, err := a.ListSessions(ctx)
+	if err != nil {
+		return client.SessionInfo{}, err
+	}
+	matches := []client.SessionInfo{}
+	for _, session := range sessions {
+		if session.Id == query {
+			return session, nil
+		}
+		if session.Id != a.Session.Id && strings.Contains(strings.ToLower(session.Title), strings.ToLower(query)) {
+			matches = append(matches, session)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return client.SessionInfo{}, fmt.Errorf("no session matches %q", query)
+	case 1:
+		return matches[0], nil
+	}
+	return client.SessionInfo{}, fmt.Errorf("%d sessions match %q, use more of the title or the id", len(matches), query)
+}
+
+// MergeSession appends the history of another session to the current one,
+// or a summary of it written by the current model when summarize is set
+func (a *App) MergeSession(ctx context.Context, query string, summarize bool) tea.Cmd {
+	sessionID := a.Session.Id
+	return func() tea.Msg {
+		if a.Provider == nil || a.Model == nil {
+			return SessionMergedMsg{Err: errors.New("select a model before merging sessions")}
+		}
+		source, err := a.FindSession(ctx, query)
+		if err != nil {
+			return SessionMergedMsg{Err: err}
+		}
+		if source.Id == sessionID {
+			return SessionMergedMsg{Source: source, Err: errors.New("cannot merge a session into itself")}
+		}
+		body := client.PostSessionMergeJSONRequestBody{
+			SessionID:  sessionID,
+			SourceID:   source.Id,
+			ProviderID: a.Provider.Id,
+			ModelID:    a.Model.Id,
+		}
+		if summarize {
+			body.Summarize = &summarize
+		}
+		response, err := a.Client.PostSessionMergeWithResponse(ctx, body)
+		if err != nil {
+			return SessionMergedMsg{Source: source, Err: err}
+		}
+		if response.StatusCode() != 200 || response.JSON200 == nil {
+			return SessionMergedMsg{Source: source, Err: fmt.Errorf("failed to merge session: %d", response.StatusCode())}
+		}
+		return SessionMergedMsg{Source: source, Messages: len(*response.JSON200)}
+	}
+}
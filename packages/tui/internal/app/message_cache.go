@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// MessagesReconciledMsg is sent when the messages of a session rendered from
+// the cache were replaced by the ones on the server
+type MessagesReconciledMsg struct {
+	SessionID string
+	Messages  []client.MessageInfo
+}
+
+func (a *App) messageCachePath(sessionID string) string {
+	return filepath.Join(a.Info.Path.State, "messages", sessionID+".json")
+}
+
+// CachedMessages returns the messages of a session as they were when it was
+// last opened
+func (a *App) CachedMessages(sessionID string) ([]client.MessageInfo, bool) {
+	data, err := os.ReadFile(a.messageCachePath(sessionID))
+	if err != nil {
+		return nil, false
+	}
+	var messages []client.MessageInfo
+	if err := json.Unmarshal(data, &messages); err != nil {
+		slog.Debug("Failed to read message cache", "session", sessionID, "error", err)
+		return nil, false
+	}
+	return messages, true
+}
+
+// CacheMessages stores the messages of a session on disk, optimistic
+// messages are left out. The messages are encoded right away so the caller
+// can keep changing them, the file is written in the background.
+func (a *App) CacheMessages(sessionID string, messages []client.MessageInfo) {
+	if sessionID == "" {
+		return
+	}
+	cached := make([]client.MessageInfo, 0, len(messages))
+	for _, message := range messages {
+		if !strings.HasPrefix(message.Id, "optimistic-") {
+			cached = append(cached, message)
+		}
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		slog.Error("Failed to encode message cache", "error", err)
+		return
+	}
+	a.messageCache.queue(a.messageCachePath(sessionID), data)
+}
+
+// DropCachedMessages removes the cached messages of a deleted session
+func (a *App) DropCachedMessages(sessionID string) {
+	a.messageCache.queue(a.messageCachePath(sessionID), nil)
+}
+
+const (
+	// messageCacheMaxAge is how long the messages of a session not opened
+	// since stay cached
+	messageCacheMaxAge = 30 * 24 * time.Hour
+	// messageCacheMaxSize is the size of the cache from which the sessions
+	// opened least recently are dropped
+	messageCacheMaxSize = 64 << 20
+)
+
+// messageCache writes the cache files one at a time per session, only the
+// latest messages queued while a file is written are written next. Nil data
+// removes the file.
+type messageCache struct {
+	mu      sync.Mutex
+	pending map[string][]byte
+	writing map[string]bool
+}
+
+func (c *messageCache) queue(path string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pending == nil {
+		c.pending = map[string][]byte{}
+		c.writing = map[string]bool{}
+	}
+	c.pending[path] = data
+	if !c.writing[path] {
+		c.writing[path] = true
+		go c.write(path)
+	}
+}
+
+func (c *messageCache) write(path string) {
+	for {
+		c.mu.Lock()
+		data, ok := c.pending[path]
+		delete(c.pending, path)
+		if !ok {
+			delete(c.writing, path)
+			c.mu.Unlock()
+			return
+		}
+		c.mu.Unlock()
+
+		if data == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				slog.Debug("Failed to remove message cache", "path", path, "error", err)
+			}
+			continue
+		}
+		if err := writeFileAtomic(path, data); err != nil {
+			slog.Error("Failed to write message cache", "error", err)
+			continue
+		}
+		evictMessageCache(filepath.Dir(path))
+	}
+}
+
+// writeFileAtomic replaces a file at once, so it is never read half written
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// evictMessageCache removes the cache files older than messageCacheMaxAge,
+// then the oldest ones until the cache fits in messageCacheMaxSize
+func evictMessageCache(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	files := []os.FileInfo{}
+	size := int64(0)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || info.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		if time.Since(info.ModTime()) > messageCacheMaxAge {
+			os.Remove(filepath.Join(dir, entry.Name()))
+			continue
+		}
+		files = append(files, info)
+		size += info.Size()
+	}
+	slices.SortFunc(files, func(a, b os.FileInfo) int {
+		return a.ModTime().Compare(b.ModTime())
+	})
+	for _, info := range files {
+		if size <= messageCacheMaxSize {
+			break
+		}
+		os.Remove(filepath.Join(dir, info.Name()))
+		size -= info.Size()
+	}
+}
+
+// ReconcileMessages fetches the messages of a session rendered from the
+// cache in the background
+func (a *App) ReconcileMessages(sessionID string) tea.Cmd {
+	return func() tea.Msg {
+		messages, err := a.ListMessages(context.Background(), sessionID)
+		if err != nil {
+			slog.Error("Failed to list messages", "error", err)
+			return nil
+		}
+		return MessagesReconciledMsg{SessionID: sessionID, Messages: messages}
+	}
+}
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// MessageMetrics are the latency and throughput of an assistant response,
+// timed from its server events as they arrive
+type MessageMetrics struct {
+	// FirstToken is the time from sending the prompt to the first text or
+	// reasoning of the response
+	FirstToken time.Duration
+	// Generating is the time from the first token to the completion of the
+	// response, without the time its tools ran
+	Generating time.Duration
+	// Tokens are the output and reasoning tokens of the response
+	Tokens float32
+}
+
+// TokensPerSecond is the output rate of the response, zero when too little
+// time was measured to tell
+func (m MessageMetrics) TokensPerSecond() float64 {
+	if m.Generating < 100*time.Millisecond {
+		return 0
+	}
+	return float64(m.Tokens) / m.Generating.Seconds()
+}
+
+// String formats the metrics for the footer of a message, such as
+// "0.8s to first token · 52 tok/s"
+func (m MessageMetrics) String() string {
+	label := fmt.Sprintf("%.1fs to first token", m.FirstToken.Seconds())
+	if rate := m.TokensPerSecond(); rate > 0 {
+		label += fmt.Sprintf(" · %.0f tok/s", rate)
+	}
+	return label
+}
+
+// ModelMetrics averages the metrics of the responses of a model
+type ModelMetrics struct {
+	Model           string
+	Responses       int
+	FirstToken      time.Duration
+	TokensPerSecond float64
+}
+
+// timing holds when the events of a response in progress arrived
+type timing struct {
+	start time.Time
+	first time.Time
+}
+
+// Metrics returns the metrics of a response seen streaming in this run of
+// the app, responses loaded from history have none
+func (a *App) Metrics(messageID string) (MessageMetrics, bool) {
+	metrics, ok := a.metrics[messageID]
+	return metrics, ok
+}
+
+// TrackTimingPart notes when the first text or reasoning of a response
+// arrives
+func (a *App) TrackTimingPart(event client.EventMessagePartUpdated) {
+	value, err := event.Properties.Part.ValueByDiscriminator()
+	if err != nil {
+		return
+	}
+	switch value.(type) {
+	case client.MessagePartText, client.MessagePartReasoning:
+	default:
+		return
+	}
+	timing := a.timing(event.Properties.SessionID, event.Properties.MessageID)
+	if timing.first.IsZero() {
+		timing.first = time.Now()
+	}
+}
+
+// TrackTimingMessage records the metrics of a response once it completes,
+// it must see the message before TrackRunMessage ends the run
+func (a *App) TrackTimingMessage(message client.MessageInfo) {
+	if message.Role != client.Assistant {
+		return
+	}
+	if _, ok := a.metrics[message.Id]; ok {
+		return
+	}
+	timing := a.timing(message.Metadata.SessionID, message.Id)
+	completed := message.Metadata.Time.Completed
+	if completed == nil {
+		return
+	}
+	delete(a.timings, message.Id)
+	if timing.first.IsZero() || message.Metadata.Assistant == nil {
+		return
+	}
+
+	generating := time.Since(timing.first)
+	for _, tool := range message.Metadata.Tool {
+		if tool.Time.End > tool.Time.Start {
+			generating -= time.Duration(tool.Time.End-tool.Time.Start) * time.Millisecond
+		}
+	}
+	tokens := message.Metadata.Assistant.Tokens
+	a.metrics[message.Id] = MessageMetrics{
+		FirstToken: timing.first.Sub(timing.start),
+		Generating: max(generating, 0),
+		Tokens:     tokens.Output + tokens.Reasoning,
+	}
+}
+
+// timing returns the timing of a response, started when its prompt was
+// sent or else when the response was first seen
+func (a *App) timing(sessionID string, messageID string) *timing {
+	if existing, ok := a.timings[messageID]; ok {
+		return existing
+	}
+	created := &timing{start: time.Now()}
+	if run, ok := a.runs[sessionID]; ok && !run.queued.IsZero() {
+		created.start = run.queued
+	}
+	a.timings[messageID] = created
+	return created
+}
+
+// modelMetrics averages the metrics of the responses in messages by model
+func (a *App) modelMetrics(messages []client.MessageInfo) []ModelMetrics {
+	byModel := map[string]*ModelMetrics{}
+	rated := map[string]int{}
+	for _, message := range messages {
+		metrics, ok := a.metrics[message.Id]
+		if !ok || message.Metadata.Assistant == nil {
+			continue
+		}
+		model := message.Metadata.Assistant.ProviderID + "/" + message.Metadata.Assistant.ModelID
+		summary, ok := byModel[model]
+		if !ok {
+			summary = &ModelMetrics{Model: model}
+			byModel[model] = summary
+		}
+		summary.Responses++
+		summary.FirstToken += metrics.FirstToken
+		if rate := metrics.TokensPerSecond(); rate > 0 {
+			summary.TokensPerSecond += rate
+			rated[model]++
+		}
+	}
+
+	models := []ModelMetrics{}
+	for model, summary := range byModel {
+		summary.FirstToken /= time.Duration(summary.Responses)
+		if rated[model] > 0 {
+			summary.TokensPerSecond /= float64(rated[model])
+		}
+		models = append(models, *summary)
+	}
+	slices.SortFunc(models, func(a, b ModelMetrics) int {
+		return b.Responses - a.Responses
+	})
+	return models
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// Agent modes change the system prompt and tool permissions the server uses
+const (
+	ModeBuild = "build"
+	ModePlan  = "plan"
+	ModeAsk   = "ask"
+)
+
+// Modes lists the agent modes in cycle order
+var Modes = []string{ModeBuild, ModePlan, ModeAsk}
+
+type ModeSelectedMsg struct {
+	Mode string
+}
+
+// Mode returns the active agent mode, sessions without one use the mode
+// last picked in the project
+func (a *App) Mode() string {
+	if a.mode != "" {
+		return a.mode
+	}
+	if mode := a.projectDefault().Mode; slices.Contains(Modes, mode) {
+		return mode
+	}
+	return ModeBuild
+}
+
+// SetMode changes the agent mode and remembers it for the current session
+// and the project
+func (a *App) SetMode(mode string) bool {
+	if !slices.Contains(Modes, mode) {
+		return false
+	}
+	a.mode = mode
+	if a.Session.Id != "" {
+		a.State.SessionModes[a.Session.Id] = mode
+	}
+	a.rememberProjectMode(mode)
+	a.SaveState()
+	return true
+}
+
+// CycleMode switches to the next agent mode
+func (a *App) CycleMode() string {
+	index := slices.Index(Modes, a.Mode())
+	mode := Modes[(index+1)%len(Modes)]
+	a.SetMode(mode)
+	return mode
+}
+
+// LoadMode restores the agent mode last used in the current session
+func (a *App) LoadMode() {
+	a.mode = a.State.SessionModes[a.Session.Id]
+}
+
+// rememberMode records the active agent mode for a session and returns it
+func (a *App) rememberMode(sessionID string) string {
+	mode := a.Mode()
+	if a.State.SessionModes[sessionID] != mode {
+		a.State.SessionModes[sessionID] = mode
+		a.SaveState()
+	}
+	return mode
+}
+
+// postChat sends a chat request in the given agent mode, with a custom
+// system prompt unless system is empty. Secrets are masked here too for the
+// requests the user did not type, such as summaries.
+func (a *App) postChat(ctx context.Context, body client.PostSessionChatJSONBody, mode string, system string, editors ...client.RequestEditorFn) (*http.Response, error) {
+	body.Parts, _ = a.RedactParts(body.Parts)
+	if mode != "" {
+		agentMode := client.PostSessionChatJSONBodyMode(mode)
+		body.Mode = &agentMode
+	}
+	if system != "" {
+		body.System = &system
+	}
+	return a.Client.PostSessionChat(ctx, client.PostSessionChatJSONRequestBody(body), editors...)
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+)
+
+// defaultOpenCommand opens files in $VISUAL or $EDITOR, most editors take
+// the line to jump to as +line
+const defaultOpenCommand = "{editor} +{line} {file}"
+
+// OpenFileMsg opens a referenced file with the open command
+type OpenFileMsg struct {
+	Reference FileReference
+}
+
+// OpenCommand returns the command template files are opened with, the one
+// of the project first
+func (a *App) OpenCommand() string {
+	if command := a.State.ProjectOpenCommands[a.Info.Path.Root]; command != "" {
+		return command
+	}
+	if a.State.OpenCommand != "" {
+		return a.State.OpenCommand
+	}
+	return defaultOpenCommand
+}
+
+// OpenFile runs the open command for a file in the shell, with {file},
+// {line} and {editor} replaced. The terminal is handed over until it exits,
+// commands such as tmux split-window return right away.
+func (a *App) OpenFile(reference FileReference) (tea.Cmd, error) {
+	template := a.OpenCommand()
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" && strings.Contains(template, "{editor}") {
+		return nil, errors.New("no EDITOR set, can't open " + reference.Path)
+	}
+	command := strings.NewReplacer(
+		"{file}", shellQuote(reference.Path),
+		"{line}", strconv.Itoa(max(reference.Start, 1)),
+		"{editor}", editor,
+	).Replace(template)
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "sh"
+	}
+	c := exec.Command(shell, "-c", command) //nolint:gosec
+	c.Dir = a.Info.Path.Cwd
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			slog.Error("Failed to open file", "command", command, "error", err)
+			return toast.NewErrorToast(i18n.T("toast.open_failed", reference.Path, err.Error()))()
+		}
+		return nil
+	}), nil
+}
+
+// shellQuote quotes s as a single shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,232 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// defaultAttachmentLimit is the prompt size in tokens, with its pasted
+// content, command outputs and attachments, from which sending asks how to
+// fit it, when the state does not set one
+const defaultAttachmentLimit = 20000
+
+// OversizeAction is how a prompt over the attachment limit is sent
+type OversizeAction string
+
+const (
+	OversizeTruncate  OversizeAction = "truncate"
+	OversizeChunk     OversizeAction = "chunk"
+	OversizeSummarize OversizeAction = "summarize"
+	OversizeSend      OversizeAction = "send"
+)
+
+// OversizedPromptMsg is sent instead of SendMsg when the prompt is over the
+// attachment limit, the draft is kept until an action is picked
+type OversizedPromptMsg struct {
+	Tokens int
+	Limit  int
+}
+
+// ResolveOversizedMsg submits the draft with the picked action
+type ResolveOversizedMsg struct {
+	Action OversizeAction
+}
+
+// PromptSummarizedMsg is sent when the summary of an oversized prompt is
+// ready to be sent in its place
+type PromptSummarizedMsg struct {
+	Text        string
+	Attachments []Attachment
+	Err         error
+}
+
+// Chunks are the parts of a prompt still to be sent, one per turn
+type Chunks struct {
+	SessionID string
+	Parts     []string
+}
+
+// AttachmentLimit returns the prompt size in tokens from which sending asks
+// how to fit it, zero when prompts are always sent as they are
+func (a *App) AttachmentLimit() int {
+	switch {
+	case a.State.AttachmentLimit < 0:
+		return 0
+	case a.State.AttachmentLimit > 0:
+		return a.State.AttachmentLimit
+	}
+	return defaultAttachmentLimit
+}
+
+// TruncatePrompt keeps the start and the end of a prompt and its outputs
+// within the attachment limit, the end of pasted logs usually matters most
+func (a *App) TruncatePrompt(text string) string {
+	text = a.attachOutputs(text)
+	lines := strings.Split(text, "\n")
+	budget := a.AttachmentLimit()
+	head, tail := []string{}, []string{}
+	used := 0
+	for i, j := 0, len(lines)-1; i <= j; {
+		// one line from the start for every two from the end
+		line, fromStart := lines[j], false
+		if len(head)*2 < len(tail) {
+			line, fromStart = lines[i], true
+		}
+		tokens := a.TextTokens(line) + 1
+		if used+tokens > budget {
+			dropped := j - i + 1
+			head = append(head, fmt.Sprintf("[… %d lines truncated to fit the context …]", dropped))
+			break
+		}
+		used += tokens
+		if fromStart {
+			head = append(head, line)
+			i++
+		} else {
+			tail = append([]string{line}, tail...)
+			j--
+		}
+	}
+	return strings.Join(append(head, tail...), "\n")
+}
+
+// chunkTokens is the size of a part of a chunked prompt, leaving room for the
+// note on each part
+func (a *App) chunkTokens() int {
+	return max(a.AttachmentLimit()*9/10, 100)
+}
+
+// ChunkCount returns about how many parts a prompt of tokens is sent in
+func (a *App) ChunkCount(tokens int) int {
+	return int(math.Ceil(float64(tokens) / float64(a.chunkTokens())))
+}
+
+// splitPrompt splits text at line ends into parts of at most budget tokens,
+// lines longer than a part are cut
+func (a *App) splitPrompt(text string, budget int) []string {
+	parts := []string{}
+	current := []string{}
+	used := 0
+	for _, line := range strings.Split(text, "\n") {
+		for a.TextTokens(line) > budget {
+			cut := max(int(float64(len([]rune(line)))*float64(budget)/float64(a.TextTokens(line))), 1)
+			parts = append(parts, string([]rune(line)[:cut]))
+			line = string([]rune(line)[cut:])
+		}
+		tokens := a.TextTokens(line) + 1
+		if used+tokens > budget && len(current) > 0 {
+			parts = append(parts, strings.Join(current, "\n"))
+			current, used = nil, 0
+		}
+		current = append(current, line)
+		used += tokens
+	}
+	if len(current) > 0 {
+		parts = append(parts, strings.Join(current, "\n"))
+	}
+	return parts
+}
+
+// SendChunked sends a prompt and its outputs in parts, the first right away
+// and each next one when the assistant acknowledged the previous one
+func (a *App) SendChunked(ctx context.Context, text string) tea.Cmd {
+	parts := a.splitPrompt(a.attachOutputs(text), a.chunkTokens())
+	for i := range parts {
+		note := fmt.Sprintf("Part %d of %d of a long message, split to fit the context. ", i+1, len(parts))
+		if i < len(parts)-1 {
+			note += "Reply only with \"Received.\" until the last part."
+		} else {
+			note += "This is the last part, answer the whole message now."
+		}
+		parts[i] = note + "\n\n" + parts[i]
+	}
+	cmd := a.SendChatMessage(ctx, parts[0], nil)
+	if len(parts) > 1 {
+		a.Chunks = &Chunks{SessionID: a.Session.Id, Parts: parts[1:]}
+	}
+	return cmd
+}
+
+// FlushChunks sends the next part of a chunked prompt once the assistant is
+// no longer busy
+func (a *App) FlushChunks(ctx context.Context) tea.Cmd {
+	if a.Chunks == nil || a.Chunks.SessionID != a.Session.Id || a.IsBusy() {
+		return nil
+	}
+	part := a.Chunks.Parts[0]
+	a.Chunks.Parts = a.Chunks.Parts[1:]
+	if len(a.Chunks.Parts) == 0 {
+		a.Chunks = nil
+	}
+	return a.SendChatMessage(ctx, part, nil)
+}
+
+// SummarizePrompt condenses a prompt and its outputs to the attachment limit
+// in a throwaway session, keeping the request itself
+func (a *App) SummarizePrompt(ctx context.Context, text string, attachments []Attachment) tea.Cmd {
+	text = a.attachOutputs(text)
+	limit := a.AttachmentLimit()
+	provider, model := a.Provider, a.Model
+	return func() tea.Msg {
+		summary, err := a.summarize(ctx, text, limit, provider, model)
+		return PromptSummarizedMsg{Text: summary, Attachments: attachments, Err: err}
+	}
+}
+
+func (a *App) summarize(ctx context.Context, text string, limit int, provider *client.ProviderInfo, model *client.ModelInfo) (string, error) {
+	if provider == nil || model == nil {
+		return "", errors.New("select a model before summarizing")
+	}
+	resp, err := a.Client.PostSessionCreateWithResponse(ctx)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode() != 200 || resp.JSON200 == nil {
+		return "", fmt.Errorf("failed to create session: %d", resp.StatusCode())
+	}
+	session := resp.JSON200
+	defer func() {
+		if err := a.DeleteSession(context.Background(), session.Id); err != nil {
+			slog.Error("Failed to delete the summary session", "error", err)
+		}
+	}()
+
+	part := client.MessagePart{}
+	part.FromMessagePartText(client.MessagePartText{
+		Type: "text",
+		Text: fmt.Sprintf(
+			"Rewrite the message below in at most %d tokens. Keep its request and questions word for word "+
+				"and condense the pasted content, logs and outputs to what they are needed for. "+
+				"Reply only with the rewritten message.\n\n<message>\n%s\n</message>",
+			limit*3/4, text,
+		),
+	})
+	response, err := a.postChat(ctx, client.PostSessionChatJSONBody{
+		SessionID:  session.Id,
+		Parts:      []client.MessagePart{part},
+		ProviderID: provider.Id,
+		ModelID:    model.Id,
+	}, "", "")
+	if err != nil {
+		return "", err
+	}
+	parsed, err := client.ParsePostSessionChatResponse(response)
+	if err != nil {
+		return "", err
+	}
+	if parsed.StatusCode() != 200 || parsed.JSON200 == nil {
+		return "", fmt.Errorf("failed to summarize: %d", parsed.StatusCode())
+	}
+	summary := strings.TrimSpace(MessageText(*parsed.JSON200))
+	if summary == "" {
+		return "", errors.New("the summary came back empty")
+	}
+	return summary, nil
+}
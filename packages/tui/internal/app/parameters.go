@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// ParametersMsg replaces the model parameters of the current session, zero
+// Parameters restore the defaults of the server
+type ParametersMsg struct {
+	Parameters config.ModelParameters
+}
+
+// Parameters returns the model parameters overridden in the current session
+func (a *App) Parameters() config.ModelParameters {
+	return a.parameters
+}
+
+// SetParameters changes the model parameters and remembers them for the
+// current session
+func (a *App) SetParameters(parameters config.ModelParameters) {
+	a.parameters = parameters
+	if a.Session.Id != "" {
+		a.rememberParameters(a.Session.Id)
+	}
+}
+
+// LoadParameters restores the model parameters of the current session
+func (a *App) LoadParameters() {
+	a.parameters = a.State.SessionParameters[a.Session.Id]
+}
+
+// rememberParameters records the model parameters for a session and returns
+// them
+func (a *App) rememberParameters(sessionID string) config.ModelParameters {
+	if !a.State.SessionParameters[sessionID].Equal(a.parameters) {
+		if a.parameters.IsZero() {
+			delete(a.State.SessionParameters, sessionID)
+		} else {
+			a.State.SessionParameters[sessionID] = a.parameters
+		}
+		a.SaveState()
+	}
+	return a.parameters
+}
+
+// withParameters sets the overridden model parameters on a chat request
+func withParameters(body client.PostSessionChatJSONBody, parameters config.ModelParameters) client.PostSessionChatJSONBody {
+	if parameters.Temperature != nil {
+		temperature := float32(*parameters.Temperature)
+		body.Temperature = &temperature
+	}
+	if parameters.TopP != nil {
+		topP := float32(*parameters.TopP)
+		body.TopP = &topP
+	}
+	if parameters.MaxTokens > 0 {
+		body.MaxTokens = &parameters.MaxTokens
+	}
+	if parameters.ReasoningEffort != "" {
+		effort := client.PostSessionChatJSONBodyReasoningEffort(parameters.ReasoningEffort)
+		body.ReasoningEffort = &effort
+	}
+	return body
+}
+
+// FormatParameters describes the overridden model parameters compactly, such
+// as "t0.2 p0.9 4k effort:high"
+func FormatParameters(parameters config.ModelParameters) string {
+	fields := []string{}
+	if parameters.Temperature != nil {
+		fields = append(fields, "t"+strconv.FormatFloat(*parameters.Temperature, 'f', -1, 64))
+	}
+	if parameters.TopP != nil {
+		fields = append(fields, "p"+strconv.FormatFloat(*parameters.TopP, 'f', -1, 64))
+	}
+	if parameters.MaxTokens > 0 {
+		if parameters.MaxTokens%1000 == 0 {
+			fields = append(fields, fmt.Sprintf("%dk", parameters.MaxTokens/1000))
+		} else {
+			fields = append(fields, strconv.Itoa(parameters.MaxTokens))
+		}
+	}
+	if parameters.ReasoningEffort != "" {
+		fields = append(fields, "effort:"+parameters.ReasoningEffort)
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// defaultPasteLines is the number of lines from which a paste is collapsed
+// in the editor, when the state does not set one
+const defaultPasteLines = 30
+
+// pasteMimeType marks the attachments holding collapsed pastes, they are
+// sent as text parts of the prompt
+const pasteMimeType = "text/plain"
+
+// PasteLines returns the number of lines from which a paste is collapsed,
+// zero when pastes are always inserted as they are
+func (a *App) PasteLines() int {
+	switch {
+	case a.State.PasteLines < 0:
+		return 0
+	case a.State.PasteLines > 0:
+		return a.State.PasteLines
+	}
+	return defaultPasteLines
+}
+
+// NewPaste creates the attachment holding a collapsed paste, its file name
+// is the label standing for it in the editor
+func NewPaste(index int, text string) Attachment {
+	lines := strings.Count(strings.TrimRight(text, "\n"), "\n") + 1
+	label := fmt.Sprintf("[Pasted %s lines #%d]", formatCount(lines), index)
+	return Attachment{FilePath: label, FileName: label, MimeType: pasteMimeType, Content: []byte(text)}
+}
+
+// IsPaste reports whether an attachment holds a collapsed paste
+func IsPaste(attachment Attachment) bool {
+	return attachment.MimeType == pasteMimeType
+}
+
+// ExpandPastes replaces the labels of the collapsed pastes in text with
+// their content
+func ExpandPastes(text string, attachments []Attachment) string {
+	for _, attachment := range attachments {
+		if IsPaste(attachment) {
+			text = strings.ReplaceAll(text, attachment.FileName, string(attachment.Content))
+		}
+	}
+	return text
+}
+
+// InlinePastes appends the collapsed pastes to text for the ways of sending
+// a prompt that work on its text only, returning the other attachments
+func InlinePastes(text string, attachments []Attachment) (string, []Attachment) {
+	others := []Attachment{}
+	for _, attachment := range attachments {
+		if !IsPaste(attachment) {
+			others = append(others, attachment)
+			continue
+		}
+		text += "\n\n" + pasteText(attachment)
+	}
+	return text, others
+}
+
+// pasteParts returns the text parts sending the collapsed pastes along with
+// the prompt
+func pasteParts(attachments []Attachment) []client.MessagePart {
+	parts := []client.MessagePart{}
+	for _, attachment := range attachments {
+		if !IsPaste(attachment) {
+			continue
+		}
+		part := client.MessagePart{}
+		part.FromMessagePartText(client.MessagePartText{Type: "text", Text: pasteText(attachment)})
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// pasteText is a collapsed paste headed by its label, which is how the
+// prompt refers to it
+func pasteText(attachment Attachment) string {
+	return attachment.FileName + "\n" + string(attachment.Content)
+}
+
+// formatCount formats a count with thousands separators, such as 1,243
+func formatCount(n int) string {
+	digits := fmt.Sprint(n)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
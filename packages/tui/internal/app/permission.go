@@ -0,0 +1,251 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// Answers to a permission request, always approves the same command or
+// file for the rest of the session
+const (
+	PermissionOnce   = "once"
+	PermissionAlways = "always"
+	PermissionReject = "reject"
+)
+
+// PermissionRespondMsg answers a permission request, Remember also adds the
+// rule approving such calls from now on
+type PermissionRespondMsg struct {
+	Permission client.PermissionInfo
+	Response   string
+	Remember   bool
+}
+
+// ToolRulesMsg replaces the auto-approve rules
+type ToolRulesMsg struct {
+	Rules config.ToolRules
+}
+
+// shellOperators chain or redirect commands, a command using them is never
+// approved by a rule written for its first part
+var shellOperators = []string{";", "&", "|", "`", "$(", ">", "<", "\n"}
+
+// permissionTool returns the tool asking for a permission, the server keeps
+// it in the metadata
+func permissionTool(permission client.PermissionInfo) string {
+	tool, _ := permission.Metadata["tool"].(string)
+	return tool
+}
+
+// permissionCommand returns the shell command a permission is asked for
+func permissionCommand(permission client.PermissionInfo) string {
+	command, _ := permission.Metadata["command"].(string)
+	return strings.Join(strings.Fields(command), " ")
+}
+
+// permissionPath returns the file a permission is asked for relative to the
+// project root, false when it is outside of the project
+func (a *App) permissionPath(permission client.PermissionInfo) (string, bool) {
+	path, _ := permission.Metadata["filePath"].(string)
+	if path == "" {
+		return "", false
+	}
+	relative, err := filepath.Rel(a.Info.Path.Root, path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(relative), true
+}
+
+// AutoApproves reports whether a rule approves a permission request without
+// asking
+func (a *App) AutoApproves(permission client.PermissionInfo) bool {
+	switch permissionTool(permission) {
+	case "bash":
+		command := permissionCommand(permission)
+		for _, operator := range shellOperators {
+			if strings.Contains(command, operator) {
+				return false
+			}
+		}
+		for _, pattern := range a.State.ToolRules.Commands {
+			if matchCommand(pattern, command) {
+				return true
+			}
+		}
+	case "edit", "write":
+		path, ok := a.permissionPath(permission)
+		if !ok {
+			return false
+		}
+		for _, glob := range a.State.ToolRules.Paths {
+			if matchPath(glob, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PermissionRule returns the rule approving calls like the one of a
+// permission request, false when no rule can
+func (a *App) PermissionRule(permission client.PermissionInfo) (string, bool) {
+	switch permissionTool(permission) {
+	case "bash":
+		command := permissionCommand(permission)
+		return command, command != ""
+	case "edit", "write":
+		return a.permissionPath(permission)
+	}
+	return "", false
+}
+
+// RememberPermission adds the rule approving calls like the one of a
+// permission request and saves it
+func (a *App) RememberPermission(permission client.PermissionInfo) {
+	rule, ok := a.PermissionRule(permission)
+	if !ok {
+		return
+	}
+	rules := &a.State.ToolRules
+	switch permissionTool(permission) {
+	case "bash":
+		rules.Commands = appendRule(rules.Commands, rule)
+	default:
+		rules.Paths = appendRule(rules.Paths, rule)
+	}
+	a.SaveState()
+}
+
+func appendRule(rules []string, rule string) []string {
+	for _, existing := range rules {
+		if existing == rule {
+			return rules
+		}
+	}
+	return append(rules, rule)
+}
+
+// RespondPermission answers a permission request of the server
+func (a *App) RespondPermission(ctx context.Context, permission client.PermissionInfo, response string) error {
+	resp, err := a.Client.PostPermissionRespondWithResponse(ctx, client.PostPermissionRespondJSONRequestBody{
+		SessionID:    permission.SessionID,
+		PermissionID: permission.Id,
+		Response:     client.PostPermissionRespondJSONBodyResponse(response),
+	})
+	if err != nil {
+		slog.Error("Failed to answer permission", "error", err)
+		return err
+	}
+	if resp.StatusCode() != 200 {
+		return fmt.Errorf("failed to answer permission: %d", resp.StatusCode())
+	}
+	return nil
+}
+
+// QueuePermission keeps a permission request until no dialog is open
+func (a *App) QueuePermission(permission client.PermissionInfo) {
+	a.Permissions = append(a.Permissions, permission)
+}
+
+// NextPermission takes the oldest queued permission request, false when
+// none is waiting
+func (a *App) NextPermission() (client.PermissionInfo, bool) {
+	if len(a.Permissions) == 0 {
+		return client.PermissionInfo{}, false
+	}
+	permission := a.Permissions[0]
+	a.Permissions = a.Permissions[1:]
+	return permission, true
+}
+
+// matchCommand matches a command against a pattern where * matches any
+// text, the rest of the pattern matches literally
+func matchCommand(pattern, command string) bool {
+	pattern = strings.Join(strings.Fields(pattern), " ")
+	if pattern == "" {
+		return false
+	}
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	matched, err := regexp.MatchString("^"+expr+"$", command)
+	return err == nil && matched
+}
+
+// matchPath matches a slash separated path against a glob, where **
+// matches any number of directories, * and ? match within one
+func matchPath(glob, path string) bool {
+	glob = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(glob)), "./")
+	if glob == "" {
+		return false
+	}
+	var expr strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expr.WriteString("[^/]*")
+		case glob[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	matched, err := regexp.MatchString("^"+expr.String()+"$", path)
+	return err == nil && matched
+}
+
+// FormatToolRules writes the rules as the lines edited in the rules dialog
+func FormatToolRules(rules config.ToolRules) string {
+	lines := []string{}
+	for _, command := range rules.Commands {
+		lines = append(lines, "command: "+command)
+	}
+	for _, path := range rules.Paths {
+		lines = append(lines, "path: "+path)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseToolRules reads the lines of the rules dialog, blank lines and lines
+// starting with # are skipped
+func ParseToolRules(text string) (config.ToolRules, error) {
+	rules := config.ToolRules{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kind, value, ok := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return rules, fmt.Errorf("line %d: expected \"command: <pattern>\" or \"path: <glob>\"", i+1)
+		}
+		switch strings.TrimSpace(kind) {
+		case "command":
+			rules.Commands = appendRule(rules.Commands, value)
+		case "path":
+			rules.Paths = appendRule(rules.Paths, value)
+		default:
+			return rules, fmt.Errorf("line %d: unknown rule %q", i+1, strings.TrimSpace(kind))
+		}
+	}
+	return rules, nil
+}
+
+// SetToolRules replaces the auto-approve rules and saves them
+func (a *App) SetToolRules(rules config.ToolRules) {
+	a.State.ToolRules = rules
+	a.SaveState()
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// pinnedFileBytes is how much of a pinned file is sent, the rest is cut
+const pinnedFileBytes = 256 * 1024
+
+// PinnedContext returns the files and snippets sent with every prompt of
+// the current session
+func (a *App) PinnedContext() []config.PinnedContext {
+	return a.State.PinnedContext[a.Session.Id]
+}
+
+// PinFile pins a file to the current session, relative to the working
+// directory, it returns an error when the file cannot be read
+func (a *App) PinFile(path string) error {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "@")
+	if path == "" {
+		return fmt.Errorf("no file to pin")
+	}
+	info, err := os.Stat(a.pinnedPath(path))
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	pinned := a.PinnedContext()
+	if slices.ContainsFunc(pinned, func(item config.PinnedContext) bool { return item.Path == path }) {
+		return nil
+	}
+	a.setPinnedContext(append(pinned, config.PinnedContext{Path: path}))
+	return nil
+}
+
+// PinSnippet pins a snippet of text to the current session
+func (a *App) PinSnippet(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false
+	}
+	a.setPinnedContext(append(a.PinnedContext(), config.PinnedContext{Text: text}))
+	return true
+}
+
+// UnpinContext removes the pinned file or snippet at index from the current
+// session
+func (a *App) UnpinContext(index int) {
+	pinned := a.PinnedContext()
+	if index < 0 || index >= len(pinned) {
+		return
+	}
+	a.setPinnedContext(slices.Delete(slices.Clone(pinned), index, index+1))
+}
+
+func (a *App) setPinnedContext(pinned []config.PinnedContext) {
+	if len(pinned) == 0 {
+		delete(a.State.PinnedContext, a.Session.Id)
+	} else {
+		a.State.PinnedContext[a.Session.Id] = pinned
+	}
+	a.SaveState()
+}
+
+// adoptPinnedContext moves the context pinned before the first prompt to the
+// session the prompt created
+func (a *App) adoptPinnedContext(sessionID string) {
+	pinned, ok := a.State.PinnedContext[""]
+	if !ok {
+		return
+	}
+	delete(a.State.PinnedContext, "")
+	a.State.PinnedContext[sessionID] = pinned
+	a.SaveState()
+}
+
+func (a *App) pinnedPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(a.Info.Path.Cwd, path)
+}
+
+// PinnedLabel names a pinned file by its path and a snippet by its first
+// line
+func PinnedLabel(item config.PinnedContext) string {
+	if item.Path != "" {
+		return item.Path
+	}
+	line, _, _ := strings.Cut(item.Text, "\n")
+	return "“" + line + "”"
+}
+
+// pinnedText is what a pinned file or snippet is sent as, the file as it is
+// now
+func (a *App) pinnedText(item config.PinnedContext) (string, error) {
+	if item.Path == "" {
+		return "<pinned-context>\n" + item.Text + "\n</pinned-context>", nil
+	}
+	content, err := os.ReadFile(a.pinnedPath(item.Path))
+	if err != nil {
+		return "", err
+	}
+	text := string(content)
+	if len(content) > pinnedFileBytes {
+		text = strings.ToValidUTF8(string(content[:pinnedFileBytes]), "") + "\n… truncated"
+	}
+	return fmt.Sprintf("<pinned-context path=%q>\n%s\n</pinned-context>", item.Path, text), nil
+}
+
+// PinnedPartLabel returns the label of a part sent for pinned context, false
+// for the other parts
+func PinnedPartLabel(text string) (string, bool) {
+	header, body, _ := strings.Cut(text, "\n")
+	if header == "<pinned-context>" {
+		return PinnedLabel(config.PinnedContext{Text: strings.TrimSuffix(body, "\n</pinned-context>")}), true
+	}
+	quoted, ok := strings.CutPrefix(header, "<pinned-context path=")
+	if !ok {
+		return "", false
+	}
+	path, err := strconv.Unquote(strings.TrimSuffix(quoted, ">"))
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// PinnedTokens estimates the tokens each pinned item adds to every prompt,
+// zero for the files that cannot be read
+func (a *App) PinnedTokens() []int {
+	tokens := []int{}
+	for _, item := range a.PinnedContext() {
+		text, err := a.pinnedText(item)
+		if err != nil {
+			tokens = append(tokens, 0)
+			continue
+		}
+		tokens = append(tokens, a.TextTokens(text))
+	}
+	return tokens
+}
+
+// pinnedContextParts are the standing parts of the prompts of the current
+// session
+func (a *App) pinnedContextParts() []client.MessagePart {
+	parts := []client.MessagePart{}
+	for _, item := range a.PinnedContext() {
+		text, err := a.pinnedText(item)
+		if err != nil {
+			slog.Warn("Failed to read pinned file", "path", item.Path, "error", err)
+			continue
+		}
+		part := client.MessagePart{}
+		part.FromMessagePartText(client.MessagePartText{Type: "text", Text: text})
+		parts = append(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"github.com/sst/opencode/internal/config"
+)
+
+// projectDefault returns the model and agent mode last used in the project
+func (a *App) projectDefault() config.ProjectDefault {
+	return a.State.ProjectDefaults[a.Info.Path.Root]
+}
+
+// applyProjectDefaults makes the model and agent mode last used in the
+// project the ones selected on startup, over the global last-used model
+func applyProjectDefaults(state *config.State, root string) {
+	defaults, ok := state.ProjectDefaults[root]
+	if !ok {
+		return
+	}
+	if defaults.Provider != "" && defaults.Model != "" {
+		state.Provider = defaults.Provider
+		state.Model = defaults.Model
+	}
+}
+
+// RememberProjectModel records the selected model as the default of the
+// project, the state is saved by the caller
+func (a *App) RememberProjectModel(providerID, modelID string) {
+	defaults := a.projectDefault()
+	defaults.Provider = providerID
+	defaults.Model = modelID
+	a.State.ProjectDefaults[a.Info.Path.Root] = defaults
+}
+
+// rememberProjectMode records the agent mode as the default of the project
+func (a *App) rememberProjectMode(mode string) {
+	defaults := a.projectDefault()
+	defaults.Mode = mode
+	a.State.ProjectDefaults[a.Info.Path.Root] = defaults
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	maxQuickReplies     = 3
+	maxQuickReplyLength = 48
+	// quickReplyWindow is how many trailing lines of a response are searched
+	// for a question or a list of options
+	quickReplyWindow = 15
+)
+
+var (
+	optionPattern   = regexp.MustCompile(`^\s*(?:\d+[.)]|[a-zA-Z][.)]|[-*+])\s+(.+)$`)
+	emphasisPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	choicePattern   = regexp.MustCompile(`(?i)\b(which|prefer|choose|pick|options?|approach|or)\b`)
+	yesNoPattern    = regexp.MustCompile(`(?i)\b(should i|shall i|do you want|would you like|want me to|can i|may i|is (?:that|this|it) (?:ok|okay|fine|correct)|does (?:that|this) (?:look|sound)|ready to|proceed|go ahead)\b`)
+)
+
+// quickReplyCache keeps the replies suggested to a response, they are asked
+// for on every render
+type quickReplyCache struct {
+	messageID string
+	completed float32
+	replies   []string
+}
+
+// QuickReplies suggests replies to the last response when it ends with a
+// question: the options it listed, or yes and no to a yes or no question
+func (a *App) QuickReplies() []string {
+	if a.IsBusy() || len(a.Messages) == 0 {
+		return nil
+	}
+	message := a.Messages[len(a.Messages)-1]
+	if message.Role != client.Assistant || message.Metadata.Time.Completed == nil || message.Metadata.Error != nil {
+		return nil
+	}
+	cache := &a.quickReplies
+	if cache.messageID != message.Id || cache.completed != *message.Metadata.Time.Completed {
+		*cache = quickReplyCache{
+			messageID: message.Id,
+			completed: *message.Metadata.Time.Completed,
+			replies:   quickReplies(MessageText(message)),
+		}
+	}
+	return cache.replies
+}
+
+func quickReplies(text string) []string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	lines = lines[max(len(lines)-quickReplyWindow, 0):]
+
+	question := ""
+	options := []string{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if match := optionPattern.FindStringSubmatch(line); match != nil {
+			options = append(options, match[1])
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		// a paragraph after the list starts the search over, unless it is
+		// the question about the options
+		if strings.Contains(line, "?") {
+			question = line
+			continue
+		}
+		options, question = options[:0], ""
+	}
+	if question == "" {
+		return nil
+	}
+
+	// a yes or no question after a plan is not asking to pick a step
+	choice := choicePattern.MatchString(question) || !yesNoPattern.MatchString(question)
+	if len(options) >= 2 && choice {
+		replies := []string{}
+		for _, option := range options[:min(len(options), maxQuickReplies)] {
+			replies = append(replies, optionLabel(option))
+		}
+		return replies
+	}
+	if yesNoPattern.MatchString(question) {
+		return []string{"Yes, go ahead", "No"}
+	}
+	return nil
+}
+
+// optionLabel shortens an option to its emphasized title, or to the text
+// before its explanation
+func optionLabel(option string) string {
+	if match := emphasisPattern.FindStringSubmatch(option); match != nil {
+		option = match[1]
+	}
+	for _, separator := range []string{" - ", " – ", " — ", ": "} {
+		if before, _, found := strings.Cut(option, separator); found {
+			option = before
+		}
+	}
+	option = strings.Trim(option, "*_` ")
+	if runes := []rune(option); len(runes) > maxQuickReplyLength {
+		option = string(runes[:maxQuickReplyLength-1]) + "…"
+	}
+	return option
+}
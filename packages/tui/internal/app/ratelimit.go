@@ -0,0 +1,164 @@
+package app
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const defaultRetryAfter = 30 * time.Second
+
+// A rate limited turn is retried up to maxRateLimitRetries times in a row,
+// the wait doubling with each retry up to maxRateLimitWait
+const (
+	maxRateLimitRetries = 5
+	maxRateLimitWait    = 10 * time.Minute
+)
+
+// RateLimit is a prompt waiting for a provider rate limit window to open,
+// either the rate limited turn or a prompt sent while waiting
+type RateLimit struct {
+	Until       time.Time
+	Text        string
+	Attachments []Attachment
+	// Turn is the rate limited turn, retried when the window opens unless a
+	// prompt was sent while waiting
+	Turn *FailedTurn
+}
+
+// RateLimitedMsg is sent when a turn is rejected by a rate limit, once per
+// rejected message
+type RateLimitedMsg struct {
+	RetryAfter time.Duration
+	MessageID  string
+	Turn       FailedTurn
+}
+
+// RateLimitTickMsg drives the rate limit countdown
+type RateLimitTickMsg struct{}
+
+var (
+	rateLimitPattern  = regexp.MustCompile(`(?i)rate.?limit|too many requests|\b429\b|overloaded`)
+	retryAfterPattern = regexp.MustCompile(`(?i)(?:retry|try again)[^0-9]{0,20}(\d+(?:\.\d+)?)\s*(ms|milliseconds?|s|sec|seconds?|m|min|minutes?)?`)
+)
+
+// Remaining returns the time left until the window opens
+func (r *RateLimit) Remaining() time.Duration {
+	return max(time.Until(r.Until), 0)
+}
+
+// QueueRateLimited holds a rate limited turn until the window opens, the
+// wait growing with each retry in a row. It returns false once the turn was
+// retried too many times.
+func (a *App) QueueRateLimited(msg RateLimitedMsg) bool {
+	if a.rateLimitRetries >= maxRateLimitRetries {
+		a.rateLimitRetries = 0
+		return false
+	}
+	wait := min(msg.RetryAfter<<a.rateLimitRetries, maxRateLimitWait)
+	a.rateLimitRetries++
+	until := time.Now().Add(wait)
+	if a.RateLimit != nil && a.RateLimit.Until.After(until) {
+		until = a.RateLimit.Until
+	}
+	a.RateLimit = &RateLimit{Until: until, Turn: &msg.Turn}
+	return true
+}
+
+// QueuePrompt holds a prompt sent while waiting for the rate limit window,
+// replacing the prompt or turn that was already waiting
+func (a *App) QueuePrompt(text string, attachments []Attachment) {
+	a.RateLimit = &RateLimit{Until: a.RateLimit.Until, Text: text, Attachments: attachments}
+}
+
+// RateLimitedTurn returns the turn an assistant message was rejected in by a
+// rate limit, once per message. A turn completed without one resets the
+// retries in a row.
+func (a *App) RateLimitedTurn(message client.MessageInfo) (RateLimitedMsg, bool) {
+	if message.Role != client.Assistant || message.Metadata.Time.Completed == nil {
+		return RateLimitedMsg{}, false
+	}
+	retryAfter, ok := RateLimitError(message)
+	if !ok {
+		a.rateLimitRetries = 0
+		return RateLimitedMsg{}, false
+	}
+	if a.failed[message.Id] {
+		return RateLimitedMsg{}, false
+	}
+	a.failed[message.Id] = true
+	turn, ok := a.failedTurn(message)
+	if !ok {
+		return RateLimitedMsg{}, false
+	}
+	return RateLimitedMsg{RetryAfter: retryAfter, MessageID: message.Id, Turn: turn}, true
+}
+
+// TickRateLimit schedules the next countdown update
+func TickRateLimit() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return RateLimitTickMsg{}
+	})
+}
+
+// ParseRetryAfter reads a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func ParseRetryAfter(header http.Header) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return defaultRetryAfter
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), time.Second)
+	}
+	return defaultRetryAfter
+}
+
+// RateLimitError reports whether a message failed because of a provider
+// rate limit and how long to wait before retrying
+func RateLimitError(message client.MessageInfo) (time.Duration, bool) {
+	if message.Metadata.Error == nil {
+		return 0, false
+	}
+	unknown, err := message.Metadata.Error.AsUnknownError()
+	if err != nil || unknown.Name != "UnknownError" || !rateLimitPattern.MatchString(unknown.Data.Message) {
+		return 0, false
+	}
+	return parseRetryDelay(unknown.Data.Message), true
+}
+
+func parseRetryDelay(text string) time.Duration {
+	match := retryAfterPattern.FindStringSubmatch(text)
+	if match == nil {
+		return defaultRetryAfter
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return defaultRetryAfter
+	}
+	unit := time.Second
+	switch suffix := strings.ToLower(match[2]); {
+	case suffix == "ms" || strings.HasPrefix(suffix, "milli"):
+		unit = time.Millisecond
+	case suffix == "m" || strings.HasPrefix(suffix, "min"):
+		unit = time.Minute
+	}
+	return max(time.Duration(value*float64(unit)), time.Second)
+}
+
+// RemoveOptimistic drops optimistic user messages that never made it to the
+// server
+func (a *App) RemoveOptimistic() {
+	a.Messages = slices.DeleteFunc(a.Messages, func(m client.MessageInfo) bool {
+		return strings.HasPrefix(m.Id, "optimistic-")
+	})
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// maxRecentFiles caps the recency index, the files changed least recently
+// drop out first
+const maxRecentFiles = 200
+
+// RecentFiles is a recency index of the files in the workspace that were
+// changed, seeded from git status and kept up to date from tool calls
+type RecentFiles struct {
+	mu      sync.Mutex
+	cwd     string
+	touched map[string]time.Time
+	// seen is the last message whose tool calls changed each file, the
+	// updates of a message repeat its tool calls
+	seen map[string]string
+}
+
+func newRecentFiles(cwd string) *RecentFiles {
+	recent := &RecentFiles{
+		cwd:     cwd,
+		touched: map[string]time.Time{},
+		seen:    map[string]string{},
+	}
+	go recent.loadGitStatus()
+	return recent
+}
+
+// loadGitStatus seeds the index with the files git reports as changed,
+// ordered by modification time
+func (r *RecentFiles) loadGitStatus() {
+	c := exec.Command("git", "status", "--porcelain", "--untracked-files=all")
+	c.Dir = r.cwd
+	output, err := c.Output()
+	if err != nil {
+		slog.Debug("Failed to read git status", "error", err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for line := range strings.SplitSeq(string(output), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := line[3:]
+		if _, renamed, ok := strings.Cut(path, " -> "); ok {
+			path = renamed
+		}
+		path = strings.Trim(path, `"`)
+		info, err := os.Stat(filepath.Join(r.cwd, path))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if _, ok := r.touched[path]; !ok {
+			r.touched[path] = info.ModTime()
+		}
+	}
+	r.prune()
+}
+
+// Touch marks a file as modified now
+func (r *RecentFiles) Touch(path string) {
+	r.touch(path, "")
+}
+
+// touch marks a file as modified now, once per message when messageID is
+// set
+func (r *RecentFiles) touch(path, messageID string) {
+	if filepath.IsAbs(path) {
+		relative, err := filepath.Rel(r.cwd, path)
+		if err != nil || strings.HasPrefix(relative, "..") {
+			return
+		}
+		path = relative
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if messageID != "" {
+		if r.seen[path] == messageID {
+			return
+		}
+		r.seen[path] = messageID
+	}
+	r.touched[path] = time.Now()
+	r.prune()
+}
+
+// prune drops the files changed least recently over maxRecentFiles, with
+// the messages that changed them
+func (r *RecentFiles) prune() {
+	for len(r.touched) > maxRecentFiles {
+		oldest := ""
+		for path, touched := range r.touched {
+			if oldest == "" || touched.Before(r.touched[oldest]) {
+				oldest = path
+			}
+		}
+		delete(r.touched, oldest)
+		delete(r.seen, oldest)
+	}
+}
+
+// List returns up to limit recent files containing query, most recent first
+func (r *RecentFiles) List(query string, limit int) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	query = strings.ToLower(query)
+	files := []string{}
+	for path := range r.touched {
+		if strings.Contains(strings.ToLower(path), query) {
+			files = append(files, path)
+		}
+	}
+	slices.SortFunc(files, func(a, b string) int {
+		return r.touched[b].Compare(r.touched[a])
+	})
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files
+}
+
+// TrackRecentFiles records the files modified by the tool calls of a message
+func (a *App) TrackRecentFiles(message client.MessageInfo) {
+	if message.Role != client.Assistant {
+		return
+	}
+	for _, path := range a.modifiedFiles(message) {
+		a.RecentFiles.touch(path, message.Id)
+	}
+}
+
+// fileModifyingTools are the tools whose calls name the file they modify
+var fileModifyingTools = []string{"edit", "write", "patch"}
+
+// modifiedFiles returns the absolute paths of the files a message's tool
+// calls modify
+func (a *App) modifiedFiles(message client.MessageInfo) []string {
+	files := []string{}
+	for _, p := range message.Parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		invocation, ok := part.(client.MessagePartToolInvocation)
+		if !ok {
+			continue
+		}
+		toolCall, err := invocation.ToolInvocation.AsMessageToolInvocationToolCall()
+		if err != nil || !slices.Contains(fileModifyingTools, toolCall.ToolName) || toolCall.Args == nil {
+			continue
+		}
+		args, ok := (*toolCall.Args).(map[string]any)
+		if !ok {
+			continue
+		}
+		path, ok := args["filePath"].(string)
+		if !ok || path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(a.Info.Path.Cwd, path)
+		}
+		files = append(files, path)
+	}
+	return files
+}
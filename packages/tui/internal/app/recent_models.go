@@ -0,0 +1,22 @@
+package app
+
+import (
+	"slices"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+const defaultRecentModelLimit = 5
+
+// RecordRecentModel moves a model to the front of the recent models
+func (a *App) RecordRecentModel(provider client.ProviderInfo, model client.ModelInfo) {
+	limit := a.State.RecentModelLimit
+	if limit <= 0 {
+		limit = defaultRecentModelLimit
+	}
+	id := provider.Id + "/" + model.Id
+	recent := slices.DeleteFunc(slices.Clone(a.State.RecentModels), func(r string) bool { return r == id })
+	recent = append([]string{id}, recent...)
+	a.State.RecentModels = recent[:min(len(recent), limit)]
+	a.SaveState()
+}
@@ -0,0 +1,255 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// RedactionRulesMsg replaces the redaction rules of the project
+type RedactionRulesMsg struct {
+	Rules config.RedactionRules
+}
+
+// secretPattern matches a kind of secret, only the first group is masked
+// when the pattern has one
+type secretPattern struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+var builtinSecrets = []secretPattern{
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"API key", regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"Stripe key", regexp.MustCompile(`\b[rs]k_(?:live|test)_[0-9A-Za-z]{16,}`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{"bearer token", regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9._~+/-]{20,}=*)`)},
+	// assignments to upper case names such as API_KEY=..., as in .env files
+	{"secret value", regexp.MustCompile(`(?m)\b[A-Z0-9_]*(?:KEY|TOKEN|SECRET|PASSWORD|PASSWD|CREDENTIALS?)["']?\s*[:=]\s*["']?([A-Za-z0-9_\-./+=:@]{8,})(?:["'\s]|$)`)},
+}
+
+// envSecretName matches the names of the .env variables whose values are
+// masked wherever they appear
+var envSecretName = regexp.MustCompile(`(?i)(KEY|TOKEN|SECRET|PASSWORD|PASSWD|CREDENTIAL|AUTH|PRIVATE|DSN|DATABASE_URL)`)
+
+// redactor masks the secrets of the current project
+type redactor struct {
+	patterns []secretPattern
+	allow    []*regexp.Regexp
+}
+
+// redactor builds the patterns of the project, nil when redaction is off
+func (a *App) redactor() *redactor {
+	rules := a.State.Redaction[a.Info.Path.Root]
+	if rules.Disabled {
+		return nil
+	}
+	r := &redactor{patterns: slices.Clone(builtinSecrets)}
+	if values := a.envSecrets(); len(values) > 0 {
+		quoted := make([]string, len(values))
+		for i, value := range values {
+			quoted[i] = regexp.QuoteMeta(value)
+		}
+		r.patterns = append(r.patterns, secretPattern{".env value", regexp.MustCompile(strings.Join(quoted, "|"))})
+	}
+	for _, deny := range rules.Deny {
+		if pattern, err := regexp.Compile(deny); err == nil {
+			r.patterns = append(r.patterns, secretPattern{"project pattern", pattern})
+		}
+	}
+	for _, allow := range rules.Allow {
+		if pattern, err := regexp.Compile(allow); err == nil {
+			r.allow = append(r.allow, pattern)
+		}
+	}
+	return r
+}
+
+// envSecrets reads the secret values of the .env files at the project root,
+// leaving out the examples checked in for documentation
+func (a *App) envSecrets() []string {
+	files, _ := filepath.Glob(filepath.Join(a.Info.Path.Root, ".env*"))
+	values := []string{}
+	for _, path := range files {
+		name := filepath.Base(path)
+		if strings.Contains(name, "example") || strings.Contains(name, "sample") || strings.Contains(name, "template") {
+			continue
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "export ")
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || strings.HasPrefix(key, "#") || !envSecretName.MatchString(key) {
+				continue
+			}
+			value = strings.Trim(strings.TrimSpace(value), `"'`)
+			if len(value) >= 8 && !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+		file.Close()
+	}
+	return values
+}
+
+func (r *redactor) allowed(secret string) bool {
+	return slices.ContainsFunc(r.allow, func(pattern *regexp.Regexp) bool {
+		return pattern.MatchString(secret)
+	})
+}
+
+// redact masks the secrets of text, it returns the kind of each one masked
+func (r *redactor) redact(text string) (string, []string) {
+	found := []string{}
+	for _, secret := range r.patterns {
+		matches := secret.pattern.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		var out strings.Builder
+		last := 0
+		for _, match := range matches {
+			start, end := match[0], match[1]
+			if len(match) >= 4 && match[2] >= 0 {
+				start, end = match[2], match[3]
+			}
+			if r.allowed(text[start:end]) {
+				continue
+			}
+			out.WriteString(text[last:start])
+			out.WriteString("[redacted " + secret.kind + "]")
+			last = end
+			found = append(found, secret.kind)
+		}
+		out.WriteString(text[last:])
+		text = out.String()
+	}
+	return text, found
+}
+
+// RedactParts masks the secrets of the text parts of a prompt, pasted and
+// pinned content included, it returns the kind of each secret masked
+func (a *App) RedactParts(parts []client.MessagePart) ([]client.MessagePart, []string) {
+	r := a.redactor()
+	if r == nil {
+		return parts, nil
+	}
+	redacted := make([]client.MessagePart, len(parts))
+	found := []string{}
+	for i, part := range parts {
+		redacted[i] = part
+		value, err := part.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		text, ok := value.(client.MessagePartText)
+		if !ok {
+			continue
+		}
+		masked, kinds := r.redact(text.Text)
+		if len(kinds) == 0 {
+			continue
+		}
+		text.Text = masked
+		redacted[i] = client.MessagePart{}
+		redacted[i].FromMessagePartText(text)
+		found = append(found, kinds...)
+	}
+	return redacted, found
+}
+
+// redactionWarning tells what was masked from a prompt, nil when nothing was
+func redactionWarning(found []string) tea.Cmd {
+	if len(found) == 0 {
+		return nil
+	}
+	kinds := slices.Compact(slices.Sorted(slices.Values(found)))
+	slog.Warn("Masked secrets in the prompt", "count", len(found), "kinds", kinds)
+	return toast.NewWarningToast(
+		i18n.N("toast.redacted", len(found), strings.Join(kinds, ", ")),
+		toast.WithTitle(i18n.T("toast.redaction_title")),
+	)
+}
+
+// RedactionRules returns the redaction rules of the current project
+func (a *App) RedactionRules() config.RedactionRules {
+	return a.State.Redaction[a.Info.Path.Root]
+}
+
+// SetRedactionRules replaces the redaction rules of the current project and
+// saves them
+func (a *App) SetRedactionRules(rules config.RedactionRules) {
+	if len(rules.Deny) == 0 && len(rules.Allow) == 0 && !rules.Disabled {
+		delete(a.State.Redaction, a.Info.Path.Root)
+	} else {
+		a.State.Redaction[a.Info.Path.Root] = rules
+	}
+	a.SaveState()
+}
+
+// FormatRedactionRules writes rules as the lines of the redaction dialog
+func FormatRedactionRules(rules config.RedactionRules) string {
+	lines := []string{}
+	if rules.Disabled {
+		lines = append(lines, "off")
+	}
+	for _, deny := range rules.Deny {
+		lines = append(lines, "deny: "+deny)
+	}
+	for _, allow := range rules.Allow {
+		lines = append(lines, "allow: "+allow)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseRedactionRules reads the lines of the redaction dialog, blank lines
+// and lines starting with # are skipped
+func ParseRedactionRules(text string) (config.RedactionRules, error) {
+	rules := config.RedactionRules{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "off" {
+			rules.Disabled = true
+			continue
+		}
+		kind, value, ok := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return rules, fmt.Errorf("line %d: expected \"deny: <regexp>\", \"allow: <regexp>\" or \"off\"", i+1)
+		}
+		if _, err := regexp.Compile(value); err != nil {
+			return rules, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		switch strings.TrimSpace(kind) {
+		case "deny":
+			rules.Deny = appendRule(rules.Deny, value)
+		case "allow":
+			rules.Allow = appendRule(rules.Allow, value)
+		default:
+			return rules, fmt.Errorf("line %d: unknown rule %q", i+1, strings.TrimSpace(kind))
+		}
+	}
+	return rules, nil
+}
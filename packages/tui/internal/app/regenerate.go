@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// RegenerateMsg resends the prompt that produced the last assistant message.
+// A nil Provider and Model keep the active model, Instruction is appended to
+// the original prompt when set.
+type RegenerateMsg struct {
+	Provider    *client.ProviderInfo
+	Model       *client.ModelInfo
+	Instruction string
+}
+
+// RegenerateModelMsg opens the model picker to regenerate with another model
+type RegenerateModelMsg struct{}
+
+// lastTurn returns the turn of the last assistant message
+func (a *App) lastTurn() (FailedTurn, bool) {
+	for i := len(a.Messages) - 1; i >= 0; i-- {
+		if a.Messages[i].Role == client.Assistant {
+			return a.failedTurn(a.Messages[i])
+		}
+	}
+	return FailedTurn{}, false
+}
+
+// CanRegenerate reports whether there is a response to regenerate
+func (a *App) CanRegenerate() bool {
+	_, ok := a.lastTurn()
+	return ok
+}
+
+// Regenerate reverts the last turn and sends its prompt again, with the
+// parameters of msg
+func (a *App) Regenerate(ctx context.Context, msg RegenerateMsg) tea.Cmd {
+	turn, ok := a.lastTurn()
+	if !ok {
+		return toast.NewWarningToast(i18n.T("toast.nothing_to_regenerate"))
+	}
+	if instruction := strings.TrimSpace(msg.Instruction); instruction != "" {
+		turn.Parts = withInstruction(turn.Parts, instruction)
+	}
+	return a.RetryTurn(ctx, turn, msg.Provider, msg.Model)
+}
+
+// withInstruction appends instruction to the text of a prompt, leaving its
+// other parts as they were
+func withInstruction(parts []client.MessagePart, instruction string) []client.MessagePart {
+	parts = slices.Clone(parts)
+	for i, p := range parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		if text, ok := part.(client.MessagePartText); ok {
+			text.Text += "\n\n" + instruction
+			parts[i].FromMessagePartText(text)
+			return parts
+		}
+	}
+	part := client.MessagePart{}
+	part.FromMessagePartText(client.MessagePartText{Type: "text", Text: instruction})
+	return append([]client.MessagePart{part}, parts...)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// ResumePromptMsg offers to resume a session whose last response did not
+// finish. Decline is sent when the user starts over instead, it is nil on
+// startup where declining keeps the empty session.
+type ResumePromptMsg struct {
+	Session client.SessionInfo
+	Decline tea.Msg
+}
+
+// InitializeProjectMsg starts over with InitializeNewSession
+type InitializeProjectMsg struct{}
+
+// UnfinishedSession returns the most recently updated session started from
+// the working directory, if its last message never completed
+func (a *App) UnfinishedSession(ctx context.Context) (*client.SessionInfo, bool) {
+	sessions, err := a.ListSessions(ctx)
+	if err != nil {
+		slog.Debug("Failed to list sessions", "error", err)
+		return nil, false
+	}
+	var latest *client.SessionInfo
+	for i, session := range sessions {
+		if session.ParentID != nil || a.State.ArchivedSessions[session.Id] ||
+			a.State.SessionDirectories[session.Id] != a.Info.Path.Cwd {
+			continue
+		}
+		if latest == nil || session.Time.Updated > latest.Time.Updated {
+			latest = &sessions[i]
+		}
+	}
+	if latest == nil {
+		return nil, false
+	}
+	messages, err := a.ListMessages(ctx, latest.Id)
+	if err != nil || len(messages) == 0 {
+		return nil, false
+	}
+	if messages[len(messages)-1].Metadata.Time.Completed != nil {
+		return nil, false
+	}
+	return latest, true
+}
+
+// ResumePrompt looks for unfinished work on startup in the background
+func (a *App) ResumePrompt() tea.Cmd {
+	return func() tea.Msg {
+		session, ok := a.UnfinishedSession(context.Background())
+		if !ok {
+			return nil
+		}
+		return ResumePromptMsg{Session: *session}
+	}
+}
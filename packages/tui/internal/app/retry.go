@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	defaultSendRetries = 3
+	defaultSendBackoff = time.Second
+	maxSendBackoff     = 15 * time.Second
+)
+
+// SendRetryMsg is sent when a request failed on a network error and is sent
+// again after Delay
+type SendRetryMsg struct {
+	// MessageID is the optimistic message the request sends, if any
+	MessageID string
+	Attempt   int
+	Retries   int
+	Delay     time.Duration
+	Err       error
+	retry     tea.Cmd
+}
+
+// SendFailedMsg is sent when a request still failed on a network error after
+// its retries
+type SendFailedMsg struct {
+	MessageID string
+	Err       error
+}
+
+// sendPolicy returns the number of retries and the delay before the first
+// one from the state
+func (a *App) sendPolicy() (int, time.Duration) {
+	retries := a.State.SendRetries
+	if retries == 0 {
+		retries = defaultSendRetries
+	}
+	backoff := defaultSendBackoff
+	if a.State.SendBackoff > 0 {
+		backoff = time.Duration(a.State.SendBackoff) * time.Millisecond
+	}
+	return max(retries, 0), backoff
+}
+
+// transientError reports whether a request failed before reaching the
+// server or lost its connection, so that sending it again may succeed
+func transientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// idempotencyKey sets the key the server recognizes the attempts of a
+// request by, so a retry of a request that did reach it is not run twice
+func idempotencyKey(key string) client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Idempotency-Key", key)
+		return nil
+	}
+}
+
+// retried runs send, and when it fails on a network error returns a
+// SendRetryMsg scheduling it again until the retries run out. done turns the
+// final response into the message of the command.
+func (a *App) retried(messageID string, send func() (*http.Response, error), done func(*http.Response, error) tea.Msg) tea.Cmd {
+	retries, backoff := a.sendPolicy()
+	var attempt func(n int) tea.Cmd
+	attempt = func(n int) tea.Cmd {
+		return func() tea.Msg {
+			response, err := send()
+			if !transientError(err) {
+				return done(response, err)
+			}
+			if n >= retries {
+				slog.Error("Request failed", "attempts", n+1, "error", err)
+				return SendFailedMsg{MessageID: messageID, Err: err}
+			}
+			slog.Warn("Request failed, retrying", "attempt", n+1, "error", err)
+			return SendRetryMsg{
+				MessageID: messageID,
+				Attempt:   n + 1,
+				Retries:   retries,
+				Delay:     min(backoff<<n, maxSendBackoff),
+				Err:       err,
+				retry:     attempt(n + 1),
+			}
+		}
+	}
+	return attempt(0)
+}
+
+// RetrySend marks the message of a failed request as retrying and sends the
+// request again once its delay passed
+func (a *App) RetrySend(msg SendRetryMsg) tea.Cmd {
+	if msg.MessageID != "" {
+		a.sending[msg.MessageID] = fmt.Sprintf("retrying (%d/%d)…", msg.Attempt, msg.Retries)
+	}
+	retry := msg.retry
+	return tea.Tick(msg.Delay, func(time.Time) tea.Msg {
+		return retry()
+	})
+}
+
+// FailSend marks the message of a request that failed for good as not sent
+func (a *App) FailSend(msg SendFailedMsg) {
+	if msg.MessageID != "" {
+		a.sending[msg.MessageID] = "not sent"
+	}
+}
+
+// SendStatus describes the state of a message whose sending is retried or
+// failed, empty otherwise
+func (a *App) SendStatus(messageID string) string {
+	return a.sending[messageID]
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// runTimeout stops commands started with /run that never finish
+	runTimeout = 2 * time.Minute
+	// outputHeadLines and outputTailLines are kept from long outputs, the
+	// end of a build log usually holds the errors so it gets the larger share
+	outputHeadLines = 40
+	outputTailLines = 120
+	outputLineBytes = 500
+)
+
+// CommandOutput is the result of a shell command run from the prompt
+type CommandOutput struct {
+	Command  string
+	Output   string
+	ExitCode int
+}
+
+// CommandOutputMsg is sent when a command started with /run finished
+type CommandOutputMsg struct {
+	Output CommandOutput
+	Err    error
+}
+
+// RunCommand executes a shell command in the working directory and reports
+// its combined output
+func (a *App) RunCommand(command string) tea.Cmd {
+	cwd := a.Info.Path.Cwd
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+		defer cancel()
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "sh"
+		}
+		c := exec.CommandContext(ctx, shell, "-c", command)
+		c.Dir = cwd
+		output, err := c.CombinedOutput()
+
+		result := CommandOutput{Command: command}
+		result.Output = truncateOutput(ansi.Strip(string(output)))
+		var exitErr *exec.ExitError
+		switch {
+		case errors.As(err, &exitErr):
+			result.ExitCode = exitErr.ExitCode()
+		case err != nil:
+			return CommandOutputMsg{Output: result, Err: err}
+		}
+		if ctx.Err() != nil {
+			return CommandOutputMsg{Output: result, Err: fmt.Errorf("timed out after %s", runTimeout)}
+		}
+		return CommandOutputMsg{Output: result}
+	}
+}
+
+// truncateOutput keeps the head and the tail of a long output, marking how
+// many lines were dropped in between
+func truncateOutput(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	for i, line := range lines {
+		if len(line) > outputLineBytes {
+			lines[i] = strings.ToValidUTF8(line[:outputLineBytes], "") + "…"
+		}
+	}
+	if len(lines) <= outputHeadLines+outputTailLines {
+		return strings.Join(lines, "\n")
+	}
+	omitted := len(lines) - outputHeadLines - outputTailLines
+	kept := append([]string{}, lines[:outputHeadLines]...)
+	kept = append(kept, fmt.Sprintf("… %d lines omitted …", omitted))
+	kept = append(kept, lines[len(lines)-outputTailLines:]...)
+	return strings.Join(kept, "\n")
+}
+
+// AttachOutput adds a command output to the context of the next prompt
+func (a *App) AttachOutput(output CommandOutput) {
+	a.Outputs = append(a.Outputs, output)
+}
+
+// attachOutputs appends the attached command outputs to a prompt and clears
+// them
+func (a *App) attachOutputs(text string) string {
+	if len(a.Outputs) == 0 {
+		return text
+	}
+	blocks := []string{text}
+	for _, output := range a.Outputs {
+		blocks = append(blocks, fmt.Sprintf(
+			"Output of `%s` (exit code %d):\n```\n%s\n```",
+			output.Command, output.ExitCode, output.Output,
+		))
+	}
+	a.Outputs = nil
+	return strings.TrimSpace(strings.Join(blocks, "\n\n"))
+}
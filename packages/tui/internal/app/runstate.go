@@ -0,0 +1,154 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// RunState is the step the assistant is at in answering a prompt
+type RunState string
+
+const (
+	RunIdle RunState = ""
+	// RunQueued is set once a prompt is sent until its first step starts
+	RunQueued     RunState = "queued"
+	RunGenerating RunState = "generating"
+	RunTool       RunState = "running tool"
+	// RunFinalizing is set once the last step produced its answer until the
+	// response is completed
+	RunFinalizing RunState = "finalizing"
+)
+
+// Run is the state of the run of a session, fed by the server events of its
+// messages and parts
+type Run struct {
+	State RunState
+	// Tool is the name of the last tool called that has no result yet
+	Tool string
+	// calls are the names of the tool calls without a result, by call ID
+	calls map[string]string
+	// output is the output tokens reported for the steps done so far, the
+	// usage of a step is reported when it finishes
+	output float32
+	// queued is when the prompt was sent
+	queued time.Time
+}
+
+// Label describes the run in the status bar and the editor
+func (r Run) Label() string {
+	switch {
+	case r.State == RunIdle:
+		return ""
+	case r.State == RunTool && r.Tool != "":
+		return i18n.T("status.run_tool", r.Tool)
+	}
+	return i18n.T("status.run_" + strings.ReplaceAll(string(r.State), " ", "_"))
+}
+
+// Run returns the state of the run of the current session
+func (a *App) Run() Run {
+	if run, ok := a.runs[a.Session.Id]; ok {
+		return *run
+	}
+	return Run{}
+}
+
+func (a *App) run(sessionID string) *Run {
+	run, ok := a.runs[sessionID]
+	if !ok {
+		run = &Run{calls: map[string]string{}}
+		a.runs[sessionID] = run
+	}
+	return run
+}
+
+// queueRun marks a prompt as sent in a session
+func (a *App) queueRun(sessionID string) {
+	a.runs[sessionID] = &Run{State: RunQueued, calls: map[string]string{}, queued: time.Now()}
+}
+
+// LoadRun restores the state of the run of the current session from its
+// messages when no events of it were seen yet
+func (a *App) LoadRun() {
+	if _, ok := a.runs[a.Session.Id]; ok || len(a.Messages) == 0 {
+		return
+	}
+	last := a.Messages[len(a.Messages)-1]
+	if last.Role == client.Assistant && last.Metadata.Time.Completed == nil {
+		a.run(a.Session.Id).State = RunGenerating
+	}
+}
+
+// TrackRunPart moves the run of the session of an updated part to the step
+// the part shows: a step started, a tool was called or returned
+func (a *App) TrackRunPart(event client.EventMessagePartUpdated) {
+	value, err := event.Properties.Part.ValueByDiscriminator()
+	if err != nil {
+		return
+	}
+	run := a.run(event.Properties.SessionID)
+	switch part := value.(type) {
+	case client.MessagePartStepStart:
+		run.State = RunGenerating
+	case client.MessagePartText, client.MessagePartReasoning:
+		if len(run.calls) == 0 {
+			run.State = RunGenerating
+		}
+	case client.MessagePartToolInvocation:
+		invocation, err := part.ToolInvocation.ValueByDiscriminator()
+		if err != nil {
+			return
+		}
+		switch invocation := invocation.(type) {
+		case client.MessageToolInvocationToolCall:
+			run.calls[invocation.ToolCallId] = invocation.ToolName
+			run.Tool = invocation.ToolName
+		case client.MessageToolInvocationToolPartialCall:
+			run.calls[invocation.ToolCallId] = invocation.ToolName
+			run.Tool = invocation.ToolName
+		case client.MessageToolInvocationToolResult:
+			delete(run.calls, invocation.ToolCallId)
+		}
+		if len(run.calls) > 0 {
+			run.State = RunTool
+		} else {
+			run.State, run.Tool = RunGenerating, ""
+		}
+	}
+}
+
+// TrackRunMessage ends the run of the session of an updated message once the
+// response is completed, and marks it finalizing once the usage of a step
+// ending in text was reported
+func (a *App) TrackRunMessage(message client.MessageInfo) {
+	sessionID := message.Metadata.SessionID
+	if message.Role != client.Assistant {
+		if _, ok := a.runs[sessionID]; !ok && message.Metadata.Time.Completed == nil {
+			a.queueRun(sessionID)
+		}
+		return
+	}
+	if message.Metadata.Time.Completed != nil {
+		delete(a.runs, sessionID)
+		return
+	}
+	run := a.run(sessionID)
+	if run.State == RunIdle || run.State == RunQueued {
+		run.State = RunGenerating
+	}
+	if message.Metadata.Assistant == nil || message.Metadata.Assistant.Tokens.Output == run.output {
+		return
+	}
+	run.output = message.Metadata.Assistant.Tokens.Output
+	if len(run.calls) > 0 || len(message.Parts) == 0 {
+		return
+	}
+	if last, err := message.Parts[len(message.Parts)-1].ValueByDiscriminator(); err == nil {
+		if _, ok := last.(client.MessagePartText); ok {
+			run.State = RunFinalizing
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ProjectScript is a script of the package.json, a target of the Makefile or
+// a recipe of the justfile of the working directory
+type ProjectScript struct {
+	Name string
+	// Command runs the script from the working directory
+	Command string
+	// Source is the file the script was found in
+	Source string
+	// Description is the body of a package.json script or the comment above
+	// a target or recipe
+	Description string
+}
+
+// RunScriptMsg runs a project script, attaching its output to the next
+// prompt, or asks the agent to run it when Ask is set
+type RunScriptMsg struct {
+	Script ProjectScript
+	Ask    bool
+}
+
+var (
+	makeTarget = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_./-]*)\s*:([^=]|$)`)
+	justRecipe = regexp.MustCompile(`^@?([A-Za-z_][A-Za-z0-9_-]*)(\s+[^:]*)?:([^=]|$)`)
+)
+
+// ProjectScripts lists the scripts of the working directory, the ones of
+// package.json first, then the Makefile targets and the justfile recipes
+func (a *App) ProjectScripts() []ProjectScript {
+	dir := a.Info.Path.Cwd
+	scripts := packageScripts(dir)
+	for _, name := range []string{"GNUmakefile", "makefile", "Makefile"} {
+		if found := makeTargets(filepath.Join(dir, name)); found != nil {
+			scripts = append(scripts, found...)
+			break
+		}
+	}
+	for _, name := range []string{"justfile", "Justfile", ".justfile"} {
+		if found := justRecipes(filepath.Join(dir, name)); found != nil {
+			scripts = append(scripts, found...)
+			break
+		}
+	}
+	return scripts
+}
+
+// packageScripts reads the scripts of package.json, run with the package
+// manager of the lockfile next to it
+func packageScripts(dir string) []ProjectScript {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var manifest struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil
+	}
+	runner := "npm run"
+	for _, lockfile := range []struct{ name, runner string }{
+		{"bun.lock", "bun run"},
+		{"bun.lockb", "bun run"},
+		{"pnpm-lock.yaml", "pnpm run"},
+		{"yarn.lock", "yarn run"},
+	} {
+		if _, err := os.Stat(filepath.Join(dir, lockfile.name)); err == nil {
+			runner = lockfile.runner
+			break
+		}
+	}
+	scripts := []ProjectScript{}
+	for name, body := range manifest.Scripts {
+		scripts = append(scripts, ProjectScript{
+			Name:        name,
+			Command:     runner + " " + name,
+			Source:      "package.json",
+			Description: body,
+		})
+	}
+	slices.SortFunc(scripts, func(a, b ProjectScript) int { return strings.Compare(a.Name, b.Name) })
+	return scripts
+}
+
+// makeTargets reads the explicit targets of a Makefile, skipping the
+// special and pattern ones
+func makeTargets(path string) []ProjectScript {
+	return scanScripts(path, func(line string) (string, bool) {
+		if strings.HasPrefix(line, "\t") || strings.Contains(line, "%") {
+			return "", false
+		}
+		match := makeTarget.FindStringSubmatch(line)
+		if match == nil {
+			return "", false
+		}
+		return match[1], true
+	}, "make ")
+}
+
+// justRecipes reads the recipes of a justfile, skipping the private ones
+func justRecipes(path string) []ProjectScript {
+	return scanScripts(path, func(line string) (string, bool) {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			return "", false
+		}
+		match := justRecipe.FindStringSubmatch(line)
+		if match == nil || strings.HasPrefix(match[1], "_") {
+			return "", false
+		}
+		return match[1], true
+	}, "just ")
+}
+
+// scanScripts lists the names matched in the lines of path, described by the
+// comment right above them. It returns nil when path cannot be read.
+func scanScripts(path string, match func(string) (string, bool), runner string) []ProjectScript {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scripts := []ProjectScript{}
+	seen := map[string]bool{}
+	comment := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if text, ok := strings.CutPrefix(line, "#"); ok {
+			comment = strings.TrimSpace(strings.TrimLeft(text, "#"))
+			continue
+		}
+		name, ok := match(line)
+		if ok && !seen[name] {
+			seen[name] = true
+			scripts = append(scripts, ProjectScript{
+				Name:        name,
+				Command:     runner + name,
+				Source:      filepath.Base(path),
+				Description: comment,
+			})
+		}
+		comment = ""
+	}
+	return scripts
+}
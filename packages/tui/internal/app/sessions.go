@@ -0,0 +1,261 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// SessionsImportedMsg is sent once the sessions of an export file were
+// recreated on the server
+type SessionsImportedMsg struct {
+	Sessions []client.SessionInfo
+	Err      error
+}
+
+// DeleteSessionsPromptMsg asks for confirmation before deleting sessions
+type DeleteSessionsPromptMsg struct {
+	SessionIDs []string
+	Messages   int
+}
+
+// DeleteSessionsMsg deletes sessions once confirmed
+type DeleteSessionsMsg struct {
+	SessionIDs []string
+}
+
+// CountMessages returns the number of messages in the given sessions
+func (a *App) CountMessages(ctx context.Context, sessionIDs []string) (int, error) {
+	count := 0
+	for _, id := range sessionIDs {
+		messages, err := a.ListMessages(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		count += len(messages)
+	}
+	return count, nil
+}
+
+// DeleteSessions deletes every session, continuing past failures
+func (a *App) DeleteSessions(ctx context.Context, sessionIDs []string) error {
+	var errs []error
+	for _, id := range sessionIDs {
+		if err := a.DeleteSession(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsArchived reports whether a session is hidden from the session list
+func (a *App) IsArchived(sessionID string) bool {
+	return a.State.ArchivedSessions[sessionID]
+}
+
+// ArchiveSessions hides sessions from the session list, or shows them again
+// when archived is false. Archiving is local to this machine.
+func (a *App) ArchiveSessions(sessionIDs []string, archived bool) {
+	for _, id := range sessionIDs {
+		if archived {
+			a.State.ArchivedSessions[id] = true
+		} else {
+			delete(a.State.ArchivedSessions, id)
+		}
+	}
+	a.SaveState()
+}
+
+// AdjacentSession returns the session created after the current one, or
+// before it when offset is negative, wrapping around at the ends. Archived
+// and sub-agent sessions are skipped, nil is returned when none are left.
+// The position of the session among the others is counted from 1, oldest
+// first.
+func (a *App) AdjacentSession(ctx context.Context, offset int) (*client.SessionInfo, int, int, error) {
+	listed, err := a.ListSessions(ctx)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	sessions := []client.SessionInfo{}
+	// ListSessions returns the newest first
+	for _, session := range slices.Backward(listed) {
+		if session.ParentID == nil && !a.IsArchived(session.Id) {
+			sessions = append(sessions, session)
+		}
+	}
+	if len(sessions) == 0 {
+		return nil, 0, 0, nil
+	}
+	current := slices.IndexFunc(sessions, func(session client.SessionInfo) bool {
+		return session.Id == a.Session.Id
+	})
+	var next int
+	switch {
+	case current >= 0:
+		next = ((current+offset)%len(sessions) + len(sessions)) % len(sessions)
+	case offset < 0:
+		// a new session comes after all others
+		next = len(sessions) - 1
+	}
+	return &sessions[next], next + 1, len(sessions), nil
+}
+
+// IsPinned reports whether a session is listed above the others in the
+// session list
+func (a *App) IsPinned(sessionID string) bool {
+	return a.State.PinnedSessions[sessionID]
+}
+
+// PinSessions lists sessions above the others in the session list whatever
+// their age, or unpins them when pinned is false
+func (a *App) PinSessions(sessionIDs []string, pinned bool) {
+	for _, id := range sessionIDs {
+		if pinned {
+			a.State.PinnedSessions[id] = true
+		} else {
+			delete(a.State.PinnedSessions, id)
+		}
+	}
+	a.SaveState()
+}
+
+// IsMessagePinned reports whether a message stays visible when the history
+// before a summary is collapsed
+func (a *App) IsMessagePinned(messageID string) bool {
+	return a.State.PinnedMessages[messageID]
+}
+
+// ToggleMessagePin pins or unpins a message and returns whether it is pinned
+func (a *App) ToggleMessagePin(messageID string) bool {
+	pinned := !a.State.PinnedMessages[messageID]
+	if pinned {
+		a.State.PinnedMessages[messageID] = true
+	} else {
+		delete(a.State.PinnedMessages, messageID)
+	}
+	a.SaveState()
+	return pinned
+}
+
+// ExportSessions writes the sessions and their messages to a JSON file in
+// the working directory and returns its path
+func (a *App) ExportSessions(ctx context.Context, sessions []client.SessionInfo) (string, error) {
+	export := []map[string]any{}
+	for _, session := range sessions {
+		messages, err := a.ListMessages(ctx, session.Id)
+		if err != nil {
+			return "", err
+		}
+		export = append(export, map[string]any{
+			"session":  session,
+			"messages": messages,
+		})
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("opencode-sessions-%s.json", time.Now().Format("20060102-150405"))
+	path := filepath.Join(a.Info.Path.Cwd, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// exportedSession is one entry of a file written by ExportSessions
+type exportedSession struct {
+	Session  client.SessionInfo   `json:"session"`
+	Messages []client.MessageInfo `json:"messages"`
+}
+
+// ImportSessions recreates the sessions of a file written by ExportSessions.
+// The server only stores messages it took part in, so each conversation is
+// replayed into a new session as a transcript the model acknowledges. The
+// sessions are returned for AdoptSessions, which must run on the UI thread.
+func (a *App) ImportSessions(ctx context.Context, path string) ([]client.SessionInfo, error) {
+	if a.Provider == nil || a.Model == nil {
+		return nil, errors.New("select a model before importing sessions")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []exportedSession
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s is not a session export: %w", filepath.Base(path), err)
+	}
+
+	imported := []client.SessionInfo{}
+	for _, entry := range entries {
+		if len(entry.Messages) == 0 {
+			continue
+		}
+		resp, err := a.Client.PostSessionCreateWithResponse(ctx)
+		if err != nil {
+			return imported, err
+		}
+		if resp.StatusCode() != 200 || resp.JSON200 == nil {
+			return imported, fmt.Errorf("failed to create session: %d", resp.StatusCode())
+		}
+		session := *resp.JSON200
+
+		part := client.MessagePart{}
+		part.FromMessagePartText(client.MessagePartText{
+			Type: "text",
+			Text: importTranscript(entry),
+		})
+		response, err := a.postChat(ctx, client.PostSessionChatJSONBody{
+			SessionID:  session.Id,
+			Parts:      []client.MessagePart{part},
+			ProviderID: a.Provider.Id,
+			ModelID:    a.Model.Id,
+		}, "", "")
+		if err != nil {
+			return imported, err
+		}
+		response.Body.Close()
+		if response.StatusCode != 200 {
+			return imported, fmt.Errorf("failed to import %q: %d", entry.Session.Title, response.StatusCode)
+		}
+		imported = append(imported, session)
+	}
+	return imported, nil
+}
+
+// AdoptSessions records imported sessions as belonging to the working
+// directory
+func (a *App) AdoptSessions(sessions []client.SessionInfo) {
+	for _, session := range sessions {
+		a.State.SessionDirectories[session.Id] = a.Info.Path.Cwd
+	}
+	a.SaveState()
+}
+
+// importTranscript renders an exported conversation as the first message of
+// the session recreating it
+func importTranscript(entry exportedSession) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This session was imported from an export of the conversation %q. ", entry.Session.Title)
+	b.WriteString("Treat the transcript below as its history and reply only with \"Imported.\"\n")
+	for _, message := range entry.Messages {
+		text := strings.TrimSpace(MessageText(message))
+		if text == "" {
+			continue
+		}
+		role := "user"
+		if message.Role == client.Assistant {
+			role = "assistant"
+		}
+		fmt.Fprintf(&b, "\n<%s>\n%s\n</%s>\n", role, text, role)
+	}
+	return b.String()
+}
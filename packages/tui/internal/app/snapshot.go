@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// The server snapshots the project files before the tools of an assistant
+// message change them, bash included, and restores them on undo.
+
+// FileChangesMsg carries the files an undo would restore
+type FileChangesMsg struct {
+	Files []string
+	Err   error
+}
+
+type UndoConfirmedMsg struct{}
+
+// FilesRestoredMsg carries the files an undo restored
+type FilesRestoredMsg struct {
+	Files []string
+	Err   error
+}
+
+// FileChanges lists the files the latest assistant message with changes
+// modified, which an undo would restore
+func (a *App) FileChanges(ctx context.Context) tea.Cmd {
+	sessionID := a.Session.Id
+	return func() tea.Msg {
+		if sessionID == "" {
+			return FileChangesMsg{}
+		}
+		response, err := a.Client.PostSessionChangesWithResponse(ctx, client.PostSessionChangesJSONRequestBody{SessionID: sessionID})
+		if err != nil {
+			return FileChangesMsg{Err: err}
+		}
+		if response.StatusCode() != 200 || response.JSON200 == nil {
+			return FileChangesMsg{Err: fmt.Errorf("failed to list file changes: %d", response.StatusCode())}
+		}
+		return FileChangesMsg{Files: *response.JSON200}
+	}
+}
+
+// UndoFileChanges restores the files of the latest assistant message with
+// changes to the content they had before
+func (a *App) UndoFileChanges(ctx context.Context) tea.Cmd {
+	sessionID := a.Session.Id
+	return func() tea.Msg {
+		files, err := a.undoFiles(ctx, sessionID, "")
+		return FilesRestoredMsg{Files: files, Err: err}
+	}
+}
+
+// undoFiles restores the files changed by messageID and the messages after
+// it, or by the latest message with changes when messageID is empty
+func (a *App) undoFiles(ctx context.Context, sessionID, messageID string) ([]string, error) {
+	if sessionID == "" {
+		return nil, errors.New("no active session")
+	}
+	body := client.PostSessionUndoJSONRequestBody{SessionID: sessionID}
+	if messageID != "" {
+		body.MessageID = &messageID
+	}
+	response, err := a.Client.PostSessionUndoWithResponse(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != 200 || response.JSON200 == nil {
+		return nil, fmt.Errorf("failed to undo file changes: %d", response.StatusCode())
+	}
+	return *response.JSON200, nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// defaultSnippets are available unless the state defines the same
+// abbreviation
+var defaultSnippets = map[string]string{
+	"bugfix": "Fix a bug in ${1:component}.\n\n" +
+		"Expected: ${2:what should happen}\n" +
+		"Actual: ${3:what happens instead}\n" +
+		"Steps to reproduce:\n1. ${4:first step}\n\n$0",
+	"review":  "Review ${1:the uncommitted changes} for ${2:bugs, unclear naming and missing tests}.$0",
+	"explain": "Explain how ${1:this part of the code} works, starting from ${2:its entry point}.$0",
+}
+
+// TabStop is a placeholder of an expanded snippet, as a rune offset into the
+// expanded text and the length of its default text
+type TabStop struct {
+	Offset int
+	Length int
+}
+
+var tabStopPattern = regexp.MustCompile(`\$\{(\d+):([^}]*)\}|\$(\d+)`)
+
+// Snippet returns the template an abbreviation expands to
+func (a *App) Snippet(abbreviation string) (string, bool) {
+	if template, ok := a.State.Snippets[abbreviation]; ok {
+		return template, true
+	}
+	template, ok := defaultSnippets[abbreviation]
+	return template, ok
+}
+
+// ExpandSnippet replaces the placeholders of a template by their default
+// text and returns the tab stops in the order they are visited. $0 is the
+// last stop, at the end of the text when the template has none.
+func ExpandSnippet(template string) (string, []TabStop) {
+	type numbered struct {
+		number int
+		stop   TabStop
+	}
+	stops := []numbered{}
+	placeholders := map[int]string{}
+	text := ""
+	last := 0
+	for _, match := range tabStopPattern.FindAllStringSubmatchIndex(template, -1) {
+		text += template[last:match[0]]
+		last = match[1]
+		var number, placeholder string
+		if match[2] >= 0 {
+			number, placeholder = template[match[2]:match[3]], template[match[4]:match[5]]
+		} else {
+			number = template[match[6]:match[7]]
+		}
+		n, _ := strconv.Atoi(number)
+		if previous, ok := placeholders[n]; ok && match[2] < 0 {
+			placeholder = previous
+		}
+		placeholders[n] = placeholder
+		stop := TabStop{Offset: utf8.RuneCountInString(text), Length: utf8.RuneCountInString(placeholder)}
+		text += placeholder
+		// a number used again only repeats the text, the first one is visited
+		if !slices.ContainsFunc(stops, func(s numbered) bool { return s.number == n }) {
+			stops = append(stops, numbered{n, stop})
+		}
+	}
+	text += template[last:]
+
+	if !slices.ContainsFunc(stops, func(s numbered) bool { return s.number == 0 }) {
+		stops = append(stops, numbered{0, TabStop{Offset: utf8.RuneCountInString(text)}})
+	}
+	slices.SortStableFunc(stops, func(a, b numbered) int {
+		switch {
+		case a.number == b.number:
+			return 0
+		case a.number == 0:
+			return 1
+		case b.number == 0:
+			return -1
+		}
+		return a.number - b.number
+	})
+	ordered := make([]TabStop, len(stops))
+	for i, s := range stops {
+		ordered[i] = s.stop
+	}
+	return text, ordered
+}
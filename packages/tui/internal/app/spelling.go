@@ -0,0 +1,53 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/sst/opencode/internal/spell"
+)
+
+// Misspelling is a misspelled word of the draft, as rune offsets into its
+// line
+type Misspelling struct {
+	Row   int
+	Start int
+	End   int
+	Word  string
+}
+
+// CorrectWordMsg replaces a misspelled word of the draft
+type CorrectWordMsg struct {
+	Misspelling Misspelling
+	Replacement string
+}
+
+// AddWordMsg adds a word to the words of the project
+type AddWordMsg struct {
+	Word string
+}
+
+// Dictionary returns the words the draft is checked against, loaded on
+// first use
+func (a *App) Dictionary() *spell.Dictionary {
+	if a.dictionary == nil {
+		a.dictionary = spell.Load(a.Info.Path.Config, a.Info.Path.Root, a.Info.Path.Cwd)
+	}
+	return a.dictionary
+}
+
+// AddWord makes a word known and saves it to CWD/.opencode/words.txt
+func (a *App) AddWord(word string) error {
+	a.Dictionary().Add(word)
+	dir := filepath.Join(a.Info.Path.Cwd, ".opencode")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, "words.txt"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.WriteString(word + "\n")
+	return err
+}
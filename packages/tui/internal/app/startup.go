@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/commands"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// launched is when the process started, near enough since the package is
+// initialized before main runs
+var launched = time.Now()
+
+var startupSteps sync.Map
+
+// StartupStep logs how long after launch a step of the startup finished, the
+// first time it is reached, to find what delays the first frame
+func StartupStep(step string) {
+	if _, done := startupSteps.LoadOrStore(step, true); done {
+		return
+	}
+	slog.Debug("Startup step", "step", step, "elapsed", time.Since(launched).Round(time.Millisecond).String())
+}
+
+// LoadingProviders reports whether the providers are still being listed,
+// the model is unknown until then
+func (a *App) LoadingProviders() bool {
+	return a.loadingProviders.Load()
+}
+
+// ConfigLoadedMsg is sent when the config fetched at launch arrived, the
+// app runs with the default keybinds until then
+type ConfigLoadedMsg struct {
+	Config *client.ConfigInfo
+	Err    error
+}
+
+// pendingConfig is the config fetched in the background at launch
+type pendingConfig struct {
+	ready  chan struct{}
+	config *client.ConfigInfo
+	err    error
+}
+
+func (p *pendingConfig) fetch(ctx context.Context, httpClient *client.ClientWithResponses) {
+	defer close(p.ready)
+	defer StartupStep("config")
+	response, err := httpClient.PostConfigGetWithResponse(ctx)
+	switch {
+	case err != nil:
+		p.err = err
+	case response.StatusCode() != 200 || response.JSON200 == nil:
+		p.err = fmt.Errorf("failed to get config: %d", response.StatusCode())
+	default:
+		p.config = response.JSON200
+	}
+}
+
+// wait blocks until the config arrived
+func (p *pendingConfig) wait() (*client.ConfigInfo, error) {
+	<-p.ready
+	return p.config, p.err
+}
+
+// LoadConfig waits for the config fetched at launch
+func (a *App) LoadConfig() tea.Cmd {
+	return func() tea.Msg {
+		config, err := a.config.wait()
+		return ConfigLoadedMsg{Config: config, Err: err}
+	}
+}
+
+// RetryConfig fetches the config again when it failed at launch, e.g.
+// because the token was rejected
+func (a *App) RetryConfig(ctx context.Context) tea.Cmd {
+	select {
+	case <-a.config.ready:
+		if a.config.err == nil {
+			return nil
+		}
+	default:
+		// still loading
+		return nil
+	}
+	a.config = &pendingConfig{ready: make(chan struct{})}
+	go a.config.fetch(ctx, a.Client)
+	return a.LoadConfig()
+}
+
+// ApplyConfig replaces the default config the app started with by the one
+// fetched at launch
+func (a *App) ApplyConfig(msg ConfigLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		slog.Error("Failed to load config", "error", msg.Err)
+		return toast.NewErrorToast(i18n.T("toast.config_failed", msg.Err.Error()))
+	}
+	configInfo := msg.Config
+	if configInfo.Keybinds == nil {
+		configInfo.Keybinds = a.Config.Keybinds
+	}
+	if configInfo.Theme != nil {
+		a.State.Theme = *configInfo.Theme
+		applyTheme(a.State.Theme)
+	}
+	if configInfo.Model != nil {
+		a.State.Provider, a.State.Model, _ = strings.Cut(*configInfo.Model, "/")
+	}
+	slog.Debug("Loaded config", "config", configInfo)
+	a.Config = configInfo
+	a.Commands = commands.LoadFromConfig(configInfo)
+	return nil
+}
+
+// AwaitConfig waits for the config fetched at launch and applies it, for
+// the modes without a program
+func (a *App) AwaitConfig() error {
+	config, err := a.config.wait()
+	a.ApplyConfig(ConfigLoadedMsg{Config: config, Err: err})
+	return err
+}
+
+// applyTheme switches to a theme of the state, following the terminal for
+// the system theme
+func applyTheme(name string) {
+	if name == "" {
+		return
+	}
+	if name == "system" && styles.Terminal != nil {
+		theme.UpdateSystemTheme(
+			styles.Terminal.Background,
+			styles.Terminal.BackgroundIsDark,
+		)
+	}
+	theme.SetTheme(name)
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// ToolRun is a finished tool call and how long it ran
+type ToolRun struct {
+	Tool     string
+	Title    string
+	Duration time.Duration
+}
+
+// SessionStats summarizes the current session from message metadata
+type SessionStats struct {
+	UserMessages      int
+	AssistantMessages int
+	ToolCalls         map[string]int
+	FilesTouched      []string
+	TokensIn          float32
+	TokensOut         float32
+	TokensCached      float32
+	Cost              float32
+	AgentTime         time.Duration
+	LongestTools      []ToolRun
+	// Models are the latency and throughput of the responses timed in this
+	// run of the app, by model
+	Models []ModelMetrics
+}
+
+const longestToolsLimit = 5
+
+// Stats computes the statistics of the current session
+func (a *App) Stats() SessionStats {
+	stats := SessionStats{ToolCalls: map[string]int{}}
+	runs := []ToolRun{}
+	for _, message := range a.Messages {
+		switch message.Role {
+		case client.User:
+			stats.UserMessages++
+			continue
+		case client.Assistant:
+			stats.AssistantMessages++
+		}
+
+		if assistant := message.Metadata.Assistant; assistant != nil {
+			stats.TokensIn += assistant.Tokens.Input
+			stats.TokensOut += assistant.Tokens.Output + assistant.Tokens.Reasoning
+			stats.TokensCached += assistant.Tokens.Cache.Read
+			stats.Cost += assistant.Cost
+		}
+		if completed := message.Metadata.Time.Completed; completed != nil {
+			stats.AgentTime += time.Duration(*completed-message.Metadata.Time.Created) * time.Millisecond
+		}
+
+		for _, p := range message.Parts {
+			part, err := p.ValueByDiscriminator()
+			if err != nil {
+				continue
+			}
+			invocation, ok := part.(client.MessagePartToolInvocation)
+			if !ok {
+				continue
+			}
+			toolCall, err := invocation.ToolInvocation.AsMessageToolInvocationToolCall()
+			if err != nil {
+				continue
+			}
+			stats.ToolCalls[toolCall.ToolName]++
+
+			if toolCall.Args != nil {
+				if args, ok := (*toolCall.Args).(map[string]any); ok {
+					if path, ok := args["filePath"].(string); ok && path != "" {
+						path = strings.TrimPrefix(path, a.Info.Path.Cwd+string(filepath.Separator))
+						if !slices.Contains(stats.FilesTouched, path) {
+							stats.FilesTouched = append(stats.FilesTouched, path)
+						}
+					}
+				}
+			}
+
+			if metadata, ok := message.Metadata.Tool[toolCall.ToolCallId]; ok && metadata.Time.End > metadata.Time.Start {
+				runs = append(runs, ToolRun{
+					Tool:     toolCall.ToolName,
+					Title:    metadata.Title,
+					Duration: time.Duration(metadata.Time.End-metadata.Time.Start) * time.Millisecond,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(runs, func(a, b ToolRun) int {
+		return int(b.Duration - a.Duration)
+	})
+	stats.LongestTools = runs[:min(len(runs), longestToolsLimit)]
+	stats.Models = a.modelMetrics(a.Messages)
+	slices.Sort(stats.FilesTouched)
+	return stats
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// SteerMsg carries a steering note typed while the agent is working
+type SteerMsg struct {
+	Text string
+}
+
+// Steering are notes queued while the agent is busy, they are sent as the
+// next user message as soon as the in-flight turn completes
+type Steering struct {
+	SessionID string
+	Notes     []string
+}
+
+// Steer queues a steering note for the current session
+func (a *App) Steer(text string) {
+	if a.Steering == nil || a.Steering.SessionID != a.Session.Id {
+		a.Steering = &Steering{SessionID: a.Session.Id}
+	}
+	a.Steering.Notes = append(a.Steering.Notes, text)
+}
+
+// SteeringNotes returns how many notes are waiting for the current session
+func (a *App) SteeringNotes() int {
+	if a.Steering == nil || a.Steering.SessionID != a.Session.Id {
+		return 0
+	}
+	return len(a.Steering.Notes)
+}
+
+// FlushSteering sends the queued notes once the agent is no longer busy
+func (a *App) FlushSteering(ctx context.Context) tea.Cmd {
+	if a.SteeringNotes() == 0 || a.IsBusy() || a.RateLimit != nil {
+		return nil
+	}
+	text := strings.Join(a.Steering.Notes, "\n\n")
+	a.Steering = nil
+	return a.SendChatMessage(ctx, text, nil)
+}
@@ -0,0 +1,40 @@
+package app
+
+// SystemPromptMsg replaces the system prompt of the current session, an
+// empty Prompt restores the default one
+type SystemPromptMsg struct {
+	Prompt string
+}
+
+// SystemPrompt returns the custom system prompt of the current session, or
+// an empty string when the server's default is used
+func (a *App) SystemPrompt() string {
+	return a.system
+}
+
+// SetSystemPrompt changes the system prompt and remembers it for the
+// current session
+func (a *App) SetSystemPrompt(prompt string) {
+	a.system = prompt
+	if a.Session.Id != "" {
+		a.rememberSystemPrompt(a.Session.Id)
+	}
+}
+
+// LoadSystemPrompt restores the custom system prompt of the current session
+func (a *App) LoadSystemPrompt() {
+	a.system = a.State.SystemPrompts[a.Session.Id]
+}
+
+// rememberSystemPrompt records the system prompt for a session and returns it
+func (a *App) rememberSystemPrompt(sessionID string) string {
+	if a.State.SystemPrompts[sessionID] != a.system {
+		if a.system == "" {
+			delete(a.State.SystemPrompts, sessionID)
+		} else {
+			a.State.SystemPrompts[sessionID] = a.system
+		}
+		a.SaveState()
+	}
+	return a.system
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// normalizeTag lowercases a tag and drops a leading #
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// Tags returns the tags of a session in the order they were added
+func (a *App) Tags(sessionID string) []string {
+	return a.State.SessionTags[sessionID]
+}
+
+// AllTags returns every tag in use, sorted
+func (a *App) AllTags() []string {
+	tags := []string{}
+	for _, sessionTags := range a.State.SessionTags {
+		for _, tag := range sessionTags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// HasTag reports whether a session is tagged with tag
+func (a *App) HasTag(sessionID, tag string) bool {
+	return slices.Contains(a.State.SessionTags[sessionID], normalizeTag(tag))
+}
+
+// AddTag tags a session, tags are stored locally
+func (a *App) AddTag(sessionID, tag string) (string, error) {
+	tag = normalizeTag(tag)
+	if tag == "" || strings.ContainsAny(tag, " \t") {
+		return "", errors.New("tags are single words")
+	}
+	if !slices.Contains(a.State.SessionTags[sessionID], tag) {
+		a.State.SessionTags[sessionID] = append(a.State.SessionTags[sessionID], tag)
+		a.SaveState()
+	}
+	return tag, nil
+}
+
+// RemoveTag removes a tag from a session, returning whether it had it
+func (a *App) RemoveTag(sessionID, tag string) bool {
+	tag = normalizeTag(tag)
+	tags := a.State.SessionTags[sessionID]
+	if !slices.Contains(tags, tag) {
+		return false
+	}
+	tags = slices.DeleteFunc(slices.Clone(tags), func(t string) bool { return t == tag })
+	if len(tags) == 0 {
+		delete(a.State.SessionTags, sessionID)
+	} else {
+		a.State.SessionTags[sessionID] = tags
+	}
+	a.SaveState()
+	return true
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/bus"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// SessionStatus is the state of the last response in a session
+type SessionStatus string
+
+const (
+	SessionRunning SessionStatus = "running"
+	SessionDone    SessionStatus = "done"
+	SessionFailed  SessionStatus = "failed"
+)
+
+func messageStatus(message client.MessageInfo) SessionStatus {
+	switch {
+	case message.Metadata.Error != nil:
+		return SessionFailed
+	case message.Metadata.Time.Completed == nil:
+		return SessionRunning
+	}
+	return SessionDone
+}
+
+// TrackSessionStatus records the status of the session of an updated message
+func (a *App) TrackSessionStatus(message client.MessageInfo) {
+	a.statuses[message.Metadata.SessionID] = messageStatus(message)
+}
+
+// SessionStatus returns the status of a session, the messages of a session
+// are loaded the first time and its status tracked from its events after
+func (a *App) SessionStatus(ctx context.Context, sessionID string) SessionStatus {
+	if status, ok := a.statuses[sessionID]; ok {
+		return status
+	}
+	messages, err := a.ListMessages(ctx, sessionID)
+	if err != nil {
+		return SessionRunning
+	}
+	status := SessionRunning
+	if len(messages) > 0 {
+		status = messageStatus(messages[len(messages)-1])
+	}
+	a.statuses[sessionID] = status
+	return status
+}
+
+// trackStatuses keeps the statuses of the sessions up to date from their
+// message events
+func (a *App) trackStatuses() {
+	bus.Subscribe(a.Bus, func(msg client.EventMessageUpdated) tea.Cmd {
+		a.TrackSessionStatus(msg.Properties.Info)
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+const (
+	// defaultWindowTitle is the title template when the state sets none
+	defaultWindowTitle = "{busy}{project}: {session}"
+	// noWindowTitle as the template leaves the terminal title alone
+	noWindowTitle   = "-"
+	busyTitleMarker = "● "
+)
+
+// WindowTitle renders the title template of the state with the {project},
+// {session}, {model} and {cwd} placeholders, {busy} is a marker shown while
+// the assistant works
+func (a *App) WindowTitle() string {
+	template := a.State.WindowTitle
+	if template == "" {
+		template = defaultWindowTitle
+	}
+	session := a.Session.Title
+	if a.Session.Id == "" || session == "" {
+		session = "new session"
+	}
+	model := ""
+	if a.Model != nil {
+		model = a.Model.Name
+	}
+	busy := ""
+	if a.IsBusy() {
+		busy = busyTitleMarker
+	}
+	return strings.NewReplacer(
+		"{project}", filepath.Base(a.Info.Path.Root),
+		"{session}", session,
+		"{model}", model,
+		"{cwd}", a.Info.Path.Cwd,
+		"{busy}", busy,
+	).Replace(template)
+}
+
+// UpdateWindowTitle sets the terminal title when it changed, through the
+// OSC sequence a multiplexer shows as the pane or tab title
+func (a *App) UpdateWindowTitle() tea.Cmd {
+	if a.State.WindowTitle == noWindowTitle {
+		return nil
+	}
+	title := a.WindowTitle()
+	if title == a.title {
+		return nil
+	}
+	a.title = title
+	return tea.SetWindowTitle(title)
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"bytes"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// defaultTokenWarning is the draft size in tokens from which the editor
+	// warns, when the state does not set one
+	defaultTokenWarning = 8000
+	// imagePixelsPerToken and maxImageTokens approximate how providers bill
+	// images, which they scale down to about 1600 tokens
+	imagePixelsPerToken = 750
+	maxImageTokens      = 1600
+)
+
+// charsPerToken approximates the tokenizer of a model family by its average
+// number of characters per token over prose and code
+func charsPerToken(modelID string) float64 {
+	id := strings.ToLower(modelID)
+	switch {
+	case strings.Contains(id, "claude"):
+		return 3.5
+	case strings.HasPrefix(id, "gpt-4o"), strings.HasPrefix(id, "gpt-4.1"), strings.HasPrefix(id, "o1"),
+		strings.HasPrefix(id, "o3"), strings.HasPrefix(id, "o4"):
+		return 4.2
+	case strings.Contains(id, "gemini"):
+		return 4
+	case strings.Contains(id, "llama"), strings.Contains(id, "mistral"), strings.Contains(id, "qwen"),
+		strings.Contains(id, "deepseek"):
+		return 3.6
+	}
+	return 4
+}
+
+// TextTokens estimates the tokens of text for the selected model
+func (a *App) TextTokens(text string) int {
+	modelID := ""
+	if a.Model != nil {
+		modelID = a.Model.Id
+	}
+	return int(math.Ceil(float64(utf8.RuneCountInString(text)) / charsPerToken(modelID)))
+}
+
+// PromptTokens estimates the tokens of a prompt with its attachments and the
+// command outputs attached to it
+func (a *App) PromptTokens(text string, attachments []Attachment) int {
+	tokens := a.TextTokens(text)
+	for _, output := range a.Outputs {
+		tokens += a.TextTokens(output.Command) + a.TextTokens(output.Output)
+	}
+	for _, attachment := range attachments {
+		tokens += a.attachmentTokens(attachment)
+	}
+	return tokens
+}
+
+func (a *App) attachmentTokens(attachment Attachment) int {
+	if !strings.HasPrefix(attachment.MimeType, "image/") {
+		return a.TextTokens(string(attachment.Content))
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(attachment.Content))
+	if err != nil {
+		return maxImageTokens
+	}
+	return min(config.Width*config.Height/imagePixelsPerToken, maxImageTokens)
+}
+
+// TokenWarning returns the draft size in tokens from which the editor warns,
+// zero when the warning is disabled
+func (a *App) TokenWarning() int {
+	switch {
+	case a.State.TokenWarning < 0:
+		return 0
+	case a.State.TokenWarning > 0:
+		return a.State.TokenWarning
+	}
+	return defaultTokenWarning
+}
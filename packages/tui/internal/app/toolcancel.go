@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// PendingTool is a tool call of the assistant still waiting for its result
+type PendingTool struct {
+	ID   string
+	Name string
+	// Summary is the main argument of the call, such as its command or file
+	Summary string
+}
+
+// CancelToolMsg aborts a single tool call of the current session
+type CancelToolMsg struct {
+	Tool PendingTool
+}
+
+// PendingTools lists the tool calls of a message without a result yet
+func PendingTools(message client.MessageInfo) []PendingTool {
+	tools := []PendingTool{}
+	if message.Metadata.Time.Completed != nil {
+		return tools
+	}
+	for _, p := range message.Parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		invocation, ok := part.(client.MessagePartToolInvocation)
+		if !ok {
+			continue
+		}
+		value, err := invocation.ToolInvocation.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		var args *interface{}
+		tool := PendingTool{}
+		switch call := value.(type) {
+		case client.MessageToolInvocationToolCall:
+			tool.ID, tool.Name, args = call.ToolCallId, call.ToolName, call.Args
+		case client.MessageToolInvocationToolPartialCall:
+			tool.ID, tool.Name, args = call.ToolCallId, call.ToolName, call.Args
+		default:
+			continue
+		}
+		tool.Summary = toolSummary(args)
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// toolSummary returns the argument telling tool calls apart
+func toolSummary(args *interface{}) string {
+	if args == nil {
+		return ""
+	}
+	values, ok := (*args).(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"command", "filePath", "pattern", "url", "path", "description"} {
+		if value, ok := values[key].(string); ok && value != "" {
+			return strings.Join(strings.Fields(value), " ")
+		}
+	}
+	return ""
+}
+
+// CancelTool aborts a single tool call, the server continues the turn with
+// an error as the result of the call
+func (a *App) CancelTool(ctx context.Context, sessionID string, toolCallID string) error {
+	response, err := a.Client.PostSessionAbortWithResponse(ctx, client.PostSessionAbortJSONRequestBody{
+		SessionID:  sessionID,
+		ToolCallID: &toolCallID,
+	})
+	if err != nil {
+		slog.Error("Failed to cancel tool call", "error", err)
+		return err
+	}
+	if response.StatusCode() != 200 {
+		return fmt.Errorf("failed to cancel tool call: %d", response.StatusCode())
+	}
+	if response.JSON200 != nil && !*response.JSON200 {
+		return errors.New("the tool call already finished")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// ToolOutput returns the full output of a tool call, which the chat only
+// shows in pages when it is long
+func (a *App) ToolOutput(messageID, toolCallID string) (string, string, bool) {
+	for _, message := range a.Messages {
+		if message.Id != messageID {
+			continue
+		}
+		for _, p := range message.Parts {
+			part, err := p.ValueByDiscriminator()
+			if err != nil {
+				continue
+			}
+			invocation, ok := part.(client.MessagePartToolInvocation)
+			if !ok {
+				continue
+			}
+			result, err := invocation.ToolInvocation.AsMessageToolInvocationToolResult()
+			if err != nil || result.ToolCallId != toolCallID {
+				continue
+			}
+			metadata := message.Metadata.Tool[toolCallID]
+			switch result.ToolName {
+			case "bash":
+				if stdout, ok := metadata.Get("stdout"); ok {
+					if stdout, ok := stdout.(string); ok {
+						return result.ToolName, stdout, true
+					}
+				}
+			case "write":
+				if result.Args != nil {
+					if args, ok := (*result.Args).(map[string]any); ok {
+						if content, ok := args["content"].(string); ok {
+							return result.ToolName, content, true
+						}
+					}
+				}
+			}
+			return result.ToolName, result.Result, true
+		}
+	}
+	return "", "", false
+}
+
+// ExportToolOutput writes the full output of a tool call to a file in the
+// working directory and returns its path
+func (a *App) ExportToolOutput(messageID, toolCallID string) (string, error) {
+	tool, output, ok := a.ToolOutput(messageID, toolCallID)
+	if !ok {
+		return "", fmt.Errorf("tool call not found: %s", toolCallID)
+	}
+	name := fmt.Sprintf("opencode-%s-output-%s.txt", tool, time.Now().Format("20060102-150405"))
+	path := filepath.Join(a.Info.Path.Cwd, name)
+	if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// FailedTurn is a prompt whose turn failed. Retrying it reverts the turn on
+// the server, the messages after After, and sends the same parts again so
+// the history does not hold the prompt twice.
+type FailedTurn struct {
+	SessionID string
+	// After is the message before the prompt, empty when the prompt was the
+	// first of the session
+	After string
+	Parts []client.MessagePart
+}
+
+// TurnRevertedMsg sends a failed turn again once it was reverted, to the
+// given model or the current one when nil
+type TurnRevertedMsg struct {
+	Turn     FailedTurn
+	Provider *client.ProviderInfo
+	Model    *client.ModelInfo
+}
+
+// failedTurn returns the turn an assistant message answered, false when its
+// prompt is not in the history
+func (a *App) failedTurn(message client.MessageInfo) (FailedTurn, bool) {
+	index := -1
+	for i, m := range a.Messages {
+		if m.Id == message.Id {
+			index = i
+		}
+	}
+	for i := index - 1; i >= 0; i-- {
+		if a.Messages[i].Role != client.User {
+			continue
+		}
+		turn := FailedTurn{SessionID: message.Metadata.SessionID, Parts: a.Messages[i].Parts}
+		if i > 0 {
+			turn.After = a.Messages[i-1].Id
+		}
+		return turn, len(turn.Parts) > 0
+	}
+	return FailedTurn{}, false
+}
+
+// lastServerMessage returns the ID of the latest message the server has,
+// optimistic ones are left out
+func (a *App) lastServerMessage() string {
+	for i := len(a.Messages) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(a.Messages[i].Id, "optimistic-") {
+			return a.Messages[i].Id
+		}
+	}
+	return ""
+}
+
+// RetryTurn reverts a failed turn on the server, then sends it again to the
+// given model, the current one when nil
+func (a *App) RetryTurn(ctx context.Context, turn FailedTurn, provider *client.ProviderInfo, model *client.ModelInfo) tea.Cmd {
+	return func() tea.Msg {
+		body := client.PostSessionRevertJSONRequestBody{SessionID: turn.SessionID}
+		if turn.After != "" {
+			body.MessageID = &turn.After
+		}
+		response, err := a.Client.PostSessionRevertWithResponse(ctx, body)
+		if err != nil {
+			return toast.NewErrorToast(i18n.T("toast.retry_failed", err.Error()))()
+		}
+		if response.StatusCode() != 200 {
+			return toast.NewErrorToast(i18n.T("toast.retry_failed", strconv.Itoa(response.StatusCode())))()
+		}
+		return TurnRevertedMsg{Turn: turn, Provider: provider, Model: model}
+	}
+}
+
+// SendTurn drops the reverted messages of a failed turn from the history
+// and sends its prompt again
+func (a *App) SendTurn(ctx context.Context, msg TurnRevertedMsg) tea.Cmd {
+	if msg.Turn.SessionID != a.Session.Id {
+		return nil
+	}
+	if msg.Turn.After == "" {
+		a.SetMessages([]client.MessageInfo{})
+	}
+	for i, m := range a.Messages {
+		if m.Id == msg.Turn.After {
+			a.SetMessages(a.Messages[:i+1])
+			break
+		}
+	}
+	provider, model := a.Provider, a.Model
+	if msg.Provider != nil && msg.Model != nil {
+		provider, model = msg.Provider, msg.Model
+	}
+	return a.sendParts(ctx, msg.Turn.Parts, provider, model)
+}
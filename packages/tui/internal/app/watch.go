@@ -0,0 +1,189 @@
+package app
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	// watchDebounce groups the events of a save or a checkout into one batch
+	watchDebounce = 500 * time.Millisecond
+	// maxWatchedDirs keeps large trees from exhausting the watch limit
+	maxWatchedDirs = 4096
+)
+
+// unwatchedDirs are the directories whose changes are never reported, next
+// to the hidden ones
+var unwatchedDirs = []string{"node_modules", "vendor", "dist", "build", "target", "__pycache__"}
+
+// FilesChangedMsg is sent when files of the project changed on disk
+type FilesChangedMsg struct {
+	Paths []string
+}
+
+// fileWatcher watches the directories of the project and sends the changed
+// files in batches
+type fileWatcher struct {
+	watcher *fsnotify.Watcher
+	dirs    int
+	changes chan []string
+}
+
+// WatchFiles waits for the next batch of changed files, starting to watch
+// the project on the first call
+func (a *App) WatchFiles() tea.Cmd {
+	if a.watcher == nil {
+		watcher, err := watchProject(a.Info.Path.Root)
+		if err != nil {
+			slog.Warn("Failed to watch the project", "error", err)
+			return nil
+		}
+		a.watcher = watcher
+	}
+	changes := a.watcher.changes
+	return func() tea.Msg {
+		paths, ok := <-changes
+		if !ok {
+			return nil
+		}
+		return FilesChangedMsg{Paths: paths}
+	}
+}
+
+func watchProject(root string) (*fileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &fileWatcher{watcher: watcher, changes: make(chan []string)}
+	w.addTree(root)
+	go w.run()
+	return w, nil
+}
+
+// addTree watches dir and the directories below it
+func (w *fileWatcher) addTree(dir string) {
+	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+		if path != dir && unwatched(entry.Name()) {
+			return filepath.SkipDir
+		}
+		if w.dirs >= maxWatchedDirs {
+			return filepath.SkipAll
+		}
+		if err := w.watcher.Add(path); err != nil {
+			slog.Debug("Failed to watch directory", "path", path, "error", err)
+			return nil
+		}
+		w.dirs++
+		return nil
+	})
+}
+
+// unwatched tells the directories and files to ignore, such as the hidden
+// ones and editor backups
+func unwatched(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") || slices.Contains(unwatchedDirs, name)
+}
+
+func (w *fileWatcher) run() {
+	pending := map[string]bool{}
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				close(w.changes)
+				return
+			}
+			if event.Op == fsnotify.Chmod || unwatched(filepath.Base(event.Name)) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					w.addTree(event.Name)
+					continue
+				}
+			}
+			pending[event.Name] = true
+			timer.Reset(watchDebounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				close(w.changes)
+				return
+			}
+			slog.Debug("File watcher error", "error", err)
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			pending = map[string]bool{}
+			w.changes <- paths
+		}
+	}
+}
+
+// NoteFileChanges records the changed files made outside the agent: the
+// ones changed while no response runs, except the files the last response
+// modified itself
+func (a *App) NoteFileChanges(paths []string) {
+	if a.IsBusy() {
+		return
+	}
+	own := []string{}
+	for i := len(a.Messages) - 1; i >= 0; i-- {
+		if a.Messages[i].Role == client.Assistant {
+			own = a.modifiedFiles(a.Messages[i])
+			break
+		}
+	}
+	for _, path := range paths {
+		if !slices.Contains(own, path) {
+			a.external[path] = true
+		}
+	}
+}
+
+// ExternalChanges lists the files changed outside the agent since the last
+// message was sent, relative to the project root
+func (a *App) ExternalChanges() []string {
+	paths := []string{}
+	for path := range a.external {
+		if relative, err := filepath.Rel(a.Info.Path.Root, path); err == nil {
+			path = relative
+		}
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// externalChangesPart tells the model which files changed outside the agent
+// since the last turn, when enabled, and forgets them
+func (a *App) externalChangesPart() []client.MessagePart {
+	paths := a.ExternalChanges()
+	clear(a.external)
+	if len(paths) == 0 || !a.State.ReportFileChanges {
+		return nil
+	}
+	part := client.MessagePart{}
+	part.FromMessagePartText(client.MessagePartText{
+		Type: "text",
+		Text: fmt.Sprintf("<file-changes>\nThese files were changed outside of the agent since the last turn, read them again before relying on their content: %s\n</file-changes>", strings.Join(paths, ", ")),
+	})
+	return []client.MessagePart{part}
+}
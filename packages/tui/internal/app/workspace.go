@@ -0,0 +1,163 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sst/opencode/internal/config"
+)
+
+// SwitchRootMsg switches the active root of the session
+type SwitchRootMsg struct {
+	Root string
+}
+
+func (a *App) workspace() config.Workspace {
+	return a.State.Workspaces[a.Session.Id]
+}
+
+func (a *App) setWorkspace(workspace config.Workspace) {
+	if len(workspace.Roots) == 0 {
+		delete(a.State.Workspaces, a.Session.Id)
+	} else {
+		a.State.Workspaces[a.Session.Id] = workspace
+	}
+	a.SaveState()
+}
+
+// Roots returns the working directory followed by the roots attached to the
+// current session
+func (a *App) Roots() []string {
+	return append([]string{a.Info.Path.Cwd}, a.workspace().Roots...)
+}
+
+// ActiveRoot returns the root file completion, git status and the agent
+// use, the working directory unless another root was switched to
+func (a *App) ActiveRoot() string {
+	workspace := a.workspace()
+	if slices.Contains(workspace.Roots, workspace.Active) {
+		return workspace.Active
+	}
+	return a.Info.Path.Cwd
+}
+
+// AttachRoot attaches a directory to the current session, relative to the
+// working directory, and returns its absolute path
+func (a *App) AttachRoot(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", fmt.Errorf("no directory to attach")
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.Info.Path.Cwd, path)
+	}
+	path = filepath.Clean(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", path)
+	}
+	workspace := a.workspace()
+	if path != a.Info.Path.Cwd && !slices.Contains(workspace.Roots, path) {
+		workspace.Roots = append(slices.Clone(workspace.Roots), path)
+		a.setWorkspace(workspace)
+	}
+	return path, nil
+}
+
+// DetachRoot removes a root from the current session, switching back to the
+// working directory when it was the active one
+func (a *App) DetachRoot(path string) {
+	workspace := a.workspace()
+	index := slices.Index(workspace.Roots, path)
+	if index < 0 {
+		return
+	}
+	workspace.Roots = slices.Delete(slices.Clone(workspace.Roots), index, index+1)
+	if workspace.Active == path {
+		workspace.Active = ""
+	}
+	a.setWorkspace(workspace)
+	a.LoadWorkspace()
+}
+
+// SetActiveRoot switches to one of the roots of the current session, it
+// returns false for a directory that is not attached
+func (a *App) SetActiveRoot(path string) bool {
+	if !slices.Contains(a.Roots(), path) {
+		return false
+	}
+	workspace := a.workspace()
+	workspace.Active = path
+	if path == a.Info.Path.Cwd {
+		workspace.Active = ""
+	}
+	a.setWorkspace(workspace)
+	a.LoadWorkspace()
+	return true
+}
+
+// LoadWorkspace seeds the recent files from the git status of the active
+// root of the current session
+func (a *App) LoadWorkspace() {
+	if root := a.ActiveRoot(); a.RecentFiles.cwd != root {
+		a.RecentFiles = newRecentFiles(root)
+	}
+}
+
+// adoptWorkspace moves the roots attached before the first prompt to the
+// session the prompt created
+func (a *App) adoptWorkspace(sessionID string) {
+	workspace, ok := a.State.Workspaces[""]
+	if !ok {
+		return
+	}
+	delete(a.State.Workspaces, "")
+	a.State.Workspaces[sessionID] = workspace
+	a.SaveState()
+}
+
+// RootParam is the active root sent with chat requests and file searches,
+// nil for the working directory the server already uses
+func (a *App) RootParam() *string {
+	root := a.ActiveRoot()
+	if root == a.Info.Path.Cwd {
+		return nil
+	}
+	return &root
+}
+
+// RootRelative turns a path relative to the active root into one relative
+// to the working directory, which mentions are resolved against
+func (a *App) RootRelative(path string) string {
+	root := a.ActiveRoot()
+	if root == a.Info.Path.Cwd {
+		return path
+	}
+	relative, err := filepath.Rel(a.Info.Path.Cwd, filepath.Join(root, path))
+	if err != nil {
+		return filepath.Join(root, path)
+	}
+	return relative
+}
+
+// RootLabel names a root by its path relative to the working directory
+func (a *App) RootLabel(root string) string {
+	if root == a.Info.Path.Cwd {
+		return "."
+	}
+	if relative, err := filepath.Rel(a.Info.Path.Cwd, root); err == nil && len(relative) < len(root) {
+		return relative
+	}
+	return root
+}
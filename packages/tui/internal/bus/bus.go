@@ -0,0 +1,67 @@
+// Package bus delivers app events to the components interested in them, so
+// a component can react to an event without the root model forwarding it.
+//
+// Events are the messages of the app package and the server events, such as
+// app.SessionSelectedMsg or client.EventSessionUpdated. The root model publishes every message once it
+// handled it, so subscribers see the updated app state. Handlers run on the
+// UI thread, they may change the state of their component and the commands
+// they return are run like the ones returned by Update.
+package bus
+
+import (
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+type subscription struct {
+	id      int
+	handler func(tea.Msg) tea.Cmd
+}
+
+// Bus keeps the handlers subscribed to each event type
+type Bus struct {
+	subscriptions map[reflect.Type][]subscription
+	next          int
+}
+
+func New() *Bus {
+	return &Bus{subscriptions: map[reflect.Type][]subscription{}}
+}
+
+// Subscribe calls handler with every published event of type T and returns
+// a function removing the subscription
+func Subscribe[T any](b *Bus, handler func(T) tea.Cmd) func() {
+	key := reflect.TypeFor[T]()
+	b.next++
+	id := b.next
+	b.subscriptions[key] = append(b.subscriptions[key], subscription{
+		id:      id,
+		handler: func(msg tea.Msg) tea.Cmd { return handler(msg.(T)) },
+	})
+	return func() {
+		subscriptions := b.subscriptions[key]
+		for i, s := range subscriptions {
+			if s.id == id {
+				b.subscriptions[key] = append(subscriptions[:i:i], subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers msg to the handlers subscribed to its type
+func (b *Bus) Publish(msg tea.Msg) tea.Cmd {
+	if msg == nil {
+		return nil
+	}
+	subscriptions := b.subscriptions[reflect.TypeOf(msg)]
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	cmds := make([]tea.Cmd, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		cmds = append(cmds, s.handler(msg))
+	}
+	return tea.Batch(cmds...)
+}
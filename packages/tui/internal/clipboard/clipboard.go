@@ -0,0 +1,119 @@
+// Package clipboard copies text to the system clipboard, trying OSC 52 first
+// and falling back to the platform clipboard utilities.
+package clipboard
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/util"
+)
+
+// MethodOSC52 is reported when the terminal is asked to set the clipboard
+const MethodOSC52 = "OSC 52"
+
+type utility struct {
+	name string
+	args []string
+}
+
+// Copy copies text and reports through a toast which mechanism was used,
+// label names what was copied, eg "Share URL"
+func Copy(text, label string) tea.Cmd {
+	if supportsOSC52() {
+		return tea.Batch(
+			tea.SetClipboard(text),
+			toast.NewSuccessToast(i18n.T("toast.copied", label), toast.WithTitle(MethodOSC52)),
+		)
+	}
+	return func() tea.Msg {
+		method, err := copyWithUtility(text)
+		if err == nil {
+			return toast.NewSuccessToast(i18n.T("toast.copied", label), toast.WithTitle(method))()
+		}
+		slog.Warn("Failed to copy with clipboard utilities", "error", err)
+		// the terminal may still support OSC 52 even if we can't tell
+		return tea.BatchMsg{
+			tea.SetClipboard(text),
+			toast.NewWarningToast(
+				i18n.T("toast.copy_fallback", strings.ToLower(label)),
+				toast.WithTitle(MethodOSC52),
+			),
+		}
+	}
+}
+
+// supportsOSC52 reports whether the terminal is known to handle OSC 52. Over
+// SSH it is the only way to reach the local clipboard.
+func supportsOSC52() bool {
+	if os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" {
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "ghostty", "rio":
+		return true
+	}
+	term := os.Getenv("TERM")
+	for _, name := range []string{"kitty", "alacritty", "foot", "ghostty", "wezterm"} {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// utilities returns the clipboard commands to try on this platform, in order
+func utilities() []utility {
+	switch {
+	case runtime.GOOS == "darwin":
+		return []utility{{name: "pbcopy"}}
+	case runtime.GOOS == "windows":
+		return []utility{{name: "clip.exe"}}
+	case util.IsWsl():
+		return []utility{{name: "clip.exe"}}
+	}
+	candidates := []utility{}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, utility{name: "wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		candidates = append(candidates,
+			utility{name: "xclip", args: []string{"-selection", "clipboard"}},
+			utility{name: "xsel", args: []string{"--clipboard", "--input"}},
+		)
+	}
+	return candidates
+}
+
+func copyWithUtility(text string) (string, error) {
+	candidates := utilities()
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no clipboard utility for this platform")
+	}
+	var errs []string
+	for _, candidate := range candidates {
+		path, err := exec.LookPath(candidate.name)
+		if err != nil {
+			errs = append(errs, candidate.name+": not installed")
+			continue
+		}
+		cmd := exec.Command(path, candidate.args...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			errs = append(errs, candidate.name+": "+err.Error())
+			continue
+		}
+		return candidate.name, nil
+	}
+	return "", fmt.Errorf("%s", strings.Join(errs, ", "))
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"encoding/json"
+	"log/slog"
 	"slices"
 	"strings"
 
@@ -13,6 +14,12 @@ type ExecuteCommandMsg Command
 type ExecuteCommandsMsg []Command
 type CommandExecutedMsg Command
 
+// ExecuteCommandWithArgsMsg runs a command with the text typed after its trigger
+type ExecuteCommandWithArgsMsg struct {
+	Command Command
+	Args    string
+}
+
 type Keybinding struct {
 	RequiresLeader bool
 	Key            string
@@ -24,14 +31,25 @@ func (k Keybinding) Matches(msg tea.KeyPressMsg, leader bool) bool {
 	return key == msg.String() && (k.RequiresLeader == leader)
 }
 
+// Steps splits a key sequence such as "g s" into the keys pressed in turn
+func (k Keybinding) Steps() []string {
+	return strings.Fields(k.Key)
+}
+
 type CommandName string
 type Command struct {
 	Name        CommandName
 	Description string
 	Keybindings []Keybinding
 	Trigger     string
+	// Expansion is the command line an alias from the config stands for,
+	// such as "compact" or "models sonnet"
+	Expansion string
 }
 
+// aliasPrefix starts the names of the commands made from config aliases
+const aliasPrefix = "alias_"
+
 func (c Command) Keys() []string {
 	var keys []string
 	for _, k := range c.Keybindings {
@@ -42,17 +60,56 @@ func (c Command) Keys() []string {
 
 type CommandRegistry map[CommandName]Command
 
+// FindTrigger matches text such as "/checkpoint before-refactor" against the
+// command triggers, returning the command and the rest of the text
+func (r CommandRegistry) FindTrigger(text string) (Command, string, bool) {
+	text, ok := strings.CutPrefix(strings.TrimSpace(text), "/")
+	if !ok {
+		return Command{}, "", false
+	}
+	trigger, args, _ := strings.Cut(text, " ")
+	for _, command := range r {
+		if command.Trigger != "" && command.Trigger == trigger {
+			return r.Resolve(command, strings.TrimSpace(args))
+		}
+	}
+	return Command{}, "", false
+}
+
+// Resolve returns the command an alias expands to with the arguments typed
+// after the alias appended to the ones of the expansion, other commands
+// are returned as they are
+func (r CommandRegistry) Resolve(command Command, args string) (Command, string, bool) {
+	if command.Expansion == "" {
+		return command, args, true
+	}
+	trigger, expanded, _ := strings.Cut(command.Expansion, " ")
+	for _, target := range r {
+		if target.Expansion == "" && target.Trigger != "" && target.Trigger == trigger {
+			return target, strings.TrimSpace(expanded + " " + args), true
+		}
+	}
+	return Command{}, "", false
+}
+
 func (r CommandRegistry) Sorted() []Command {
 	var commands []Command
 	for _, command := range r {
 		commands = append(commands, command)
 	}
-	slices.SortFunc(commands, func(a, b Command) int {
-		if a.Name == AppExitCommand {
+	// aliases follow the built-in commands, exit comes last
+	rank := func(command Command) int {
+		switch {
+		case command.Name == AppExitCommand:
+			return 2
+		case command.Expansion != "":
 			return 1
 		}
-		if b.Name == AppExitCommand {
-			return -1
+		return 0
+	}
+	slices.SortFunc(commands, func(a, b Command) int {
+		if rankA, rankB := rank(a), rank(b); rankA != rankB {
+			return rankA - rankB
 		}
 		return strings.Compare(string(a.Name), string(b.Name))
 	})
@@ -69,22 +126,123 @@ func (r CommandRegistry) Matches(msg tea.KeyPressMsg, leader bool) []Command {
 	return matched
 }
 
+// Continuation is a key that can be pressed next in a leader sequence, with
+// the commands reachable through it
+type Continuation struct {
+	Key      string
+	Commands []Command
+}
+
+// LeaderSequence resolves the keys pressed after the leader, returning the
+// commands bound to exactly that sequence and the keys that would continue it
+func (r CommandRegistry) LeaderSequence(keys []string) ([]Command, []Continuation) {
+	var matched []Command
+	next := map[string][]Command{}
+	for _, command := range r.Sorted() {
+		for _, binding := range command.Keybindings {
+			steps := binding.Steps()
+			if !binding.RequiresLeader || len(steps) < len(keys) || !slices.Equal(steps[:len(keys)], keys) {
+				continue
+			}
+			if len(steps) == len(keys) {
+				matched = append(matched, command)
+			} else {
+				next[steps[len(keys)]] = append(next[steps[len(keys)]], command)
+			}
+		}
+	}
+
+	continuations := []Continuation{}
+	for key, commands := range next {
+		continuations = append(continuations, Continuation{Key: key, Commands: commands})
+	}
+	slices.SortFunc(continuations, func(a, b Continuation) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return matched, continuations
+}
+
 const (
 	AppHelpCommand              CommandName = "app_help"
 	EditorOpenCommand           CommandName = "editor_open"
 	SessionNewCommand           CommandName = "session_new"
 	SessionListCommand          CommandName = "session_list"
+	SessionNextCommand          CommandName = "session_next"
+	SessionPreviousCommand      CommandName = "session_previous"
 	SessionShareCommand         CommandName = "session_share"
 	SessionInterruptCommand     CommandName = "session_interrupt"
+	SessionStopCommand          CommandName = "session_stop"
+	SessionCancelToolCommand    CommandName = "session_cancel_tool"
 	SessionCompactCommand       CommandName = "session_compact"
+	SessionContextCommand       CommandName = "session_context"
+	ContextPinCommand           CommandName = "context_pin"
+	WorkspaceRootsCommand       CommandName = "workspace_roots"
+	SessionCompareCommand       CommandName = "session_compare"
+	SessionImportCommand        CommandName = "session_import"
+	SessionMergeCommand         CommandName = "session_merge"
+	SessionCleanupCommand       CommandName = "session_cleanup"
+	SessionTagCommand           CommandName = "session_tag"
+	SessionPinCommand           CommandName = "session_pin"
+	UndoCommand                 CommandName = "undo"
+	RegenerateCommand           CommandName = "regenerate"
+	ProviderSetupCommand        CommandName = "provider_setup"
+	SessionSearchCommand        CommandName = "session_search"
+	SessionReplayCommand        CommandName = "session_replay"
+	MessagesDensityCommand      CommandName = "messages_density"
+	AgentModeCycleCommand       CommandName = "agent_mode_cycle"
+	MessagesTimestampsCommand   CommandName = "messages_timestamps"
+	SessionStatsCommand         CommandName = "session_stats"
+	SessionAuditCommand         CommandName = "session_audit"
+	DebugInspectorCommand       CommandName = "debug_inspector"
+	SystemPromptCommand         CommandName = "system_prompt"
+	ModelParametersCommand      CommandName = "model_parameters"
+	ToolRulesCommand            CommandName = "tool_rules"
+	RedactionRulesCommand       CommandName = "redaction_rules"
+	InputSteerCommand           CommandName = "input_steer"
+	MessagesWidthCommand        CommandName = "messages_width"
+	MessagesWrapCommand         CommandName = "messages_wrap"
+	MessagesReasoningCommand    CommandName = "messages_reasoning"
+	FileChangesCommand          CommandName = "file_changes"
+	MessagesScrollLeftCommand   CommandName = "messages_scroll_left"
+	MessagesScrollRightCommand  CommandName = "messages_scroll_right"
+	FilePreviewCommand          CommandName = "file_preview"
+	FileOpenCommand             CommandName = "file_open"
+	LinkOpenCommand             CommandName = "link_open"
+	MessageInspectCommand       CommandName = "message_inspect"
+	MessagesNavigateCommand     CommandName = "messages_navigate"
+	MessageRateUpCommand        CommandName = "message_rate_up"
+	MessageRateDownCommand      CommandName = "message_rate_down"
+	MessagesRatedDownCommand    CommandName = "messages_rated_down"
+	RunShellCommand             CommandName = "run_shell"
+	ProjectScriptsCommand       CommandName = "project_scripts"
+	ModelPaletteCommand         CommandName = "model_palette"
+	AgentModeListCommand        CommandName = "agent_mode_list"
+	CheckpointCreateCommand     CommandName = "checkpoint_create"
+	CheckpointRestoreCommand    CommandName = "checkpoint_restore"
 	ToolDetailsCommand          CommandName = "tool_details"
+	ToolOutputMoreCommand       CommandName = "tool_output_more"
+	ToolOutputExportCommand     CommandName = "tool_output_export"
 	ModelListCommand            CommandName = "model_list"
 	ThemeListCommand            CommandName = "theme_list"
+	ThemeDesignCommand          CommandName = "theme_design"
+	LayoutSaveCommand           CommandName = "layout_save"
+	LayoutSwitchCommand         CommandName = "layout_switch"
+	ScreenReaderCommand         CommandName = "screen_reader"
+	ConfirmationsResetCommand   CommandName = "confirmations_reset"
+	LogsCommand                 CommandName = "logs"
+	NotificationsCommand        CommandName = "notifications"
+	ToastDismissCommand         CommandName = "toast_dismiss"
+	BugReportCommand            CommandName = "bug_report"
 	ProjectInitCommand          CommandName = "project_init"
 	InputClearCommand           CommandName = "input_clear"
 	InputPasteCommand           CommandName = "input_paste"
+	AttachmentPreviewCommand    CommandName = "attachment_preview"
+	AttachmentRemoveCommand     CommandName = "attachment_remove"
 	InputSubmitCommand          CommandName = "input_submit"
 	InputNewlineCommand         CommandName = "input_newline"
+	InputEnterModeCommand       CommandName = "input_enter_mode"
+	InputSpellCheckCommand      CommandName = "input_spell_check"
+	InputCorrectCommand         CommandName = "input_correct"
 	HistoryPreviousCommand      CommandName = "history_previous"
 	HistoryNextCommand          CommandName = "history_next"
 	MessagesPageUpCommand       CommandName = "messages_page_up"
@@ -149,6 +307,41 @@ func LoadFromConfig(config *client.ConfigInfo) CommandRegistry {
 			Keybindings: parseBindings("<leader>l"),
 			Trigger:     "sessions",
 		},
+		{
+			Name:        SessionNextCommand,
+			Description: "next session",
+			Keybindings: parseBindings("<leader>]"),
+		},
+		{
+			Name:        SessionPreviousCommand,
+			Description: "previous session",
+			Keybindings: parseBindings("<leader>["),
+		},
+		{
+			Name:        SessionTagCommand,
+			Description: "tag the session",
+			Trigger:     "tag",
+		},
+		{
+			Name:        SessionPinCommand,
+			Description: "pin the session to the top of the list",
+			Trigger:     "pin",
+		},
+		{
+			Name:        SessionImportCommand,
+			Description: "import exported sessions",
+			Trigger:     "import",
+		},
+		{
+			Name:        SessionMergeCommand,
+			Description: "append another session's history",
+			Trigger:     "merge",
+		},
+		{
+			Name:        SessionCleanupCommand,
+			Description: "review old sessions to delete",
+			Trigger:     "cleanup",
+		},
 		{
 			Name:        SessionShareCommand,
 			Description: "share session",
@@ -160,18 +353,254 @@ func LoadFromConfig(config *client.ConfigInfo) CommandRegistry {
 			Description: "interrupt session",
 			Keybindings: parseBindings("esc"),
 		},
+		{
+			Name:        SessionStopCommand,
+			Description: "stop and keep partial output",
+			Trigger:     "stop",
+		},
+		{
+			Name:        SessionCancelToolCommand,
+			Description: "cancel a running tool call",
+			Keybindings: parseBindings("<leader>k"),
+			Trigger:     "canceltool",
+		},
+		{
+			Name:        UndoCommand,
+			Description: "undo last file changes",
+			Trigger:     "undo",
+		},
+		{
+			Name:        RegenerateCommand,
+			Description: "regenerate last response",
+			Trigger:     "regenerate",
+		},
+		{
+			Name:        ProviderSetupCommand,
+			Description: "add provider credentials",
+			Trigger:     "login",
+		},
+		{
+			Name:        SessionSearchCommand,
+			Description: "find in session",
+			Keybindings: parseBindings("<leader>/"),
+			Trigger:     "find",
+		},
+		{
+			Name:        SessionReplayCommand,
+			Description: "replay the session step by step",
+			Trigger:     "replay",
+		},
+		{
+			Name:        MessagesDensityCommand,
+			Description: "cycle message density",
+			Keybindings: parseBindings("<leader>v"),
+			Trigger:     "density",
+		},
+		{
+			Name:        MessagesWidthCommand,
+			Description: "cycle content width",
+			Trigger:     "width",
+		},
+		{
+			Name:        MessagesWrapCommand,
+			Description: "cycle code block wrapping",
+			Trigger:     "wrap",
+		},
+		{
+			Name:        MessagesReasoningCommand,
+			Description: "cycle reasoning display",
+			Trigger:     "reasoning",
+		},
+		{
+			Name:        FileChangesCommand,
+			Description: "toggle telling the model about external file changes",
+			Trigger:     "file-changes",
+		},
+		{
+			Name:        ModelPaletteCommand,
+			Description: "quick switch model",
+			Keybindings: parseBindings("<leader>p"),
+		},
+		{
+			Name:        SessionStatsCommand,
+			Description: "show session stats",
+			Trigger:     "stats",
+		},
+		{
+			Name:        SessionAuditCommand,
+			Description: "show the audit log of the agent's tool calls",
+			Trigger:     "audit",
+		},
+		{
+			Name:        SystemPromptCommand,
+			Description: "edit the system prompt",
+			Trigger:     "system",
+		},
+		{
+			Name:        ModelParametersCommand,
+			Description: "set the temperature, top p, max tokens and reasoning effort",
+			Trigger:     "parameters",
+		},
+		{
+			Name:        ToolRulesCommand,
+			Description: "edit tool auto-approve rules",
+			Trigger:     "rules",
+		},
+		{
+			Name:        RedactionRulesCommand,
+			Description: "edit the secrets masked in this project's prompts",
+			Trigger:     "redaction",
+		},
+		{
+			Name:        FilePreviewCommand,
+			Description: "preview mentioned file",
+			Keybindings: parseBindings("<leader>f"),
+			Trigger:     "preview",
+		},
+		{
+			Name:        FileOpenCommand,
+			Description: "open mentioned file in editor",
+			Keybindings: parseBindings("<leader>o"),
+			Trigger:     "open",
+		},
+		{
+			Name:        LinkOpenCommand,
+			Description: "open a link of the message in the browser",
+			Keybindings: parseBindings("<leader>u"),
+			Trigger:     "links",
+		},
+		{
+			Name:        MessageInspectCommand,
+			Description: "inspect message JSON",
+			Keybindings: parseBindings("<leader>j"),
+			Trigger:     "json",
+		},
+		{
+			Name:        MessagesNavigateCommand,
+			Description: "select messages with j/k to copy, fork, pin or collapse",
+			Keybindings: parseBindings("esc"),
+			Trigger:     "navigate",
+		},
+		{
+			Name:        MessageRateUpCommand,
+			Description: "rate the response good",
+			Keybindings: parseBindings("<leader>+"),
+			Trigger:     "good",
+		},
+		{
+			Name:        MessageRateDownCommand,
+			Description: "rate the response bad",
+			Keybindings: parseBindings("<leader>-"),
+			Trigger:     "bad",
+		},
+		{
+			Name:        MessagesRatedDownCommand,
+			Description: "jump to the next response rated bad",
+			Trigger:     "badturns",
+		},
+		{
+			Name:        RunShellCommand,
+			Description: "run a command and attach its output",
+			Trigger:     "run",
+		},
+		{
+			Name:        ProjectScriptsCommand,
+			Description: "run a script of package.json, the Makefile or the justfile",
+			Trigger:     "scripts",
+		},
+		{
+			Name:        LogsCommand,
+			Description: "tail the log file",
+			Trigger:     "logs",
+		},
+		{
+			Name:        NotificationsCommand,
+			Description: "show the last notifications",
+			Trigger:     "notifications",
+		},
+		{
+			Name:        ToastDismissCommand,
+			Description: "dismiss the notifications",
+			Keybindings: parseBindings("ctrl+alt+n"),
+		},
+		{
+			Name:        BugReportCommand,
+			Description: "bundle diagnostics for a bug report",
+			Trigger:     "bug-report",
+		},
+		{
+			Name:        DebugInspectorCommand,
+			Description: "inspect client calls",
+			Trigger:     "debug",
+		},
+		{
+			Name:        MessagesTimestampsCommand,
+			Description: "toggle relative timestamps",
+			Keybindings: parseBindings("<leader>r"),
+			Trigger:     "timestamps",
+		},
+		{
+			Name:        AgentModeCycleCommand,
+			Description: "cycle agent mode",
+			Keybindings: parseBindings("<leader>a"),
+		},
+		{
+			Name:        AgentModeListCommand,
+			Description: "switch agent mode",
+			Trigger:     "mode",
+		},
+		{
+			Name:        CheckpointCreateCommand,
+			Description: "create a named checkpoint",
+			Trigger:     "checkpoint",
+		},
+		{
+			Name:        CheckpointRestoreCommand,
+			Description: "restore a checkpoint",
+			Trigger:     "restore",
+		},
 		{
 			Name:        SessionCompactCommand,
 			Description: "compact the session",
 			Keybindings: parseBindings("<leader>c"),
 			Trigger:     "compact",
 		},
+		{
+			Name:        SessionContextCommand,
+			Description: "choose the history sent next",
+			Trigger:     "context",
+		},
+		{
+			Name:        ContextPinCommand,
+			Description: "pin files and snippets sent with every prompt",
+			Trigger:     "context-pin",
+		},
+		{
+			Name:        WorkspaceRootsCommand,
+			Description: "attach workspace roots and switch between them",
+			Trigger:     "roots",
+		},
+		{
+			Name:        SessionCompareCommand,
+			Description: "compare two models",
+			Trigger:     "compare",
+		},
 		{
 			Name:        ToolDetailsCommand,
 			Description: "toggle tool details",
 			Keybindings: parseBindings("<leader>d"),
 			Trigger:     "details",
 		},
+		{
+			Name:        ToolOutputMoreCommand,
+			Description: "show more of a long tool output",
+			Trigger:     "more",
+		},
+		{
+			Name:        ToolOutputExportCommand,
+			Description: "save a long tool output to a file",
+			Trigger:     "output",
+		},
 		{
 			Name:        ModelListCommand,
 			Description: "list models",
@@ -184,6 +613,31 @@ func LoadFromConfig(config *client.ConfigInfo) CommandRegistry {
 			Keybindings: parseBindings("<leader>t"),
 			Trigger:     "themes",
 		},
+		{
+			Name:        ThemeDesignCommand,
+			Description: "design a new theme",
+			Trigger:     "design",
+		},
+		{
+			Name:        LayoutSaveCommand,
+			Description: "save the layout, density and theme as a preset",
+			Trigger:     "layout-save",
+		},
+		{
+			Name:        LayoutSwitchCommand,
+			Description: "switch to a layout preset",
+			Trigger:     "layout",
+		},
+		{
+			Name:        ScreenReaderCommand,
+			Description: "toggle screen reader mode",
+			Trigger:     "screenreader",
+		},
+		{
+			Name:        ConfirmationsResetCommand,
+			Description: "ask before destructive actions again",
+			Trigger:     "confirmations",
+		},
 		{
 			Name:        ProjectInitCommand,
 			Description: "create/update AGENTS.md",
@@ -200,16 +654,50 @@ func LoadFromConfig(config *client.ConfigInfo) CommandRegistry {
 			Description: "paste content",
 			Keybindings: parseBindings("ctrl+v"),
 		},
+		{
+			Name:        AttachmentPreviewCommand,
+			Description: "preview a pending attachment",
+			Keybindings: parseBindings("<leader>w"),
+			Trigger:     "attachments",
+		},
+		{
+			Name:        AttachmentRemoveCommand,
+			Description: "remove a pending attachment",
+			Keybindings: parseBindings("<leader>x"),
+			Trigger:     "detach",
+		},
 		{
 			Name:        InputSubmitCommand,
 			Description: "submit message",
 			Keybindings: parseBindings("enter"),
 		},
+		{
+			Name:        InputSteerCommand,
+			Description: "steer the busy agent",
+			Keybindings: parseBindings("alt+enter"),
+			Trigger:     "steer",
+		},
 		{
 			Name:        InputNewlineCommand,
 			Description: "insert newline",
 			Keybindings: parseBindings("shift+enter", "ctrl+j"),
 		},
+		{
+			Name:        InputEnterModeCommand,
+			Description: "toggle whether enter sends",
+			Trigger:     "enter",
+		},
+		{
+			Name:        InputSpellCheckCommand,
+			Description: "toggle spell check",
+			Trigger:     "spellcheck",
+		},
+		{
+			Name:        InputCorrectCommand,
+			Description: "correct the misspelled word",
+			Keybindings: parseBindings("<leader>z"),
+			Trigger:     "correct",
+		},
 		// {
 		// 	Name:        HistoryPreviousCommand,
 		// 	Description: "previous prompt",
@@ -250,6 +738,16 @@ func LoadFromConfig(config *client.ConfigInfo) CommandRegistry {
 			Description: "next message",
 			Keybindings: parseBindings("ctrl+alt+j"),
 		},
+		{
+			Name:        MessagesScrollLeftCommand,
+			Description: "scroll code left",
+			Keybindings: parseBindings("ctrl+alt+h"),
+		},
+		{
+			Name:        MessagesScrollRightCommand,
+			Description: "scroll code right",
+			Keybindings: parseBindings("ctrl+alt+l"),
+		},
 		{
 			Name:        MessagesFirstCommand,
 			Description: "first message",
@@ -277,5 +775,37 @@ func LoadFromConfig(config *client.ConfigInfo) CommandRegistry {
 		}
 		registry[command.Name] = command
 	}
+	if config.Aliases != nil {
+		registry.addAliases(*config.Aliases)
+	}
 	return registry
 }
+
+// addAliases registers the aliases of the config as commands triggered by
+// the alias. Aliases taken by a built-in trigger or expanding to no known
+// command are skipped.
+func (r CommandRegistry) addAliases(aliases map[string]string) {
+	for alias, expansion := range aliases {
+		alias = strings.TrimPrefix(strings.TrimSpace(alias), "/")
+		expansion = strings.TrimPrefix(strings.TrimSpace(expansion), "/")
+		if alias == "" || strings.Contains(alias, " ") {
+			slog.Warn("Ignoring alias with an invalid name", "alias", alias)
+			continue
+		}
+		if _, _, ok := r.FindTrigger("/" + alias); ok {
+			slog.Warn("Ignoring alias of a built-in command", "alias", alias)
+			continue
+		}
+		command := Command{
+			Name:        CommandName(aliasPrefix + alias),
+			Description: "alias for /" + expansion,
+			Trigger:     alias,
+			Expansion:   expansion,
+		}
+		if _, _, ok := r.Resolve(command, ""); !ok {
+			slog.Warn("Ignoring alias of an unknown command", "alias", alias, "expansion", expansion)
+			continue
+		}
+		r[command.Name] = command
+	}
+}
@@ -2,12 +2,15 @@ package completions
 
 import (
 	"context"
+	"slices"
 
 	"github.com/sst/opencode/internal/app"
 	"github.com/sst/opencode/internal/components/dialog"
 	"github.com/sst/opencode/pkg/client"
 )
 
+const recentFilesLimit = 5
+
 type filesAndFoldersContextGroup struct {
 	app    *app.App
 	prefix string
@@ -31,6 +34,7 @@ func (cg *filesAndFoldersContextGroup) GetEmptyMessage() string {
 func (cg *filesAndFoldersContextGroup) getFiles(query string) ([]string, error) {
 	response, err := cg.app.Client.PostFileSearchWithResponse(context.Background(), client.PostFileSearchJSONRequestBody{
 		Query: query,
+		Root:  cg.app.RootParam(),
 	})
 	if err != nil {
 		return []string{}, err
@@ -48,11 +52,23 @@ func (cg *filesAndFoldersContextGroup) GetChildEntries(query string) ([]dialog.C
 		return nil, err
 	}
 
-	items := make([]dialog.CompletionItemI, 0, len(matches))
+	// recently modified files come first
+	recent := cg.app.RecentFiles.List(query, recentFilesLimit)
+	items := make([]dialog.CompletionItemI, 0, len(recent)+len(matches))
+	// files of another root are inserted relative to the working directory
+	for _, file := range recent {
+		items = append(items, dialog.NewCompletionItem(dialog.CompletionItem{
+			Title: file,
+			Value: cg.app.RootRelative(file),
+		}))
+	}
 	for _, file := range matches {
+		if slices.Contains(recent, file) {
+			continue
+		}
 		item := dialog.NewCompletionItem(dialog.CompletionItem{
 			Title: file,
-			Value: file,
+			Value: cg.app.RootRelative(file),
 		})
 		items = append(items, item)
 	}
@@ -0,0 +1,79 @@
+package chat
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/commands"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// Attachments returns the attachments pending in the editor
+func (m *editorComponent) Attachments() []app.Attachment {
+	return m.sentAttachments(m.Value())
+}
+
+// RemoveAttachment drops a pending attachment, along with the label of a
+// collapsed paste in the editor
+func (m *editorComponent) RemoveAttachment(index int) (app.Attachment, bool) {
+	value := m.Value()
+	pending := -1
+	for i, attachment := range m.attachments {
+		if !isSent(attachment, value) {
+			continue
+		}
+		if pending++; pending != index {
+			continue
+		}
+		m.attachments = slices.Delete(m.attachments, i, i+1)
+		if app.IsPaste(attachment) {
+			m.textarea.SetValue(strings.Replace(value, attachment.FileName, "", 1))
+		}
+		return attachment, true
+	}
+	return app.Attachment{}, false
+}
+
+// RenderAttachmentStrip renders the pending attachments on one line above
+// the editor, with the keys previewing and removing them
+func RenderAttachmentStrip(a *app.App, attachments []app.Attachment, width int) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+
+	items := []string{}
+	for i, attachment := range attachments {
+		items = append(items, base(fmt.Sprintf("%d ", i+1))+muted(app.AttachmentSummary(attachment)))
+	}
+	hint := base(commandKey(a, commands.AttachmentPreviewCommand)) + muted(" preview  ") +
+		base(commandKey(a, commands.AttachmentRemoveCommand)) + muted(" remove")
+	strip := ansi.Truncate(strings.Join(items, muted("   ")), max(width-lipgloss.Width(hint)-4, 0), "…")
+	gap := max(width-lipgloss.Width(strip)-lipgloss.Width(hint)-2, 1)
+	return styles.NewStyle().
+		Background(t.BackgroundElement()).
+		Width(width).
+		Padding(0, 1).
+		Render(strip + muted(strings.Repeat(" ", gap)) + hint)
+}
+
+// commandKey returns the first key of a command as it is typed, or its
+// trigger when it has no key
+func commandKey(a *app.App, name commands.CommandName) string {
+	command := a.Commands[name]
+	if len(command.Keybindings) == 0 {
+		return "/" + command.Trigger
+	}
+	binding := command.Keybindings[0]
+	if binding.RequiresLeader && a.Config.Keybinds.Leader != nil {
+		return *a.Config.Keybinds.Leader + " " + binding.Key
+	}
+	return binding.Key
+}
@@ -0,0 +1,123 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// renderComparison renders both sides of a comparison next to each other
+func (m *messagesComponent) renderComparison() string {
+	t := theme.CurrentTheme()
+	comparison := m.app.Comparison
+
+	totalWidth := min(m.width-4, 160)
+	columnWidth := (totalWidth - 1) / 2
+
+	prompt := ""
+	for _, message := range comparison.Sides[0].Messages {
+		if message.Role == client.User {
+			prompt = app.MessageText(message)
+			break
+		}
+	}
+
+	columns := []string{}
+	for i, side := range comparison.Sides {
+		borderColor := t.Accent()
+		if i == 1 {
+			borderColor = t.Secondary()
+		}
+		columns = append(columns, renderCompareColumn(side, columnWidth, borderColor))
+	}
+	gap := styles.NewStyle().Background(t.Background()).Render(" ")
+	body := lipgloss.JoinHorizontal(lipgloss.Top, columns[0], gap, columns[1])
+
+	blocks := []string{}
+	if prompt != "" {
+		blocks = append(blocks, renderContentBlock(
+			styles.NewStyle().Background(t.BackgroundPanel()).Foreground(t.Text()).Render(prompt),
+			WithAlign(lipgloss.Right),
+			WithBorderColor(t.Secondary()),
+		), "")
+	}
+	blocks = append(blocks, lipgloss.PlaceHorizontal(
+		m.width,
+		lipgloss.Center,
+		body,
+		styles.WhitespaceStyle(t.Background()),
+	))
+
+	if !comparison.Busy() {
+		muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Render
+		base := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Render
+		hint := base("/compare") + muted(" to pick a winner and continue")
+		blocks = append(blocks, "", lipgloss.PlaceHorizontal(
+			m.width,
+			lipgloss.Center,
+			hint,
+			styles.WhitespaceStyle(t.Background()),
+		))
+	}
+
+	return strings.Join(blocks, "\n")
+}
+
+func renderCompareColumn(side *app.CompareSide, width int, borderColor compat.AdaptiveColor) string {
+	t := theme.CurrentTheme()
+	innerWidth := width - 5
+
+	title := styles.NewStyle().
+		Foreground(t.Text()).
+		Background(t.BackgroundPanel()).
+		Bold(true).
+		Render(side.Provider.Name + " " + side.Model.Name)
+
+	lines := []string{title, ""}
+	status := "working..."
+	for _, message := range side.Messages {
+		if message.Role != client.Assistant {
+			continue
+		}
+		for _, p := range message.Parts {
+			part, err := p.ValueByDiscriminator()
+			if err != nil {
+				continue
+			}
+			switch part := part.(type) {
+			case client.MessagePartText:
+				lines = append(lines, toMarkdown(part.Text, innerWidth, t.BackgroundPanel()))
+			case client.MessagePartToolInvocation:
+				toolCall, _ := part.ToolInvocation.AsMessageToolInvocationToolCall()
+				lines = append(lines, "∟ "+renderToolName(toolCall.ToolName))
+			}
+		}
+		if message.Metadata.Time.Completed != nil && message.Metadata.Assistant != nil {
+			status = fmt.Sprintf("done, $%.2f", message.Metadata.Assistant.Cost)
+		}
+		if message.Metadata.Error != nil {
+			status = "failed"
+		}
+	}
+	if side.Failed {
+		status = "failed"
+	}
+	lines = append(lines, "", status)
+
+	return styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundPanel()).
+		Width(width).
+		Padding(1, 2).
+		BorderStyle(lipgloss.ThickBorder()).
+		BorderLeft(true).
+		BorderLeftForeground(borderColor).
+		BorderLeftBackground(t.Background()).
+		Render(strings.Join(lines, "\n"))
+}
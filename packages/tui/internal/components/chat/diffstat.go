@@ -0,0 +1,160 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/components/diff"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// fileStat is the lines a turn added to and removed from a file, with the
+// diffs of the tool calls that changed it
+type fileStat struct {
+	path           string
+	added, removed int
+	diffs          []string
+}
+
+// diffStat sums the changes of the file modifying tool calls of a message
+// from their metadata, in the order the files were first changed
+func diffStat(message client.MessageInfo) []fileStat {
+	stats := []fileStat{}
+	index := map[string]int{}
+	for _, p := range message.Parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		invocation, ok := part.(client.MessagePartToolInvocation)
+		if !ok {
+			continue
+		}
+		result, err := invocation.ToolInvocation.AsMessageToolInvocationToolResult()
+		if err != nil || result.State != "result" || result.Args == nil {
+			continue
+		}
+		args, _ := (*result.Args).(map[string]any)
+		path, _ := args["filePath"].(string)
+		if path == "" {
+			continue
+		}
+		metadata := message.Metadata.Tool[result.ToolCallId]
+		if failed, _ := metadata.Get("error"); failed == true {
+			continue
+		}
+		added, removed, patch := 0, 0, ""
+		switch result.ToolName {
+		case "edit", "patch":
+			d, ok := metadata.Get("diff")
+			if !ok {
+				continue
+			}
+			patch, _ = d.(string)
+			added, removed = countDiff(patch)
+		case "write":
+			// the previous content is unknown, the whole file counts as added
+			content, _ := args["content"].(string)
+			added = strings.Count(strings.TrimRight(content, "\n"), "\n") + 1
+		default:
+			continue
+		}
+		i, ok := index[path]
+		if !ok {
+			i = len(stats)
+			index[path] = i
+			stats = append(stats, fileStat{path: path})
+		}
+		stats[i].added += added
+		stats[i].removed += removed
+		if patch != "" {
+			stats[i].diffs = append(stats[i].diffs, patch)
+		}
+	}
+	return stats
+}
+
+// countDiff counts the added and removed lines of a unified diff
+func countDiff(patch string) (added, removed int) {
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// diffStatKey is the key of the diffstat footer of a message in the
+// expanded map, or of one of its files when path is set
+func diffStatKey(messageID, path string) string {
+	if path == "" {
+		return "diffstat:" + messageID
+	}
+	return "diffstat:" + messageID + ":" + path
+}
+
+// renderDiffStat renders the summary line of the files a turn changed
+func renderDiffStat(stats []fileStat, expanded bool) string {
+	added, removed := 0, 0
+	for _, stat := range stats {
+		added += stat.added
+		removed += stat.removed
+	}
+	files := "files"
+	if len(stats) == 1 {
+		files = "file"
+	}
+	label := fmt.Sprintf("%d %s changed", len(stats), files)
+	return renderDiffStatBlock(diffStatLine(expanded, label, added, removed, ""))
+}
+
+// renderFileStat renders the line of a changed file, followed by its diffs
+// when expanded
+func renderFileStat(stat fileStat, expanded bool) string {
+	lines := []string{diffStatLine(expanded, relative(stat.path), stat.added, stat.removed, "  ")}
+	if expanded {
+		for _, patch := range stat.diffs {
+			formatted, err := diff.FormatUnifiedDiff(stat.path, patch, diff.WithWidth(diffStatWidth()))
+			if err == nil {
+				lines = append(lines, strings.TrimSpace(formatted))
+			}
+		}
+	}
+	return renderDiffStatBlock(strings.Join(lines, "\n"))
+}
+
+func diffStatWidth() int {
+	return layout.Current.Container.Width - calculatePadding() - 4 - 3
+}
+
+func diffStatLine(expanded bool, label string, added, removed int, indent string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel()).Render
+	plus := styles.NewStyle().Foreground(t.DiffAdded()).Background(t.BackgroundPanel()).Render
+	minus := styles.NewStyle().Foreground(t.DiffRemoved()).Background(t.BackgroundPanel()).Render
+
+	marker := "▸ "
+	if expanded {
+		marker = "▾ "
+	}
+	line := muted(indent+marker) + base(label) + muted(", ") +
+		plus(fmt.Sprintf("+%d", added)) + muted(" ") + minus(fmt.Sprintf("−%d", removed))
+	return styles.NewStyle().Background(t.BackgroundPanel()).Width(diffStatWidth()).MaxHeight(1).Render(line)
+}
+
+func renderDiffStatBlock(content string) string {
+	return renderContentBlock(content,
+		WithAlign(lipgloss.Left),
+		WithPaddingTop(0),
+		WithPaddingBottom(0),
+	)
+}
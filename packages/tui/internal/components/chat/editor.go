@@ -2,13 +2,17 @@ package chat
 
 import (
 	"fmt"
-	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/bus"
 	"github.com/sst/opencode/internal/commands"
 	"github.com/sst/opencode/internal/components/dialog"
 	"github.com/sst/opencode/internal/components/textarea"
@@ -30,12 +34,19 @@ type EditorComponent interface {
 	Focus() (tea.Model, tea.Cmd)
 	Blur()
 	Submit() (tea.Model, tea.Cmd)
+	SubmitOversized(action app.OversizeAction) (tea.Model, tea.Cmd)
 	Clear() (tea.Model, tea.Cmd)
 	Paste() (tea.Model, tea.Cmd)
 	Newline() (tea.Model, tea.Cmd)
 	Previous() (tea.Model, tea.Cmd)
 	Next() (tea.Model, tea.Cmd)
 	SetInterruptKeyInDebounce(inDebounce bool)
+	SaveDraft()
+	MentionAtCursor() string
+	Misspelling() (app.Misspelling, bool)
+	Correct(misspelling app.Misspelling, replacement string)
+	Attachments() []app.Attachment
+	RemoveAttachment(index int) (app.Attachment, bool)
 }
 
 type editorComponent struct {
@@ -48,10 +59,16 @@ type editorComponent struct {
 	currentMessage         string
 	spinner                spinner.Model
 	interruptKeyInDebounce bool
+	// draftSession is the session the editor content is a draft for
+	draftSession string
+	snippet      *snippetSession
+	// fenced marks the rows inside code blocks, which are not spell checked
+	fenced []bool
 }
 
 func (m *editorComponent) Init() tea.Cmd {
-	return tea.Batch(m.textarea.Focus(), m.spinner.Tick, tea.EnableReportFocus)
+	m.textarea.SetValue(m.app.Draft(m.draftSession))
+	return tea.Batch(m.textarea.Focus(), m.spinner.Tick, tea.EnableReportFocus, m.app.TickDraft())
 }
 
 func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -63,12 +80,30 @@ func (m *editorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 	case tea.KeyPressMsg:
+		if msg.String() == "tab" && (m.nextStop() || m.expandSnippet()) {
+			return m, nil
+		}
+		if m.replacePlaceholder(msg) {
+			return m, nil
+		}
+		if m.quickReply(msg) {
+			return m, nil
+		}
 		// Maximize editor responsiveness for printable characters
 		if msg.Text != "" {
 			m.textarea, cmd = m.textarea.Update(msg)
 			cmds = append(cmds, cmd)
 			return m, tea.Batch(cmds...)
 		}
+	case tea.PasteMsg:
+		if m.collapsePaste(string(msg)) {
+			return m, nil
+		}
+	case app.DraftRestoredMsg:
+		m.textarea.SetValue(m.app.Draft(m.draftSession))
+	case app.DraftTickMsg:
+		m.app.SaveDraft(m.draftSession, m.draft())
+		return m, m.app.TickDraft()
 	case dialog.ThemeSelectedMsg:
 		m.textarea = createTextArea(&m.textarea)
 		m.spinner = createSpinner()
@@ -114,38 +149,83 @@ func (m *editorComponent) Content() string {
 		Bold(true)
 	prompt := promptStyle.Render(">")
 
+	m.textarea.Marks = nil
+	if m.app.State.SpellCheck {
+		m.fenced = fencedRows(m.textarea.Value())
+		m.textarea.Marks = m.marks
+	}
 	textarea := lipgloss.JoinHorizontal(
 		lipgloss.Top,
 		prompt,
 		m.textarea.View(),
 	)
-	textarea = styles.NewStyle().
+	box := styles.NewStyle().
 		Background(t.BackgroundElement()).
 		Width(m.width).
 		PaddingTop(1).
-		PaddingBottom(1).
-		BorderStyle(lipgloss.ThickBorder()).
-		BorderForeground(t.Border()).
-		BorderBackground(t.Background()).
-		BorderLeft(true).
-		BorderRight(true).
-		Render(textarea)
-
-	hint := base(m.getSubmitKeyText()) + muted(" send   ")
+		PaddingBottom(1)
+	if m.app.ScreenReader {
+		box = box.PaddingLeft(1).PaddingRight(1)
+	} else {
+		box = box.
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(t.Border()).
+			BorderBackground(t.Background()).
+			BorderLeft(true).
+			BorderRight(true)
+	}
+	textarea = box.Render(textarea)
+
+	hint := base(m.getSubmitKeyText()) + muted(" send  ") + base(m.getNewlineKeyText()) + muted(" newline   ")
+	if outputs := len(m.app.Outputs); outputs > 0 {
+		hint += muted(fmt.Sprintf("%d output(s) attached   ", outputs))
+	}
+	if m.textarea.Value() == "" {
+		for i, reply := range m.app.QuickReplies() {
+			if i == 0 {
+				hint = ""
+			}
+			hint += base("alt+"+strconv.Itoa(i+1)) + muted(" "+reply+"   ")
+		}
+	}
 	if m.app.IsBusy() {
 		keyText := m.getInterruptKeyText()
+		label := m.app.Run().Label()
+		if label == "" {
+			label = "working"
+		}
+		working := muted(label) + m.spinner.View()
+		if m.app.ScreenReader {
+			working = muted(label)
+		}
 		if m.interruptKeyInDebounce {
-			hint = muted("working") + m.spinner.View() + muted("  ") + base(keyText+" again") + muted(" interrupt")
+			hint = working + muted("  ") + base(keyText+" again") + muted(" interrupt")
+		} else {
+			hint = working + muted("  ") + base(keyText) + muted(" interrupt")
+		}
+		if notes := m.app.SteeringNotes(); notes > 0 {
+			hint += muted(fmt.Sprintf("  %d note(s) queued", notes))
 		} else {
-			hint = muted("working") + m.spinner.View() + muted("  ") + base(keyText) + muted(" interrupt")
+			hint += muted("  ") + base(m.app.Commands[commands.InputSteerCommand].Keys()[0]) + muted(" steer")
 		}
 	}
 
+	if m.app.RateLimit != nil {
+		warning := styles.NewStyle().Foreground(t.Warning()).Background(t.Background()).Render
+		seconds := int(m.app.RateLimit.Remaining().Seconds())
+		hint = warning(fmt.Sprintf("rate limited, retrying in %ds", seconds)) + muted("  ")
+	}
+
 	model := ""
 	if m.app.Model != nil {
 		model = muted(m.app.Provider.Name) + base(" "+m.app.Model.Name)
 	}
+	if tokens := m.tokens(); tokens != "" {
+		model = tokens + muted("  ") + model
+	}
 
+	// a long hint, such as quick replies, is cut before the model
+	hint = ansi.Truncate(hint, max(m.width-4-lipgloss.Width(model), 0), "…")
 	space := m.width - 2 - lipgloss.Width(model) - lipgloss.Width(hint)
 	spacer := styles.NewStyle().Background(t.Background()).Width(space).Render("")
 
@@ -156,6 +236,24 @@ func (m *editorComponent) Content() string {
 	return content
 }
 
+// quickReply fills the empty editor with the suggested reply of the number
+// pressed with alt, which is then sent or edited as usual. Plain digits are
+// typed, a prompt can start with a number.
+func (m *editorComponent) quickReply(msg tea.KeyPressMsg) bool {
+	digit, ok := strings.CutPrefix(msg.String(), "alt+")
+	if m.textarea.Value() != "" || !ok || len(digit) != 1 || digit[0] < '1' || digit[0] > '9' {
+		return false
+	}
+	replies := m.app.QuickReplies()
+	index := int(digit[0] - '1')
+	if index >= len(replies) {
+		return false
+	}
+	m.textarea.SetValue(replies[index])
+	m.textarea.CursorEnd()
+	return true
+}
+
 func (m *editorComponent) View() string {
 	if m.Lines() > 1 {
 		return ""
@@ -182,6 +280,7 @@ func (m *editorComponent) GetSize() (width, height int) {
 func (m *editorComponent) SetSize(width, height int) tea.Cmd {
 	m.width = width
 	m.height = height
+	m.textarea.SetWidth(max(width-6, 0))
 	return nil
 }
 
@@ -194,6 +293,13 @@ func (m *editorComponent) Value() string {
 }
 
 func (m *editorComponent) Submit() (tea.Model, tea.Cmd) {
+	return m.SubmitOversized("")
+}
+
+// SubmitOversized sends the draft, fitting it with action when it is over
+// the attachment limit. Without an action such a draft is kept and
+// OversizedPromptMsg asks for one.
+func (m *editorComponent) SubmitOversized(action app.OversizeAction) (tea.Model, tea.Cmd) {
 	value := strings.TrimSpace(m.Value())
 	if value == "" {
 		return m, nil
@@ -203,45 +309,81 @@ func (m *editorComponent) Submit() (tea.Model, tea.Cmd) {
 		m.textarea.SetValue(value[:len(value)-1] + "\n")
 		return m, nil
 	}
-
-	var cmds []tea.Cmd
-	updated, cmd := m.Clear()
-	m = updated.(*editorComponent)
-	cmds = append(cmds, cmd)
-
-	attachments := m.attachments
+	attachments := m.sentAttachments(value)
+	if limit := m.app.AttachmentLimit(); action == "" && limit > 0 {
+		if tokens := m.app.PromptTokens(value, attachments); tokens > limit {
+			return m, util.CmdHandler(app.OversizedPromptMsg{Tokens: tokens, Limit: limit})
+		}
+	}
 
 	// Save to history if not empty and not a duplicate of the last entry
-	if value != "" {
-		if len(m.history) == 0 || m.history[len(m.history)-1] != value {
-			m.history = append(m.history, value)
+	if entry := strings.TrimSpace(m.draft()); entry != "" {
+		if len(m.history) == 0 || m.history[len(m.history)-1] != entry {
+			m.history = append(m.history, entry)
 		}
 		m.historyIndex = len(m.history)
 		m.currentMessage = ""
 	}
 
+	var cmds []tea.Cmd
+	updated, cmd := m.Clear()
+	m = updated.(*editorComponent)
+	cmds = append(cmds, cmd)
+
 	m.attachments = nil
+	m.app.SaveDraft(m.draftSession, "")
 
-	cmds = append(cmds, util.CmdHandler(app.SendMsg{Text: value, Attachments: attachments}))
+	cmds = append(cmds, util.CmdHandler(app.SendMsg{Text: value, Attachments: attachments, Oversize: action}))
 	return m, tea.Batch(cmds...)
 }
 
+// subscribe switches drafts along with the session
+func (m *editorComponent) subscribe() {
+	bus.Subscribe(m.app.Bus, func(app.SessionSelectedMsg) tea.Cmd {
+		m.SwitchDraft()
+		return nil
+	})
+	bus.Subscribe(m.app.Bus, func(app.SessionClearedMsg) tea.Cmd {
+		m.SwitchDraft()
+		return nil
+	})
+}
+
+// SwitchDraft saves the content as the draft of the session it was written
+// for and restores the draft of the current session
+func (m *editorComponent) SwitchDraft() {
+	if m.draftSession == m.app.Session.Id {
+		return
+	}
+	m.app.SaveDraft(m.draftSession, m.draft())
+	m.attachments = slices.DeleteFunc(m.attachments, app.IsPaste)
+	m.draftSession = m.app.Session.Id
+	m.textarea.SetValue(m.app.Draft(m.draftSession))
+	m.snippet = nil
+}
+
+// SaveDraft persists the content as the draft of the current session
+func (m *editorComponent) SaveDraft() {
+	m.app.SaveDraft(m.draftSession, m.draft())
+}
+
 func (m *editorComponent) Clear() (tea.Model, tea.Cmd) {
 	m.textarea.Reset()
+	m.snippet = nil
 	return m, nil
 }
 
 func (m *editorComponent) Paste() (tea.Model, tea.Cmd) {
 	imageBytes, text, err := image.GetImageFromClipboard()
 	if err != nil {
-		slog.Error(err.Error())
+		renderer.Error("Failed to read the clipboard", "error", err)
 		return m, nil
 	}
 	if len(imageBytes) != 0 {
 		attachmentName := fmt.Sprintf("clipboard-image-%d", len(m.attachments))
 		attachment := app.Attachment{FilePath: attachmentName, FileName: attachmentName, Content: imageBytes, MimeType: "image/png"}
 		m.attachments = append(m.attachments, attachment)
-	} else {
+	} else if !m.collapsePaste(text) {
 		m.textarea.SetValue(m.textarea.Value() + text)
 	}
 	return m, nil
@@ -294,6 +436,26 @@ func (m *editorComponent) Next() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// MentionAtCursor returns the word under the cursor, such as an @file mention
+func (m *editorComponent) MentionAtCursor() string {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	row := m.textarea.Line()
+	if row >= len(lines) {
+		return ""
+	}
+	line := []rune(lines[row])
+	info := m.textarea.LineInfo()
+	col := min(info.StartColumn+info.ColumnOffset, len(line))
+	start, end := col, col
+	for start > 0 && !unicode.IsSpace(line[start-1]) {
+		start--
+	}
+	for end < len(line) && !unicode.IsSpace(line[end]) {
+		end++
+	}
+	return string(line[start:end])
+}
+
 func (m *editorComponent) SetInterruptKeyInDebounce(inDebounce bool) {
 	m.interruptKeyInDebounce = inDebounce
 }
@@ -302,10 +464,38 @@ func (m *editorComponent) getInterruptKeyText() string {
 	return m.app.Commands[commands.SessionInterruptCommand].Keys()[0]
 }
 
+// tokens renders the estimated size of the draft, warning when the draft
+// alone is over the threshold
+func (m *editorComponent) tokens() string {
+	value := m.textarea.Value()
+	if strings.TrimSpace(value) == "" && len(m.attachments) == 0 && len(m.app.Outputs) == 0 {
+		return ""
+	}
+	t := theme.CurrentTheme()
+	text := fmt.Sprintf("~%d tokens", m.app.PromptTokens(value, m.attachments))
+	if threshold := m.app.TokenWarning(); threshold > 0 && m.app.TextTokens(value) > threshold {
+		return styles.NewStyle().Foreground(t.Warning()).Background(t.Background()).
+			Render(fmt.Sprintf("%s, draft over %d", text, threshold))
+	}
+	return styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Render(text)
+}
+
+// getSubmitKeyText returns the key sending the message, which depends on
+// whether enter inserts newlines
 func (m *editorComponent) getSubmitKeyText() string {
+	if m.app.State.NewlineOnEnter {
+		return m.app.Commands[commands.InputNewlineCommand].Keys()[0]
+	}
 	return m.app.Commands[commands.InputSubmitCommand].Keys()[0]
 }
 
+func (m *editorComponent) getNewlineKeyText() string {
+	if m.app.State.NewlineOnEnter {
+		return m.app.Commands[commands.InputSubmitCommand].Keys()[0]
+	}
+	return m.app.Commands[commands.InputNewlineCommand].Keys()[0]
+}
+
 func createTextArea(existing *textarea.Model) textarea.Model {
 	t := theme.CurrentTheme()
 	bgColor := t.BackgroundElement()
@@ -322,6 +512,8 @@ func createTextArea(existing *textarea.Model) textarea.Model {
 	ta.Styles.Focused.CursorLine = styles.NewStyle().Background(bgColor).Lipgloss()
 	ta.Styles.Focused.Placeholder = styles.NewStyle().Foreground(textMutedColor).Background(bgColor).Lipgloss()
 	ta.Styles.Focused.Text = styles.NewStyle().Foreground(textColor).Background(bgColor).Lipgloss()
+	ta.Styles.Focused.Marked = styles.NewStyle().Foreground(t.Error()).Underline(true).Lipgloss()
+	ta.Styles.Blurred.Marked = ta.Styles.Focused.Marked
 	ta.Styles.Cursor.Color = t.Primary()
 
 	ta.Prompt = " "
@@ -357,7 +549,7 @@ func NewEditorComponent(app *app.App) EditorComponent {
 	s := createSpinner()
 	ta := createTextArea(nil)
 
-	return &editorComponent{
+	m := &editorComponent{
 		app:                    app,
 		textarea:               ta,
 		history:                []string{},
@@ -366,4 +558,6 @@ func NewEditorComponent(app *app.App) EditorComponent {
 		spinner:                s,
 		interruptKeyInDebounce: false,
 	}
+	m.subscribe()
+	return m
 }
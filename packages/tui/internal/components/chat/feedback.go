@@ -0,0 +1,58 @@
+package chat
+
+import (
+	"slices"
+
+	"github.com/sst/opencode/pkg/client"
+)
+
+// JumpToRatedMsg selects and scrolls to the next message rated with
+// Feedback, wrapping around to the first one
+type JumpToRatedMsg struct {
+	Feedback client.MessageMetadataFeedback
+}
+
+// feedbackLabel returns the label shown in the info line of a rated message
+func feedbackLabel(message client.MessageInfo) string {
+	if message.Metadata.Feedback == nil {
+		return ""
+	}
+	switch *message.Metadata.Feedback {
+	case client.MessageMetadataFeedbackUp:
+		return "[good]"
+	case client.MessageMetadataFeedbackDown:
+		return "[bad]"
+	}
+	return ""
+}
+
+// jumpToRated selects the rated message after the selected one
+func (m *messagesComponent) jumpToRated(feedback client.MessageMetadataFeedback) {
+	rated := m.app.RatedMessages(feedback)
+	if len(rated) == 0 {
+		return
+	}
+	next := rated[0]
+	current := slices.IndexFunc(m.app.Messages, func(message client.MessageInfo) bool {
+		return message.Id == m.selected
+	})
+	for _, message := range rated {
+		index := slices.IndexFunc(m.app.Messages, func(candidate client.MessageInfo) bool {
+			return candidate.Id == message.Id
+		})
+		if index > current {
+			next = message
+			break
+		}
+	}
+
+	m.selected = next.Id
+	m.renderView()
+	for _, region := range m.regions {
+		if region.messageID == next.Id {
+			m.viewport.SetYOffset(region.start - 1)
+			break
+		}
+	}
+	m.scrolled()
+}
@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// anchor records the conversation as it was when the user scrolled away from
+// the live tail, to tell how much arrived since
+type anchor struct {
+	messages int
+	lines    int
+}
+
+// follow keeps the viewport on the live tail. While the user reads earlier
+// output the viewport stays put and the new content is announced by a pill.
+func (m *messagesComponent) follow() {
+	if m.tail {
+		m.viewport.GotoBottom()
+	}
+}
+
+// scrolled updates whether the viewport follows the tail after it moved
+func (m *messagesComponent) scrolled() {
+	following := m.tail
+	m.tail = m.viewport.AtBottom()
+	if m.tail {
+		m.anchor = anchor{}
+	} else if following {
+		m.anchor = anchor{messages: len(m.app.Messages), lines: m.viewport.TotalLineCount()}
+	}
+}
+
+// jumpToTail scrolls back to the live tail and follows it again
+func (m *messagesComponent) jumpToTail() {
+	m.viewport.GotoBottom()
+	m.tail = true
+	m.anchor = anchor{}
+}
+
+// unseen returns how many messages arrived below the viewport since the user
+// scrolled away, output streamed into a message already there counts as one
+func (m *messagesComponent) unseen() int {
+	if m.tail || m.viewport.TotalLineCount() <= m.anchor.lines {
+		return 0
+	}
+	return max(len(m.app.Messages)-m.anchor.messages, 1)
+}
+
+// pill renders the jump to tail button and its column in the viewport
+func (m *messagesComponent) pill() (string, int) {
+	t := theme.CurrentTheme()
+	pill := styles.NewStyle().
+		Foreground(t.Background()).
+		Background(t.Primary()).
+		Bold(true).
+		Padding(0, 1).
+		Render(fmt.Sprintf("↓ %d new", m.unseen()))
+	return pill, (m.viewport.Width() - lipgloss.Width(pill)) / 2
+}
+
+// withPill draws the pill over the last line of the viewport when new
+// content arrived below it
+func (m *messagesComponent) withPill(view string) string {
+	if m.unseen() == 0 {
+		return view
+	}
+	pill, x := m.pill()
+	return layout.PlaceOverlay(x, m.viewport.Height()-1, pill, view)
+}
+
+// pillClicked reports whether a click at x on the last viewport line hit the
+// pill
+func (m *messagesComponent) pillClicked(x int) bool {
+	if m.unseen() == 0 {
+		return false
+	}
+	pill, start := m.pill()
+	return x >= start && x < start+lipgloss.Width(pill)
+}
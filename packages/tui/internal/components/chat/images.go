@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	imageutil "github.com/sst/opencode/internal/image"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// maxImageRows is the height images are drawn at most in the messages
+const maxImageRows = 16
+
+// inlineImages draws the images of the messages. With the kitty protocol
+// each image is sent once per size, the messages hold placeholders showing
+// it and the transmissions wait in pending until the next update writes
+// them, as the messages may render away from the update loop.
+type inlineImages struct {
+	mu      sync.Mutex
+	ids     map[string]int
+	next    int
+	pending []string
+}
+
+func newInlineImages() *inlineImages {
+	return &inlineImages{ids: map[string]int{}, next: 1}
+}
+
+// render renders an image file part of a message, as the image itself when
+// the terminal draws images inline and as its dimensions otherwise
+func (i *inlineImages) render(protocol imageutil.Protocol, message client.MessageInfo, file client.MessagePartFile) string {
+	t := theme.CurrentTheme()
+	options := []renderingOption{WithAlign(lipgloss.Left), WithBorderColor(t.Accent())}
+	if message.Role == client.User {
+		options = []renderingOption{WithAlign(lipgloss.Right), WithBorderColor(t.Secondary())}
+	}
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	img, err := decodeDataURL(file.Url)
+	if err != nil {
+		return renderContentBlock(muted.Render("▣ image · "+file.MediaType), options...)
+	}
+	size := img.Bounds().Size()
+	label := imageutil.Placeholder(size.X, size.Y, file.MediaType)
+	if file.Filename != nil && *file.Filename != "" {
+		label += " · " + *file.Filename
+	}
+	if protocol != imageutil.ProtocolKitty || screenReader {
+		return renderContentBlock(muted.Render(label), options...)
+	}
+
+	cols, rows := imageutil.Fit(size.X, size.Y, layout.Current.Container.Width-6, maxImageRows)
+	id, err := i.transmit(file.Url, img, cols, rows)
+	if err != nil {
+		return renderContentBlock(muted.Render(label), options...)
+	}
+	return renderContentBlock(imageutil.KittyPlaceholder(id, cols, rows)+"\n"+muted.Render(label), options...)
+}
+
+// transmit returns the id of an image sent at cols by rows cells, queueing
+// its transmission the first time
+func (i *inlineImages) transmit(url string, img image.Image, cols, rows int) (int, error) {
+	key := fmt.Sprintf("%x:%d:%d", sha256.Sum256([]byte(url)), cols, rows)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if id, ok := i.ids[key]; ok {
+		return id, nil
+	}
+	seq, err := imageutil.KittyTransmit(i.next, img, cols, rows)
+	if err != nil {
+		return 0, err
+	}
+	id := i.next
+	i.next++
+	i.ids[key] = id
+	i.pending = append(i.pending, seq)
+	return id, nil
+}
+
+// flush writes the transmissions queued since the last update
+func (i *inlineImages) flush() tea.Cmd {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.pending) == 0 {
+		return nil
+	}
+	seq := strings.Join(i.pending, "")
+	i.pending = nil
+	return tea.Raw(seq)
+}
+
+// decodeDataURL decodes the image of a base64 data URL
+func decodeDataURL(url string) (image.Image, error) {
+	_, data, ok := strings.Cut(url, ";base64,")
+	if !ok || !strings.HasPrefix(url, "data:") {
+		return nil, fmt.Errorf("not a base64 data URL")
+	}
+	content, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	return img, err
+}
@@ -0,0 +1,373 @@
+package chat
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// markdownSegment is a run of markdown lines, tables are rendered by
+// renderTable instead of glamour, which mangles wide tables
+type markdownSegment struct {
+	lines []string
+	table bool
+	code  bool
+}
+
+// codeBlocks is how code blocks wider than the chat column are laid out,
+// set by the messages component from the state
+var codeBlocks = struct {
+	wrap   string
+	offset int
+}{wrap: config.CodeWrapSoft}
+
+var (
+	tableSeparatorPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	inlineMarkupPattern   = regexp.MustCompile("\\*\\*|__|`")
+)
+
+// splitMarkdown separates GFM tables from the rest of the markdown, and
+// fenced code blocks too when splitCode is set
+func splitMarkdown(content string, splitCode bool) []markdownSegment {
+	lines := strings.Split(content, "\n")
+	segments := []markdownSegment{}
+	current := []string{}
+	fenced := false
+
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, markdownSegment{lines: current})
+			current = []string{}
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			if splitCode && fenced {
+				flush()
+			}
+			if splitCode && !fenced {
+				current = append(current, line)
+				segments = append(segments, markdownSegment{lines: current, code: true})
+				current = []string{}
+				continue
+			}
+		}
+		isTable := !fenced &&
+			strings.Contains(line, "|") &&
+			i+1 < len(lines) &&
+			strings.Contains(lines[i+1], "-") &&
+			tableSeparatorPattern.MatchString(lines[i+1])
+		if !isTable {
+			current = append(current, line)
+			continue
+		}
+
+		flush()
+		table := []string{line, lines[i+1]}
+		i += 2
+		for i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != "" {
+			table = append(table, lines[i])
+			i++
+		}
+		i--
+		segments = append(segments, markdownSegment{lines: table, table: true})
+	}
+	flush()
+	return segments
+}
+
+func parseTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	line = strings.ReplaceAll(line, `\|`, "\x00")
+	cells := []string{}
+	for cell := range strings.SplitSeq(line, "|") {
+		cell = strings.ReplaceAll(cell, "\x00", "|")
+		cell = inlineMarkupPattern.ReplaceAllString(strings.TrimSpace(cell), "")
+		cells = append(cells, renderMath(cell))
+	}
+	return cells
+}
+
+// renderTable lays out a markdown table with aligned columns, wrapping cells
+// so the table fits in width
+func renderTable(lines []string, width int, backgroundColor compat.AdaptiveColor) string {
+	t := theme.CurrentTheme()
+	header := parseTableRow(lines[0])
+	alignments := []lipgloss.Position{}
+	for _, spec := range parseTableRow(lines[1]) {
+		switch {
+		case strings.HasPrefix(spec, ":") && strings.HasSuffix(spec, ":"):
+			alignments = append(alignments, lipgloss.Center)
+		case strings.HasSuffix(spec, ":"):
+			alignments = append(alignments, lipgloss.Right)
+		default:
+			alignments = append(alignments, lipgloss.Left)
+		}
+	}
+	rows := [][]string{}
+	for _, line := range lines[2:] {
+		rows = append(rows, parseTableRow(line))
+	}
+
+	columns := len(header)
+	widths := make([]int, columns)
+	for _, row := range append([][]string{header}, rows...) {
+		for i := 0; i < columns && i < len(row); i++ {
+			widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+		}
+	}
+
+	// shrink the widest column until the table fits
+	available := width - 2 - (columns-1)*3
+	for {
+		total, widest := 0, 0
+		for i, w := range widths {
+			total += w
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if total <= available || widths[widest] <= 3 {
+			break
+		}
+		widths[widest]--
+	}
+
+	text := styles.NewStyle().Foreground(t.MarkdownText()).Background(backgroundColor)
+	heading := text.Foreground(t.MarkdownHeading()).Bold(true)
+	border := styles.NewStyle().Foreground(t.TextMuted()).Background(backgroundColor)
+	line := styles.NewStyle().Background(backgroundColor).Width(width)
+
+	renderRow := func(row []string, style styles.Style) []string {
+		cells := make([][]string, columns)
+		height := 1
+		for i := range columns {
+			value := ""
+			if i < len(row) {
+				value = row[i]
+			}
+			cells[i] = strings.Split(ansi.Wrap(value, widths[i], ""), "\n")
+			height = max(height, len(cells[i]))
+		}
+		rendered := []string{}
+		for l := range height {
+			parts := []string{}
+			for i, cell := range cells {
+				value := ""
+				if l < len(cell) {
+					value = cell[l]
+				}
+				align := lipgloss.Left
+				if i < len(alignments) {
+					align = alignments[i]
+				}
+				parts = append(parts, style.Width(widths[i]).Align(align).Render(value))
+			}
+			rendered = append(rendered, line.Render(" "+strings.Join(parts, border.Render(" │ "))))
+		}
+		return rendered
+	}
+
+	output := renderRow(header, heading)
+	separators := []string{}
+	for _, w := range widths {
+		separators = append(separators, strings.Repeat("─", w))
+	}
+	output = append(output, line.Render(border.Render(" "+strings.Join(separators, "─┼─"))))
+	for _, row := range rows {
+		output = append(output, renderRow(row, text)...)
+	}
+	return strings.Join(output, "\n")
+}
+
+var (
+	blockMathPattern  = regexp.MustCompile(`(?s)\$\$(.+?)\$\$|\\\[(.+?)\\\]`)
+	inlineMathPattern = regexp.MustCompile(`\$([^\s$](?:[^$\n]*[^\s$])?)\$|\\\((.+?)\\\)`)
+	mathCommand       = regexp.MustCompile(`\\([a-zA-Z]+)`)
+	mathFrac          = regexp.MustCompile(`\\frac\{([^{}]*)\}\{([^{}]*)\}`)
+	mathSqrt          = regexp.MustCompile(`\\sqrt\{([^{}]*)\}`)
+	mathText          = regexp.MustCompile(`\\(?:text|mathrm|mathbf|mathit|operatorname)\{([^{}]*)\}`)
+	mathScript        = regexp.MustCompile(`([\^_])(\{[^{}]*\}|.)`)
+)
+
+var mathSymbols = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ε", "zeta": "ζ",
+	"eta": "η", "theta": "θ", "iota": "ι", "kappa": "κ", "lambda": "λ", "mu": "μ",
+	"nu": "ν", "xi": "ξ", "pi": "π", "rho": "ρ", "sigma": "σ", "tau": "τ",
+	"upsilon": "υ", "phi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠", "ne": "≠",
+	"approx": "≈", "equiv": "≡", "sim": "∼", "propto": "∝",
+	"times": "×", "cdot": "·", "div": "÷", "pm": "±", "mp": "∓",
+	"infty": "∞", "partial": "∂", "nabla": "∇", "sum": "∑", "prod": "∏", "int": "∫",
+	"in": "∈", "notin": "∉", "subset": "⊂", "subseteq": "⊆", "cup": "∪", "cap": "∩",
+	"forall": "∀", "exists": "∃", "emptyset": "∅", "neg": "¬", "land": "∧", "lor": "∨",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "Rightarrow": "⇒", "Leftarrow": "⇐",
+	"leftrightarrow": "↔", "iff": "⇔", "mapsto": "↦",
+	"ldots": "…", "cdots": "⋯", "dots": "…", "circ": "∘", "degree": "°",
+	"left": "", "right": "", "quad": "  ", "qquad": "    ",
+}
+
+var superscripts = map[rune]rune{
+	'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴', '5': '⁵', '6': '⁶', '7': '⁷', '8': '⁸', '9': '⁹',
+	'+': '⁺', '-': '⁻', '=': '⁼', '(': '⁽', ')': '⁾', 'n': 'ⁿ', 'i': 'ⁱ', 'x': 'ˣ', 'y': 'ʸ',
+	'a': 'ᵃ', 'b': 'ᵇ', 'c': 'ᶜ', 'd': 'ᵈ', 'e': 'ᵉ', 'k': 'ᵏ', 'm': 'ᵐ', 't': 'ᵗ', 'T': 'ᵀ',
+}
+
+var subscripts = map[rune]rune{
+	'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄', '5': '₅', '6': '₆', '7': '₇', '8': '₈', '9': '₉',
+	'+': '₊', '-': '₋', '=': '₌', '(': '₍', ')': '₎', 'a': 'ₐ', 'e': 'ₑ', 'i': 'ᵢ', 'j': 'ⱼ',
+	'k': 'ₖ', 'n': 'ₙ', 'o': 'ₒ', 'x': 'ₓ', 't': 'ₜ', 'm': 'ₘ', 'p': 'ₚ', 's': 'ₛ',
+}
+
+// renderMath replaces LaTeX math in text with unicode approximations
+func renderMath(text string) string {
+	text = blockMathPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := blockMathPattern.FindStringSubmatch(match)
+		return latexToUnicode(groups[1] + groups[2])
+	})
+	return inlineMathPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := inlineMathPattern.FindStringSubmatch(match)
+		expr := groups[1] + groups[2]
+		// avoid treating prices like "$5 and $10" as math
+		if groups[1] != "" && !strings.ContainsAny(expr, `\^_=`) {
+			return match
+		}
+		return latexToUnicode(expr)
+	})
+}
+
+func latexToUnicode(expr string) string {
+	expr = strings.TrimSpace(expr)
+	expr = mathText.ReplaceAllString(expr, "$1")
+	for mathFrac.MatchString(expr) {
+		expr = mathFrac.ReplaceAllStringFunc(expr, func(match string) string {
+			groups := mathFrac.FindStringSubmatch(match)
+			return wrapMathGroup(groups[1]) + "/" + wrapMathGroup(groups[2])
+		})
+	}
+	expr = mathSqrt.ReplaceAllStringFunc(expr, func(match string) string {
+		return "√" + wrapMathGroup(mathSqrt.FindStringSubmatch(match)[1])
+	})
+	expr = mathCommand.ReplaceAllStringFunc(expr, func(match string) string {
+		if symbol, ok := mathSymbols[match[1:]]; ok {
+			return symbol
+		}
+		return match
+	})
+	expr = mathScript.ReplaceAllStringFunc(expr, func(match string) string {
+		table := superscripts
+		if match[0] == '_' {
+			table = subscripts
+		}
+		value := strings.TrimSuffix(strings.TrimPrefix(match[1:], "{"), "}")
+		converted := []rune{}
+		for _, r := range value {
+			mapped, ok := table[r]
+			if !ok {
+				// fall back to the plain notation if any rune has no script form
+				return match[:1] + wrapMathGroup(value)
+			}
+			converted = append(converted, mapped)
+		}
+		return string(converted)
+	})
+	expr = strings.NewReplacer("{", "", "}", "", `\,`, " ", `\;`, " ", `\!`, "", `\\`, "\n").Replace(expr)
+	return expr
+}
+
+func wrapMathGroup(value string) string {
+	if len([]rune(value)) <= 1 {
+		return value
+	}
+	return "(" + value + ")"
+}
+
+// renderMarkdownMath converts math in markdown prose, skipping fenced code
+// blocks and inline code spans
+func renderMarkdownMath(content string) string {
+	lines := strings.Split(content, "\n")
+	output := []string{}
+	prose := []string{}
+	fenced := false
+
+	flush := func() {
+		if len(prose) == 0 {
+			return
+		}
+		parts := strings.Split(strings.Join(prose, "\n"), "`")
+		for i := 0; i < len(parts); i += 2 {
+			parts[i] = renderMath(parts[i])
+		}
+		output = append(output, strings.Join(parts, "`"))
+		prose = []string{}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		fence := strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+		if fence || fenced {
+			flush()
+			output = append(output, line)
+			if fence {
+				fenced = !fenced
+			}
+			continue
+		}
+		prose = append(prose, line)
+	}
+	flush()
+	return strings.Join(output, "\n")
+}
+
+// hyperlinks marks the URLs of rendered text as OSC 8 hyperlinks, which the
+// terminals supporting them make clickable. A URL wrapped over lines links
+// its first line only.
+func hyperlinks(rendered string) string {
+	return app.LinkPattern.ReplaceAllStringFunc(rendered, func(match string) string {
+		link := app.TrimLink(match)
+		return ansi.SetHyperlink(link) + link + ansi.ResetHyperlink() + match[len(link):]
+	})
+}
+
+// renderCodeBlock renders a fenced code block without letting glamour wrap
+// it, then hard wraps or cuts its lines to width depending on codeBlocks
+func renderCodeBlock(lines []string, width int, backgroundColor compat.AdaptiveColor) string {
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, ansi.StringWidth(line))
+	}
+	r := styles.GetMarkdownRenderer(max(width, longest+4), backgroundColor)
+	rendered, _ := r.Render(strings.Join(lines, "\n"))
+	rendered = trimBlankLines(rendered)
+
+	pad := styles.NewStyle().Background(backgroundColor).Width(width)
+	result := []string{}
+	for line := range strings.SplitSeq(rendered, "\n") {
+		// drop the padding glamour adds up to the render width
+		line = ansi.Truncate(line, ansi.StringWidth(strings.TrimRight(ansi.Strip(line), " ")), "")
+		switch codeBlocks.wrap {
+		case config.CodeWrapHard:
+			for part := range strings.SplitSeq(ansi.Hardwrap(line, width, true), "\n") {
+				result = append(result, pad.Render(part))
+			}
+		default:
+			result = append(result, pad.Render(ansi.Cut(line, codeBlocks.offset, codeBlocks.offset+width)))
+		}
+	}
+	return strings.Join(result, "\n")
+}
@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/charmbracelet/lipgloss/v2"
@@ -14,9 +13,11 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/sst/opencode/internal/app"
 	"github.com/sst/opencode/internal/components/diff"
+	"github.com/sst/opencode/internal/config"
 	"github.com/sst/opencode/internal/layout"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
 	"github.com/sst/opencode/pkg/client"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -25,7 +26,40 @@ import (
 func toMarkdown(content string, width int, backgroundColor compat.AdaptiveColor) string {
 	r := styles.GetMarkdownRenderer(width, backgroundColor)
 	content = strings.ReplaceAll(content, app.RootPath+"/", "")
-	rendered, _ := r.Render(content)
+
+	blocks := []string{}
+	for _, segment := range splitMarkdown(content, codeBlocks.wrap != config.CodeWrapSoft) {
+		if segment.table {
+			blocks = append(blocks, renderTable(segment.lines, width, backgroundColor))
+			continue
+		}
+		if segment.code {
+			blocks = append(blocks, renderCodeBlock(segment.lines, width, backgroundColor))
+			continue
+		}
+		rendered, _ := r.Render(renderMarkdownMath(strings.Join(segment.lines, "\n")))
+		if rendered = wrapWide(trimBlankLines(rendered), width); rendered != "" {
+			blocks = append(blocks, rendered)
+		}
+	}
+	blank := styles.NewStyle().Background(backgroundColor).Width(width).Render("")
+	return strings.Join(blocks, "\n"+blank+"\n")
+}
+
+// wrapWide breaks the lines glamour left wider than width, which it does for
+// text without spaces to wrap at such as CJK prose
+func wrapWide(rendered string, width int) string {
+	lines := strings.Split(rendered, "\n")
+	for i, line := range lines {
+		if ansi.StringWidth(line) > width {
+			lines[i] = ansi.Wrap(line, width, "")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trimBlankLines drops the empty margin lines glamour adds around a document
+func trimBlankLines(rendered string) string {
 	lines := strings.Split(rendered, "\n")
 
 	if len(lines) > 0 {
@@ -44,7 +78,7 @@ func toMarkdown(content string, width int, backgroundColor compat.AdaptiveColor)
 			}
 		}
 	}
-	content = strings.Join(lines, "\n")
+	content := strings.Join(lines, "\n")
 	return strings.TrimSuffix(content, "\n")
 }
 
@@ -116,6 +150,10 @@ func WithPaddingBottom(padding int) renderingOption {
 	}
 }
 
+// screenReader renders messages as a linear transcript without borders,
+// alignment or spinners, see config.State.ScreenReader
+var screenReader bool
+
 func renderContentBlock(content string, options ...renderingOption) string {
 	t := theme.CurrentTheme()
 	renderer := &blockRenderer{
@@ -128,6 +166,11 @@ func renderContentBlock(content string, options ...renderingOption) string {
 	for _, option := range options {
 		option(renderer)
 	}
+	if screenReader {
+		renderer.paddingTop, renderer.paddingBottom = 0, 0
+		renderer.paddingLeft, renderer.paddingRight = 0, 0
+		renderer.align = nil
+	}
 
 	style := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).
 		// MarginTop(renderer.marginTop).
@@ -135,8 +178,10 @@ func renderContentBlock(content string, options ...renderingOption) string {
 		PaddingTop(renderer.paddingTop).
 		PaddingBottom(renderer.paddingBottom).
 		PaddingLeft(renderer.paddingLeft).
-		PaddingRight(renderer.paddingRight).
-		BorderStyle(lipgloss.ThickBorder())
+		PaddingRight(renderer.paddingRight)
+	if !screenReader {
+		style = style.BorderStyle(lipgloss.ThickBorder())
+	}
 
 	align := lipgloss.Left
 	if renderer.align != nil {
@@ -148,8 +193,9 @@ func renderContentBlock(content string, options ...renderingOption) string {
 		borderColor = *renderer.borderColor
 	}
 
-	switch align {
-	case lipgloss.Left:
+	switch {
+	case screenReader:
+	case align == lipgloss.Left:
 		style = style.
 			BorderLeft(true).
 			BorderRight(true).
@@ -158,7 +204,7 @@ func renderContentBlock(content string, options ...renderingOption) string {
 			BorderLeftBackground(t.Background()).
 			BorderRightForeground(t.BackgroundPanel()).
 			BorderRightBackground(t.Background())
-	case lipgloss.Right:
+	case align == lipgloss.Right:
 		style = style.
 			BorderRight(true).
 			BorderLeft(true).
@@ -209,17 +255,30 @@ func calculatePadding() int {
 	}
 }
 
-func renderText(message client.MessageInfo, text string, author string) string {
+func renderText(message client.MessageInfo, text string, author string, timestamp string, metrics string, labels string, truncated bool, density string) string {
 	t := theme.CurrentTheme()
 	width := layout.Current.Container.Width
 	padding := calculatePadding()
 
-	timestamp := time.UnixMilli(int64(message.Metadata.Time.Created)).Local().Format("02 Jan 2006 03:04 PM")
-	if time.Now().Format("02 Jan 2006") == timestamp[:11] {
-		// don't show the date if it's today
-		timestamp = timestamp[12:]
-	}
 	info := fmt.Sprintf("%s (%s)", author, timestamp)
+	if density == config.DensityVerbose {
+		info = renderVerboseInfo(message, author, timestamp)
+	}
+	if duration := turnDuration(message); duration != "" {
+		info += " · " + duration
+	}
+	if metrics != "" {
+		info += " · " + metrics
+	}
+	if truncated {
+		info += " [truncated]"
+	}
+	if labels != "" {
+		info += " " + labels
+	}
+	if screenReader {
+		return renderTranscriptText(message, text, info)
+	}
 
 	textWidth := max(lipgloss.Width(text), lipgloss.Width(info))
 	markdownWidth := min(textWidth, width-padding-4) // -4 for the border and padding
@@ -238,7 +297,10 @@ func renderText(message client.MessageInfo, text string, author string) string {
 	if message.Role == client.Assistant {
 		content = toMarkdown(text, markdownWidth, t.BackgroundPanel())
 	}
-	content = strings.Join([]string{content, info}, "\n")
+	content = hyperlinks(content)
+	if density != config.DensityCompact {
+		content = strings.Join([]string{content, info}, "\n")
+	}
 
 	switch message.Role {
 	case client.User:
@@ -255,11 +317,49 @@ func renderText(message client.MessageInfo, text string, author string) string {
 	return ""
 }
 
+// renderTranscriptText renders a message for screen readers, starting with
+// who wrote it
+func renderTranscriptText(message client.MessageInfo, text string, info string) string {
+	t := theme.CurrentTheme()
+	width := layout.Current.Container.Width
+	role := "You"
+	if message.Role == client.Assistant {
+		role = "Assistant"
+		text = toMarkdown(text, width, t.BackgroundPanel())
+	} else {
+		text = styles.NewStyle().Width(width).Background(t.BackgroundPanel()).Foreground(t.Text()).Render(text)
+	}
+	header := styles.NewStyle().Width(width).Background(t.BackgroundPanel()).Foreground(t.Text()).Bold(true).
+		Render(role + " said, " + info + ":")
+	return renderContentBlock(header + "\n" + text)
+}
+
+// renderVerboseInfo renders the model, token counts and cost of a message
+// next to its full timestamp
+func renderVerboseInfo(message client.MessageInfo, author string, timestamp string) string {
+	info := fmt.Sprintf("%s (%s)", author, timestamp)
+	assistant := message.Metadata.Assistant
+	if message.Role != client.Assistant || assistant == nil {
+		return info
+	}
+	tokens := assistant.Tokens
+	return fmt.Sprintf("%s/%s (%s) · %s in · %s out · %s cached · $%.4f",
+		assistant.ProviderID,
+		assistant.ModelID,
+		timestamp,
+		util.FormatTokens(tokens.Input),
+		util.FormatTokens(tokens.Output),
+		util.FormatTokens(tokens.Cache.Read),
+		assistant.Cost,
+	)
+}
+
 func renderToolInvocation(
 	toolCall client.MessageToolInvocationToolCall,
 	result *string,
 	metadata client.MessageMetadata_Tool_AdditionalProperties,
 	showDetails bool,
+	pages int,
 	isLast bool,
 	contentOnly bool,
 ) string {
@@ -415,7 +515,7 @@ func renderToolInvocation(
 		if filename, ok := toolArgsMap["filePath"].(string); ok {
 			title = fmt.Sprintf("WRITE %s", relative(filename))
 			if content, ok := toolArgsMap["content"].(string); ok {
-				body = renderFile(filename, content)
+				body = renderFile(filename, pageOutput(content, pages))
 
 				// Add diagnostics at the bottom if they exist
 				if diagnostics := renderDiagnostics(metadata, filename); diagnostics != "" {
@@ -429,7 +529,7 @@ func renderToolInvocation(
 		}
 		if stdout, ok := metadata.Get("stdout"); ok {
 			command := toolArgsMap["command"].(string)
-			stdout := stdout.(string)
+			stdout := pageOutput(stdout.(string), pages)
 			body = fmt.Sprintf("```console\n> %s\n%s```", command, stdout)
 			body = toMarkdown(body, innerWidth, t.BackgroundPanel())
 			body = renderContentBlock(body, WithFullWidth(), WithMarginBottom(1))
@@ -439,8 +539,7 @@ func renderToolInvocation(
 		title = fmt.Sprintf("FETCH %s", toolArgs)
 		if format, ok := toolArgsMap["format"].(string); ok {
 			if result != nil {
-				body = *result
-				body = truncateHeight(body, 10)
+				body = pageOutput(*result, pages)
 				if format == "html" || format == "markdown" {
 					body = toMarkdown(body, innerWidth, t.BackgroundPanel())
 				}
@@ -492,6 +591,7 @@ func renderToolInvocation(
 								nil,
 								toolMetadata,
 								false,
+								0,
 								false,
 								true,
 							)
@@ -511,8 +611,7 @@ func renderToolInvocation(
 			empty := ""
 			result = &empty
 		}
-		body = *result
-		body = truncateHeight(body, 10)
+		body = pageOutput(*result, pages)
 		body = renderContentBlock(body, WithFullWidth(), WithMarginBottom(1))
 	}
 
@@ -538,8 +637,7 @@ func renderToolInvocation(
 	}
 
 	if body == "" && error == "" {
-		body = *result
-		body = truncateHeight(body, 10)
+		body = pageOutput(*result, pages)
 		body = renderContentBlock(body, WithFullWidth(), WithMarginBottom(1))
 	}
 
@@ -1,7 +1,6 @@
 package chat
 
 import (
-	"slices"
 	"strings"
 	"time"
 
@@ -10,9 +9,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/bus"
 	"github.com/sst/opencode/internal/components/commands"
 	"github.com/sst/opencode/internal/components/dialog"
+	"github.com/sst/opencode/internal/config"
 	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/logging"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
 	"github.com/sst/opencode/pkg/client"
@@ -30,6 +32,15 @@ type MessagesComponent interface {
 	// Previous() (tea.Model, tea.Cmd)
 	// Next() (tea.Model, tea.Cmd)
 	ToolDetailsVisible() bool
+	HandleSearchKey(msg tea.KeyPressMsg) (bool, tea.Cmd)
+	HandleReplayKey(msg tea.KeyPressMsg) (bool, tea.Cmd)
+	HandleNavigationKey(msg tea.KeyPressMsg) (bool, tea.Cmd)
+	StartNavigation() bool
+	FileReference() (app.FileReference, bool)
+	Links() []string
+	SelectedMessage() (client.MessageInfo, bool)
+	ShowMore() bool
+	PagedOutput() (string, string, bool)
 }
 
 type messagesComponent struct {
@@ -43,26 +54,57 @@ type messagesComponent struct {
 	rendering       bool
 	showToolDetails bool
 	tail            bool
+	anchor          anchor
+	tools           *toolTimer
+	ticking         bool
+	clockTicking    bool
+	search          *viewportSearch
+	replay          *sessionReplay
+	lines           []string
+	regions         []messageRegion
+	selected        string
+	navigating      bool
+	expanded        map[string]bool
+	// pages counts the chunks of long tool outputs loaded with show more
+	pages  map[string]int
+	images *inlineImages
 }
 type renderFinishedMsg struct{}
 type ToggleToolDetailsMsg struct{}
+type DensityChangedMsg struct{}
+
+// slowRender is the render time from which renders are logged
+const slowRender = 50 * time.Millisecond
+
+var renderer = logging.For(logging.Renderer)
+
+// LayoutChangedMsg re-renders the messages after the content width, the
+// code block wrapping or the screen reader mode changed
+type LayoutChangedMsg struct{}
+
+// ScrollCodeMsg scrolls code blocks horizontally when they are not wrapped
+type ScrollCodeMsg struct {
+	Offset int
+}
 
 func (m *messagesComponent) Init() tea.Cmd {
-	return tea.Batch(m.viewport.Init(), m.spinner.Tick, m.commands.Init())
+	cmds := []tea.Cmd{m.viewport.Init(), m.spinner.Tick, m.commands.Init()}
+	if m.app.State.RelativeTimestamps {
+		m.clockTicking = true
+		cmds = append(cmds, tickTimestamps())
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	switch msg.(type) {
 	case app.SendMsg:
-		m.viewport.GotoBottom()
-		m.tail = true
+		m.jumpToTail()
 		return m, nil
 	case app.OptimisticMessageAddedMsg:
 		m.renderView()
-		if m.tail {
-			m.viewport.GotoBottom()
-		}
+		m.follow()
 		return m, nil
 	case dialog.ThemeSelectedMsg:
 		m.cache.Clear()
@@ -70,29 +112,82 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ToggleToolDetailsMsg:
 		m.showToolDetails = !m.showToolDetails
 		return m, m.Reload()
-	case app.SessionSelectedMsg:
+	case DensityChangedMsg:
+		return m, m.Reload()
+	case ReasoningChangedMsg:
+		// the blocks toggled by hand follow the new mode again
+		for key := range m.expanded {
+			if strings.HasPrefix(key, "reasoning:") {
+				delete(m.expanded, key)
+			}
+		}
+		return m, m.Reload()
+	case LayoutChangedMsg:
+		m.applyLayout()
 		m.cache.Clear()
-		m.tail = true
 		return m, m.Reload()
-	case app.SessionClearedMsg:
+	case ScrollCodeMsg:
+		if codeBlocks.wrap != config.CodeWrapScroll {
+			return m, nil
+		}
+		codeBlocks.offset = max(codeBlocks.offset+msg.(ScrollCodeMsg).Offset, 0)
+		m.cache.Clear()
+		m.renderView()
+		return m, nil
+	case ToggleTimestampsMsg:
+		var cmd tea.Cmd
+		if m.app.State.RelativeTimestamps && !m.clockTicking {
+			m.clockTicking = true
+			cmd = tickTimestamps()
+		}
+		return m, tea.Batch(m.Reload(), cmd)
+	case timestampTickMsg:
+		if !m.app.State.RelativeTimestamps {
+			m.clockTicking = false
+			return m, nil
+		}
+		m.renderView()
+		m.follow()
+		return m, tickTimestamps()
+	case OpenSearchMsg:
+		return m, m.openSearch()
+	case OpenReplayMsg:
+		m.openReplay()
+		return m, nil
+	case JumpToRatedMsg:
+		m.jumpToRated(msg.(JumpToRatedMsg).Feedback)
+		return m, nil
+	case tea.MouseClickMsg:
+		m.handleClick(msg.(tea.MouseClickMsg))
+		return m, nil
+	case app.ComparisonClearedMsg:
 		m.cache.Clear()
 		cmd := m.Reload()
 		return m, cmd
 	case renderFinishedMsg:
 		m.rendering = false
-		if m.tail {
-			m.viewport.GotoBottom()
+		m.follow()
+	case client.EventSessionUpdated, client.EventMessageUpdated, app.MessagesReconciledMsg,
+		app.SendRetryMsg, app.SendFailedMsg:
+		m.renderView()
+		m.follow()
+		if m.tools.running() && !m.ticking {
+			m.ticking = true
+			cmds = append(cmds, tickToolProgress())
 		}
-	case client.EventSessionUpdated, client.EventMessageUpdated:
+	case toolProgressTickMsg:
 		m.renderView()
-		if m.tail {
-			m.viewport.GotoBottom()
+		m.follow()
+		if !m.tools.running() {
+			m.ticking = false
+			return m, nil
 		}
+		return m, tickToolProgress()
 	}
 
 	viewport, cmd := m.viewport.Update(msg)
 	m.viewport = viewport
-	m.tail = m.viewport.AtBottom()
+	m.scrolled()
 	cmds = append(cmds, cmd)
 
 	spinner, cmd := m.spinner.Update(msg)
@@ -101,7 +196,7 @@ func (m *messagesComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	updated, cmd := m.commands.Update(msg)
 	m.commands = updated.(commands.CommandsComponent)
-	cmds = append(cmds, cmd)
+	cmds = append(cmds, cmd, m.images.flush())
 
 	return m, tea.Batch(cmds...)
 }
@@ -120,108 +215,100 @@ func (m *messagesComponent) renderView() {
 	if m.width == 0 {
 		return
 	}
+	started := time.Now()
+	defer func() {
+		if elapsed := time.Since(started); elapsed > slowRender {
+			renderer.Debug("Slow render", "messages", len(m.app.Messages), "duration", elapsed)
+		}
+	}()
+
+	if m.app.IsComparing() {
+		m.viewport.SetHeight(m.height)
+		m.viewport.SetContent("\n" + m.renderComparison() + "\n")
+		m.regions = nil
+		return
+	}
 
 	t := theme.CurrentTheme()
 	blocks := make([]string, 0)
+	owners := make([]messageRegion, 0)
+	addBlock := func(content string, owner messageRegion) {
+		blocks = append(blocks, content)
+		owners = append(owners, owner)
+	}
 	previousBlockType := none
-	for _, message := range m.app.Messages {
-		var content string
-		var cached bool
-		lastToolIndex := 0
-		lastToolIndices := []int{}
-		for i, p := range message.Parts {
-			part, _ := p.ValueByDiscriminator()
-			switch part.(type) {
-			case client.MessagePartText:
-				lastToolIndices = append(lastToolIndices, lastToolIndex)
-			case client.MessagePartToolInvocation:
-				lastToolIndex = i
-			}
+	inFlight := []string{}
+	density := m.app.State.Density
+	messages := m.replayed()
+	visible, summaries := summarized(messages, m.expanded, m.app.State.PinnedMessages)
+	for index, message := range messages {
+		if !visible[index] {
+			continue
 		}
-
 		author := ""
 		switch message.Role {
 		case client.User:
 			author = m.app.Info.User
 		case client.Assistant:
 			author = message.Metadata.Assistant.ModelID
+			if from, ok := m.app.FallbackFrom(message.Id); ok {
+				author += " (fallback for " + from + ")"
+			}
 		}
 
-		for i, p := range message.Parts {
-			part, err := p.ValueByDiscriminator()
-			if err != nil {
-				continue //TODO: handle error?
+		// Stand in for the messages a summary replaced, which are only
+		// rendered once expanded
+		if count := summaries[message.Id]; count > 0 {
+			key := summaryKey(message.Id)
+			if previousBlockType != none {
+				addBlock("", messageRegion{})
 			}
+			addBlock(renderSummaryHeader(count, m.expanded[key]), messageRegion{
+				messageID:  message.Id,
+				toolCallID: key,
+				details:    m.expanded[key],
+			})
+			previousBlockType = toolInvocationBlock
+		}
 
-			switch part.(type) {
-			// case client.MessagePartStepStart:
-			// 	messages = append(messages, "")
-			case client.MessagePartText:
-				text := part.(client.MessagePartText)
-				key := m.cache.GenerateKey(message.Id, text.Text, layout.Current.Viewport.Width)
-				content, cached = m.cache.Get(key)
-				if !cached {
-					content = renderText(message, text.Text, author)
-					m.cache.Set(key, content)
-				}
-				if previousBlockType != none {
-					blocks = append(blocks, "")
-				}
-				blocks = append(blocks, content)
-				if message.Role == client.User {
-					previousBlockType = userTextBlock
-				} else if message.Role == client.Assistant {
-					previousBlockType = assistantTextBlock
-				}
-			case client.MessagePartToolInvocation:
-				isLastToolInvocation := slices.Contains(lastToolIndices, i)
-				toolInvocationPart := part.(client.MessagePartToolInvocation)
-				toolCall, _ := toolInvocationPart.ToolInvocation.AsMessageToolInvocationToolCall()
-				metadata := client.MessageMetadata_Tool_AdditionalProperties{}
-				if _, ok := message.Metadata.Tool[toolCall.ToolCallId]; ok {
-					metadata = message.Metadata.Tool[toolCall.ToolCallId]
-				}
-				var result *string
-				resultPart, resultError := toolInvocationPart.ToolInvocation.AsMessageToolInvocationToolResult()
-				if resultError == nil {
-					result = &resultPart.Result
-				}
+		if key := collapseKey(message.Id); m.expanded[key] {
+			if previousBlockType != none {
+				addBlock("", messageRegion{})
+			}
+			addBlock(renderCollapsed(message, author), messageRegion{
+				messageID:  message.Id,
+				toolCallID: key,
+				details:    true,
+			})
+			previousBlockType = toolInvocationBlock
+			continue
+		}
 
-				if toolCall.State == "result" {
-					key := m.cache.GenerateKey(message.Id,
-						toolCall.ToolCallId,
-						m.showToolDetails,
-						layout.Current.Viewport.Width,
-					)
-					content, cached = m.cache.Get(key)
-					if !cached {
-						content = renderToolInvocation(
-							toolCall,
-							result,
-							metadata,
-							m.showToolDetails,
-							isLastToolInvocation,
-							false,
-						)
-						m.cache.Set(key, content)
-					}
-				} else {
-					// if the tool call isn't finished, don't cache
-					content = renderToolInvocation(
-						toolCall,
-						result,
-						metadata,
-						m.showToolDetails,
-						isLastToolInvocation,
-						false,
-					)
+		ctx := &PartContext{
+			Message:   message,
+			Author:    author,
+			Density:   density,
+			Width:     layout.Current.Viewport.Width,
+			m:         m,
+			lastTools: lastToolIndexes(message),
+			inFlight:  &inFlight,
+		}
+		for i, p := range message.Parts {
+			ctx.Index, ctx.previous = i, previousBlockType
+			for _, block := range renderPart(ctx, p) {
+				if block.Gap {
+					addBlock("", messageRegion{})
 				}
-
-				if previousBlockType != toolInvocationBlock && m.showToolDetails {
-					blocks = append(blocks, "")
+				addBlock(block.Content, messageRegion{
+					messageID:  message.Id,
+					toolCallID: block.Key,
+					details:    block.Expanded,
+				})
+				previousBlockType = block.kind
+				if block.kind == none {
+					previousBlockType = assistantTextBlock
 				}
-				blocks = append(blocks, content)
-				previousBlockType = toolInvocationBlock
+				ctx.previous = previousBlockType
 			}
 		}
 
@@ -233,24 +320,81 @@ func (m *messagesComponent) renderView() {
 				clientError := errorValue.(client.UnknownError)
 				error = clientError.Data.Message
 				error = renderContentBlock(error, WithBorderColor(t.Error()), WithFullWidth(), WithMarginTop(1), WithMarginBottom(1))
-				blocks = append(blocks, error)
+				addBlock(error, messageRegion{messageID: message.Id})
 				previousBlockType = errorBlock
 			}
 		}
+
+		// Sum up the files a finished turn changed below it
+		if message.Role == client.Assistant && message.Metadata.Time.Completed != nil {
+			if stats := diffStat(message); len(stats) > 0 {
+				key := diffStatKey(message.Id, "")
+				expanded := m.showToolDetails && density != config.DensityCompact
+				if value, ok := m.expanded[key]; ok {
+					expanded = value
+				}
+				addBlock("", messageRegion{})
+				addBlock(renderDiffStat(stats, expanded), messageRegion{
+					messageID:  message.Id,
+					toolCallID: key,
+					details:    expanded,
+				})
+				for i := 0; expanded && i < len(stats); i++ {
+					key := diffStatKey(message.Id, stats[i].path)
+					addBlock(renderFileStat(stats[i], m.expanded[key]), messageRegion{
+						messageID:  message.Id,
+						toolCallID: key,
+						details:    m.expanded[key],
+					})
+				}
+				previousBlockType = toolInvocationBlock
+			}
+		}
 	}
 
+	m.tools.retain(inFlight)
+
 	centered := []string{}
-	for _, block := range blocks {
-		centered = append(centered, lipgloss.PlaceHorizontal(
+	m.regions = []messageRegion{}
+	line := 1
+	for i, block := range blocks {
+		placed := lipgloss.PlaceHorizontal(
 			m.width,
 			lipgloss.Center,
 			block,
 			styles.WhitespaceStyle(t.Background()),
-		))
+		)
+		if owners[i].messageID != "" && owners[i].messageID == m.selected {
+			placed = markSelected(placed, (m.width-lipgloss.Width(block))/2)
+		}
+		centered = append(centered, placed)
+
+		region := owners[i]
+		region.start = line
+		line += lipgloss.Height(placed)
+		region.end = line
+		region.more = -1
+		if region.toolCallID != "" && region.details {
+			region.more = moreLine(placed)
+		}
+		if region.messageID != "" {
+			m.regions = append(m.regions, region)
+		}
 	}
 
-	m.viewport.SetHeight(m.height - lipgloss.Height(m.header()))
-	m.viewport.SetContent("\n" + strings.Join(centered, "\n") + "\n")
+	height := m.height - lipgloss.Height(m.header())
+	if m.search != nil {
+		height--
+	}
+	if m.replay != nil {
+		height--
+	}
+	if m.navigating {
+		height--
+	}
+	m.viewport.SetHeight(height)
+	m.lines = strings.Split("\n"+strings.Join(centered, "\n")+"\n", "\n")
+	m.applySearch()
 }
 
 func (m *messagesComponent) header() string {
@@ -287,6 +431,9 @@ func (m *messagesComponent) header() string {
 }
 
 func (m *messagesComponent) View() string {
+	if m.app.IsComparing() {
+		return m.viewport.View()
+	}
 	if len(m.app.Messages) == 0 {
 		return m.home()
 	}
@@ -300,16 +447,25 @@ func (m *messagesComponent) View() string {
 		)
 	}
 	t := theme.CurrentTheme()
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
+	views := []string{
 		lipgloss.PlaceHorizontal(
 			m.width,
 			lipgloss.Center,
 			m.header(),
 			styles.WhitespaceStyle(t.Background()),
 		),
-		m.viewport.View(),
-	)
+		m.withPill(m.viewport.View()),
+	}
+	if m.search != nil {
+		views = append(views, m.search.View(m.width))
+	}
+	if m.replay != nil && len(m.replay.steps) > 0 {
+		views = append(views, m.replay.View(m.app.Messages, m.width))
+	}
+	if m.navigating {
+		views = append(views, m.navigationBar())
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, views...)
 }
 
 func (m *messagesComponent) home() string {
@@ -409,33 +565,36 @@ func (m *messagesComponent) Reload() tea.Cmd {
 
 func (m *messagesComponent) PageUp() (tea.Model, tea.Cmd) {
 	m.viewport.ViewUp()
+	m.scrolled()
 	return m, nil
 }
 
 func (m *messagesComponent) PageDown() (tea.Model, tea.Cmd) {
 	m.viewport.ViewDown()
+	m.scrolled()
 	return m, nil
 }
 
 func (m *messagesComponent) HalfPageUp() (tea.Model, tea.Cmd) {
 	m.viewport.HalfViewUp()
+	m.scrolled()
 	return m, nil
 }
 
 func (m *messagesComponent) HalfPageDown() (tea.Model, tea.Cmd) {
 	m.viewport.HalfViewDown()
+	m.scrolled()
 	return m, nil
 }
 
 func (m *messagesComponent) First() (tea.Model, tea.Cmd) {
 	m.viewport.GotoTop()
-	m.tail = false
+	m.scrolled()
 	return m, nil
 }
 
 func (m *messagesComponent) Last() (tea.Model, tea.Cmd) {
-	m.viewport.GotoBottom()
-	m.tail = true
+	m.jumpToTail()
 	return m, nil
 }
 
@@ -443,6 +602,106 @@ func (m *messagesComponent) ToolDetailsVisible() bool {
 	return m.showToolDetails
 }
 
+// toolFiles lists the files the tools of a message read or changed
+func toolFiles(message client.MessageInfo) string {
+	files := []string{}
+	for _, p := range message.Parts {
+		part, err := p.ValueByDiscriminator()
+		if err != nil {
+			continue
+		}
+		invocation, ok := part.(client.MessagePartToolInvocation)
+		if !ok {
+			continue
+		}
+		toolCall, _ := invocation.ToolInvocation.AsMessageToolInvocationToolCall()
+		if toolCall.Args == nil {
+			continue
+		}
+		if args, ok := (*toolCall.Args).(map[string]any); ok {
+			if path, ok := args["filePath"].(string); ok {
+				files = append(files, path)
+			}
+		}
+	}
+	return strings.Join(files, "\n")
+}
+
+// FileReference returns the first file mentioned or used by the tools of the
+// selected message, or of the latest assistant message when none is selected
+func (m *messagesComponent) FileReference() (app.FileReference, bool) {
+	for i := len(m.app.Messages) - 1; i >= 0; i-- {
+		message := m.app.Messages[i]
+		if m.selected != "" && message.Id != m.selected {
+			continue
+		}
+		if m.selected == "" && message.Role != client.Assistant {
+			continue
+		}
+		if reference, ok := m.app.FindFileReference(app.MessageText(message) + "\n" + toolFiles(message)); ok {
+			return reference, true
+		}
+		if m.selected != "" {
+			break
+		}
+	}
+	return app.FileReference{}, false
+}
+
+// Links returns the URLs of the selected message, or of the latest message
+// with links when none is selected
+func (m *messagesComponent) Links() []string {
+	for i := len(m.app.Messages) - 1; i >= 0; i-- {
+		message := m.app.Messages[i]
+		if m.selected != "" && message.Id != m.selected {
+			continue
+		}
+		if links := app.FindLinks(app.MessageText(message)); len(links) > 0 || m.selected != "" {
+			return links
+		}
+	}
+	return []string{}
+}
+
+// subscribe renders the session again when it is switched or cleared
+func (m *messagesComponent) subscribe() {
+	bus.Subscribe(m.app.Bus, func(app.SessionSelectedMsg) tea.Cmd {
+		m.cache.Clear()
+		m.selected = ""
+		m.navigating = false
+		m.expanded = map[string]bool{}
+		m.pages = map[string]int{}
+		m.replay = nil
+		m.jumpToTail()
+		return m.Reload()
+	})
+	bus.Subscribe(m.app.Bus, func(app.SessionClearedMsg) tea.Cmd {
+		m.cache.Clear()
+		m.replay = nil
+		return m.Reload()
+	})
+}
+
+// SelectedMessage returns the selected message, or the latest one when none
+// is selected
+func (m *messagesComponent) SelectedMessage() (client.MessageInfo, bool) {
+	for i := len(m.app.Messages) - 1; i >= 0; i-- {
+		if m.selected == "" || m.app.Messages[i].Id == m.selected {
+			return m.app.Messages[i], true
+		}
+	}
+	return client.MessageInfo{}, false
+}
+
+// applyCodeWrap sets how code blocks are wrapped from the state
+func (m *messagesComponent) applyLayout() {
+	codeBlocks.wrap = m.app.State.CodeWrap
+	if codeBlocks.wrap == "" {
+		codeBlocks.wrap = config.CodeWrapSoft
+	}
+	codeBlocks.offset = 0
+}
+
 func NewMessagesComponent(app *app.App) MessagesComponent {
 	customSpinner := spinner.Spinner{
 		Frames: []string{" ", "┃", "┃"},
@@ -461,14 +720,21 @@ func NewMessagesComponent(app *app.App) MessagesComponent {
 		commands.WithLimit(6),
 	)
 
-	return &messagesComponent{
+	m := &messagesComponent{
 		app:             app,
 		viewport:        vp,
 		spinner:         s,
 		attachments:     attachments,
 		commands:        commandsView,
 		showToolDetails: true,
+		tools:           newToolTimer(),
+		expanded:        map[string]bool{},
+		pages:           map[string]int{},
+		images:          newInlineImages(),
 		cache:           NewMessageCache(),
 		tail:            true,
 	}
+	m.applyLayout()
+	m.subscribe()
+	return m
 }
@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// messageRegion records which rendered lines of the viewport belong to a
+// message, or to one of its tool calls, so clicks can be mapped back to them
+type messageRegion struct {
+	start, end int
+	messageID  string
+	toolCallID string
+	details    bool
+	// more is the line of the show more marker of a paged output, -1 when
+	// the output is shown in full
+	more int
+}
+
+// handleClick selects the clicked message, or toggles the output of the
+// clicked tool call
+func (m *messagesComponent) handleClick(msg tea.MouseClickMsg) {
+	if msg.Button != tea.MouseLeft || m.rendering || m.app.IsComparing() {
+		return
+	}
+	line := msg.Y - lipgloss.Height(m.header()) + m.viewport.YOffset
+	if msg.Y >= lipgloss.Height(m.header())+m.viewport.Height() {
+		return
+	}
+	if msg.Y == lipgloss.Height(m.header())+m.viewport.Height()-1 && m.pillClicked(msg.X) {
+		m.jumpToTail()
+		return
+	}
+
+	for _, region := range m.regions {
+		if line < region.start || line >= region.end {
+			continue
+		}
+		if region.more >= 0 && line == region.start+region.more {
+			m.showMore(region.toolCallID)
+			return
+		}
+		if region.toolCallID != "" {
+			m.expanded[region.toolCallID] = !region.details
+		} else if m.selected == region.messageID {
+			m.selected = ""
+		} else {
+			m.selected = region.messageID
+		}
+		offset := m.viewport.YOffset
+		m.renderView()
+		m.viewport.SetYOffset(offset)
+		m.scrolled()
+		return
+	}
+	if m.selected != "" {
+		m.selected = ""
+		m.renderView()
+	}
+}
+
+// markSelected draws a marker in the margin left of a selected block
+func markSelected(block string, margin int) string {
+	if margin < 2 {
+		return block
+	}
+	t := theme.CurrentTheme()
+	marker := styles.NewStyle().Foreground(t.Primary()).Background(t.Background()).Render("▌")
+	height := lipgloss.Height(block)
+	markers := strings.TrimSuffix(strings.Repeat(marker+"\n", height), "\n")
+	return layout.PlaceOverlay(margin-2, 0, markers, block)
+}
@@ -0,0 +1,204 @@
+package chat
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/clipboard"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// InspectMessageMsg shows the JSON of a message
+type InspectMessageMsg struct {
+	Message client.MessageInfo
+}
+
+// collapseKey is the key of a collapsed message in the expanded map, where
+// true means collapsed
+func collapseKey(messageID string) string {
+	return "collapsed:" + messageID
+}
+
+// shownMessages lists the messages rendered in the viewport, in order
+func (m *messagesComponent) shownMessages() []string {
+	ids := []string{}
+	for _, region := range m.regions {
+		if len(ids) == 0 || ids[len(ids)-1] != region.messageID {
+			ids = append(ids, region.messageID)
+		}
+	}
+	return ids
+}
+
+// StartNavigation moves the selection through the messages with the
+// keyboard, starting from the selected message or the latest one
+func (m *messagesComponent) StartNavigation() bool {
+	ids := m.shownMessages()
+	if len(ids) == 0 {
+		return false
+	}
+	m.navigating = true
+	if !slices.Contains(ids, m.selected) {
+		m.selected = ids[len(ids)-1]
+	}
+	m.refreshSelection()
+	return true
+}
+
+func (m *messagesComponent) stopNavigation() {
+	m.navigating = false
+	m.selected = ""
+	m.refreshSelection()
+}
+
+// moveSelection selects the message steps after the selected one, clamped
+// to the first and last messages
+func (m *messagesComponent) moveSelection(steps int) {
+	ids := m.shownMessages()
+	if len(ids) == 0 {
+		return
+	}
+	index := slices.Index(ids, m.selected) + steps
+	m.selected = ids[min(max(index, 0), len(ids)-1)]
+	m.refreshSelection()
+}
+
+// refreshSelection renders the selection and scrolls the selected message
+// into view, showing its top when it is taller than the viewport
+func (m *messagesComponent) refreshSelection() {
+	offset := m.viewport.YOffset
+	m.renderView()
+	m.viewport.SetYOffset(offset)
+	start, end := -1, -1
+	for _, region := range m.regions {
+		if region.messageID != m.selected {
+			continue
+		}
+		if start < 0 {
+			start = region.start - 1
+		}
+		end = region.end
+	}
+	if start >= 0 {
+		if end-m.viewport.Height() > m.viewport.YOffset {
+			m.viewport.SetYOffset(end - m.viewport.Height())
+		}
+		if start < m.viewport.YOffset {
+			m.viewport.SetYOffset(start)
+		}
+	}
+	m.scrolled()
+}
+
+// HandleNavigationKey runs the actions on the selected message while
+// navigating the messages. Other keys leave the navigation and are handled
+// as usual, so typing starts a prompt.
+func (m *messagesComponent) HandleNavigationKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if !m.navigating {
+		return false, nil
+	}
+	message, ok := m.SelectedMessage()
+	if !ok {
+		m.stopNavigation()
+		return false, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		m.moveSelection(1)
+	case "k", "up":
+		m.moveSelection(-1)
+	case "g", "home":
+		m.moveSelection(-len(m.app.Messages))
+	case "G", "end":
+		m.moveSelection(len(m.app.Messages))
+	case "y":
+		return true, clipboard.Copy(app.MessageText(message), "Message")
+	case "f":
+		m.stopNavigation()
+		return true, m.app.ForkSession(context.Background(), message.Id)
+	case "i", "enter":
+		return true, util.CmdHandler(InspectMessageMsg{Message: message})
+	case "p":
+		pinned := m.app.ToggleMessagePin(message.Id)
+		m.refreshSelection()
+		if pinned {
+			return true, toast.NewInfoToast(i18n.T("navigate.pinned"))
+		}
+		return true, toast.NewInfoToast(i18n.T("navigate.unpinned"))
+	case "c", "space", " ":
+		key := collapseKey(message.Id)
+		m.expanded[key] = !m.expanded[key]
+		m.refreshSelection()
+	case "/":
+		m.stopNavigation()
+		return true, m.openSearch()
+	case "esc", "q":
+		m.stopNavigation()
+	default:
+		m.stopNavigation()
+		return false, nil
+	}
+	return true, nil
+}
+
+// renderCollapsed renders the line standing in for a collapsed message
+func renderCollapsed(message client.MessageInfo, author string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel()).Render
+
+	preview := ""
+	for line := range strings.SplitSeq(app.MessageText(message), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			preview = line
+			break
+		}
+	}
+	label := muted("▸ ") + base(author)
+	if preview != "" {
+		label += muted(" · " + preview)
+	}
+	line := ansi.Truncate(label, diffStatWidth(), "…")
+	return renderDiffStatBlock(styles.NewStyle().Background(t.BackgroundPanel()).Width(diffStatWidth()).MaxHeight(1).Render(line))
+}
+
+// navigationBar renders the keys of the navigation, shown below the messages
+func (m *messagesComponent) navigationBar() string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Render
+	accent := styles.NewStyle().Foreground(t.Accent()).Background(t.Background()).Bold(true).Render
+
+	ids := m.shownMessages()
+	info := accent(i18n.T("navigate.title")) + muted("  "+i18n.T("navigate.position", slices.Index(ids, m.selected)+1, len(ids)))
+	keys := []string{
+		"j/k", i18n.T("navigate.move"),
+		"y", i18n.T("navigate.copy"),
+		"f", i18n.T("navigate.fork"),
+		"i", i18n.T("navigate.inspect"),
+		"p", i18n.T("navigate.pin"),
+		"c", i18n.T("navigate.collapse"),
+		"/", i18n.T("navigate.find"),
+		"esc", i18n.T("navigate.exit"),
+	}
+	hints := []string{}
+	for i := 0; i < len(keys); i += 2 {
+		hints = append(hints, base(keys[i])+muted(" "+keys[i+1]))
+	}
+	hint := strings.Join(hints, muted("  "))
+	gap := max(m.width-lipgloss.Width(info)-lipgloss.Width(hint), 1)
+	return ansi.Truncate(styles.NewStyle().
+		Background(t.Background()).
+		Width(m.width).
+		Render(info+strings.Repeat(" ", gap)+hint), m.width, "")
+}
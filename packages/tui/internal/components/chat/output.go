@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// outputHeadLines and outputTailLines are shown of a long tool output,
+	// each show more loads outputPageLines more lines after the head
+	outputHeadLines = 10
+	outputTailLines = 5
+	outputPageLines = 200
+	moreMarker      = "click or /more to show more, /output to save all"
+)
+
+var moreLinePattern = regexp.MustCompile(`… \d+ more lines, click`)
+
+// pageOutput shortens a long tool output to its head and tail, around a
+// marker counting the hidden lines. Pages is the number of chunks loaded.
+func pageOutput(content string, pages int) string {
+	lines := strings.Split(content, "\n")
+	head := outputHeadLines + pages*outputPageLines
+	if len(lines) <= head+outputTailLines {
+		return content
+	}
+	hidden := len(lines) - head - outputTailLines
+	return strings.Join(lines[:head], "\n") +
+		fmt.Sprintf("\n… %d more lines, %s\n", hidden, moreMarker) +
+		strings.Join(lines[len(lines)-outputTailLines:], "\n")
+}
+
+// moreLine returns the line of a rendered block showing the marker of a paged
+// output, or -1
+func moreLine(block string) int {
+	for i, line := range strings.Split(block, "\n") {
+		if moreLinePattern.MatchString(ansi.Strip(line)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// pagedRegion returns the tool call with a paged output in the selected
+// message, or in the last one having one
+func (m *messagesComponent) pagedRegion() (messageRegion, bool) {
+	for i := len(m.regions) - 1; i >= 0; i-- {
+		region := m.regions[i]
+		if region.more >= 0 && (m.selected == "" || region.messageID == m.selected) {
+			return region, true
+		}
+	}
+	return messageRegion{}, false
+}
+
+// showMore loads the next chunk of a paged tool output, keeping the
+// viewport where it was
+func (m *messagesComponent) showMore(toolCallID string) {
+	m.pages[toolCallID]++
+	offset := m.viewport.YOffset
+	m.renderView()
+	m.viewport.SetYOffset(offset)
+	m.scrolled()
+}
+
+// ShowMore loads the next chunk of the paged output in view
+func (m *messagesComponent) ShowMore() bool {
+	region, ok := m.pagedRegion()
+	if !ok {
+		return false
+	}
+	m.showMore(region.toolCallID)
+	return true
+}
+
+// PagedOutput returns the message and tool call of the paged output
+// ShowMore loads
+func (m *messagesComponent) PagedOutput() (string, string, bool) {
+	region, ok := m.pagedRegion()
+	return region.messageID, region.toolCallID, ok
+}
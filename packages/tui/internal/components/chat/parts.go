@@ -0,0 +1,292 @@
+package chat
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// PartContext is the part of a message being rendered, with what renderers
+// need to know about the message and the chat
+type PartContext struct {
+	Message client.MessageInfo
+	// Index is the position of the part in the message
+	Index   int
+	Author  string
+	Density string
+	// Width is the width of the chat column
+	Width int
+
+	m        *messagesComponent
+	previous blockType
+	// lastTools are the indexes of the tool calls followed by a text part
+	lastTools []int
+	inFlight  *[]string
+}
+
+// Expanded returns whether the block of key was expanded by clicking it, or
+// fallback when it was never clicked
+func (c *PartContext) Expanded(key string, fallback bool) bool {
+	if value, ok := c.m.expanded[key]; ok {
+		return value
+	}
+	return fallback
+}
+
+// PartBlock is a block of the chat rendered for a part
+type PartBlock struct {
+	Content string
+	// Key makes clicking the block toggle it, Expanded is its current state
+	Key      string
+	Expanded bool
+	// Gap separates the block from the previous one of the chat with a blank
+	// line
+	Gap  bool
+	kind blockType
+}
+
+// PartRenderer renders a part of a message as blocks of the chat, or none to
+// leave it out
+type PartRenderer func(ctx *PartContext, part client.MessagePart) []PartBlock
+
+// partRenderers maps the part types to their renderer, the parts of other
+// types are rendered by renderUnknownPart
+var partRenderers = map[string]PartRenderer{
+	"text":            renderTextPart,
+	"tool-invocation": renderToolPart,
+	"reasoning":       renderReasoningPart,
+	"file":            renderFilePart,
+	"step-start":      skipPart,
+	"source-url":      skipPart,
+}
+
+// RegisterPartRenderer renders the parts of partType with renderer, replacing
+// the built-in renderer of the type if any
+func RegisterPartRenderer(partType string, renderer PartRenderer) {
+	partRenderers[partType] = renderer
+}
+
+// renderPart renders a part with the renderer of its type
+func renderPart(ctx *PartContext, part client.MessagePart) []PartBlock {
+	partType, err := part.Discriminator()
+	if err != nil {
+		return nil
+	}
+	renderer, ok := partRenderers[partType]
+	if !ok {
+		renderer = renderUnknownPart
+	}
+	return renderer(ctx, part)
+}
+
+// lastToolIndexes returns the indexes of the tool calls followed by a text
+// part, which render without a bottom margin
+func lastToolIndexes(message client.MessageInfo) []int {
+	last := 0
+	indexes := []int{}
+	for i, p := range message.Parts {
+		partType, _ := p.Discriminator()
+		switch partType {
+		case "text":
+			indexes = append(indexes, last)
+		case "tool-invocation":
+			last = i
+		}
+	}
+	return indexes
+}
+
+func textKind(message client.MessageInfo) blockType {
+	if message.Role == client.User {
+		return userTextBlock
+	}
+	return assistantTextBlock
+}
+
+func skipPart(*PartContext, client.MessagePart) []PartBlock {
+	return nil
+}
+
+func renderTextPart(ctx *PartContext, part client.MessagePart) []PartBlock {
+	text, err := part.AsMessagePartText()
+	if err != nil {
+		return nil
+	}
+	if label, ok := app.PinnedPartLabel(text.Text); ok && ctx.Message.Role == client.User {
+		return []PartBlock{{Content: renderPinnedPart(label), Gap: true, kind: toolInvocationBlock}}
+	}
+	return []PartBlock{ctx.textBlock(text.Text)}
+}
+
+// renderPinnedPart renders the line standing in for the pinned context sent
+// with a prompt
+func renderPinnedPart(label string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).Render
+	line := ansi.Truncate(muted("pinned · "+label), diffStatWidth(), "…")
+	return renderDiffStatBlock(styles.NewStyle().Background(t.BackgroundPanel()).Width(diffStatWidth()).MaxHeight(1).Render(line))
+}
+
+// textBlock renders text as a message of its author
+func (c *PartContext) textBlock(text string) PartBlock {
+	m, message := c.m, c.Message
+	truncated := m.app.IsTruncated(message.Id)
+	timestamp := formatTimestamp(message.Metadata.Time.Created, m.app.State, c.Density == config.DensityVerbose)
+	metrics := ""
+	if value, ok := m.app.Metrics(message.Id); ok {
+		metrics = value.String()
+	} else if status := m.app.SendStatus(message.Id); status != "" {
+		metrics = status
+	}
+	labels := feedbackLabel(message)
+	if m.app.IsMessagePinned(message.Id) {
+		labels = strings.TrimSpace("[pinned] " + labels)
+	}
+	key := m.cache.GenerateKey(message.Id, text, c.Author, timestamp, truncated, labels, metrics, c.Density, layout.Current.Viewport.Width)
+	content, cached := m.cache.Get(key)
+	if !cached {
+		content = renderText(message, text, c.Author, timestamp, metrics, labels, truncated, c.Density)
+		m.cache.Set(key, content)
+	}
+	return PartBlock{Content: content, Gap: c.previous != none, kind: textKind(message)}
+}
+
+func renderToolPart(ctx *PartContext, part client.MessagePart) []PartBlock {
+	invocation, err := part.AsMessagePartToolInvocation()
+	if err != nil {
+		return nil
+	}
+	m, message := ctx.m, ctx.Message
+	isLastToolInvocation := slices.Contains(ctx.lastTools, ctx.Index)
+	toolCall, _ := invocation.ToolInvocation.AsMessageToolInvocationToolCall()
+	metadata := client.MessageMetadata_Tool_AdditionalProperties{}
+	if _, ok := message.Metadata.Tool[toolCall.ToolCallId]; ok {
+		metadata = message.Metadata.Tool[toolCall.ToolCallId]
+	}
+	var result *string
+	resultPart, resultError := invocation.ToolInvocation.AsMessageToolInvocationToolResult()
+	if resultError == nil {
+		result = &resultPart.Result
+	}
+
+	showDetails := m.showToolDetails && ctx.Density != config.DensityCompact
+	showDetails = showDetails || m.search.matchesTool(result, metadata)
+	showDetails = ctx.Expanded(toolCall.ToolCallId, showDetails)
+
+	var content string
+	if toolCall.State == "result" {
+		key := m.cache.GenerateKey(message.Id,
+			toolCall.ToolCallId,
+			showDetails,
+			m.pages[toolCall.ToolCallId],
+			layout.Current.Viewport.Width,
+		)
+		var cached bool
+		content, cached = m.cache.Get(key)
+		if !cached {
+			content = renderToolInvocation(
+				toolCall,
+				result,
+				metadata,
+				showDetails,
+				m.pages[toolCall.ToolCallId],
+				isLastToolInvocation,
+				false,
+			)
+			m.cache.Set(key, content)
+		}
+	} else if message.Metadata.Time.Completed == nil {
+		*ctx.inFlight = append(*ctx.inFlight, toolCall.ToolCallId)
+		content = renderToolProgress(toolCall, m.tools.elapsed(toolCall.ToolCallId))
+	} else {
+		// if the tool call isn't finished, don't cache
+		content = renderToolInvocation(
+			toolCall,
+			result,
+			metadata,
+			showDetails,
+			m.pages[toolCall.ToolCallId],
+			isLastToolInvocation,
+			false,
+		)
+	}
+
+	return []PartBlock{{
+		Content:  content,
+		Key:      toolCall.ToolCallId,
+		Expanded: showDetails,
+		Gap:      ctx.previous != toolInvocationBlock && showDetails,
+		kind:     toolInvocationBlock,
+	}}
+}
+
+func renderReasoningPart(ctx *PartContext, part client.MessagePart) []PartBlock {
+	reasoning, err := part.AsMessagePartReasoning()
+	m, message := ctx.m, ctx.Message
+	if err != nil || m.app.State.Reasoning == config.ReasoningHidden || strings.TrimSpace(reasoning.Text) == "" {
+		return nil
+	}
+	key := reasoningKey(message.Id, ctx.Index)
+	expanded := ctx.Expanded(key, m.app.State.Reasoning == config.ReasoningExpanded)
+	thinking := message.Metadata.Time.Completed == nil && ctx.Index == len(message.Parts)-1
+	cacheKey := m.cache.GenerateKey(message.Id, key, reasoning.Text, expanded, thinking, layout.Current.Viewport.Width)
+	content, cached := m.cache.Get(cacheKey)
+	if !cached {
+		content = renderReasoning(reasoning.Text, expanded, thinking)
+		m.cache.Set(cacheKey, content)
+	}
+	return []PartBlock{{
+		Content:  content,
+		Key:      key,
+		Expanded: expanded,
+		Gap:      ctx.previous != none,
+		kind:     toolInvocationBlock,
+	}}
+}
+
+func renderFilePart(ctx *PartContext, part client.MessagePart) []PartBlock {
+	file, err := part.AsMessagePartFile()
+	if err != nil || !strings.HasPrefix(file.MediaType, "image/") {
+		return nil
+	}
+	return []PartBlock{{
+		Content: ctx.m.images.render(ctx.m.app.Graphics, ctx.Message, file),
+		Gap:     ctx.previous != none,
+		kind:    textKind(ctx.Message),
+	}}
+}
+
+// renderUnknownPart degrades the parts of types this version does not know,
+// showing their text when they have one and a notice otherwise
+func renderUnknownPart(ctx *PartContext, part client.MessagePart) []PartBlock {
+	partType, _ := part.Discriminator()
+	var fields struct {
+		Text string `json:"text"`
+	}
+	if raw, err := part.MarshalJSON(); err == nil {
+		json.Unmarshal(raw, &fields)
+	}
+	if strings.TrimSpace(fields.Text) != "" {
+		return []PartBlock{ctx.textBlock(fields.Text)}
+	}
+	return []PartBlock{{
+		Content: renderUnsupportedPart(partType),
+		Gap:     ctx.previous != none,
+		kind:    toolInvocationBlock,
+	}}
+}
+
+func renderUnsupportedPart(partType string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).Italic(true).Render
+	line := muted("Unsupported " + partType + " part, update opencode to show it")
+	return renderDiffStatBlock(styles.NewStyle().Background(t.BackgroundPanel()).Width(diffStatWidth()).MaxHeight(1).Render(line))
+}
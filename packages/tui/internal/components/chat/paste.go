@@ -0,0 +1,50 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/sst/opencode/internal/app"
+)
+
+// collapsePaste inserts a paste of many lines as a label standing for it,
+// its content is sent as its own part of the prompt
+func (m *editorComponent) collapsePaste(text string) bool {
+	limit := m.app.PasteLines()
+	if limit == 0 || strings.Count(strings.TrimRight(text, "\n"), "\n")+1 < limit {
+		return false
+	}
+	index := 1
+	for _, attachment := range m.attachments {
+		if app.IsPaste(attachment) {
+			index++
+		}
+	}
+	paste := app.NewPaste(index, text)
+	m.attachments = append(m.attachments, paste)
+	m.textarea.InsertString(paste.FileName)
+	return true
+}
+
+// sentAttachments returns the attachments sent with value, the pastes whose
+// label was deleted from the editor are dropped
+func (m *editorComponent) sentAttachments(value string) []app.Attachment {
+	attachments := []app.Attachment{}
+	for _, attachment := range m.attachments {
+		if isSent(attachment, value) {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments
+}
+
+// isSent reports whether an attachment is sent with value, which pastes are
+// only while their label is in it
+func isSent(attachment app.Attachment, value string) bool {
+	return !app.IsPaste(attachment) || strings.Contains(value, attachment.FileName)
+}
+
+// draft returns the content saved as a draft or in the history, with the
+// collapsed pastes expanded so that none is lost
+func (m *editorComponent) draft() string {
+	return app.ExpandPastes(m.Value(), m.attachments)
+}
@@ -0,0 +1,130 @@
+package chat
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/spinner"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const toolProgressInterval = time.Second / 4
+
+var toolProgressFrames = spinner.MiniDot.Frames
+
+type toolProgressTickMsg struct{}
+
+// toolTimer remembers when each in-flight tool call was first seen, since
+// the server only reports tool timings once a call has finished
+type toolTimer struct {
+	mu      sync.Mutex
+	started map[string]time.Time
+}
+
+func newToolTimer() *toolTimer {
+	return &toolTimer{started: map[string]time.Time{}}
+}
+
+func (t *toolTimer) elapsed(toolCallID string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	start, ok := t.started[toolCallID]
+	if !ok {
+		start = time.Now()
+		t.started[toolCallID] = start
+	}
+	return time.Since(start)
+}
+
+// retain forgets every tool call that is no longer in flight
+func (t *toolTimer) retain(toolCallIDs []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id := range t.started {
+		if !slices.Contains(toolCallIDs, id) {
+			delete(t.started, id)
+		}
+	}
+}
+
+func (t *toolTimer) running() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.started) > 0
+}
+
+func tickToolProgress() tea.Cmd {
+	return tea.Tick(toolProgressInterval, func(time.Time) tea.Msg {
+		return toolProgressTickMsg{}
+	})
+}
+
+// renderToolProgress renders an in-flight tool call as a single spinner line
+// with the elapsed time, eg "running bash: npm test … 34s"
+func renderToolProgress(toolCall client.MessageToolInvocationToolCall, elapsed time.Duration) string {
+	t := theme.CurrentTheme()
+
+	frame := toolProgressFrames[int(elapsed/toolProgressInterval)%len(toolProgressFrames)]
+	var label string
+	if toolCall.State == "partial-call" {
+		label = renderToolAction(toolCall.ToolName)
+	} else {
+		label = "running " + toolCall.ToolName
+		if summary := toolSummary(toolCall); summary != "" {
+			label += ": " + summary
+		}
+	}
+
+	accent := styles.NewStyle().Foreground(t.Accent()).Background(t.BackgroundPanel()).Render
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).Render
+	line := accent(frame) + muted(" "+label+" … "+formatElapsed(elapsed))
+	if screenReader {
+		// a ticking timer would be read out again on every frame
+		line = muted("Tool " + label)
+	}
+
+	padding := calculatePadding()
+	width := layout.Current.Container.Width - padding - 4 - 3
+	line = styles.NewStyle().Background(t.BackgroundPanel()).Width(width).MaxHeight(1).Render(line)
+	return renderContentBlock(line,
+		WithAlign(lipgloss.Left),
+		WithBorderColor(t.Accent()),
+		WithPaddingTop(0),
+		WithPaddingBottom(1),
+	)
+}
+
+// toolSummary picks the most descriptive argument of a tool call
+func toolSummary(toolCall client.MessageToolInvocationToolCall) string {
+	if toolCall.Args == nil {
+		return ""
+	}
+	args, ok := (*toolCall.Args).(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"command", "filePath", "path", "pattern", "url", "description"} {
+		if value, ok := args[key].(string); ok && value != "" {
+			if key == "filePath" || key == "path" {
+				return relative(value)
+			}
+			return value
+		}
+	}
+	return ""
+}
+
+func formatElapsed(elapsed time.Duration) string {
+	seconds := int(elapsed.Seconds())
+	if seconds < 60 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+	return fmt.Sprintf("%dm%02ds", seconds/60, seconds%60)
+}
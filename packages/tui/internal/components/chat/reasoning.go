@@ -0,0 +1,49 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// ReasoningChangedMsg renders the messages again after the reasoning display
+// mode changed
+type ReasoningChangedMsg struct{}
+
+// reasoningKey is the key of a reasoning part of a message in the expanded
+// map
+func reasoningKey(messageID string, part int) string {
+	return fmt.Sprintf("reasoning:%s:%d", messageID, part)
+}
+
+// renderReasoning renders the reasoning of a model dimmed, as a single line
+// that expands to the whole text on click. Reasoning still streaming is
+// labeled as thinking.
+func renderReasoning(text string, expanded, thinking bool) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel())
+
+	marker := "▸ "
+	if expanded {
+		marker = "▾ "
+	}
+	label := i18n.T("chat.thought")
+	if thinking {
+		label = i18n.T("chat.thinking")
+	}
+	text = strings.TrimSpace(text)
+	words := len(strings.Fields(text))
+	header := muted.Render(marker) + muted.Bold(true).Render(label) +
+		muted.Render(" · "+i18n.N("chat.words", words))
+	header = styles.NewStyle().Background(t.BackgroundPanel()).Width(diffStatWidth()).MaxHeight(1).Render(header)
+	if !expanded || text == "" {
+		return renderDiffStatBlock(header)
+	}
+
+	body := muted.Italic(true).Width(diffStatWidth()).PaddingLeft(2).Render(text)
+	return renderDiffStatBlock(lipgloss.JoinVertical(lipgloss.Left, header, body))
+}
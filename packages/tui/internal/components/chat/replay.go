@@ -0,0 +1,160 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// OpenReplayMsg steps through the session from its first message
+type OpenReplayMsg struct{}
+
+// replayStep is a point of the conversation, the messages before message
+// and the first parts of message are shown
+type replayStep struct {
+	message int
+	parts   int
+}
+
+// sessionReplay plays the session back one message, or one text or tool
+// call, at a time
+type sessionReplay struct {
+	steps   []replayStep
+	current int
+	byPart  bool
+}
+
+// replaySteps lists the steps through messages, by message or by part
+func replaySteps(messages []client.MessageInfo, byPart bool) []replayStep {
+	steps := []replayStep{}
+	for i, message := range messages {
+		if !byPart {
+			steps = append(steps, replayStep{message: i, parts: len(message.Parts)})
+			continue
+		}
+		shown := false
+		for j, p := range message.Parts {
+			part, err := p.ValueByDiscriminator()
+			if err != nil {
+				continue
+			}
+			switch part.(type) {
+			case client.MessagePartText, client.MessagePartToolInvocation:
+				steps = append(steps, replayStep{message: i, parts: j + 1})
+				shown = true
+			}
+		}
+		if !shown {
+			steps = append(steps, replayStep{message: i, parts: len(message.Parts)})
+		}
+	}
+	return steps
+}
+
+// replayed returns the messages shown at the current step of the replay, or
+// every message when the session is not replayed
+func (m *messagesComponent) replayed() []client.MessageInfo {
+	if m.replay == nil || len(m.replay.steps) == 0 {
+		return m.app.Messages
+	}
+	step := m.replay.steps[m.replay.current]
+	if step.message >= len(m.app.Messages) {
+		return m.app.Messages
+	}
+	messages := m.app.Messages[:step.message+1]
+	last := messages[step.message]
+	last.Parts = last.Parts[:step.parts]
+	return append(messages[:step.message:step.message], last)
+}
+
+func (m *messagesComponent) openReplay() {
+	m.replay = &sessionReplay{steps: replaySteps(m.app.Messages, false)}
+	m.renderView()
+	m.jumpToTail()
+}
+
+func (m *messagesComponent) closeReplay() {
+	m.replay = nil
+	m.renderView()
+	m.jumpToTail()
+}
+
+// toggleReplayUnit switches between stepping by message and by part, staying
+// on the current message
+func (m *messagesComponent) toggleReplayUnit() {
+	r := m.replay
+	message := r.steps[r.current].message
+	r.byPart = !r.byPart
+	r.steps = replaySteps(m.app.Messages, r.byPart)
+	r.current = 0
+	for i, step := range r.steps {
+		if step.message <= message {
+			r.current = i
+		}
+	}
+}
+
+func (m *messagesComponent) stepReplay(steps int) {
+	r := m.replay
+	r.current = min(max(r.current+steps, 0), len(r.steps)-1)
+	m.renderView()
+	m.jumpToTail()
+}
+
+// HandleReplayKey routes key presses to the replay while the session is
+// played back
+func (m *messagesComponent) HandleReplayKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if m.replay == nil {
+		return false, nil
+	}
+	if len(m.replay.steps) == 0 {
+		m.closeReplay()
+		return false, nil
+	}
+	switch msg.String() {
+	case "right", "l", "n", "space", " ":
+		m.stepReplay(1)
+	case "left", "h", "p":
+		m.stepReplay(-1)
+	case "home", "g":
+		m.stepReplay(-len(m.replay.steps))
+	case "end", "G":
+		m.stepReplay(len(m.replay.steps))
+	case "t":
+		m.toggleReplayUnit()
+		m.stepReplay(0)
+	case "esc", "q":
+		m.closeReplay()
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// View renders the replay bar shown below the messages
+func (r *sessionReplay) View(messages []client.MessageInfo, width int) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Render
+	accent := styles.NewStyle().Foreground(t.Accent()).Background(t.Background()).Bold(true).Render
+
+	step := r.steps[r.current]
+	position := fmt.Sprintf("message %d/%d", step.message+1, len(messages))
+	unit := " by part"
+	if r.byPart {
+		position += fmt.Sprintf("  step %d/%d", r.current+1, len(r.steps))
+		unit = " by message"
+	}
+	info := accent("replay") + muted("  "+position)
+	hint := base("←/→") + muted(" step  ") + base("t") + muted(unit+"  ") + base("esc") + muted(" exit")
+	gap := max(width-lipgloss.Width(info)-lipgloss.Width(hint), 1)
+	return styles.NewStyle().
+		Background(t.Background()).
+		Width(width).
+		Render(info + strings.Repeat(" ", gap) + hint)
+}
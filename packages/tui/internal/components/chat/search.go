@@ -0,0 +1,253 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// OpenSearchMsg opens the in-session search bar
+type OpenSearchMsg struct{}
+
+type searchMatch struct {
+	line       int
+	start, end int
+}
+
+// viewportSearch tracks a find-in-session query over the rendered viewport
+type viewportSearch struct {
+	input   textinput.Model
+	editing bool
+	query   string
+	matches []searchMatch
+	current int
+}
+
+func newViewportSearch() *viewportSearch {
+	t := theme.CurrentTheme()
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "find in session"
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.Background()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Lipgloss()
+	input.Styles.Blurred = input.Styles.Focused
+	input.Styles.Cursor.Color = t.Primary()
+	return &viewportSearch{input: input, editing: true}
+}
+
+// matchesTool reports whether the query occurs in the output of a tool call,
+// so collapsed tool output containing a match can be expanded
+func (s *viewportSearch) matchesTool(result *string, metadata client.MessageMetadata_Tool_AdditionalProperties) bool {
+	if s == nil || s.query == "" {
+		return false
+	}
+	query := strings.ToLower(s.query)
+	if result != nil && strings.Contains(strings.ToLower(*result), query) {
+		return true
+	}
+	if stdout, ok := metadata.Get("stdout"); ok {
+		if stdout, ok := stdout.(string); ok && strings.Contains(strings.ToLower(stdout), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// find collects every case-insensitive occurrence of the query in lines,
+// with offsets in cells so they can be passed to lipgloss.StyleRanges
+func (s *viewportSearch) find(lines []string) {
+	s.matches = []searchMatch{}
+	if s.query == "" {
+		s.current = 0
+		return
+	}
+	query, _ := foldCase(s.query)
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		lower, offsets := foldCase(plain)
+		offset := 0
+		for {
+			idx := strings.Index(lower[offset:], query)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			end := start + len(query)
+			s.matches = append(s.matches, searchMatch{
+				line:  i,
+				start: ansi.StringWidth(plain[:offsets[start]]),
+				end:   ansi.StringWidth(plain[:offsets[end]]),
+			})
+			offset = end
+		}
+	}
+	if s.current >= len(s.matches) {
+		s.current = 0
+	}
+}
+
+// foldCase lowercases text rune by rune, returning with it the offset in
+// text of each byte of the result, so that matches map back to text even
+// where lowercasing changed the length of a rune
+func foldCase(text string) (string, []int) {
+	var folded strings.Builder
+	offsets := make([]int, 0, len(text)+1)
+	for i, r := range text {
+		n, _ := folded.WriteRune(unicode.ToLower(r))
+		for range n {
+			offsets = append(offsets, i)
+		}
+	}
+	offsets = append(offsets, len(text))
+	return folded.String(), offsets
+}
+
+// highlight returns lines with all matches styled and the current one emphasized
+func (s *viewportSearch) highlight(lines []string) []string {
+	if len(s.matches) == 0 {
+		return lines
+	}
+	t := theme.CurrentTheme()
+	matchStyle := styles.NewStyle().Foreground(t.Background()).Background(t.Warning()).Lipgloss()
+	currentStyle := styles.NewStyle().Foreground(t.Background()).Background(t.Primary()).Bold(true).Lipgloss()
+
+	highlighted := make([]string, len(lines))
+	copy(highlighted, lines)
+	ranges := map[int][]lipgloss.Range{}
+	for i, match := range s.matches {
+		style := matchStyle
+		if i == s.current {
+			style = currentStyle
+		}
+		ranges[match.line] = append(ranges[match.line], lipgloss.NewRange(match.start, match.end, style))
+	}
+	for line, r := range ranges {
+		highlighted[line] = lipgloss.StyleRanges(lines[line], r...)
+	}
+	return highlighted
+}
+
+func (s *viewportSearch) move(offset int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = (s.current + offset + len(s.matches)) % len(s.matches)
+}
+
+func (s *viewportSearch) View(width int) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.Background()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.Background()).Render
+
+	count := "no matches"
+	if len(s.matches) > 0 {
+		count = fmt.Sprintf("%d/%d", s.current+1, len(s.matches))
+	}
+	hint := muted(count + "  ")
+	if s.editing {
+		hint += base("enter") + muted(" search  ") + base("esc") + muted(" close")
+	} else {
+		hint += base("n") + muted("/") + base("N") + muted(" next/prev  ") + base("esc") + muted(" close")
+	}
+
+	input := s.input.View()
+	if !s.editing {
+		input = base("/" + s.query)
+	}
+	gap := max(width-lipgloss.Width(input)-lipgloss.Width(hint), 1)
+	return styles.NewStyle().
+		Background(t.Background()).
+		Width(width).
+		Render(input + strings.Repeat(" ", gap) + hint)
+}
+
+// HandleSearchKey routes key presses to the search bar while it is open
+func (m *messagesComponent) HandleSearchKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if m.search == nil {
+		return false, nil
+	}
+	s := m.search
+	if s.editing {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.closeSearch()
+			return true, nil
+		case "enter":
+			s.editing = false
+			s.input.Blur()
+			if s.query == "" {
+				m.closeSearch()
+			}
+			return true, nil
+		}
+		var cmd tea.Cmd
+		s.input, cmd = s.input.Update(msg)
+		if s.input.Value() != s.query {
+			s.query = s.input.Value()
+			s.current = 0
+			m.renderView()
+			m.jumpToMatch()
+		}
+		return true, cmd
+	}
+
+	switch msg.String() {
+	case "n":
+		s.move(1)
+	case "N", "shift+n":
+		s.move(-1)
+	case "/":
+		s.editing = true
+		return true, s.input.Focus()
+	case "esc", "ctrl+c":
+		m.closeSearch()
+		return true, nil
+	default:
+		return false, nil
+	}
+	m.applySearch()
+	m.jumpToMatch()
+	return true, nil
+}
+
+func (m *messagesComponent) openSearch() tea.Cmd {
+	if m.search == nil {
+		m.search = newViewportSearch()
+	}
+	m.search.editing = true
+	m.renderView()
+	return m.search.input.Focus()
+}
+
+func (m *messagesComponent) closeSearch() {
+	m.search = nil
+	m.renderView()
+}
+
+// applySearch sets the viewport content, highlighting matches of the query
+func (m *messagesComponent) applySearch() {
+	if m.search == nil {
+		m.viewport.SetContentLines(m.lines)
+		return
+	}
+	m.search.find(m.lines)
+	m.viewport.SetContentLines(m.search.highlight(m.lines))
+}
+
+func (m *messagesComponent) jumpToMatch() {
+	if m.search == nil || len(m.search.matches) == 0 {
+		return
+	}
+	line := m.search.matches[m.search.current].line
+	m.viewport.SetYOffset(line - m.viewport.Height()/2)
+	m.scrolled()
+}
@@ -0,0 +1,122 @@
+package chat
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+)
+
+// snippetSession is an expanded snippet whose tab stops are visited with tab.
+// Stops are rune offsets into the editor content.
+type snippetSession struct {
+	stops   []app.TabStop
+	current int
+	// length is the length of the content when the current stop was
+	// entered, the stops after it move by what was typed since
+	length int
+	// pending is the length of the default text at the cursor, replaced by
+	// the next character typed like a selection
+	pending int
+}
+
+// cursorOffset returns the rune offset of the cursor in the content
+func (m *editorComponent) cursorOffset() int {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	offset := 0
+	for _, line := range lines[:min(m.textarea.Line(), len(lines))] {
+		offset += len([]rune(line)) + 1
+	}
+	info := m.textarea.LineInfo()
+	return offset + info.StartColumn + info.ColumnOffset
+}
+
+// setCursorOffset moves the cursor to a rune offset in the content
+func (m *editorComponent) setCursorOffset(offset int) {
+	for row, line := range strings.Split(m.textarea.Value(), "\n") {
+		length := len([]rune(line))
+		if offset <= length {
+			m.textarea.SetCursor(row, offset)
+			return
+		}
+		offset -= length + 1
+	}
+	m.textarea.CursorEnd()
+}
+
+// expandSnippet replaces the abbreviation before the cursor by its snippet
+// and moves to the first tab stop
+func (m *editorComponent) expandSnippet() bool {
+	value := []rune(m.textarea.Value())
+	end := min(m.cursorOffset(), len(value))
+	start := end
+	for start > 0 && !unicode.IsSpace(value[start-1]) {
+		start--
+	}
+	if start == end {
+		return false
+	}
+	template, ok := m.app.Snippet(string(value[start:end]))
+	if !ok {
+		return false
+	}
+	text, stops := app.ExpandSnippet(template)
+	for i := range stops {
+		stops[i].Offset += start
+	}
+	m.textarea.SetValue(string(value[:start]) + text + string(value[end:]))
+	m.snippet = &snippetSession{stops: stops}
+	m.visitStop()
+	return true
+}
+
+// visitStop moves the cursor to the current tab stop, the session ends on
+// the last one
+func (m *editorComponent) visitStop() {
+	s := m.snippet
+	stop := s.stops[s.current]
+	m.setCursorOffset(stop.Offset)
+	s.pending = stop.Length
+	s.length = len([]rune(m.textarea.Value()))
+	if s.current == len(s.stops)-1 {
+		m.snippet = nil
+	}
+}
+
+// nextStop moves to the next tab stop of the snippet being filled in
+func (m *editorComponent) nextStop() bool {
+	s := m.snippet
+	if s == nil {
+		return false
+	}
+	delta := len([]rune(m.textarea.Value())) - s.length
+	for i := s.current + 1; i < len(s.stops); i++ {
+		s.stops[i].Offset += delta
+	}
+	s.current++
+	m.visitStop()
+	return true
+}
+
+// replacePlaceholder deletes the default text at the cursor before the key
+// typed over it, other keys keep it. It reports whether the key was used up,
+// backspace only deletes the default text.
+func (m *editorComponent) replacePlaceholder(msg tea.KeyPressMsg) bool {
+	s := m.snippet
+	if s == nil || s.pending == 0 {
+		return false
+	}
+	pending := s.pending
+	s.pending = 0
+	backspace := msg.String() == "backspace"
+	if msg.Text == "" && !backspace {
+		return false
+	}
+	value := []rune(m.textarea.Value())
+	offset := m.cursorOffset()
+	end := min(offset+pending, len(value))
+	m.textarea.SetValue(string(value[:offset]) + string(value[end:]))
+	m.setCursorOffset(offset)
+	return backspace
+}
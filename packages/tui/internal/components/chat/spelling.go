@@ -0,0 +1,89 @@
+package chat
+
+import (
+	"strings"
+
+	"github.com/sst/opencode/internal/app"
+)
+
+// fencedRows marks the rows of text inside ``` code blocks, fences included
+func fencedRows(text string) []bool {
+	lines := strings.Split(text, "\n")
+	fenced := make([]bool, len(lines))
+	inside := false
+	for i, line := range lines {
+		fence := strings.HasPrefix(strings.TrimSpace(line), "```")
+		fenced[i] = inside || fence
+		if fence {
+			inside = !inside
+		}
+	}
+	return fenced
+}
+
+// marks returns the misspelled words of a row for the textarea, except the
+// word being typed at the cursor
+func (m *editorComponent) marks(row int, line []rune) [][2]int {
+	if row < len(m.fenced) && m.fenced[row] {
+		return nil
+	}
+	info := m.textarea.LineInfo()
+	col := info.StartColumn + info.ColumnOffset
+	marks := [][2]int{}
+	for _, word := range m.app.Dictionary().Misspelled(line) {
+		if row == m.textarea.Line() && col == word.End {
+			continue
+		}
+		marks = append(marks, [2]int{word.Start, word.End})
+	}
+	return marks
+}
+
+// Misspelling returns the misspelled word under the cursor, or the closest
+// one before it
+func (m *editorComponent) Misspelling() (app.Misspelling, bool) {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	fenced := fencedRows(m.textarea.Value())
+	info := m.textarea.LineInfo()
+	col := info.StartColumn + info.ColumnOffset
+	for row := min(m.textarea.Line(), len(lines)-1); row >= 0; row-- {
+		if fenced[row] {
+			continue
+		}
+		line := []rune(lines[row])
+		words := m.app.Dictionary().Misspelled(line)
+		for i := len(words) - 1; i >= 0; i-- {
+			word := words[i]
+			if row == m.textarea.Line() && word.Start > col {
+				continue
+			}
+			return app.Misspelling{
+				Row:   row,
+				Start: word.Start,
+				End:   word.End,
+				Word:  string(line[word.Start:word.End]),
+			}, true
+		}
+	}
+	return app.Misspelling{}, false
+}
+
+// Correct replaces a misspelled word, leaving the cursor after it
+func (m *editorComponent) Correct(misspelling app.Misspelling, replacement string) {
+	lines := strings.Split(m.textarea.Value(), "\n")
+	if misspelling.Row >= len(lines) {
+		return
+	}
+	line := []rune(lines[misspelling.Row])
+	if misspelling.End > len(line) || string(line[misspelling.Start:misspelling.End]) != misspelling.Word {
+		return
+	}
+	lines[misspelling.Row] = string(line[:misspelling.Start]) + replacement + string(line[misspelling.End:])
+	m.textarea.SetValue(strings.Join(lines, "\n"))
+
+	offset := misspelling.Start + len([]rune(replacement))
+	for _, line := range lines[:misspelling.Row] {
+		offset += len([]rune(line)) + 1
+	}
+	m.setCursorOffset(offset)
+}
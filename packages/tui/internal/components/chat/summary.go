@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// isSummary reports whether a message is a summary written by compacting
+// the session
+func isSummary(message client.MessageInfo) bool {
+	assistant := message.Metadata.Assistant
+	return assistant != nil && assistant.Summary != nil && *assistant.Summary
+}
+
+// summaryKey is the key of the summary block of a message in the expanded map
+func summaryKey(messageID string) string {
+	return "summary:" + messageID
+}
+
+// summarized decides which messages are collapsed into a later summary and
+// counts the messages each summary replaced. A message is only shown when
+// every summary after it is expanded or when it is pinned, so the messages
+// are not rendered at all until asked for.
+func summarized(messages []client.MessageInfo, expanded, pinned map[string]bool) ([]bool, map[string]int) {
+	visible := make([]bool, len(messages))
+	counts := map[string]int{}
+	previous := 0
+	for i, message := range messages {
+		if isSummary(message) {
+			counts[message.Id] = i - previous
+			previous = i
+		}
+	}
+	shown := true
+	for i := len(messages) - 1; i >= 0; i-- {
+		visible[i] = shown || pinned[messages[i].Id]
+		if isSummary(messages[i]) && counts[messages[i].Id] > 0 {
+			shown = shown && expanded[summaryKey(messages[i].Id)]
+		}
+	}
+	return visible, counts
+}
+
+// renderSummaryHeader renders the line standing in for the messages a
+// summary replaced
+func renderSummaryHeader(count int, expanded bool) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundPanel()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundPanel()).Render
+
+	marker := "▸ "
+	hint := i18n.T("chat.click_to_show")
+	if expanded {
+		marker = "▾ "
+		hint = i18n.T("chat.click_to_hide")
+	}
+	label := i18n.N("chat.summary", count)
+	line := muted(marker) + base(label) + muted(" · "+hint)
+	return renderDiffStatBlock(styles.NewStyle().Background(t.BackgroundPanel()).Width(diffStatWidth()).MaxHeight(1).Render(line))
+}
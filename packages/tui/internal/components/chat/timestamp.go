@@ -0,0 +1,93 @@
+package chat
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	defaultTimestampFormat = "02 Jan 2006 03:04 PM"
+	timestampRefresh       = 30 * time.Second
+)
+
+type ToggleTimestampsMsg struct{}
+type timestampTickMsg struct{}
+
+var locations sync.Map
+
+// timestampLocation resolves the configured timezone, defaulting to local time
+func timestampLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		renderer.Warn("Unknown timezone, using local time", "timezone", name, "error", err)
+		location = time.Local
+	}
+	locations.Store(name, location)
+	return location
+}
+
+// formatTimestamp renders a message timestamp in the configured format, or
+// relative to now when relative timestamps are enabled
+func formatTimestamp(created float32, state *config.State, verbose bool) string {
+	at := time.UnixMilli(int64(created))
+	if state.RelativeTimestamps {
+		return formatRelative(time.Since(at))
+	}
+
+	at = at.In(timestampLocation(state.Timezone))
+	if state.TimestampFormat != "" {
+		return at.Format(state.TimestampFormat)
+	}
+	timestamp := at.Format(defaultTimestampFormat)
+	if time.Now().In(at.Location()).Format("02 Jan 2006") == timestamp[:11] && !verbose {
+		// don't show the date if it's today
+		timestamp = timestamp[12:]
+	}
+	return timestamp
+}
+
+func formatRelative(elapsed time.Duration) string {
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm ago", int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(elapsed.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(elapsed.Hours()/24))
+	}
+}
+
+// turnDuration returns how long a completed assistant turn took
+func turnDuration(message client.MessageInfo) string {
+	completed := message.Metadata.Time.Completed
+	if message.Role != client.Assistant || completed == nil {
+		return ""
+	}
+	elapsed := time.Duration(*completed-message.Metadata.Time.Created) * time.Millisecond
+	if elapsed <= 0 {
+		return ""
+	}
+	if elapsed < 10*time.Second {
+		return fmt.Sprintf("%.1fs", elapsed.Seconds())
+	}
+	return formatElapsed(elapsed)
+}
+
+func tickTimestamps() tea.Cmd {
+	return tea.Tick(timestampRefresh, func(time.Time) tea.Msg {
+		return timestampTickMsg{}
+	})
+}
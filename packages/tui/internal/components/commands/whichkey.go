@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/sst/opencode/internal/commands"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// WhichKey renders the popup listing the keys that can follow the leader
+// and the keys already pressed in the sequence
+func WhichKey(registry commands.CommandRegistry, leader string, pressed []string) string {
+	_, continuations := registry.LeaderSequence(pressed)
+	if len(continuations) == 0 {
+		return ""
+	}
+
+	t := theme.CurrentTheme()
+	keyStyle := styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Bold(true)
+	descriptionStyle := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement())
+	groupStyle := styles.NewStyle().Foreground(t.Accent()).Background(t.BackgroundElement())
+
+	keyWidth := 0
+	for _, continuation := range continuations {
+		keyWidth = max(keyWidth, lipgloss.Width(continuation.Key))
+	}
+
+	lines := []string{}
+	for _, continuation := range continuations {
+		key := keyStyle.Width(keyWidth + 2).Render(continuation.Key)
+		command := continuation.Commands[0]
+		steps := []string{}
+		for _, binding := range command.Keybindings {
+			if binding.RequiresLeader && len(binding.Steps()) == len(pressed)+1 {
+				steps = binding.Steps()
+			}
+		}
+		if len(continuation.Commands) == 1 && len(steps) > 0 {
+			lines = append(lines, key+descriptionStyle.Render(command.Description))
+			continue
+		}
+		lines = append(lines, key+groupStyle.Render(fmt.Sprintf("+%d commands", len(continuation.Commands))))
+	}
+
+	title := strings.TrimSpace(leader + " " + strings.Join(pressed, " "))
+	return styles.NewStyle().
+		Background(t.BackgroundElement()).
+		Foreground(t.TextMuted()).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(t.BorderActive()).
+		BorderBackground(t.Background()).
+		Padding(0, 1).
+		Render(descriptionStyle.Foreground(t.TextMuted()).Render(title) + "\n" + strings.Join(lines, "\n"))
+}
@@ -0,0 +1,93 @@
+package dialog
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/textarea"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const projectContextDialogWidth = 90
+
+// ProjectContextDialog interface for reviewing the context file written by
+// the project initialization
+type ProjectContextDialog interface {
+	layout.Modal
+}
+
+type projectContextDialog struct {
+	modal    *modal.Modal
+	textarea textarea.Model
+	context  app.ProjectContextMsg
+	approved bool
+}
+
+func (p *projectContextDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *projectContextDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "ctrl+s" {
+		p.approved = true
+		return p, tea.Sequence(
+			util.CmdHandler(modal.CloseModalMsg{}),
+			util.CmdHandler(app.ApproveProjectContextMsg{Context: p.context, Content: p.textarea.Value()}),
+		)
+	}
+	var cmd tea.Cmd
+	p.textarea, cmd = p.textarea.Update(msg)
+	return p, cmd
+}
+
+func (p *projectContextDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("ctrl+s") + muted(" approve and initialize  ") + base("esc") + muted(" discard")
+	return p.modal.Render(p.textarea.View()+"\n\n"+help, background)
+}
+
+// Close discards the generated file unless it was approved
+func (p *projectContextDialog) Close() tea.Cmd {
+	if p.approved {
+		return nil
+	}
+	return util.CmdHandler(app.DiscardProjectContextMsg{Context: p.context})
+}
+
+// NewProjectContextDialog creates an editor previewing the context file the
+// project initialization wrote, the project is marked initialized once it is
+// approved
+func NewProjectContextDialog(context app.ProjectContextMsg) ProjectContextDialog {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+	width := min(projectContextDialogWidth, layout.Current.Container.Width-8)
+
+	ta := textarea.New()
+	ta.Styles.Focused.Base = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Focused.CursorLine = styles.NewStyle().Background(bg).Lipgloss()
+	ta.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Lipgloss()
+	ta.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Blurred = ta.Styles.Focused
+	ta.Styles.Cursor.Color = t.Primary()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	ta.CharLimit = -1
+	ta.SetWidth(width - 4)
+	ta.SetHeight(max(layout.Current.Viewport.Height-12, 8))
+	ta.SetValue(context.Content)
+	ta.SetCursor(0, 0)
+	ta.Focus()
+
+	return &projectContextDialog{
+		textarea: ta,
+		context:  context,
+		modal:    modal.New(modal.WithTitle("Review "+filepath.Base(context.Path)), modal.WithMaxWidth(width)),
+	}
+}
@@ -0,0 +1,131 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const approveDialogWidth = 64
+
+// ApproveDialog interface for answering a permission request of a tool
+type ApproveDialog interface {
+	layout.Modal
+}
+
+type approveOption struct {
+	label    string
+	response string
+	remember bool
+}
+
+type approveDialog struct {
+	modal      *modal.Modal
+	list       list.List[list.StringItem]
+	permission client.PermissionInfo
+	options    []approveOption
+	answered   bool
+}
+
+func (a *approveDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (a *approveDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := a.list.GetSelectedItem(); idx >= 0 {
+				option := a.options[idx]
+				a.answered = true
+				return a, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					a.respond(option.response, option.remember),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := a.list.Update(msg)
+	a.list = listModel.(list.List[list.StringItem])
+	return a, cmd
+}
+
+func (a *approveDialog) respond(response string, remember bool) tea.Cmd {
+	return util.CmdHandler(app.PermissionRespondMsg{
+		Permission: a.permission,
+		Response:   response,
+		Remember:   remember,
+	})
+}
+
+func (a *approveDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	title := styles.NewStyle().
+		Foreground(t.Text()).
+		Background(t.BackgroundElement()).
+		Width(approveDialogWidth - 4).
+		Render(a.permission.Title)
+	return a.modal.Render(title+"\n\n"+a.list.View(), background)
+}
+
+// Close rejects the request unless it was answered, the tool would wait
+// forever otherwise
+func (a *approveDialog) Close() tea.Cmd {
+	if a.answered {
+		return nil
+	}
+	a.answered = true
+	return a.respond(app.PermissionReject, false)
+}
+
+// sessionApprovalLabel names what an approval for the session covers, the
+// server remembers it for the same command or file only
+func sessionApprovalLabel(permission client.PermissionInfo) string {
+	switch permission.Metadata["tool"] {
+	case "bash":
+		return "Allow this command for this session"
+	case "edit", "write":
+		return "Allow changes to this file for this session"
+	}
+	return "Allow this request for this session"
+}
+
+// NewApproveDialog creates a dialog asking whether a tool call may run, rule
+// is the auto-approve rule offered for such calls, if any
+func NewApproveDialog(permission client.PermissionInfo, rule string) ApproveDialog {
+	options := []approveOption{
+		{label: "Allow once", response: app.PermissionOnce},
+		{label: sessionApprovalLabel(permission), response: app.PermissionAlways},
+	}
+	if rule != "" {
+		options = append(options, approveOption{
+			label:    ansi.Truncate("Always allow "+rule, approveDialogWidth-8, "…"),
+			response: app.PermissionOnce,
+			remember: true,
+		})
+	}
+	options = append(options, approveOption{label: "Reject", response: app.PermissionReject})
+
+	items := make([]string, len(options))
+	for i, option := range options {
+		items[i] = option.label
+	}
+	list := list.NewStringList(items, len(items), "", true)
+	list.SetMaxWidth(approveDialogWidth - 4)
+
+	return &approveDialog{
+		list:       list,
+		permission: permission,
+		options:    options,
+		modal:      modal.New(modal.WithTitle("Permission Required"), modal.WithMaxWidth(approveDialogWidth)),
+	}
+}
@@ -0,0 +1,205 @@
+package dialog
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	imageutil "github.com/sst/opencode/internal/image"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// AttachmentsDialog interface for picking the pending attachment to preview
+// or remove
+type AttachmentsDialog interface {
+	layout.Modal
+}
+
+type attachmentsDialog struct {
+	modal  *modal.Modal
+	list   list.List[list.StringItem]
+	remove bool
+}
+
+func (d *attachmentsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *attachmentsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := d.list.GetSelectedItem(); idx >= 0 {
+				var picked tea.Msg = app.PreviewAttachmentMsg{Index: idx}
+				if d.remove {
+					picked = app.RemoveAttachmentMsg{Index: idx}
+				}
+				return d, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(picked),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := d.list.Update(msg)
+	d.list = listModel.(list.List[list.StringItem])
+	return d, cmd
+}
+
+func (d *attachmentsDialog) Render(background string) string {
+	return d.modal.Render(d.list.View(), background)
+}
+
+func (d *attachmentsDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewAttachmentsDialog creates a dialog listing the pending attachments, the
+// picked one is previewed, or removed when remove is set
+func NewAttachmentsDialog(attachments []app.Attachment, remove bool) AttachmentsDialog {
+	items := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		items[i] = ansi.Truncate(app.AttachmentSummary(attachment), 56, "…")
+	}
+	list := list.NewStringList(items, min(len(items), 10), "", true)
+	list.SetMaxWidth(56)
+
+	title := "Preview Attachment"
+	if remove {
+		title = "Remove Attachment"
+	}
+	return &attachmentsDialog{
+		list:   list,
+		remove: remove,
+		modal:  modal.New(modal.WithTitle(title), modal.WithMaxWidth(60)),
+	}
+}
+
+// NewAttachmentPreviewDialog shows an attachment before it is sent, images
+// are drawn with the graphics protocol of the terminal or else with half
+// blocks, and text is shown as it is
+func NewAttachmentPreviewDialog(attachment app.Attachment, protocol imageutil.Protocol) FilePreviewDialog {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	width := min(previewDialogWidth, layout.Current.Viewport.Width-4) - 4
+	title := fmt.Sprintf("%s (%s)", attachment.FileName, app.FormatSize(len(attachment.Content)))
+
+	var content string
+	if strings.HasPrefix(attachment.MimeType, "image/") {
+		img, _, err := image.Decode(bytes.NewReader(attachment.Content))
+		if err != nil {
+			content = muted("Could not decode image: " + err.Error())
+		} else if protocol != imageutil.ProtocolNone {
+			return newImagePreviewDialog(title, img, protocol, width)
+		} else {
+			size := img.Bounds().Size()
+			content = strings.TrimSuffix(imageutil.ToString(min(width, size.X), img), "\n") +
+				"\n\n" + muted(fmt.Sprintf("%d×%d", size.X, size.Y))
+		}
+	} else {
+		lines := strings.Split(strings.TrimRight(string(attachment.Content), "\n"), "\n")
+		if len(lines) > previewMaxLines {
+			lines = append(lines[:previewMaxLines], muted(fmt.Sprintf("… %d more lines", len(lines)-previewMaxLines)))
+		}
+		for i, line := range lines {
+			lines[i] = ansi.Truncate(strings.ReplaceAll(line, "\t", "  "), width, "…")
+		}
+		content = strings.Join(lines, "\n")
+	}
+
+	return newScrollDialog(title, content, width)
+}
+
+// previewImageID is the kitty image id of the previewed image, apart from
+// the ids of the images in the messages
+const previewImageID = 0xfffffe
+
+// drawImageMsg draws the previewed image once the dialog is on the screen
+type drawImageMsg struct{}
+
+// imagePreviewDialog previews an image with a graphics protocol. Kitty
+// shows it through placeholder text, iTerm2 and sixel draw it over blank
+// cells kept for it once the dialog is rendered.
+type imagePreviewDialog struct {
+	modal      *modal.Modal
+	protocol   imageutil.Protocol
+	img        image.Image
+	content    string
+	cols, rows int
+	x, y       int
+}
+
+func newImagePreviewDialog(title string, img image.Image, protocol imageutil.Protocol, width int) *imagePreviewDialog {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	size := img.Bounds().Size()
+	cols, rows := imageutil.Fit(size.X, size.Y, width, max(layout.Current.Viewport.Height-14, 4))
+
+	area := imageutil.KittyPlaceholder(previewImageID, cols, rows)
+	if protocol != imageutil.ProtocolKitty {
+		area = strings.TrimSuffix(strings.Repeat(strings.Repeat(" ", cols)+"\n", rows), "\n")
+	}
+	return &imagePreviewDialog{
+		modal:    modal.New(modal.WithTitle(title), modal.WithMaxWidth(width+4)),
+		protocol: protocol,
+		img:      img,
+		content:  area + "\n\n" + muted(fmt.Sprintf("%d×%d", size.X, size.Y)),
+		cols:     cols,
+		rows:     rows,
+	}
+}
+
+func (d *imagePreviewDialog) Init() tea.Cmd {
+	if d.protocol == imageutil.ProtocolKitty {
+		seq, err := imageutil.KittyTransmit(previewImageID, d.img, d.cols, d.rows)
+		if err != nil {
+			return nil
+		}
+		return tea.Raw(seq)
+	}
+	return tea.Tick(50*time.Millisecond, func(time.Time) tea.Msg {
+		return drawImageMsg{}
+	})
+}
+
+func (d *imagePreviewDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tea.WindowSizeMsg:
+		if d.protocol != imageutil.ProtocolKitty {
+			return d, tea.Sequence(tea.ClearScreen, d.Init())
+		}
+	case drawImageMsg:
+		seq, err := imageutil.Draw(d.protocol, d.img, d.x, d.y, d.cols, d.rows)
+		if err != nil {
+			return d, nil
+		}
+		return d, tea.Raw(seq)
+	}
+	return d, nil
+}
+
+func (d *imagePreviewDialog) Render(background string) string {
+	d.x, d.y = d.modal.ContentOrigin(d.content, background)
+	return d.modal.Render(d.content, background)
+}
+
+// Close frees the image, the pixels iTerm2 and sixel drew stay on the
+// screen until it is cleared
+func (d *imagePreviewDialog) Close() tea.Cmd {
+	if d.protocol == imageutil.ProtocolKitty {
+		return tea.Raw(imageutil.KittyDelete(previewImageID))
+	}
+	return tea.ClearScreen
+}
@@ -0,0 +1,130 @@
+package dialog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const auditDialogWidth = 110
+
+// AuditDialog interface for the audit log pane of a session
+type AuditDialog interface {
+	layout.Modal
+}
+
+type auditDialog struct {
+	modal    *modal.Modal
+	viewport viewport.Model
+	entries  []client.AuditEntry
+}
+
+func (d *auditDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *auditDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		d.viewport.SetHeight(max(msg.Height-14, 5))
+	case tea.KeyPressMsg:
+		if msg.String() == "e" && len(d.entries) > 0 {
+			return d, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.ExportAuditMsg{Entries: d.entries}),
+			)
+		}
+	}
+	var cmd tea.Cmd
+	d.viewport, cmd = d.viewport.Update(msg)
+	return d, cmd
+}
+
+// load renders each tool call on a line with its outcome, followed by its
+// arguments and the files it touched
+func (d *auditDialog) load() {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	text := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	tool := styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Bold(true).Render
+	failed := styles.NewStyle().Foreground(t.Error()).Background(t.BackgroundElement()).Render
+
+	if len(d.entries) == 0 {
+		d.viewport.SetContent(muted("No tool calls in this session yet"))
+		return
+	}
+	width := d.viewport.Width()
+	lines := []string{}
+	for _, entry := range d.entries {
+		start := time.UnixMilli(int64(entry.Time.Start))
+		duration := time.Duration(entry.Time.End-entry.Time.Start) * time.Millisecond
+		line := muted(start.Format("15:04:05")+" ") + tool(entry.Tool) + muted(" "+duration.Round(time.Millisecond).String())
+		switch {
+		case entry.Error != nil:
+			line += " " + failed(strings.Join(strings.Fields(*entry.Error), " "))
+		case entry.Exit != nil && *entry.Exit != 0:
+			line += " " + failed(fmt.Sprintf("exit %d", *entry.Exit))
+		case entry.Exit != nil:
+			line += " " + text("exit 0")
+		}
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+		if entry.Args != nil {
+			args, _ := json.Marshal(*entry.Args)
+			lines = append(lines, ansi.Truncate(muted("  "+string(args)), width, "…"))
+		}
+		for _, file := range entry.Files {
+			change := fmt.Sprintf("  %-9s ", app.AuditChange(file))
+			hashes := auditHash(file.Before) + " → " + auditHash(file.After)
+			lines = append(lines, ansi.Truncate(muted(change)+text(file.Path)+muted("  "+hashes), width, "…"))
+		}
+	}
+	d.viewport.SetContent(strings.Join(lines, "\n"))
+	d.viewport.GotoBottom()
+}
+
+// auditHash shortens a file hash for display
+func auditHash(hash *string) string {
+	if hash == nil {
+		return "-"
+	}
+	return (*hash)[:min(len(*hash), 12)]
+}
+
+func (d *auditDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := muted(fmt.Sprintf("%d tool calls  ", len(d.entries))) + base("e") + muted(" export")
+	return d.modal.Render(d.viewport.View()+"\n\n"+help, background)
+}
+
+func (d *auditDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewAuditDialog creates a pane showing the audit log of a session, the
+// latest tool calls in view
+func NewAuditDialog(entries []client.AuditEntry) AuditDialog {
+	dialog := &auditDialog{
+		entries: entries,
+		viewport: viewport.New(
+			viewport.WithWidth(min(auditDialogWidth, layout.Current.Container.Width-8)-4),
+			viewport.WithHeight(max(layout.Current.Viewport.Height-14, 5)),
+		),
+		modal: modal.New(modal.WithTitle("Audit Log"), modal.WithMaxWidth(auditDialogWidth)),
+	}
+	dialog.load()
+	return dialog
+}
@@ -0,0 +1,69 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+// CheckpointDialog interface for picking a checkpoint to restore
+type CheckpointDialog interface {
+	layout.Modal
+}
+
+type checkpointDialog struct {
+	modal       *modal.Modal
+	list        list.List[list.StringItem]
+	checkpoints []config.Checkpoint
+}
+
+func (c *checkpointDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *checkpointDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := c.list.GetSelectedItem(); idx >= 0 && idx < len(c.checkpoints) {
+				return c, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.RestoreCheckpointMsg{Name: c.checkpoints[idx].Name}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := c.list.Update(msg)
+	c.list = listModel.(list.List[list.StringItem])
+	return c, cmd
+}
+
+func (c *checkpointDialog) Render(background string) string {
+	return c.modal.Render(c.list.View(), background)
+}
+
+func (c *checkpointDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewCheckpointDialog creates a dialog listing the checkpoints of a session
+func NewCheckpointDialog(checkpoints []config.Checkpoint) CheckpointDialog {
+	items := []string{}
+	for _, checkpoint := range checkpoints {
+		items = append(items, checkpoint.Name+"  "+checkpoint.Created.Format("15:04:05"))
+	}
+	list := list.NewStringList(items, 8, "No checkpoints", true)
+	list.SetMaxWidth(40)
+
+	return &checkpointDialog{
+		list:        list,
+		checkpoints: checkpoints,
+		modal:       modal.New(modal.WithTitle("Restore Checkpoint"), modal.WithMaxWidth(44)),
+	}
+}
@@ -0,0 +1,146 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const cleanupDialogWidth = 80
+
+// CleanupDialog interface for reviewing the sessions the retention policy
+// would delete
+type CleanupDialog interface {
+	layout.Modal
+}
+
+type cleanupDialog struct {
+	modal      *modal.Modal
+	candidates []app.CleanupCandidate
+	// deleted marks the candidates that are deleted, the other ones are kept
+	deleted []bool
+	cursor  int
+	offset  int
+	height  int
+	width   int
+}
+
+func (c *cleanupDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *cleanupDialog) scroll() {
+	c.cursor = min(max(c.cursor, 0), len(c.candidates)-1)
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	} else if c.cursor >= c.offset+c.height {
+		c.offset = c.cursor - c.height + 1
+	}
+}
+
+func (c *cleanupDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.height = max(msg.Height-14, 5)
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "up", "k":
+			c.cursor--
+		case "down", "j":
+			c.cursor++
+		case "space", " ", "x":
+			c.deleted[c.cursor] = !c.deleted[c.cursor]
+		case "a":
+			all := !c.deleted[c.cursor]
+			for i := range c.deleted {
+				c.deleted[i] = all
+			}
+		case "enter":
+			reviewed := app.CleanupReviewedMsg{}
+			for i, candidate := range c.candidates {
+				if c.deleted[i] {
+					reviewed.Delete = append(reviewed.Delete, candidate.Session.Id)
+				} else {
+					reviewed.Keep = append(reviewed.Keep, candidate.Session.Id)
+				}
+			}
+			return c, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(reviewed),
+			)
+		}
+	}
+	c.scroll()
+	return c, nil
+}
+
+func (c *cleanupDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(bg).Render
+	kept := styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Render
+
+	lines := []string{}
+	deleted := 0
+	for i, candidate := range c.candidates {
+		if c.deleted[i] {
+			deleted++
+		}
+		if i < c.offset || i >= c.offset+c.height {
+			continue
+		}
+		marker, render := "[x]", base
+		if !c.deleted[i] {
+			marker, render = "[ ]", kept
+		}
+		updated := time.UnixMilli(int64(candidate.Session.Time.Updated)).Local().Format("Jan 2 2006")
+		info := fmt.Sprintf("%s, %d message(s)", updated, candidate.Messages)
+		title := ansi.Truncate(candidate.Session.Title, c.width-len(marker)-len(info)-4, "…")
+		gap := max(c.width-len(marker)-1-ansi.StringWidth(title)-len(info), 1)
+		line := render(marker+" "+title) + muted(strings.Repeat(" ", gap)+info)
+		if i == c.cursor {
+			line = styles.NewStyle().Background(t.Primary()).Foreground(t.Background()).
+				Width(c.width).Render(ansi.Strip(line))
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "",
+		base("space")+muted(" delete/keep  ")+base("a")+muted(" all  ")+
+			base("enter")+muted(fmt.Sprintf(" delete %d, keep the rest  ", deleted))+
+			base("esc")+muted(" later"),
+	)
+	return c.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (c *cleanupDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewCleanupDialog creates a dialog reviewing the sessions older than days
+// before they are deleted, every one is deleted unless unmarked
+func NewCleanupDialog(candidates []app.CleanupCandidate, days int) CleanupDialog {
+	deleted := make([]bool, len(candidates))
+	for i := range deleted {
+		deleted[i] = true
+	}
+	return &cleanupDialog{
+		candidates: candidates,
+		deleted:    deleted,
+		height:     max(layout.Current.Viewport.Height-14, 5),
+		width:      min(cleanupDialogWidth, layout.Current.Container.Width-8) - 4,
+		modal: modal.New(
+			modal.WithTitle(fmt.Sprintf("Clean Up Sessions Older Than %d Days", days)),
+			modal.WithMaxWidth(cleanupDialogWidth),
+		),
+	}
+}
@@ -0,0 +1,66 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+// CompareDialog interface for picking the winner of a model comparison
+type CompareDialog interface {
+	layout.Modal
+}
+
+type compareDialog struct {
+	modal *modal.Modal
+	list  list.List[list.StringItem]
+}
+
+func (c *compareDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *compareDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := c.list.GetSelectedItem(); idx >= 0 {
+				return c, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.CompareWinnerSelectedMsg{Side: idx}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := c.list.Update(msg)
+	c.list = listModel.(list.List[list.StringItem])
+	return c, cmd
+}
+
+func (c *compareDialog) Render(background string) string {
+	return c.modal.Render(c.list.View(), background)
+}
+
+func (c *compareDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewCompareDialog creates a dialog to pick which model to continue with
+func NewCompareDialog(comparison *app.Comparison) CompareDialog {
+	items := []string{}
+	for _, side := range comparison.Sides {
+		items = append(items, side.Provider.Name+" "+side.Model.Name)
+	}
+	list := list.NewStringList(items, 2, "Nothing to compare", true)
+	list.SetMaxWidth(36)
+
+	return &compareDialog{
+		list:  list,
+		modal: modal.New(modal.WithTitle("Continue With"), modal.WithMaxWidth(40)),
+	}
+}
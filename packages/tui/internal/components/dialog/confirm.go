@@ -0,0 +1,93 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// ConfirmDialog interface for confirming a destructive action
+type ConfirmDialog interface {
+	layout.Modal
+}
+
+type confirmDialog struct {
+	modal    *modal.Modal
+	message  string
+	confirm  tea.Msg
+	remember tea.Msg
+}
+
+// ConfirmOption configures a confirm dialog
+type ConfirmOption func(*confirmDialog)
+
+// WithRemember offers to confirm without being asked again, remember is
+// sent before the confirm message then
+func WithRemember(remember tea.Msg) ConfirmOption {
+	return func(c *confirmDialog) {
+		c.remember = remember
+	}
+}
+
+func (c *confirmDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *confirmDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter", "y":
+			return c, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(c.confirm),
+			)
+		case "a":
+			if c.remember == nil {
+				return c, nil
+			}
+			return c, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(c.remember),
+				util.CmdHandler(c.confirm),
+			)
+		case "n":
+			return c, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return c, nil
+}
+
+func (c *confirmDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement())
+
+	content := base.Render(c.message) + "\n\n" + base.Render("enter") + muted.Render(" "+i18n.T("dialog.confirm")+"  ")
+	if c.remember != nil {
+		content += base.Render("a") + muted.Render(" "+i18n.T("dialog.dont_ask_again")+"  ")
+	}
+	content += base.Render("esc") + muted.Render(" "+i18n.T("dialog.cancel"))
+	return c.modal.Render(content, background)
+}
+
+func (c *confirmDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewConfirmDialog creates a dialog that sends confirm once the user accepts
+func NewConfirmDialog(title, message string, confirm tea.Msg, options ...ConfirmOption) ConfirmDialog {
+	dialog := &confirmDialog{
+		message: message,
+		confirm: confirm,
+		modal:   modal.New(modal.WithTitle(title), modal.WithMaxWidth(56)),
+	}
+	for _, option := range options {
+		option(dialog)
+	}
+	return dialog
+}
@@ -0,0 +1,207 @@
+package dialog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const contextDialogWidth = 100
+
+// ContextDialog interface for picking the history sent with the next prompt
+type ContextDialog interface {
+	layout.Modal
+}
+
+type contextDialog struct {
+	modal   *modal.Modal
+	entries []app.ContextEntry
+	plan    app.ContextPlan
+	// mark is the first message of the range being collapsed, -1 when none
+	mark   int
+	cursor int
+	offset int
+	height int
+	width  int
+}
+
+func (c *contextDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *contextDialog) scroll() {
+	c.cursor = min(max(c.cursor, 0), len(c.entries)-1)
+	if c.cursor < c.offset {
+		c.offset = c.cursor
+	} else if c.cursor >= c.offset+c.height {
+		c.offset = c.cursor - c.height + 1
+	}
+}
+
+// toggleExcluded leaves the message under the cursor out, or keeps it again
+func (c *contextDialog) toggleExcluded() {
+	if _, ok := c.plan.RangeAt(c.cursor); ok {
+		return
+	}
+	id := c.entries[c.cursor].Message.Id
+	if c.plan.Excluded[id] {
+		delete(c.plan.Excluded, id)
+	} else {
+		c.plan.Excluded[id] = true
+	}
+}
+
+// collapse marks the start of a range, collapses the range from the mark to
+// the cursor, or expands the range under the cursor again
+func (c *contextDialog) collapse() {
+	if r, ok := c.plan.RangeAt(c.cursor); ok && c.mark < 0 {
+		c.plan.Collapsed = slices.DeleteFunc(c.plan.Collapsed, func(other app.ContextRange) bool {
+			return other == r
+		})
+		return
+	}
+	if c.mark < 0 {
+		c.mark = c.cursor
+		return
+	}
+	r := app.ContextRange{From: min(c.mark, c.cursor), To: max(c.mark, c.cursor)}
+	c.mark = -1
+	// ranges never overlap, any range inside the new one is merged into it
+	c.plan.Collapsed = slices.DeleteFunc(c.plan.Collapsed, func(other app.ContextRange) bool {
+		if other.To < r.From || other.From > r.To {
+			return false
+		}
+		r.From, r.To = min(r.From, other.From), max(r.To, other.To)
+		return true
+	})
+	for i := r.From; i <= r.To; i++ {
+		delete(c.plan.Excluded, c.entries[i].Message.Id)
+	}
+	c.plan.Collapsed = append(c.plan.Collapsed, r)
+}
+
+func (c *contextDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.height = max(msg.Height-14, 5)
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "up", "k":
+			c.cursor--
+		case "down", "j":
+			c.cursor++
+		case "pgup":
+			c.cursor -= c.height
+		case "pgdown":
+			c.cursor += c.height
+		case "home", "g":
+			c.cursor = 0
+		case "end", "G":
+			c.cursor = len(c.entries) - 1
+		case "space", " ", "x":
+			c.toggleExcluded()
+		case "v":
+			c.collapse()
+		case "r":
+			c.plan = app.ContextPlan{Excluded: map[string]bool{}}
+			c.mark = -1
+		case "enter":
+			if !c.plan.Changed() {
+				return c, util.CmdHandler(modal.CloseModalMsg{})
+			}
+			return c, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.ApplyContextMsg{Plan: c.plan}),
+			)
+		}
+	}
+	c.scroll()
+	return c, nil
+}
+
+func (c *contextDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(bg).Render
+	excluded := styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Strikethrough(true).Render
+	collapsed := styles.NewStyle().Foreground(t.Accent()).Background(bg).Render
+	marked := styles.NewStyle().Foreground(t.Warning()).Background(bg).Render
+
+	lines := []string{}
+	for i := c.offset; i < min(c.offset+c.height, len(c.entries)); i++ {
+		entry := c.entries[i]
+		role := "user     "
+		if entry.Message.Role == client.Assistant {
+			role = "assistant"
+		}
+		text := app.ContextExcerpt(entry.Message)
+		if text == "" {
+			text = "(tool calls)"
+		}
+
+		marker, render := "✓", base
+		switch r, ok := c.plan.RangeAt(i); {
+		case ok:
+			marker, render = "│", collapsed
+			if i == r.From {
+				marker = "┌"
+			} else if i == r.To {
+				marker = "└"
+			}
+		case c.plan.Excluded[entry.Message.Id]:
+			marker, render = "✗", excluded
+		}
+		if i == c.mark {
+			marker, render = "▸", marked
+		}
+		line := render(fmt.Sprintf("%s %s %7s  ", marker, role, fmt.Sprintf("~%d", entry.Tokens))) + render(text)
+		line = ansi.Truncate(line, c.width, "…")
+		if i == c.cursor {
+			line = styles.NewStyle().Background(t.Primary()).Foreground(t.Background()).
+				Width(c.width).Render(ansi.Strip(line))
+		}
+		lines = append(lines, line)
+	}
+
+	total := 0
+	for _, entry := range c.entries {
+		total += entry.Tokens
+	}
+	lines = append(lines, "",
+		base("space")+muted(" exclude  ")+base("v")+muted(" collapse range  ")+
+			base("r")+muted(" reset  ")+base("enter")+muted(" apply  ")+
+			muted(fmt.Sprintf("~%d of ~%d tokens", c.plan.Tokens(c.entries), total)),
+	)
+	return c.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (c *contextDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewContextDialog creates a dialog listing the messages included in the
+// next request with their estimated token counts
+func NewContextDialog(entries []app.ContextEntry) ContextDialog {
+	dialog := &contextDialog{
+		entries: entries,
+		plan:    app.ContextPlan{Excluded: map[string]bool{}},
+		mark:    -1,
+		cursor:  len(entries) - 1,
+		height:  max(layout.Current.Viewport.Height-14, 5),
+		width:   min(contextDialogWidth, layout.Current.Container.Width-8) - 4,
+		modal:   modal.New(modal.WithTitle("Context"), modal.WithMaxWidth(contextDialogWidth)),
+	}
+	dialog.scroll()
+	return dialog
+}
@@ -0,0 +1,290 @@
+package dialog
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const (
+	designerDialogWidth = 56
+	designerRows        = 14
+	// designerHueStep and designerStep are how far one key press moves the
+	// hue, in degrees, and the saturation or lightness
+	designerHueStep = 5.0
+	designerStep    = 0.03
+)
+
+var themeName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ThemeDesignedMsg is sent when a theme made in the designer should be saved
+type ThemeDesignedMsg struct {
+	Name  string
+	Theme theme.Theme
+}
+
+// ThemeDesignerDialog interface for the theme creation dialog
+type ThemeDesignerDialog interface {
+	layout.Modal
+}
+
+type designerInput int
+
+const (
+	designerBrowsing designerInput = iota
+	designerHex
+	designerName
+)
+
+type themeDesignerDialog struct {
+	modal         *modal.Modal
+	draft         *theme.LoadedTheme
+	name          string
+	originalTheme string
+	// previous is the theme registered under the draft's name before
+	previous  theme.Theme
+	selected  int
+	offset    int
+	dark      bool
+	input     designerInput
+	textinput textinput.Model
+	saved     bool
+	err       string
+}
+
+func (d *themeDesignerDialog) Init() tea.Cmd {
+	return nil
+}
+
+// current returns the variant of the selected color being edited
+func (d *themeDesignerDialog) current() color.Color {
+	c := theme.Colors[d.selected].Get(d.draft)
+	if d.dark {
+		return c.Dark
+	}
+	return c.Light
+}
+
+// set changes the edited variant of the selected color and previews it
+func (d *themeDesignerDialog) set(value color.Color) tea.Cmd {
+	entry := theme.Colors[d.selected]
+	c := entry.Get(d.draft)
+	if d.dark {
+		c.Dark = value
+	} else {
+		c.Light = value
+	}
+	d.draft.SetColor(entry.Key, c)
+	theme.SetTheme(d.name)
+	return util.CmdHandler(ThemeSelectedMsg{ThemeName: d.name})
+}
+
+// adjust shifts the selected color in HSL space
+func (d *themeDesignerDialog) adjust(hue, saturation, lightness float64) tea.Cmd {
+	c, ok := colorful.MakeColor(d.current())
+	if !ok {
+		c = colorful.Color{R: 0.5, G: 0.5, B: 0.5}
+	}
+	h, s, l := c.Hsl()
+	h = h + hue
+	if h < 0 {
+		h += 360
+	} else if h >= 360 {
+		h -= 360
+	}
+	s = min(max(s+saturation, 0), 1)
+	l = min(max(l+lightness, 0), 1)
+	return d.set(lipgloss.Color(colorful.Hsl(h, s, l).Clamped().Hex()))
+}
+
+func (d *themeDesignerDialog) move(offset int) {
+	d.selected = (d.selected + offset + len(theme.Colors)) % len(theme.Colors)
+	if d.selected < d.offset {
+		d.offset = d.selected
+	} else if d.selected >= d.offset+designerRows {
+		d.offset = d.selected - designerRows + 1
+	}
+}
+
+func (d *themeDesignerDialog) edit(input designerInput, value string) tea.Cmd {
+	d.input = input
+	d.err = ""
+	d.textinput.SetValue(value)
+	d.textinput.CursorEnd()
+	return d.textinput.Focus()
+}
+
+func (d *themeDesignerDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return d, nil
+	}
+	if d.input != designerBrowsing {
+		if key.String() != "enter" {
+			var cmd tea.Cmd
+			d.textinput, cmd = d.textinput.Update(msg)
+			return d, cmd
+		}
+		value := strings.TrimSpace(d.textinput.Value())
+		switch d.input {
+		case designerHex:
+			c, err := colorful.Hex(value)
+			if err != nil {
+				d.err = "Use a hex color like #fab283"
+				return d, nil
+			}
+			d.input = designerBrowsing
+			return d, d.set(lipgloss.Color(c.Hex()))
+		case designerName:
+			if !themeName.MatchString(value) {
+				d.err = "Use letters, digits, - and _ only"
+				return d, nil
+			}
+			d.saved = true
+			if value != d.name {
+				theme.RegisterTheme(value, d.draft)
+				theme.SetTheme(value)
+				d.release()
+			}
+			return d, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(ThemeDesignedMsg{Name: value, Theme: d.draft}),
+			)
+		}
+	}
+
+	switch key.String() {
+	case "up", "k":
+		d.move(-1)
+	case "down", "j":
+		d.move(1)
+	case "left", "h":
+		return d, d.adjust(-designerHueStep, 0, 0)
+	case "right", "l":
+		return d, d.adjust(designerHueStep, 0, 0)
+	case "s":
+		return d, d.adjust(0, -designerStep, 0)
+	case "S", "shift+s":
+		return d, d.adjust(0, designerStep, 0)
+	case "-":
+		return d, d.adjust(0, 0, -designerStep)
+	case "+", "=":
+		return d, d.adjust(0, 0, designerStep)
+	case "tab":
+		d.dark = !d.dark
+	case "enter":
+		value := theme.ColorValue(d.current())
+		hex, _ := value.(string)
+		return d, d.edit(designerHex, strings.TrimPrefix(hex, "none"))
+	case "ctrl+s":
+		return d, d.edit(designerName, d.name)
+	}
+	return d, nil
+}
+
+func (d *themeDesignerDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(bg).Render
+	width := designerDialogWidth - 4
+
+	variant := "light"
+	if d.dark {
+		variant = "dark"
+	}
+	lines := []string{muted("editing the " + variant + " variant")}
+	for i := d.offset; i < min(d.offset+designerRows, len(theme.Colors)); i++ {
+		entry := theme.Colors[i]
+		c := entry.Get(d.draft).Light
+		if d.dark {
+			c = entry.Get(d.draft).Dark
+		}
+		swatch := styles.NewStyle().Background(bg).Render("  ")
+		if _, none := c.(lipgloss.NoColor); !none {
+			swatch = lipgloss.NewStyle().Background(c).Render("  ")
+		}
+		value := fmt.Sprint(theme.ColorValue(c))
+		label := fmt.Sprintf(" %-26s %s", entry.Key, value)
+		style := styles.NewStyle().Foreground(t.Text()).Background(bg)
+		if i == d.selected {
+			style = styles.NewStyle().Foreground(t.Background()).Background(t.Primary()).Bold(true)
+		}
+		lines = append(lines, swatch+style.Width(width-2).Render(label))
+	}
+
+	lines = append(lines, "")
+	switch d.input {
+	case designerHex:
+		lines = append(lines, muted("hex  ")+d.textinput.View())
+	case designerName:
+		lines = append(lines, muted("name ")+d.textinput.View())
+	default:
+		lines = append(lines,
+			base("←/→")+muted(" hue  ")+base("s/S")+muted(" saturation  ")+base("-/+")+muted(" lightness"),
+			base("enter")+muted(" hex  ")+base("tab")+muted(" dark/light  ")+base("ctrl+s")+muted(" save"),
+		)
+	}
+	if d.err != "" {
+		lines = append(lines, styles.NewStyle().Foreground(t.Error()).Background(bg).Render(d.err))
+	}
+	return d.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (d *themeDesignerDialog) Close() tea.Cmd {
+	if d.saved {
+		return nil
+	}
+	theme.SetTheme(d.originalTheme)
+	d.release()
+	return util.CmdHandler(ThemeSelectedMsg{ThemeName: d.originalTheme})
+}
+
+// release gives the draft's name back to the theme registered under it
+// before, the draft must no longer be active
+func (d *themeDesignerDialog) release() {
+	if d.previous != nil {
+		theme.RegisterTheme(d.name, d.previous)
+	} else {
+		theme.UnregisterTheme(d.name)
+	}
+}
+
+// NewThemeDesignerDialog creates a dialog to derive a new theme from the
+// palette of the current one, changes are previewed live
+func NewThemeDesignerDialog() ThemeDesignerDialog {
+	t := theme.CurrentTheme()
+	original := theme.CurrentThemeName()
+	name := strings.TrimSuffix(original, "-custom") + "-custom"
+	draft := theme.NewCustomTheme(name, t)
+	previous := theme.GetTheme(name)
+	theme.RegisterTheme(name, draft)
+
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetWidth(designerDialogWidth - 12)
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+
+	return &themeDesignerDialog{
+		modal:         modal.New(modal.WithTitle("Design Theme"), modal.WithMaxWidth(designerDialogWidth)),
+		draft:         draft,
+		name:          name,
+		originalTheme: original,
+		previous:      previous,
+		dark:          compat.HasDarkBackground,
+		textinput:     input,
+	}
+}
@@ -0,0 +1,118 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	inspectorDialogWidth = 100
+	inspectorBodyLines   = 4
+)
+
+// InspectorDialog interface for the client call debug overlay
+type InspectorDialog interface {
+	layout.Modal
+}
+
+type inspectorDialog struct {
+	modal     *modal.Modal
+	inspector *client.Inspector
+	viewport  viewport.Model
+}
+
+func (i *inspectorDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (i *inspectorDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		i.viewport.SetHeight(max(msg.Height-12, 5))
+	}
+	var cmd tea.Cmd
+	i.viewport, cmd = i.viewport.Update(msg)
+	return i, cmd
+}
+
+// content renders the recorded calls, newest first
+func (i *inspectorDialog) content() string {
+	t := theme.CurrentTheme()
+	width := i.viewport.Width()
+	value := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	success := styles.NewStyle().Foreground(t.Success()).Background(t.BackgroundElement()).Render
+	failure := styles.NewStyle().Foreground(t.Error()).Background(t.BackgroundElement()).Render
+
+	calls := i.inspector.Calls()
+	if len(calls) == 0 {
+		return muted("No client calls recorded yet")
+	}
+	body := func(label, text string) []string {
+		if text == "" {
+			return nil
+		}
+		wrapped := strings.Split(ansi.Hardwrap(strings.TrimSpace(text), width-4, true), "\n")
+		if len(wrapped) > inspectorBodyLines {
+			wrapped = append(wrapped[:inspectorBodyLines], "…")
+		}
+		lines := []string{muted("  " + label)}
+		for _, line := range wrapped {
+			lines = append(lines, muted("    "+line))
+		}
+		return lines
+	}
+
+	lines := []string{}
+	for idx := len(calls) - 1; idx >= 0; idx-- {
+		call := calls[idx]
+		status := success(fmt.Sprintf("%d", call.Status))
+		switch {
+		case call.Error != nil:
+			status = failure("error")
+		case call.Status >= 400:
+			status = failure(fmt.Sprintf("%d", call.Status))
+		}
+		header := muted(call.Started.Format("15:04:05.000")+"  ") +
+			value(call.Operation) + "  " + status +
+			muted(fmt.Sprintf("  %s", call.Duration.Round(1e6)))
+		lines = append(lines, ansi.Truncate(header, width, "…"))
+		if call.Error != nil {
+			lines = append(lines, body("error", call.Error.Error())...)
+		}
+		lines = append(lines, body("request", call.Request)...)
+		lines = append(lines, body("response", call.Response)...)
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (i *inspectorDialog) Render(background string) string {
+	i.viewport.SetContent(i.content())
+	return i.modal.Render(i.viewport.View(), background)
+}
+
+func (i *inspectorDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewInspectorDialog creates the overlay listing the calls recorded by the
+// client inspector
+func NewInspectorDialog(inspector *client.Inspector) InspectorDialog {
+	return &inspectorDialog{
+		inspector: inspector,
+		viewport: viewport.New(
+			viewport.WithWidth(inspectorDialogWidth-4),
+			viewport.WithHeight(max(layout.Current.Viewport.Height-12, 5)),
+		),
+		modal: modal.New(modal.WithTitle("Client Calls"), modal.WithMaxWidth(inspectorDialogWidth)),
+	}
+}
@@ -0,0 +1,240 @@
+package dialog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+const (
+	jsonDialogWidth = 100
+	// jsonExpandedDepth is how deep the tree is expanded when it opens
+	jsonExpandedDepth = 2
+)
+
+// jsonNode is a value of the inspected document, objects and arrays have
+// children and can be collapsed
+type jsonNode struct {
+	key       string
+	value     any
+	children  []*jsonNode
+	container bool
+	depth     int
+	collapsed bool
+}
+
+// newJSONNode builds the tree of a decoded value, object keys are sorted
+func newJSONNode(key string, value any, depth int) *jsonNode {
+	node := &jsonNode{key: key, value: value, depth: depth}
+	switch value := value.(type) {
+	case map[string]any:
+		node.container = true
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			node.children = append(node.children, newJSONNode(k, value[k], depth+1))
+		}
+	case []any:
+		node.container = true
+		for i, v := range value {
+			node.children = append(node.children, newJSONNode(fmt.Sprintf("%d", i), v, depth+1))
+		}
+	}
+	node.collapsed = node.container && depth >= jsonExpandedDepth
+	return node
+}
+
+// visible appends the node and its expanded descendants
+func (n *jsonNode) visible(nodes []*jsonNode) []*jsonNode {
+	nodes = append(nodes, n)
+	if n.container && !n.collapsed {
+		for _, child := range n.children {
+			nodes = child.visible(nodes)
+		}
+	}
+	return nodes
+}
+
+func (n *jsonNode) setCollapsed(collapsed bool) {
+	if n.container {
+		n.collapsed = collapsed && n.depth > 0
+	}
+	for _, child := range n.children {
+		child.setCollapsed(collapsed)
+	}
+}
+
+// JSONDialog interface for the collapsible JSON viewer
+type JSONDialog interface {
+	layout.Modal
+}
+
+type jsonDialog struct {
+	modal  *modal.Modal
+	root   *jsonNode
+	rows   []*jsonNode
+	cursor int
+	offset int
+	height int
+	width  int
+}
+
+func (j *jsonDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (j *jsonDialog) refresh() {
+	j.rows = j.root.visible(nil)
+	j.cursor = min(max(j.cursor, 0), len(j.rows)-1)
+	if j.cursor < j.offset {
+		j.offset = j.cursor
+	} else if j.cursor >= j.offset+j.height {
+		j.offset = j.cursor - j.height + 1
+	}
+}
+
+// parent moves the cursor to the container of the current row
+func (j *jsonDialog) parent() {
+	depth := j.rows[j.cursor].depth
+	for i := j.cursor - 1; i >= 0; i-- {
+		if j.rows[i].depth < depth {
+			j.cursor = i
+			return
+		}
+	}
+}
+
+func (j *jsonDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		j.height = max(msg.Height-14, 5)
+	case tea.KeyPressMsg:
+		node := j.rows[j.cursor]
+		switch msg.String() {
+		case "up", "k":
+			j.cursor--
+		case "down", "j":
+			j.cursor++
+		case "pgup":
+			j.cursor -= j.height
+		case "pgdown", " ":
+			j.cursor += j.height
+		case "home", "g":
+			j.cursor = 0
+		case "end", "G":
+			j.cursor = len(j.rows) - 1
+		case "enter", "tab":
+			if node.container {
+				node.collapsed = !node.collapsed
+			}
+		case "right", "l":
+			if node.container {
+				node.collapsed = false
+			}
+		case "left", "h":
+			if node.container && !node.collapsed && node.depth > 0 {
+				node.collapsed = true
+			} else {
+				j.parent()
+			}
+		case "e":
+			j.root.setCollapsed(false)
+		case "c":
+			j.root.setCollapsed(true)
+			j.cursor = 0
+		}
+	}
+	j.refresh()
+	return j, nil
+}
+
+func (j *jsonDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Render
+	key := styles.NewStyle().Foreground(t.SyntaxVariable()).Background(bg).Render
+	str := styles.NewStyle().Foreground(t.SyntaxString()).Background(bg).Render
+	number := styles.NewStyle().Foreground(t.SyntaxNumber()).Background(bg).Render
+	keyword := styles.NewStyle().Foreground(t.SyntaxKeyword()).Background(bg).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(bg).Render
+
+	lines := []string{}
+	for i := j.offset; i < min(j.offset+j.height, len(j.rows)); i++ {
+		node := j.rows[i]
+		marker := "  "
+		if node.container {
+			marker = "▾ "
+			if node.collapsed {
+				marker = "▸ "
+			}
+		}
+		line := muted(strings.Repeat("  ", node.depth) + marker)
+		if node.depth > 0 {
+			line += key(node.key) + muted(": ")
+		}
+		switch value := node.value.(type) {
+		case map[string]any:
+			line += muted(fmt.Sprintf("{%d}", len(value)))
+		case []any:
+			line += muted(fmt.Sprintf("[%d]", len(value)))
+		case string:
+			encoded, _ := json.Marshal(value)
+			line += str(string(encoded))
+		case json.Number:
+			line += number(value.String())
+		case nil:
+			line += keyword("null")
+		default:
+			line += keyword(fmt.Sprint(value))
+		}
+		line = ansi.Truncate(line, j.width, "…")
+		if i == j.cursor {
+			line = styles.NewStyle().Background(t.Primary()).Foreground(t.Background()).
+				Width(j.width).Render(ansi.Strip(line))
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "",
+		base("enter")+muted(" toggle  ")+base("←/→")+muted(" collapse/expand  ")+
+			base("e/c")+muted(" expand/collapse all  ")+
+			muted(fmt.Sprintf("%d/%d", j.cursor+1, len(j.rows))),
+	)
+	return j.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (j *jsonDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewJSONDialog creates a collapsible view of value encoded as JSON
+func NewJSONDialog(title string, value any) JSONDialog {
+	data, err := json.Marshal(value)
+	var decoded any = fmt.Sprintf("failed to encode: %v", err)
+	if err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&decoded); err != nil {
+			decoded = fmt.Sprintf("failed to decode: %v", err)
+		}
+	}
+	dialog := &jsonDialog{
+		root:   newJSONNode("", decoded, 0),
+		height: max(layout.Current.Viewport.Height-14, 5),
+		width:  min(jsonDialogWidth, layout.Current.Container.Width-8) - 4,
+		modal:  modal.New(modal.WithTitle(title), modal.WithMaxWidth(jsonDialogWidth)),
+	}
+	dialog.refresh()
+	return dialog
+}
@@ -0,0 +1,71 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+// LayoutDialog interface for picking a layout preset
+type LayoutDialog interface {
+	layout.Modal
+}
+
+type layoutDialog struct {
+	modal *modal.Modal
+	list  list.List[list.StringItem]
+	names []string
+}
+
+func (l *layoutDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (l *layoutDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "enter" {
+		if _, idx := l.list.GetSelectedItem(); idx >= 0 && idx < len(l.names) {
+			return l, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.ApplyLayoutMsg{Name: l.names[idx]}),
+			)
+		}
+	}
+
+	listModel, cmd := l.list.Update(msg)
+	l.list = listModel.(list.List[list.StringItem])
+	return l, cmd
+}
+
+func (l *layoutDialog) Render(background string) string {
+	return l.modal.Render(l.list.View(), background)
+}
+
+func (l *layoutDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewLayoutDialog creates a dialog listing the layout presets, the current
+// one marked and selected
+func NewLayoutDialog(names []string, current string) LayoutDialog {
+	items := []string{}
+	selected := 0
+	for i, name := range names {
+		if name == current {
+			name += "  (current)"
+			selected = i
+		}
+		items = append(items, name)
+	}
+	list := list.NewStringList(items, 8, "No layouts", true)
+	list.SetMaxWidth(40)
+	list.SetSelectedIndex(selected)
+
+	return &layoutDialog{
+		list:  list,
+		names: names,
+		modal: modal.New(modal.WithTitle("Switch Layout"), modal.WithMaxWidth(44)),
+	}
+}
@@ -0,0 +1,82 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/clipboard"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const linksDialogWidth = 80
+
+// LinksDialog interface for picking a link of a message to open
+type LinksDialog interface {
+	layout.Modal
+}
+
+type linksDialog struct {
+	modal *modal.Modal
+	list  list.List[list.StringItem]
+	links []string
+}
+
+func (l *linksDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (l *linksDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		_, idx := l.list.GetSelectedItem()
+		if idx >= 0 && idx < len(l.links) {
+			switch msg.String() {
+			case "enter":
+				return l, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.OpenLinkMsg{URL: l.links[idx]}),
+				)
+			case "y":
+				return l, clipboard.Copy(l.links[idx], "Link")
+			}
+		}
+	}
+
+	listModel, cmd := l.list.Update(msg)
+	l.list = listModel.(list.List[list.StringItem])
+	return l, cmd
+}
+
+func (l *linksDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("enter") + muted(" open in the browser  ") + base("y") + muted(" copy")
+	return l.modal.Render(l.list.View()+"\n\n"+help, background)
+}
+
+func (l *linksDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewLinksDialog creates a dialog listing the links of a message in the
+// order they appear
+func NewLinksDialog(links []string) LinksDialog {
+	width := min(linksDialogWidth, layout.Current.Container.Width-8)
+	items := []string{}
+	for _, link := range links {
+		items = append(items, ansi.Truncate(link, width-6, "…"))
+	}
+	list := list.NewStringList(items, 12, "No links in this message", true)
+	list.SetMaxWidth(width - 4)
+
+	return &linksDialog{
+		list:  list,
+		links: links,
+		modal: modal.New(modal.WithTitle("Links"), modal.WithMaxWidth(width)),
+	}
+}
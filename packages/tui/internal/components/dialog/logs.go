@@ -0,0 +1,121 @@
+package dialog
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/logging"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+const (
+	logsDialogWidth = 120
+	logsLines       = 500
+	logsInterval    = time.Second
+)
+
+// logsTickMsg reloads the dialog it was scheduled by
+type logsTickMsg struct {
+	dialog *logsDialog
+}
+
+// LogsDialog interface for the log tail overlay
+type LogsDialog interface {
+	layout.Modal
+	Tick() tea.Cmd
+}
+
+type logsDialog struct {
+	modal    *modal.Modal
+	path     string
+	viewport viewport.Model
+}
+
+func (l *logsDialog) Init() tea.Cmd {
+	return nil
+}
+
+// Tick schedules the next reload of the log file
+func (l *logsDialog) Tick() tea.Cmd {
+	return tea.Tick(logsInterval, func(time.Time) tea.Msg { return logsTickMsg{dialog: l} })
+}
+
+func (l *logsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case logsTickMsg:
+		if msg.dialog != l {
+			return l, nil
+		}
+		l.load()
+		return l, l.Tick()
+	case tea.WindowSizeMsg:
+		l.viewport.SetHeight(max(msg.Height-12, 5))
+	}
+	var cmd tea.Cmd
+	l.viewport, cmd = l.viewport.Update(msg)
+	return l, cmd
+}
+
+// load reads the end of the log file, following it while scrolled to the
+// bottom
+func (l *logsDialog) load() {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	text := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	warning := styles.NewStyle().Foreground(t.Warning()).Background(t.BackgroundElement()).Render
+	failure := styles.NewStyle().Foreground(t.Error()).Background(t.BackgroundElement()).Render
+
+	follow := l.viewport.AtBottom()
+	lines, err := logging.Tail(l.path, logsLines)
+	if err != nil {
+		l.viewport.SetContent(muted("Could not read " + l.path + ": " + err.Error()))
+		return
+	}
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		record := logging.Format(line)
+		style := text
+		switch {
+		case strings.Contains(line, `"level":"ERROR"`):
+			style = failure
+		case strings.Contains(line, `"level":"WARN"`):
+			style = warning
+		case strings.Contains(line, `"level":"DEBUG"`):
+			style = muted
+		}
+		rendered[i] = style(ansi.Truncate(record, l.viewport.Width(), "…"))
+	}
+	l.viewport.SetContent(strings.Join(rendered, "\n"))
+	if follow {
+		l.viewport.GotoBottom()
+	}
+}
+
+func (l *logsDialog) Render(background string) string {
+	return l.modal.Render(l.viewport.View(), background)
+}
+
+func (l *logsDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewLogsDialog creates an overlay following the end of the log file
+func NewLogsDialog(path string) LogsDialog {
+	dialog := &logsDialog{
+		path: path,
+		viewport: viewport.New(
+			viewport.WithWidth(min(logsDialogWidth, layout.Current.Container.Width-8)-4),
+			viewport.WithHeight(max(layout.Current.Viewport.Height-12, 5)),
+		),
+		modal: modal.New(modal.WithTitle("Logs"), modal.WithMaxWidth(logsDialogWidth)),
+	}
+	dialog.load()
+	dialog.viewport.GotoBottom()
+	return dialog
+}
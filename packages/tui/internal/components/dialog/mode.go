@@ -0,0 +1,75 @@
+package dialog
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+var modeDescriptions = map[string]string{
+	app.ModeBuild: "build  make changes with every tool",
+	app.ModePlan:  "plan   read and plan, no edits",
+	app.ModeAsk:   "ask    answer questions only",
+}
+
+// ModeDialog interface for picking the agent mode
+type ModeDialog interface {
+	layout.Modal
+}
+
+type modeDialog struct {
+	modal *modal.Modal
+	list  list.List[list.StringItem]
+}
+
+func (m *modeDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (m *modeDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := m.list.GetSelectedItem(); idx >= 0 {
+				return m, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.ModeSelectedMsg{Mode: app.Modes[idx]}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := m.list.Update(msg)
+	m.list = listModel.(list.List[list.StringItem])
+	return m, cmd
+}
+
+func (m *modeDialog) Render(background string) string {
+	return m.modal.Render(m.list.View(), background)
+}
+
+func (m *modeDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewModeDialog creates a dialog listing the agent modes
+func NewModeDialog(current string) ModeDialog {
+	items := []string{}
+	for _, mode := range app.Modes {
+		items = append(items, modeDescriptions[mode])
+	}
+	list := list.NewStringList(items, len(items), "No modes", true)
+	list.SetMaxWidth(40)
+	list.SetSelectedIndex(max(slices.Index(app.Modes, current), 0))
+
+	return &modeDialog{
+		list:  list,
+		modal: modal.New(modal.WithTitle("Agent Mode"), modal.WithMaxWidth(44)),
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/reflow/truncate"
 	"github.com/sst/opencode/internal/app"
 	"github.com/sst/opencode/internal/components/list"
 	"github.com/sst/opencode/internal/components/modal"
@@ -22,7 +24,7 @@ import (
 
 const (
 	numVisibleModels = 6
-	maxDialogWidth   = 40
+	maxDialogWidth   = 48
 )
 
 // ModelDialog interface for the model selection dialog
@@ -39,14 +41,129 @@ type modelDialog struct {
 	hScrollOffset      int
 	hScrollPossible    bool
 	modal              *modal.Modal
-	modelList          list.List[list.StringItem]
+	modelList          list.List[modelItem]
+	modelInfos         []client.ModelInfo
+	mode               modelDialogMode
 }
 
+// modelItem is a list item for a model, starred models are listed first
+// under a "Favorites" header
+type modelItem struct {
+	name     string
+	group    string // header rendered above the first model of a group
+	favorite bool
+	context  string // context window, shown right aligned
+}
+
+func (m modelItem) Render(selected bool, width int) string {
+	t := theme.CurrentTheme()
+	baseStyle := styles.NewStyle()
+
+	header := ""
+	if m.group != "" {
+		header = baseStyle.
+			Foreground(t.TextMuted()).
+			Bold(true).
+			PaddingLeft(1).
+			Render(truncate.StringWithTail(m.group, uint(max(width-1, 0)), "...")) + "\n"
+	}
+
+	text := m.name
+	if m.favorite {
+		text = "★ " + text
+	}
+	nameWidth := max(width-2-lipgloss.Width(m.context), 0)
+	text = truncate.StringWithTail(text, uint(nameWidth), "...")
+	text += strings.Repeat(" ", max(nameWidth-lipgloss.Width(text), 0)+1)
+
+	itemStyle := baseStyle.PaddingLeft(1)
+	contextStyle := baseStyle.Foreground(t.TextMuted())
+	if selected {
+		itemStyle = itemStyle.
+			Background(t.Primary()).
+			Foreground(t.BackgroundElement()).
+			Width(width)
+		contextStyle = contextStyle.
+			Background(t.Primary()).
+			Foreground(t.BackgroundElement())
+	}
+	return header + itemStyle.Render(text+contextStyle.Render(m.context))
+}
+
+// renderModelDetails renders the limits, prices and capabilities of the
+// highlighted model, prices are per million tokens
+func renderModelDetails(model client.ModelInfo, width int) string {
+	t := theme.CurrentTheme()
+	label := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Width(12).Render
+	value := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+
+	limit := func(tokens float32) string {
+		if tokens <= 0 {
+			return "unknown"
+		}
+		return util.FormatTokens(tokens) + " tokens"
+	}
+	price := fmt.Sprintf("$%.2f in · $%.2f out", model.Cost.Input, model.Cost.Output)
+	if model.Cost.Input == 0 && model.Cost.Output == 0 {
+		price = "free"
+	}
+
+	capabilities := []string{}
+	for _, capability := range []struct {
+		name      string
+		supported bool
+	}{
+		{"vision", model.Attachment},
+		{"tools", model.ToolCall},
+		{"reasoning", model.Reasoning},
+		{"temperature", model.Temperature},
+	} {
+		if capability.supported {
+			capabilities = append(capabilities, capability.name)
+		}
+	}
+	if len(capabilities) == 0 {
+		capabilities = append(capabilities, "text only")
+	}
+
+	lines := []string{
+		styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Bold(true).Render(model.Name),
+		label("id") + value(model.Id),
+		label("context") + value(limit(model.Limit.Context)),
+		label("max output") + value(limit(model.Limit.Output)),
+		label("price / 1M") + value(price),
+	}
+	if model.Cost.CacheRead != nil || model.Cost.CacheWrite != nil {
+		cache := []string{}
+		if model.Cost.CacheRead != nil {
+			cache = append(cache, fmt.Sprintf("$%.2f read", *model.Cost.CacheRead))
+		}
+		if model.Cost.CacheWrite != nil {
+			cache = append(cache, fmt.Sprintf("$%.2f write", *model.Cost.CacheWrite))
+		}
+		lines = append(lines, label("cache / 1M")+value(strings.Join(cache, " · ")))
+	}
+	lines = append(lines, label("supports")+value(strings.Join(capabilities, " · ")))
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+type modelDialogMode int
+
+const (
+	modelSelectMode modelDialogMode = iota
+	modelCompareMode
+	modelRegenerateMode
+)
+
 type modelKeyMap struct {
-	Left   key.Binding
-	Right  key.Binding
-	Enter  key.Binding
-	Escape key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	Favorite key.Binding
+	Enter    key.Binding
+	Escape   key.Binding
 }
 
 var modelKeys = modelKeyMap{
@@ -58,6 +175,10 @@ var modelKeys = modelKeyMap{
 		key.WithKeys("right", "l"),
 		key.WithHelp("→", "scroll right"),
 	),
+	Favorite: key.NewBinding(
+		key.WithKeys("f", "*"),
+		key.WithHelp("f", "toggle favorite"),
+	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "select model"),
@@ -87,23 +208,41 @@ func (m *modelDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.switchProvider(1)
 			}
 			return m, nil
+		case key.Matches(msg, modelKeys.Favorite):
+			if _, idx := m.modelList.GetSelectedItem(); idx >= 0 && idx < len(m.modelInfos) {
+				model := m.modelInfos[idx]
+				m.app.ToggleFavorite(m.provider.Id, model.Id)
+				m.setupModelsForProvider(m.provider.Id)
+				m.selectModel(model.Id)
+			}
+			return m, nil
 		case key.Matches(msg, modelKeys.Enter):
-			selectedItem, _ := m.modelList.GetSelectedItem()
-			models := m.models()
-			var selectedModel client.ModelInfo
-			for _, model := range models {
-				if model.Name == string(selectedItem) {
-					selectedModel = model
-					break
+			_, idx := m.modelList.GetSelectedItem()
+			if idx < 0 || idx >= len(m.modelInfos) {
+				return m, nil
+			}
+			selectedModel := m.modelInfos[idx]
+			var selectedMsg tea.Msg
+			switch m.mode {
+			case modelCompareMode:
+				selectedMsg = app.CompareModelSelectedMsg{
+					Provider: m.provider,
+					Model:    selectedModel,
+				}
+			case modelRegenerateMode:
+				selectedMsg = app.RegenerateMsg{
+					Provider: &m.provider,
+					Model:    &selectedModel,
+				}
+			default:
+				selectedMsg = app.ModelSelectedMsg{
+					Provider: m.provider,
+					Model:    selectedModel,
 				}
 			}
 			return m, tea.Sequence(
 				util.CmdHandler(modal.CloseModalMsg{}),
-				util.CmdHandler(
-					app.ModelSelectedMsg{
-						Provider: m.provider,
-						Model:    selectedModel,
-					}),
+				util.CmdHandler(selectedMsg),
 			)
 		case key.Matches(msg, modelKeys.Escape):
 			return m, util.CmdHandler(modal.CloseModalMsg{})
@@ -115,14 +254,33 @@ func (m *modelDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Update the list component
 	updatedList, cmd := m.modelList.Update(msg)
-	m.modelList = updatedList.(list.List[list.StringItem])
+	m.modelList = updatedList.(list.List[modelItem])
 	return m, cmd
 }
 
+// models returns the models of the current provider sorted by name, with
+// favorites first in the order they were starred
 func (m *modelDialog) models() []client.ModelInfo {
 	models := slices.SortedFunc(maps.Values(m.provider.Models), func(a, b client.ModelInfo) int {
 		return strings.Compare(a.Name, b.Name)
 	})
+	rank := map[string]int{}
+	for i, id := range m.app.State.FavoriteModels {
+		rank[id] = i
+	}
+	slices.SortStableFunc(models, func(a, b client.ModelInfo) int {
+		ra, fa := rank[m.provider.Id+"/"+a.Id]
+		rb, fb := rank[m.provider.Id+"/"+b.Id]
+		switch {
+		case fa && fb:
+			return ra - rb
+		case fa:
+			return -1
+		case fb:
+			return 1
+		}
+		return 0
+	})
 	return models
 }
 
@@ -138,14 +296,28 @@ func (m *modelDialog) switchProvider(offset int) {
 
 	m.hScrollOffset = newOffset
 	m.provider = m.availableProviders[m.hScrollOffset]
-	m.modal.SetTitle(fmt.Sprintf("Select %s Model", m.provider.Name))
+	m.modal.SetTitle(m.title())
 	m.setupModelsForProvider(m.provider.Id)
 }
 
+func (m *modelDialog) title() string {
+	switch m.mode {
+	case modelCompareMode:
+		return fmt.Sprintf("Compare with %s Model", m.provider.Name)
+	case modelRegenerateMode:
+		return fmt.Sprintf("Regenerate with %s Model", m.provider.Name)
+	}
+	return fmt.Sprintf("Select %s Model", m.provider.Name)
+}
+
 func (m *modelDialog) View() string {
 	listView := m.modelList.View()
 	scrollIndicator := m.getScrollIndicators(maxDialogWidth)
-	return strings.Join([]string{listView, scrollIndicator}, "\n")
+	views := []string{listView, scrollIndicator}
+	if _, idx := m.modelList.GetSelectedItem(); idx >= 0 && idx < len(m.modelInfos) {
+		views = append(views, "", renderModelDetails(m.modelInfos[idx], maxDialogWidth))
+	}
+	return strings.Join(views, "\n")
 }
 
 func (m *modelDialog) getScrollIndicators(maxWidth int) string {
@@ -153,6 +325,9 @@ func (m *modelDialog) getScrollIndicators(maxWidth int) string {
 	if m.hScrollPossible {
 		indicator = "← → (switch provider) "
 	}
+	if len(m.modelInfos) > 0 {
+		indicator = "f (favorite) " + indicator
+	}
 	if indicator == "" {
 		return ""
 	}
@@ -166,21 +341,37 @@ func (m *modelDialog) getScrollIndicators(maxWidth int) string {
 }
 
 func (m *modelDialog) setupModelsForProvider(providerId string) {
-	models := m.models()
-	modelNames := make([]string, len(models))
-	for i, model := range models {
-		modelNames[i] = model.Name
+	m.modelInfos = m.models()
+	items := make([]modelItem, len(m.modelInfos))
+	hasFavorites := false
+	for i, model := range m.modelInfos {
+		favorite := m.app.IsFavorite(providerId, model.Id)
+		items[i] = modelItem{name: model.Name, favorite: favorite}
+		if model.Limit.Context > 0 {
+			items[i].context = util.FormatTokens(model.Limit.Context)
+		}
+		switch {
+		case favorite && i == 0:
+			items[i].group = "Favorites"
+			hasFavorites = true
+		case !favorite && hasFavorites && items[i-1].favorite:
+			items[i].group = "All models"
+		}
 	}
 
-	m.modelList = list.NewStringList(modelNames, numVisibleModels, "No models available", true)
+	m.modelList = list.NewListComponent(items, numVisibleModels, "No models available", true)
 	m.modelList.SetMaxWidth(maxDialogWidth)
 
 	if m.app.Provider != nil && m.app.Model != nil && m.app.Provider.Id == providerId {
-		for i, model := range models {
-			if model.Id == m.app.Model.Id {
-				m.modelList.SetSelectedIndex(i)
-				break
-			}
+		m.selectModel(m.app.Model.Id)
+	}
+}
+
+func (m *modelDialog) selectModel(modelID string) {
+	for i, model := range m.modelInfos {
+		if model.Id == modelID {
+			m.modelList.SetSelectedIndex(i)
+			return
 		}
 	}
 }
@@ -194,6 +385,22 @@ func (s *modelDialog) Close() tea.Cmd {
 }
 
 func NewModelDialog(app *app.App) ModelDialog {
+	return newModelDialog(app, modelSelectMode)
+}
+
+// NewCompareModelDialog creates a model dialog that picks the second model
+// of a comparison instead of switching the active model
+func NewCompareModelDialog(app *app.App) ModelDialog {
+	return newModelDialog(app, modelCompareMode)
+}
+
+// NewRegenerateModelDialog creates a model dialog that regenerates the last
+// response with the chosen model without switching the active model
+func NewRegenerateModelDialog(app *app.App) ModelDialog {
+	return newModelDialog(app, modelRegenerateMode)
+}
+
+func newModelDialog(app *app.App, mode modelDialogMode) ModelDialog {
 	availableProviders, _ := app.ListProviders(context.Background())
 
 	currentProvider := availableProviders[0]
@@ -214,11 +421,12 @@ func NewModelDialog(app *app.App) ModelDialog {
 		hScrollOffset:      hScrollOffset,
 		hScrollPossible:    len(availableProviders) > 1,
 		provider:           currentProvider,
+		mode:               mode,
 		modal: modal.New(
-			modal.WithTitle(fmt.Sprintf("Select %s Model", currentProvider.Name)),
-			modal.WithMaxWidth(maxDialogWidth+4),
+			modal.WithMaxWidth(maxDialogWidth + 4),
 		),
 	}
+	dialog.modal.SetTitle(dialog.title())
 
 	dialog.setupModelsForProvider(currentProvider.Id)
 	return dialog
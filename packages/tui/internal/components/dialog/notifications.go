@@ -0,0 +1,85 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+const notificationsDialogWidth = 100
+
+// NotificationsDialog interface for the notification history overlay
+type NotificationsDialog interface {
+	layout.Modal
+}
+
+type notificationsDialog struct {
+	modal    *modal.Modal
+	viewport viewport.Model
+}
+
+func (n *notificationsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (n *notificationsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		n.viewport.SetHeight(max(msg.Height-12, 5))
+	}
+	var cmd tea.Cmd
+	n.viewport, cmd = n.viewport.Update(msg)
+	return n, cmd
+}
+
+// load renders the toasts of the history, one per line with its time and
+// level
+func (n *notificationsDialog) load(history []toast.Toast) {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	text := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+
+	if len(history) == 0 {
+		n.viewport.SetContent(muted("No notifications yet"))
+		return
+	}
+	lines := make([]string, len(history))
+	for i, item := range history {
+		level := styles.NewStyle().Foreground(item.Color).Background(t.BackgroundElement()).Bold(true).Render
+		message := strings.Join(strings.Fields(item.Message), " ")
+		if item.Title != nil {
+			message = *item.Title + ": " + message
+		}
+		line := muted(item.CreatedAt.Format("15:04:05")+" ") + level(strings.ToUpper(string(item.Level))) + " " + text(message)
+		lines[i] = ansi.Truncate(line, n.viewport.Width(), "…")
+	}
+	n.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func (n *notificationsDialog) Render(background string) string {
+	return n.modal.Render(n.viewport.View(), background)
+}
+
+func (n *notificationsDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewNotificationsDialog creates an overlay listing the toasts last shown,
+// the most recent first
+func NewNotificationsDialog(history []toast.Toast) NotificationsDialog {
+	dialog := &notificationsDialog{
+		viewport: viewport.New(
+			viewport.WithWidth(min(notificationsDialogWidth, layout.Current.Container.Width-8)-4),
+			viewport.WithHeight(min(max(len(history), 1), max(layout.Current.Viewport.Height-12, 5))),
+		),
+		modal: modal.New(modal.WithTitle("Notifications"), modal.WithMaxWidth(notificationsDialogWidth)),
+	}
+	dialog.load(history)
+	return dialog
+}
@@ -0,0 +1,79 @@
+package dialog
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+var oversizeActions = []app.OversizeAction{
+	app.OversizeTruncate,
+	app.OversizeChunk,
+	app.OversizeSummarize,
+	app.OversizeSend,
+}
+
+// OversizeDialog interface for picking how to send a prompt over the
+// attachment limit
+type OversizeDialog interface {
+	layout.Modal
+}
+
+type oversizeDialog struct {
+	modal *modal.Modal
+	list  list.List[list.StringItem]
+}
+
+func (o *oversizeDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (o *oversizeDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := o.list.GetSelectedItem(); idx >= 0 {
+				return o, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.ResolveOversizedMsg{Action: oversizeActions[idx]}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := o.list.Update(msg)
+	o.list = listModel.(list.List[list.StringItem])
+	return o, cmd
+}
+
+func (o *oversizeDialog) Render(background string) string {
+	return o.modal.Render(o.list.View(), background)
+}
+
+func (o *oversizeDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewOversizeDialog creates a dialog offering to truncate, chunk or
+// summarize a prompt of tokens over limit, or to send it as it is
+func NewOversizeDialog(tokens, limit, parts int) OversizeDialog {
+	items := []string{
+		fmt.Sprintf("truncate to ~%d tokens", limit),
+		fmt.Sprintf("send in ~%d parts, one per reply", parts),
+		"summarize first with the current model",
+		"send anyway",
+	}
+	list := list.NewStringList(items, len(items), "", true)
+	list.SetMaxWidth(44)
+
+	return &oversizeDialog{
+		list:  list,
+		modal: modal.New(modal.WithTitle(fmt.Sprintf("Large Prompt: ~%d tokens", tokens)), modal.WithMaxWidth(48)),
+	}
+}
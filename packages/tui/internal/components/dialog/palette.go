@@ -0,0 +1,196 @@
+package dialog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/muesli/reflow/truncate"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+	"github.com/sst/opencode/pkg/client"
+)
+
+const (
+	paletteDialogWidth   = 72
+	paletteVisibleModels = 10
+)
+
+// ModelPaletteDialog interface for the fuzzy model palette
+type ModelPaletteDialog interface {
+	layout.Modal
+}
+
+// paletteItem is a provider/model combination in the palette
+type paletteItem struct {
+	provider client.ProviderInfo
+	model    client.ModelInfo
+	recent   bool
+}
+
+func (p paletteItem) label() string {
+	return p.provider.Name + " / " + p.model.Name
+}
+
+// hint summarizes the context window and price per million tokens
+func (p paletteItem) hint() string {
+	hint := util.FormatTokens(p.model.Limit.Context) + " ctx"
+	if p.model.Cost.Input > 0 || p.model.Cost.Output > 0 {
+		hint += fmt.Sprintf(" · $%g/$%g", p.model.Cost.Input, p.model.Cost.Output)
+	} else {
+		hint += " · free"
+	}
+	return hint
+}
+
+func (p paletteItem) Render(selected bool, width int) string {
+	t := theme.CurrentTheme()
+	hint := p.hint()
+	if p.recent {
+		hint = "recent · " + hint
+	}
+	label := truncate.StringWithTail(p.label(), uint(max(width-ansi.StringWidth(hint)-3, 1)), "...")
+	gap := strings.Repeat(" ", max(width-ansi.StringWidth(label)-ansi.StringWidth(hint)-2, 1))
+
+	labelStyle := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement())
+	hintStyle := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement())
+	if selected {
+		labelStyle = styles.NewStyle().Foreground(t.BackgroundElement()).Background(t.Primary())
+		hintStyle = labelStyle
+	}
+	return labelStyle.PaddingLeft(1).Render(label+gap) + hintStyle.PaddingRight(1).Render(hint)
+}
+
+type modelPaletteDialog struct {
+	modal *modal.Modal
+	input textinput.Model
+	list  list.List[paletteItem]
+	items []paletteItem
+	query string
+}
+
+func (m *modelPaletteDialog) Init() tea.Cmd {
+	return m.input.Focus()
+}
+
+func (m *modelPaletteDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch msg.String() {
+		case "enter":
+			if item, idx := m.list.GetSelectedItem(); idx >= 0 {
+				return m, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.ModelSelectedMsg{Provider: item.provider, Model: item.model}),
+				)
+			}
+			return m, nil
+		case "up", "down", "ctrl+p", "ctrl+n":
+			key := msg
+			if msg.String() == "ctrl+p" {
+				key = tea.KeyPressMsg{Code: tea.KeyUp}
+			} else if msg.String() == "ctrl+n" {
+				key = tea.KeyPressMsg{Code: tea.KeyDown}
+			}
+			listModel, cmd := m.list.Update(key)
+			m.list = listModel.(list.List[paletteItem])
+			return m, cmd
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != m.query {
+		m.query = m.input.Value()
+		m.list.SetItems(m.filter(m.query))
+	}
+	return m, cmd
+}
+
+// filter fuzzy matches the query against "provider model", keeping recent
+// models first when there is no query
+func (m *modelPaletteDialog) filter(query string) []paletteItem {
+	if strings.TrimSpace(query) == "" {
+		return m.items
+	}
+	targets := make([]string, len(m.items))
+	for i, item := range m.items {
+		targets[i] = item.provider.Name + " " + item.model.Name + " " + item.model.Id
+	}
+	matches := fuzzy.RankFindFold(query, targets)
+	sort.Stable(matches)
+	filtered := []paletteItem{}
+	for _, match := range matches {
+		filtered = append(filtered, m.items[match.OriginalIndex])
+	}
+	return filtered
+}
+
+func (m *modelPaletteDialog) Render(background string) string {
+	content := strings.Join([]string{m.input.View(), "", m.list.View()}, "\n")
+	return m.modal.Render(content, background)
+}
+
+func (m *modelPaletteDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewModelPaletteDialog creates a flat, fuzzy searchable list of every
+// provider and model, with the recently used models at the top
+func NewModelPaletteDialog(a *app.App) ModelPaletteDialog {
+	t := theme.CurrentTheme()
+	providers, _ := a.ListProviders(context.Background())
+
+	items := []paletteItem{}
+	for _, provider := range providers {
+		for _, model := range provider.Models {
+			items = append(items, paletteItem{provider: provider, model: model})
+		}
+	}
+	rank := func(item paletteItem) int {
+		index := slices.Index(a.State.RecentModels, item.provider.Id+"/"+item.model.Id)
+		if index < 0 {
+			return len(a.State.RecentModels)
+		}
+		return index
+	}
+	for i := range items {
+		items[i].recent = rank(items[i]) < len(a.State.RecentModels)
+	}
+	slices.SortFunc(items, func(x, y paletteItem) int {
+		if rx, ry := rank(x), rank(y); rx != ry {
+			return rx - ry
+		}
+		return strings.Compare(x.label(), y.label())
+	})
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "search models"
+	input.SetWidth(paletteDialogWidth - 8)
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+	input.Focus()
+
+	modelList := list.NewListComponent(items, paletteVisibleModels, "No matching models", false)
+	modelList.SetMaxWidth(paletteDialogWidth - 4)
+
+	return &modelPaletteDialog{
+		input: input,
+		list:  modelList,
+		items: items,
+		modal: modal.New(modal.WithTitle("Switch Model"), modal.WithMaxWidth(paletteDialogWidth)),
+	}
+}
@@ -0,0 +1,219 @@
+package dialog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const parametersDialogWidth = 56
+
+// the fields of the dialog, the reasoning effort is picked rather than typed
+const (
+	parameterTemperature = iota
+	parameterTopP
+	parameterMaxTokens
+	parameterEffort
+	parameterCount
+)
+
+var parameterLabels = []string{"Temperature", "Top P", "Max tokens", "Reasoning effort"}
+
+// ParametersDialog interface for the model parameters of a session
+type ParametersDialog interface {
+	layout.Modal
+}
+
+type parametersDialog struct {
+	modal    *modal.Modal
+	inputs   []textinput.Model
+	effort   string
+	selected int
+	err      string
+}
+
+func (p *parametersDialog) Init() tea.Cmd {
+	return p.inputs[parameterTemperature].Focus()
+}
+
+func (p *parametersDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch msg.String() {
+		case "tab", "down":
+			return p, p.focus((p.selected + 1) % parameterCount)
+		case "shift+tab", "up":
+			return p, p.focus((p.selected + parameterCount - 1) % parameterCount)
+		case "ctrl+r":
+			return p, p.submit(config.ModelParameters{})
+		case "enter":
+			parameters, err := p.parameters()
+			if err != nil {
+				p.err = err.Error()
+				return p, nil
+			}
+			return p, p.submit(parameters)
+		}
+		if p.selected == parameterEffort {
+			switch msg.String() {
+			case "left", "h":
+				p.cycleEffort(-1)
+			case "right", "l", "space", " ":
+				p.cycleEffort(1)
+			}
+			return p, nil
+		}
+	}
+	if p.selected == parameterEffort {
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.inputs[p.selected], cmd = p.inputs[p.selected].Update(msg)
+	return p, cmd
+}
+
+func (p *parametersDialog) focus(selected int) tea.Cmd {
+	if p.selected < len(p.inputs) {
+		p.inputs[p.selected].Blur()
+	}
+	p.selected = selected
+	if selected < len(p.inputs) {
+		return p.inputs[selected].Focus()
+	}
+	return nil
+}
+
+// cycleEffort picks the next reasoning effort, the empty one being the
+// default of the model
+func (p *parametersDialog) cycleEffort(step int) {
+	efforts := append([]string{""}, config.ReasoningEfforts...)
+	index := slices.Index(efforts, p.effort) + step
+	p.effort = efforts[(index+len(efforts))%len(efforts)]
+}
+
+// parameters parses the fields, the empty ones are not overridden
+func (p *parametersDialog) parameters() (config.ModelParameters, error) {
+	parameters := config.ModelParameters{ReasoningEffort: p.effort}
+	float := func(field int, maximum float64) (*float64, error) {
+		value := strings.TrimSpace(p.inputs[field].Value())
+		if value == "" {
+			return nil, nil
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > maximum {
+			return nil, fmt.Errorf("%s must be a number from 0 to %g", parameterLabels[field], maximum)
+		}
+		return &parsed, nil
+	}
+	var err error
+	if parameters.Temperature, err = float(parameterTemperature, 2); err != nil {
+		return parameters, err
+	}
+	if parameters.TopP, err = float(parameterTopP, 1); err != nil {
+		return parameters, err
+	}
+	if value := strings.TrimSpace(p.inputs[parameterMaxTokens].Value()); value != "" {
+		parameters.MaxTokens, err = strconv.Atoi(value)
+		if err != nil || parameters.MaxTokens <= 0 {
+			return parameters, fmt.Errorf("%s must be a positive number", parameterLabels[parameterMaxTokens])
+		}
+	}
+	return parameters, nil
+}
+
+func (p *parametersDialog) submit(parameters config.ModelParameters) tea.Cmd {
+	return tea.Sequence(
+		util.CmdHandler(modal.CloseModalMsg{}),
+		util.CmdHandler(app.ParametersMsg{Parameters: parameters}),
+	)
+}
+
+func (p *parametersDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	accent := styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Render
+	failure := styles.NewStyle().Foreground(t.Error()).Background(t.BackgroundElement()).Render
+
+	labelWidth := 18
+	lines := []string{}
+	for field, label := range parameterLabels {
+		padded := fmt.Sprintf("%-*s", labelWidth, label)
+		if field == p.selected {
+			padded = accent(padded)
+		} else {
+			padded = muted(padded)
+		}
+		if field < len(p.inputs) {
+			lines = append(lines, padded+p.inputs[field].View())
+			continue
+		}
+		effort := p.effort
+		if effort == "" {
+			effort = "default"
+		}
+		if field == p.selected {
+			lines = append(lines, padded+accent("‹ ")+base(effort)+accent(" ›"))
+		} else {
+			lines = append(lines, padded+base("  "+effort))
+		}
+	}
+	if p.err != "" {
+		lines = append(lines, "", failure(p.err))
+	}
+	lines = append(lines, "", base("enter")+muted(" save  ")+base("ctrl+r")+muted(" reset  ")+base("esc")+muted(" cancel"))
+	return p.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (p *parametersDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewParametersDialog creates a form for the model parameters of the current
+// session, empty fields use the defaults of the server
+func NewParametersDialog(parameters config.ModelParameters) ParametersDialog {
+	t := theme.CurrentTheme()
+	values := []string{"", "", ""}
+	if parameters.Temperature != nil {
+		values[parameterTemperature] = strconv.FormatFloat(*parameters.Temperature, 'f', -1, 64)
+	}
+	if parameters.TopP != nil {
+		values[parameterTopP] = strconv.FormatFloat(*parameters.TopP, 'f', -1, 64)
+	}
+	if parameters.MaxTokens > 0 {
+		values[parameterMaxTokens] = strconv.Itoa(parameters.MaxTokens)
+	}
+
+	inputs := []textinput.Model{}
+	for field, value := range values {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = "default"
+		input.SetWidth(parametersDialogWidth - 26)
+		input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+		input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+		input.Styles.Blurred = input.Styles.Focused
+		input.Styles.Cursor.Color = t.Primary()
+		input.SetValue(value)
+		if field == parameterTemperature {
+			input.Focus()
+		}
+		inputs = append(inputs, input)
+	}
+
+	return &parametersDialog{
+		inputs: inputs,
+		effort: parameters.ReasoningEffort,
+		modal:  modal.New(modal.WithTitle("Model Parameters"), modal.WithMaxWidth(parametersDialogWidth)),
+	}
+}
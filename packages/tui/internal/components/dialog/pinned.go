@@ -0,0 +1,156 @@
+package dialog
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+const pinnedDialogWidth = 64
+
+type pinnedInputMode int
+
+const (
+	pinnedInputNone pinnedInputMode = iota
+	pinnedInputFile
+	pinnedInputSnippet
+)
+
+// PinnedContextDialog interface for managing the files and snippets sent
+// with every prompt of the session
+type PinnedContextDialog interface {
+	layout.Modal
+}
+
+type pinnedContextDialog struct {
+	app    *app.App
+	modal  *modal.Modal
+	list   list.List[list.StringItem]
+	input  textinput.Model
+	mode   pinnedInputMode
+	tokens []int
+}
+
+func (p *pinnedContextDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *pinnedContextDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if p.mode != pinnedInputNone {
+		return p.updateInput(msg)
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch msg.String() {
+		case "a":
+			return p, p.startInput(pinnedInputFile, "path/to/file")
+		case "s":
+			return p, p.startInput(pinnedInputSnippet, "text sent with every prompt")
+		case "d", "x", "delete", "backspace":
+			if _, idx := p.list.GetSelectedItem(); idx >= 0 && idx < len(p.tokens) {
+				p.app.UnpinContext(idx)
+				p.refresh()
+				p.list.SetSelectedIndex(min(idx, len(p.tokens)-1))
+			}
+			return p, nil
+		}
+	}
+
+	listModel, cmd := p.list.Update(msg)
+	p.list = listModel.(list.List[list.StringItem])
+	return p, cmd
+}
+
+func (p *pinnedContextDialog) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "enter" {
+		value := p.input.Value()
+		mode := p.mode
+		p.mode = pinnedInputNone
+		if mode == pinnedInputSnippet {
+			p.app.PinSnippet(value)
+		} else if err := p.app.PinFile(value); err != nil {
+			return p, toast.NewErrorToast(i18n.T("toast.pin_failed", value, err.Error()))
+		}
+		p.refresh()
+		p.list.SetSelectedIndex(len(p.tokens) - 1)
+		return p, nil
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p *pinnedContextDialog) startInput(mode pinnedInputMode, placeholder string) tea.Cmd {
+	p.mode = mode
+	p.input.Placeholder = placeholder
+	p.input.SetValue("")
+	return p.input.Focus()
+}
+
+// refresh lists the pinned items again with their token cost
+func (p *pinnedContextDialog) refresh() {
+	p.tokens = p.app.PinnedTokens()
+	items := []list.StringItem{}
+	for i, item := range p.app.PinnedContext() {
+		items = append(items, list.StringItem(fmt.Sprintf("%s  (~%d tokens)", app.PinnedLabel(item), p.tokens[i])))
+	}
+	p.list.SetItems(items)
+}
+
+func (p *pinnedContextDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+
+	total := 0
+	for _, tokens := range p.tokens {
+		total += tokens
+	}
+	readout := muted(fmt.Sprintf("~%d tokens added to every prompt", total))
+
+	footer := base("a") + muted(" pin file  ") +
+		base("s") + muted(" pin snippet  ") +
+		base("d") + muted(" unpin")
+	if p.mode != pinnedInputNone {
+		footer = p.input.View()
+	}
+	return p.modal.Render(p.list.View()+"\n\n"+readout+"\n"+footer, background)
+}
+
+func (p *pinnedContextDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewPinnedContextDialog creates a dialog listing the context pinned to the
+// current session with what each item costs
+func NewPinnedContextDialog(a *app.App) PinnedContextDialog {
+	t := theme.CurrentTheme()
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.SetWidth(pinnedDialogWidth - 8)
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+
+	list := list.NewStringList([]string{}, 8, "Nothing pinned", true)
+	list.SetMaxWidth(pinnedDialogWidth - 4)
+
+	dialog := &pinnedContextDialog{
+		app:   a,
+		list:  list,
+		input: input,
+		modal: modal.New(modal.WithTitle("Pinned Context"), modal.WithMaxWidth(pinnedDialogWidth)),
+	}
+	dialog.refresh()
+	return dialog
+}
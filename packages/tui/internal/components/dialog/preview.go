@@ -0,0 +1,156 @@
+package dialog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/diff"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const (
+	previewDialogWidth = 100
+	// previewLines is how many lines are shown when no range is referenced
+	previewLines = 40
+	// previewMaxLines caps the length of a referenced range
+	previewMaxLines = 500
+)
+
+// FilePreviewDialog interface for the floating file preview
+type FilePreviewDialog interface {
+	layout.Modal
+}
+
+// scrollDialog shows read-only content in a scrollable modal
+type scrollDialog struct {
+	modal    *modal.Modal
+	viewport viewport.Model
+}
+
+func newScrollDialog(title, content string, width int) *scrollDialog {
+	lines := strings.Count(content, "\n") + 1
+	vp := viewport.New(
+		viewport.WithWidth(width),
+		viewport.WithHeight(max(min(lines, layout.Current.Viewport.Height-12), 1)),
+	)
+	vp.SetContent(content)
+	return &scrollDialog{
+		viewport: vp,
+		modal:    modal.New(modal.WithTitle(title), modal.WithMaxWidth(width+4)),
+	}
+}
+
+func (p *scrollDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *scrollDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		p.viewport.SetHeight(max(msg.Height-12, 5))
+	}
+	var cmd tea.Cmd
+	p.viewport, cmd = p.viewport.Update(msg)
+	return p, cmd
+}
+
+func (p *scrollDialog) Render(background string) string {
+	return p.modal.Render(p.viewport.View(), background)
+}
+
+func (p *scrollDialog) Close() tea.Cmd {
+	return nil
+}
+
+// filePreviewDialog previews a file, o opens it with the open command
+type filePreviewDialog struct {
+	*scrollDialog
+	reference app.FileReference
+}
+
+func (p *filePreviewDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "o" {
+		return p, tea.Sequence(
+			util.CmdHandler(modal.CloseModalMsg{}),
+			util.CmdHandler(app.OpenFileMsg{Reference: p.reference}),
+		)
+	}
+	_, cmd := p.scrollDialog.Update(msg)
+	return p, cmd
+}
+
+// readPreview returns the referenced lines of a file and the number of the
+// first one
+func readPreview(reference app.FileReference) ([]string, int, error) {
+	file, err := os.Open(reference.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	start, end := 1, previewLines
+	if reference.Start > 0 {
+		start = reference.Start
+		end = min(reference.End, start+previewMaxLines-1)
+	}
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan() && number <= end; number++ {
+		if number >= start {
+			lines = append(lines, strings.ReplaceAll(scanner.Text(), "\t", "  "))
+		}
+	}
+	return lines, start, scanner.Err()
+}
+
+// NewFilePreviewDialog creates a read-only preview of the referenced lines
+// of a file
+func NewFilePreviewDialog(reference app.FileReference) FilePreviewDialog {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	width := min(previewDialogWidth, layout.Current.Viewport.Width-4) - 4
+
+	content := ""
+	lines, start, err := readPreview(reference)
+	switch {
+	case err != nil:
+		content = muted("Could not read file: " + err.Error())
+	case len(lines) == 0:
+		content = muted("No lines to show")
+	default:
+		var highlighted bytes.Buffer
+		source := strings.Join(lines, "\n")
+		if diff.SyntaxHighlight(&highlighted, source, reference.Path, "terminal16m", t.BackgroundElement()) == nil {
+			lines = strings.Split(strings.TrimSuffix(highlighted.String(), "\n"), "\n")
+		}
+		gutter := len(fmt.Sprint(start + len(lines) - 1))
+		rendered := make([]string, len(lines))
+		for i, line := range lines {
+			number := muted(fmt.Sprintf("%*d ", gutter, start+i))
+			rendered[i] = number + ansi.Truncate(line, width-gutter-1, "…")
+		}
+		content = strings.Join(rendered, "\n")
+	}
+
+	content += "\n\n" + muted("o open in editor")
+
+	title := strings.TrimPrefix(reference.Path, app.RootPath+"/")
+	if reference.Start > 0 {
+		title += fmt.Sprintf(":%d-%d", reference.Start, reference.End)
+	}
+	return &filePreviewDialog{
+		scrollDialog: newScrollDialog(title, content, width),
+		reference:    reference,
+	}
+}
@@ -0,0 +1,90 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const promptDialogWidth = 48
+
+// PromptDialog interface for asking the user for a single line of text
+type PromptDialog interface {
+	layout.Modal
+	// OnCancel sets the message sent when the dialog is closed without
+	// submitting a value
+	OnCancel(onCancel func() tea.Msg) PromptDialog
+}
+
+type promptDialog struct {
+	modal     *modal.Modal
+	input     textinput.Model
+	onSubmit  func(value string) tea.Msg
+	onCancel  func() tea.Msg
+	submitted bool
+}
+
+func (p *promptDialog) Init() tea.Cmd {
+	return p.input.Focus()
+}
+
+func (p *promptDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "enter" {
+		value := strings.TrimSpace(p.input.Value())
+		if value == "" {
+			return p, nil
+		}
+		p.submitted = true
+		return p, tea.Sequence(
+			util.CmdHandler(modal.CloseModalMsg{}),
+			util.CmdHandler(p.onSubmit(value)),
+		)
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, cmd
+}
+
+func (p *promptDialog) Render(background string) string {
+	return p.modal.Render(p.input.View(), background)
+}
+
+func (p *promptDialog) Close() tea.Cmd {
+	if p.submitted || p.onCancel == nil {
+		return nil
+	}
+	return util.CmdHandler(p.onCancel())
+}
+
+func (p *promptDialog) OnCancel(onCancel func() tea.Msg) PromptDialog {
+	p.onCancel = onCancel
+	return p
+}
+
+// NewPromptDialog creates a dialog with a text input, onSubmit builds the
+// message sent when enter is pressed
+func NewPromptDialog(title string, placeholder string, onSubmit func(value string) tea.Msg) PromptDialog {
+	t := theme.CurrentTheme()
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = placeholder
+	input.SetWidth(promptDialogWidth - 8)
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+	input.Focus()
+
+	return &promptDialog{
+		input:    input,
+		onSubmit: onSubmit,
+		modal:    modal.New(modal.WithTitle(title), modal.WithMaxWidth(promptDialogWidth)),
+	}
+}
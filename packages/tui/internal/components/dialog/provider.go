@@ -0,0 +1,122 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const providerDialogWidth = 48
+
+// ProviderSetupDialog interface for the provider onboarding wizard
+type ProviderSetupDialog interface {
+	layout.Modal
+}
+
+type providerSetupDialog struct {
+	modal    *modal.Modal
+	list     list.List[list.StringItem]
+	input    textinput.Model
+	provider *app.ProviderOption
+}
+
+func (p *providerSetupDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (p *providerSetupDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if p.provider != nil {
+		if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "enter" {
+			key := strings.TrimSpace(p.input.Value())
+			if key == "" {
+				return p, nil
+			}
+			return p, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.ProviderCredentialsMsg{ProviderID: p.provider.ID, Key: key}),
+			)
+		}
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		return p, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := p.list.GetSelectedItem(); idx >= 0 {
+				p.provider = &app.SupportedProviders[idx]
+				p.modal.SetTitle(p.provider.Name + " API Key")
+				return p, p.input.Focus()
+			}
+		}
+	}
+
+	listModel, cmd := p.list.Update(msg)
+	p.list = listModel.(list.List[list.StringItem])
+	return p, cmd
+}
+
+func (p *providerSetupDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement())
+
+	if p.provider == nil {
+		content := strings.Join([]string{
+			muted.Render("Pick a provider to set up:"),
+			"",
+			p.list.View(),
+		}, "\n")
+		return p.modal.Render(content, background)
+	}
+
+	content := strings.Join([]string{
+		p.input.View(),
+		"",
+		muted.Render("The key is stored in auth.json, readable only by you"),
+	}, "\n")
+	return p.modal.Render(content, background)
+}
+
+func (p *providerSetupDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewProviderSetupDialog creates the onboarding wizard that lists supported
+// providers and prompts for an API key
+func NewProviderSetupDialog() ProviderSetupDialog {
+	t := theme.CurrentTheme()
+
+	names := []string{}
+	for _, provider := range app.SupportedProviders {
+		names = append(names, provider.Name)
+	}
+	providers := list.NewStringList(names, len(names), "No providers available", true)
+	providers.SetMaxWidth(providerDialogWidth - 4)
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "paste your api key"
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.SetWidth(providerDialogWidth - 8)
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+
+	return &providerSetupDialog{
+		list:  providers,
+		input: input,
+		modal: modal.New(modal.WithTitle("Set Up a Provider"), modal.WithMaxWidth(providerDialogWidth)),
+	}
+}
@@ -0,0 +1,94 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/textarea"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const (
+	redactionDialogWidth  = 72
+	redactionDialogHeight = 12
+)
+
+// RedactionDialog interface for editing the redaction rules of the project
+type RedactionDialog interface {
+	layout.Modal
+}
+
+type redactionDialog struct {
+	modal    *modal.Modal
+	textarea textarea.Model
+	err      error
+}
+
+func (r *redactionDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (r *redactionDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "ctrl+s" {
+		rules, err := app.ParseRedactionRules(r.textarea.Value())
+		if err != nil {
+			r.err = err
+			return r, nil
+		}
+		return r, tea.Sequence(
+			util.CmdHandler(modal.CloseModalMsg{}),
+			util.CmdHandler(app.RedactionRulesMsg{Rules: rules}),
+		)
+	}
+	r.err = nil
+	var cmd tea.Cmd
+	r.textarea, cmd = r.textarea.Update(msg)
+	return r, cmd
+}
+
+func (r *redactionDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("ctrl+s") + muted(" save  ") + base("esc") + muted(" cancel")
+	if r.err != nil {
+		help = styles.NewStyle().Foreground(t.Error()).Background(t.BackgroundElement()).Render(r.err.Error())
+	}
+	return r.modal.Render(r.textarea.View()+"\n\n"+help, background)
+}
+
+func (r *redactionDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewRedactionDialog creates an editor for the redaction rules of the
+// project, one "deny: <regexp>" or "allow: <regexp>" per line, or "off"
+func NewRedactionDialog(rules config.RedactionRules) RedactionDialog {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+
+	ta := textarea.New()
+	ta.Styles.Focused.Base = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Focused.CursorLine = styles.NewStyle().Background(bg).Lipgloss()
+	ta.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Lipgloss()
+	ta.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Blurred = ta.Styles.Focused
+	ta.Styles.Cursor.Color = t.Primary()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	ta.CharLimit = -1
+	ta.Placeholder = "deny: internal-[0-9a-f]{32}\nallow: sk-test-.*\n\nAPI keys, tokens, private keys and .env values are masked too"
+	ta.SetWidth(redactionDialogWidth - 4)
+	ta.SetHeight(redactionDialogHeight)
+	ta.SetValue(app.FormatRedactionRules(rules))
+	ta.Focus()
+
+	return &redactionDialog{
+		textarea: ta,
+		modal:    modal.New(modal.WithTitle("Redaction"), modal.WithMaxWidth(redactionDialogWidth)),
+	}
+}
@@ -0,0 +1,127 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const regenerateDialogWidth = 40
+
+const (
+	regenerateSameModel = iota
+	regenerateDifferentModel
+	regenerateWithInstruction
+)
+
+// RegenerateDialog interface for regenerating the last response
+type RegenerateDialog interface {
+	layout.Modal
+}
+
+type regenerateDialog struct {
+	modal    *modal.Modal
+	list     list.List[list.StringItem]
+	input    textinput.Model
+	steering bool
+}
+
+func (r *regenerateDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (r *regenerateDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if r.steering {
+		if msg, ok := msg.(tea.KeyPressMsg); ok {
+			switch msg.String() {
+			case "enter":
+				instruction := strings.TrimSpace(r.input.Value())
+				if instruction == "" {
+					return r, nil
+				}
+				return r, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.RegenerateMsg{Instruction: instruction}),
+				)
+			}
+		}
+		var cmd tea.Cmd
+		r.input, cmd = r.input.Update(msg)
+		return r, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			_, idx := r.list.GetSelectedItem()
+			switch idx {
+			case regenerateSameModel:
+				return r, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.RegenerateMsg{}),
+				)
+			case regenerateDifferentModel:
+				return r, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.RegenerateModelMsg{}),
+				)
+			case regenerateWithInstruction:
+				r.steering = true
+				return r, r.input.Focus()
+			}
+		}
+	}
+
+	listModel, cmd := r.list.Update(msg)
+	r.list = listModel.(list.List[list.StringItem])
+	return r, cmd
+}
+
+func (r *regenerateDialog) Render(background string) string {
+	if !r.steering {
+		return r.modal.Render(r.list.View(), background)
+	}
+	return r.modal.Render(r.input.View(), background)
+}
+
+func (r *regenerateDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewRegenerateDialog creates a dialog to resend the prompt of the last
+// assistant message with the same model, another model or extra steering
+func NewRegenerateDialog() RegenerateDialog {
+	t := theme.CurrentTheme()
+
+	options := list.NewStringList(
+		[]string{"Same model", "Different model", "With instruction"},
+		3,
+		"",
+		true,
+	)
+	options.SetMaxWidth(regenerateDialogWidth - 4)
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "e.g. keep it shorter"
+	input.SetWidth(regenerateDialogWidth - 8)
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+
+	return &regenerateDialog{
+		list:  options,
+		input: input,
+		modal: modal.New(modal.WithTitle("Regenerate Response"), modal.WithMaxWidth(regenerateDialogWidth)),
+	}
+}
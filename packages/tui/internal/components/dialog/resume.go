@@ -0,0 +1,76 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// ResumeDialog interface for the resume previous session prompt
+type ResumeDialog interface {
+	layout.Modal
+}
+
+type resumeDialog struct {
+	modal  *modal.Modal
+	prompt app.ResumePromptMsg
+}
+
+func (r *resumeDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (r *resumeDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter", "y":
+			session := r.prompt.Session
+			return r, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.SessionSelectedMsg(&session)),
+			)
+		case "n":
+			cmds := []tea.Cmd{util.CmdHandler(modal.CloseModalMsg{})}
+			if r.prompt.Decline != nil {
+				cmds = append(cmds, util.CmdHandler(r.prompt.Decline))
+			}
+			return r, tea.Sequence(cmds...)
+		}
+	}
+	return r, nil
+}
+
+func (r *resumeDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement())
+
+	title := r.prompt.Session.Title
+	if title == "" {
+		title = "Untitled session"
+	}
+	content := base.Render("Resume previous session?") + "\n\n" +
+		base.Bold(true).Render(title) + "\n" +
+		muted.Render("Its last response did not finish.") + "\n\n" +
+		base.Render("enter") + muted.Render(" resume  ") +
+		base.Render("n") + muted.Render(" start over  ") +
+		base.Render("esc") + muted.Render(" cancel")
+	return r.modal.Render(content, background)
+}
+
+func (r *resumeDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewResumeDialog creates a prompt to resume a session with unfinished work
+func NewResumeDialog(prompt app.ResumePromptMsg) ResumeDialog {
+	return &resumeDialog{
+		prompt: prompt,
+		modal:  modal.New(modal.WithTitle("Resume Session"), modal.WithMaxWidth(56)),
+	}
+}
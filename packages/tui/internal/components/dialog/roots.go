@@ -0,0 +1,157 @@
+package dialog
+
+import (
+	"github.com/charmbracelet/bubbles/v2/textinput"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const rootsDialogWidth = 72
+
+// RootsDialog interface for attaching workspace roots to the session and
+// switching between them
+type RootsDialog interface {
+	layout.Modal
+}
+
+type rootsDialog struct {
+	app       *app.App
+	modal     *modal.Modal
+	list      list.List[list.StringItem]
+	input     textinput.Model
+	attaching bool
+	roots     []string
+}
+
+func (r *rootsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (r *rootsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if r.attaching {
+		return r.updateInput(msg)
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		_, idx := r.list.GetSelectedItem()
+		switch msg.String() {
+		case "enter":
+			if idx >= 0 && idx < len(r.roots) {
+				return r, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.SwitchRootMsg{Root: r.roots[idx]}),
+				)
+			}
+		case "a":
+			r.attaching = true
+			r.input.SetValue("")
+			return r, r.input.Focus()
+		case "d", "x", "delete", "backspace":
+			// the working directory stays
+			if idx > 0 && idx < len(r.roots) {
+				r.app.DetachRoot(r.roots[idx])
+				r.refresh()
+				r.list.SetSelectedIndex(min(idx, len(r.roots)-1))
+			}
+			return r, nil
+		}
+	}
+
+	listModel, cmd := r.list.Update(msg)
+	r.list = listModel.(list.List[list.StringItem])
+	return r, cmd
+}
+
+func (r *rootsDialog) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "enter" {
+		r.attaching = false
+		root, err := r.app.AttachRoot(r.input.Value())
+		if err != nil {
+			return r, toast.NewErrorToast(i18n.T("toast.attach_failed", r.input.Value(), err.Error()))
+		}
+		r.refresh()
+		for i, existing := range r.roots {
+			if existing == root {
+				r.list.SetSelectedIndex(i)
+			}
+		}
+		return r, nil
+	}
+	var cmd tea.Cmd
+	r.input, cmd = r.input.Update(msg)
+	return r, cmd
+}
+
+// refresh lists the roots again, the active one marked
+func (r *rootsDialog) refresh() {
+	width := min(rootsDialogWidth, layout.Current.Container.Width-8)
+	r.roots = r.app.Roots()
+	active := r.app.ActiveRoot()
+	items := []list.StringItem{}
+	for _, root := range r.roots {
+		item := r.app.RootLabel(root)
+		if root == active {
+			item += "  (active)"
+		}
+		items = append(items, list.StringItem(ansi.Truncate(item, width-6, "…")))
+	}
+	r.list.SetItems(items)
+}
+
+func (r *rootsDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("enter") + muted(" switch  ") +
+		base("a") + muted(" attach  ") +
+		base("d") + muted(" detach")
+	if r.attaching {
+		help = r.input.View()
+	}
+	return r.modal.Render(r.list.View()+"\n\n"+help, background)
+}
+
+func (r *rootsDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewRootsDialog creates a dialog listing the working directory and the
+// roots attached to the session, with the active one selected
+func NewRootsDialog(a *app.App) RootsDialog {
+	t := theme.CurrentTheme()
+	width := min(rootsDialogWidth, layout.Current.Container.Width-8)
+
+	input := textinput.New()
+	input.Prompt = "> "
+	input.Placeholder = "../backend"
+	input.SetWidth(width - 8)
+	input.Styles.Focused.Prompt = styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Lipgloss()
+	input.Styles.Cursor.Color = t.Primary()
+
+	list := list.NewStringList([]string{}, 8, "No roots", true)
+	list.SetMaxWidth(width - 4)
+
+	dialog := &rootsDialog{
+		app:   a,
+		list:  list,
+		input: input,
+		modal: modal.New(modal.WithTitle("Workspace Roots"), modal.WithMaxWidth(width)),
+	}
+	dialog.refresh()
+	for i, root := range dialog.roots {
+		if root == a.ActiveRoot() {
+			dialog.list.SetSelectedIndex(i)
+		}
+	}
+	return dialog
+}
@@ -0,0 +1,41 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+)
+
+// CommandOutputDialog interface for the output of a /run command
+type CommandOutputDialog interface {
+	layout.Modal
+}
+
+// NewCommandOutputDialog shows the output of a shell command that was
+// attached to the next prompt
+func NewCommandOutputDialog(output app.CommandOutput) CommandOutputDialog {
+	t := theme.CurrentTheme()
+	text := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	width := min(previewDialogWidth, layout.Current.Viewport.Width-4) - 4
+
+	lines := []string{}
+	for line := range strings.SplitSeq(ansi.Strip(output.Output), "\n") {
+		lines = append(lines, text(ansi.Truncate(strings.ReplaceAll(line, "\t", "  "), width, "…")))
+	}
+	if strings.TrimSpace(output.Output) == "" {
+		lines = []string{muted("No output")}
+	}
+	lines = append(lines, "", muted("Attached to your next prompt"))
+
+	title := fmt.Sprintf("$ %s", output.Command)
+	if output.ExitCode != 0 {
+		title += fmt.Sprintf(" (exit %d)", output.ExitCode)
+	}
+	return newScrollDialog(ansi.Truncate(title, width-4, "…"), strings.Join(lines, "\n"), width)
+}
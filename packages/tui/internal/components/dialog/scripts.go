@@ -0,0 +1,79 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const scriptsDialogWidth = 80
+
+// ScriptsDialog interface for picking a project script to run
+type ScriptsDialog interface {
+	layout.Modal
+}
+
+type scriptsDialog struct {
+	modal   *modal.Modal
+	list    list.List[list.StringItem]
+	scripts []app.ProjectScript
+}
+
+func (s *scriptsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (s *scriptsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && (msg.String() == "enter" || msg.String() == "a") {
+		if _, idx := s.list.GetSelectedItem(); idx >= 0 && idx < len(s.scripts) {
+			return s, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.RunScriptMsg{Script: s.scripts[idx], Ask: msg.String() == "a"}),
+			)
+		}
+	}
+
+	listModel, cmd := s.list.Update(msg)
+	s.list = listModel.(list.List[list.StringItem])
+	return s, cmd
+}
+
+func (s *scriptsDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("enter") + muted(" run and attach the output  ") + base("a") + muted(" ask the agent to run it")
+	return s.modal.Render(s.list.View()+"\n\n"+help, background)
+}
+
+func (s *scriptsDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewScriptsDialog creates a dialog listing the scripts, targets and recipes
+// of the project
+func NewScriptsDialog(scripts []app.ProjectScript) ScriptsDialog {
+	width := min(scriptsDialogWidth, layout.Current.Container.Width-8)
+	items := []string{}
+	for _, script := range scripts {
+		item := script.Command
+		if script.Description != "" {
+			item += "  " + script.Description
+		}
+		items = append(items, ansi.Truncate(item, width-6, "…"))
+	}
+	list := list.NewStringList(items, 12, "No scripts in package.json, a Makefile or a justfile", true)
+	list.SetMaxWidth(width - 4)
+
+	return &scriptsDialog{
+		list:    list,
+		scripts: scripts,
+		modal:   modal.New(modal.WithTitle("Project Scripts"), modal.WithMaxWidth(width)),
+	}
+}
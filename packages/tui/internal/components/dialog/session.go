@@ -2,16 +2,23 @@ package dialog
 
 import (
 	"context"
-	"strings"
-
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
 	"slices"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
 	"github.com/muesli/reflow/truncate"
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/bus"
 	"github.com/sst/opencode/internal/components/list"
 	"github.com/sst/opencode/internal/components/modal"
 	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/i18n"
 	"github.com/sst/opencode/internal/layout"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
@@ -22,26 +29,93 @@ import (
 // SessionDialog interface for the session switching dialog
 type SessionDialog interface {
 	layout.Modal
+	SetTag(tag string)
 }
 
 // sessionItem is a custom list item for sessions that can show delete confirmation
 type sessionItem struct {
 	title              string
+	group              string // header rendered above the first session of a group
 	isDeleteConfirming bool
+	marked             bool
+	tags               []string
+	// depth is how deep a sub-agent session is nested under its parent, the
+	// status of its last response is shown as a badge
+	depth  int
+	status app.SessionStatus
+}
+
+// tagColor picks a stable theme color for a tag
+func tagColor(tag string) compat.AdaptiveColor {
+	t := theme.CurrentTheme()
+	colors := []compat.AdaptiveColor{t.Primary(), t.Secondary(), t.Accent(), t.Success(), t.Warning(), t.Info()}
+	h := fnv.New32a()
+	h.Write([]byte(tag))
+	return colors[h.Sum32()%uint32(len(colors))]
+}
+
+// renderTags renders tags as colored chips
+func renderTags(tags []string) string {
+	t := theme.CurrentTheme()
+	chips := []string{}
+	for _, tag := range tags {
+		chips = append(chips, styles.NewStyle().
+			Foreground(t.BackgroundElement()).
+			Background(tagColor(tag)).
+			Padding(0, 1).
+			Render(tag))
+	}
+	return strings.Join(chips, " ")
+}
+
+// renderStatus renders the status badge of a sub-agent session
+func renderStatus(status app.SessionStatus) string {
+	t := theme.CurrentTheme()
+	color := t.Info()
+	switch status {
+	case app.SessionDone:
+		color = t.Success()
+	case app.SessionFailed:
+		color = t.Error()
+	}
+	return styles.NewStyle().Foreground(color).Render(string(status))
 }
 
 func (s sessionItem) Render(selected bool, width int) string {
 	t := theme.CurrentTheme()
 	baseStyle := styles.NewStyle()
 
+	header := ""
+	if s.group != "" {
+		header = baseStyle.
+			Foreground(t.TextMuted()).
+			Bold(true).
+			PaddingLeft(1).
+			Render(truncate.StringWithTail(s.group, uint(width-1), "...")) + "\n"
+	}
+
 	var text string
 	if s.isDeleteConfirming {
 		text = "Press again to confirm delete"
 	} else {
 		text = s.title
 	}
+	if s.marked {
+		text = "● " + text
+	}
+
+	if s.depth > 0 {
+		text = strings.Repeat("  ", s.depth-1) + "└ " + text
+	}
 
-	truncatedStr := truncate.StringWithTail(text, uint(width-1), "...")
+	chips := ""
+	if len(s.tags) > 0 && !s.isDeleteConfirming {
+		chips = " " + renderTags(s.tags)
+	}
+	if s.status != "" && !s.isDeleteConfirming {
+		chips += " " + renderStatus(s.status)
+	}
+	truncatedStr := truncate.StringWithTail(text, uint(max(width-1-lipgloss.Width(chips), 0)), "...") + chips
 
 	var itemStyle styles.Style
 	if selected {
@@ -72,17 +146,58 @@ func (s sessionItem) Render(selected bool, width int) string {
 		}
 	}
 
-	return itemStyle.Render(truncatedStr)
+	return header + itemStyle.Render(truncatedStr)
+}
+
+// sessionGroup returns the day label and, when sessions were started from
+// more than one directory, the directory a session belongs to
+func sessionGroup(session client.SessionInfo, directory string, now time.Time, showDirectory bool) string {
+	created := time.UnixMilli(int64(session.Time.Created)).Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var label string
+	switch {
+	case !created.Before(today):
+		label = "Today"
+	case !created.Before(today.AddDate(0, 0, -1)):
+		label = "Yesterday"
+	case created.Year() == now.Year():
+		label = created.Format("Mon, 02 Jan")
+	default:
+		label = created.Format("02 Jan 2006")
+	}
+
+	if showDirectory {
+		if directory == "" {
+			directory = "unknown directory"
+		} else if rel, err := filepath.Rel(app.RootPath, directory); err == nil && !strings.HasPrefix(rel, "..") {
+			directory = "./" + rel
+			if rel == "." {
+				directory = "."
+			}
+		}
+		label += " · " + directory
+	}
+	return label
 }
 
 type sessionDialog struct {
-	width              int
-	height             int
-	modal              *modal.Modal
-	sessions           []client.SessionInfo
+	width    int
+	height   int
+	modal    *modal.Modal
+	all      []client.SessionInfo
+	sessions []client.SessionInfo
+	// children are the sub-agent sessions of each session, listed nested
+	// under their parent
+	children           map[string][]client.SessionInfo
+	depth              map[string]int
 	list               list.List[sessionItem]
 	app                *app.App
 	deleteConfirmation int // -1 means no confirmation, >= 0 means confirming deletion of session at this index
+	marked             map[string]bool
+	showArchived       bool
+	tag                string // only sessions with this tag are listed when set
+	unsubscribe        []func()
 }
 
 func (s *sessionDialog) Init() tea.Cmd {
@@ -110,16 +225,66 @@ func (s *sessionDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					util.CmdHandler(app.SessionSelectedMsg(&selectedSession)),
 				)
 			}
+		case "space":
+			if _, idx := s.list.GetSelectedItem(); idx >= 0 && idx < len(s.sessions) {
+				id := s.sessions[idx].Id
+				if s.marked[id] {
+					delete(s.marked, id)
+				} else {
+					s.marked[id] = true
+				}
+				s.updateListItems()
+				return s, nil
+			}
+		case "a":
+			if ids := s.targets(); len(ids) > 0 {
+				s.app.ArchiveSessions(ids, !s.showArchived)
+				s.marked = map[string]bool{}
+				s.filter()
+				s.updateListItems()
+				message := i18n.N("toast.sessions_archived", len(ids))
+				if s.showArchived {
+					message = i18n.N("toast.sessions_restored", len(ids))
+				}
+				return s, toast.NewSuccessToast(message)
+			}
+		case "e":
+			if ids := s.targets(); len(ids) > 0 {
+				return s, s.exportSessions(ids)
+			}
+		case "p":
+			if ids := s.targets(); len(ids) > 0 {
+				// unpin when every target is pinned, pin them all otherwise
+				pin := slices.ContainsFunc(ids, func(id string) bool { return !s.app.IsPinned(id) })
+				s.app.PinSessions(ids, pin)
+				s.marked = map[string]bool{}
+				s.relist()
+				return s, nil
+			}
+		case "t":
+			tags := append([]string{""}, s.app.AllTags()...)
+			next := (slices.Index(tags, s.tag) + 1) % len(tags)
+			s.SetTag(tags[next])
+			return s, nil
+		case "tab":
+			s.showArchived = !s.showArchived
+			s.marked = map[string]bool{}
+			s.deleteConfirmation = -1
+			s.filter()
+			s.list.SetItems(s.items())
+			s.updateTitle()
+			return s, nil
 		case "x", "delete", "backspace":
+			if len(s.marked) > 0 {
+				return s, s.promptDelete(s.targets())
+			}
 			if _, idx := s.list.GetSelectedItem(); idx >= 0 && idx < len(s.sessions) {
-				if s.deleteConfirmation == idx {
+				if s.deleteConfirmation == idx || !s.app.Confirms(app.ConfirmDeleteSessions) {
 					// Second press - actually delete the session
 					sessionToDelete := s.sessions[idx]
 					return s, tea.Sequence(
 						func() tea.Msg {
-							s.sessions = slices.Delete(s.sessions, idx, idx+1)
-							s.deleteConfirmation = -1
-							s.updateListItems()
+							s.removeSession(sessionToDelete.Id)
 							return nil
 						},
 						s.deleteSession(sessionToDelete.Id),
@@ -151,8 +316,22 @@ func (s *sessionDialog) Render(background string) string {
 
 	t := theme.CurrentTheme()
 	helpStyle := styles.NewStyle().PaddingLeft(1).PaddingTop(1)
-	helpText := styles.NewStyle().Foreground(t.Text()).Render("x/del")
-	helpText = helpText + styles.NewStyle().Background(t.BackgroundElement()).Foreground(t.TextMuted()).Render(" delete session")
+	key := styles.NewStyle().Foreground(t.Text()).Render
+	muted := styles.NewStyle().Background(t.BackgroundElement()).Foreground(t.TextMuted()).Render
+	archive := " archive  "
+	if s.showArchived {
+		archive = " restore  "
+	}
+	helpText := key("space") + muted(" mark  ") +
+		key("x/del") + muted(" delete  ") +
+		key("a") + muted(archive) +
+		key("e") + muted(" export  ") +
+		key("p") + muted(" pin  ") +
+		key("t") + muted(" tag  ") +
+		key("tab") + muted(" archived")
+	if len(s.marked) > 0 {
+		helpText = muted(fmt.Sprintf("%d marked  ", len(s.marked))) + helpText
+	}
 	helpText = helpStyle.Render(helpText)
 
 	content := strings.Join([]string{listView, helpText}, "\n")
@@ -162,67 +341,293 @@ func (s *sessionDialog) Render(background string) string {
 
 func (s *sessionDialog) updateListItems() {
 	_, currentIdx := s.list.GetSelectedItem()
+	s.list.SetItems(s.items())
+	s.list.SetSelectedIndex(currentIdx)
+}
+
+func (s *sessionDialog) items() []sessionItem {
+	directories := map[string]bool{}
+	for _, sess := range s.sessions {
+		directories[s.app.State.SessionDirectories[sess.Id]] = true
+	}
+	showDirectory := len(directories) > 1
 
+	now := time.Now()
+	previousGroup := ""
 	var items []sessionItem
 	for i, sess := range s.sessions {
 		item := sessionItem{
 			title:              sess.Title,
 			isDeleteConfirming: s.deleteConfirmation == i,
+			marked:             s.marked[sess.Id],
+			tags:               s.app.Tags(sess.Id),
+			depth:              s.depth[sess.Id],
+		}
+		if item.depth > 0 {
+			item.status = s.app.SessionStatus(context.Background(), sess.Id)
+			items = append(items, item)
+			continue
+		}
+		group := "Pinned"
+		if !s.app.IsPinned(sess.Id) {
+			group = sessionGroup(sess, s.app.State.SessionDirectories[sess.Id], now, showDirectory)
+		}
+		if group != previousGroup {
+			item.group = group
+			previousGroup = group
 		}
 		items = append(items, item)
 	}
-	s.list.SetItems(items)
-	s.list.SetSelectedIndex(currentIdx)
+	return items
+}
+
+// filter shows either the archived sessions or the rest, limited to the
+// sessions with the selected tag. Pinned sessions come first.
+func (s *sessionDialog) filter() {
+	s.sessions = []client.SessionInfo{}
+	for _, pinned := range []bool{true, false} {
+		for _, sess := range s.all {
+			if s.app.IsPinned(sess.Id) != pinned || s.app.IsArchived(sess.Id) != s.showArchived {
+				continue
+			}
+			if s.tag != "" && !s.app.HasTag(sess.Id, s.tag) {
+				continue
+			}
+			s.sessions = append(s.sessions, sess)
+			s.appendChildren(sess.Id, 1)
+		}
+	}
+}
+
+// appendChildren lists the sub-agent sessions of a session below it
+func (s *sessionDialog) appendChildren(parentID string, depth int) {
+	for _, child := range s.children[parentID] {
+		s.depth[child.Id] = depth
+		s.sessions = append(s.sessions, child)
+		s.appendChildren(child.Id, depth+1)
+	}
+}
+
+// nest splits sub-agent sessions from the top level sessions, children are
+// ordered by creation
+func (s *sessionDialog) nest(sessions []client.SessionInfo) []client.SessionInfo {
+	top := []client.SessionInfo{}
+	s.children = map[string][]client.SessionInfo{}
+	s.depth = map[string]int{}
+	for _, sess := range sessions {
+		if sess.ParentID == nil {
+			top = append(top, sess)
+			continue
+		}
+		s.children[*sess.ParentID] = append(s.children[*sess.ParentID], sess)
+	}
+	for _, children := range s.children {
+		slices.SortFunc(children, func(a, b client.SessionInfo) int {
+			return int(a.Time.Created - b.Time.Created)
+		})
+	}
+	return top
+}
+
+// SetTag lists only the sessions tagged with tag, or all when it is empty
+func (s *sessionDialog) SetTag(tag string) {
+	s.tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	s.marked = map[string]bool{}
+	s.deleteConfirmation = -1
+	s.filter()
+	s.list.SetItems(s.items())
+	s.updateTitle()
+}
+
+func (s *sessionDialog) updateTitle() {
+	title := "Switch Session"
+	if s.showArchived {
+		title = "Archived Sessions"
+	}
+	if s.tag != "" {
+		title += " · " + s.tag
+	}
+	s.modal.SetTitle(title)
+}
+
+// targets returns the marked sessions, or the selected one when none are marked
+func (s *sessionDialog) targets() []string {
+	ids := []string{}
+	for _, sess := range s.sessions {
+		if s.marked[sess.Id] {
+			ids = append(ids, sess.Id)
+		}
+	}
+	if len(ids) == 0 {
+		if _, idx := s.list.GetSelectedItem(); idx >= 0 && idx < len(s.sessions) {
+			ids = append(ids, s.sessions[idx].Id)
+		}
+	}
+	return ids
+}
+
+// promptDelete counts the messages of the sessions so the confirmation can
+// show how much will be removed
+func (s *sessionDialog) promptDelete(ids []string) tea.Cmd {
+	return tea.Sequence(
+		util.CmdHandler(modal.CloseModalMsg{}),
+		func() tea.Msg {
+			count, err := s.app.CountMessages(context.Background(), ids)
+			if err != nil {
+				return toast.NewErrorToast(i18n.T("toast.count_failed", err.Error()))()
+			}
+			return app.DeleteSessionsPromptMsg{SessionIDs: ids, Messages: count}
+		},
+	)
+}
+
+func (s *sessionDialog) exportSessions(ids []string) tea.Cmd {
+	sessions := []client.SessionInfo{}
+	for _, sess := range s.sessions {
+		if slices.Contains(ids, sess.Id) {
+			sessions = append(sessions, sess)
+		}
+	}
+	return func() tea.Msg {
+		path, err := s.app.ExportSessions(context.Background(), sessions)
+		if err != nil {
+			return toast.NewErrorToast(i18n.T("toast.export_failed", err.Error()))()
+		}
+		return toast.NewSuccessToast(i18n.N("toast.exported", len(sessions), filepath.Base(path)))()
+	}
 }
 
 func (s *sessionDialog) deleteSession(sessionID string) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		if err := s.app.DeleteSession(ctx, sessionID); err != nil {
-			return toast.NewErrorToast("Failed to delete session: " + err.Error())()
+			return toast.NewErrorToast(i18n.T("toast.delete_failed", err.Error()))()
 		}
 		return nil
 	}
 }
 
+// subscribe keeps the listed sessions up to date with the server, which
+// creates and renames sessions from other clients and for sub-agents
+func (s *sessionDialog) subscribe() {
+	s.unsubscribe = []func(){
+		bus.Subscribe(s.app.Bus, func(msg client.EventSessionUpdated) tea.Cmd {
+			s.updateSession(msg.Properties.Info)
+			return nil
+		}),
+		bus.Subscribe(s.app.Bus, func(msg client.EventSessionDeleted) tea.Cmd {
+			s.removeSession(msg.Properties.Info.Id)
+			return nil
+		}),
+		bus.Subscribe(s.app.Bus, func(msg client.EventMessageUpdated) tea.Cmd {
+			if s.depth[msg.Properties.Info.Metadata.SessionID] > 0 {
+				s.updateListItems()
+			}
+			return nil
+		}),
+	}
+}
+
+// updateSession replaces a listed session, or lists a new one
+func (s *sessionDialog) updateSession(session client.SessionInfo) {
+	s.all = s.nest(append(s.sessionsWithChildren(session.Id), session))
+	s.all = sortSessions(s.app, s.all)
+	s.relist()
+}
+
+func (s *sessionDialog) removeSession(sessionID string) {
+	s.all = s.nest(s.sessionsWithChildren(sessionID))
+	delete(s.marked, sessionID)
+	s.deleteConfirmation = -1
+	s.relist()
+}
+
+// sessionsWithChildren returns the top level and sub-agent sessions, without
+// the one with the ID except
+func (s *sessionDialog) sessionsWithChildren(except string) []client.SessionInfo {
+	sessions := []client.SessionInfo{}
+	for _, sess := range s.all {
+		if sess.Id != except {
+			sessions = append(sessions, sess)
+		}
+	}
+	for _, children := range s.children {
+		for _, child := range children {
+			if child.Id != except {
+				sessions = append(sessions, child)
+			}
+		}
+	}
+	return sessions
+}
+
+// relist filters the sessions again, keeping the selected session selected
+func (s *sessionDialog) relist() {
+	selected := ""
+	if _, idx := s.list.GetSelectedItem(); idx >= 0 && idx < len(s.sessions) {
+		selected = s.sessions[idx].Id
+	}
+	s.filter()
+	s.list.SetItems(s.items())
+	if idx := slices.IndexFunc(s.sessions, func(sess client.SessionInfo) bool {
+		return sess.Id == selected
+	}); idx >= 0 {
+		s.list.SetSelectedIndex(idx)
+	}
+}
+
 func (s *sessionDialog) Close() tea.Cmd {
+	for _, unsubscribe := range s.unsubscribe {
+		unsubscribe()
+	}
+	s.unsubscribe = nil
 	return nil
 }
 
+// sortSessions keeps sessions of the same day together, grouped by directory
+func sortSessions(app *app.App, sessions []client.SessionInfo) []client.SessionInfo {
+	day := func(session client.SessionInfo) int64 {
+		created := time.UnixMilli(int64(session.Time.Created)).Local()
+		return time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, created.Location()).Unix()
+	}
+	slices.SortStableFunc(sessions, func(a, b client.SessionInfo) int {
+		if dayA, dayB := day(a), day(b); dayA != dayB {
+			return int(dayB - dayA)
+		}
+		return strings.Compare(
+			app.State.SessionDirectories[a.Id],
+			app.State.SessionDirectories[b.Id],
+		)
+	})
+	return sessions
+}
+
 // NewSessionDialog creates a new session switching dialog
 func NewSessionDialog(app *app.App) SessionDialog {
 	sessions, _ := app.ListSessions(context.Background())
 
-	var filteredSessions []client.SessionInfo
-	var items []sessionItem
-	for _, sess := range sessions {
-		if sess.ParentID != nil {
-			continue
-		}
-		filteredSessions = append(filteredSessions, sess)
-		items = append(items, sessionItem{
-			title:              sess.Title,
-			isDeleteConfirming: false,
-		})
-	}
-
 	// Create a generic list component
 	listComponent := list.NewListComponent(
-		items,
+		[]sessionItem{},
 		10, // maxVisibleSessions
 		"No sessions available",
 		true, // useAlphaNumericKeys
 	)
 	listComponent.SetMaxWidth(layout.Current.Container.Width - 12)
 
-	return &sessionDialog{
-		sessions:           filteredSessions,
+	dialog := &sessionDialog{
 		list:               listComponent,
 		app:                app,
 		deleteConfirmation: -1,
+		marked:             map[string]bool{},
 		modal: modal.New(
 			modal.WithTitle("Switch Session"),
 			modal.WithMaxWidth(layout.Current.Container.Width-8),
 		),
 	}
+	dialog.all = sortSessions(app, dialog.nest(sessions))
+	dialog.filter()
+	listComponent.SetItems(dialog.items())
+	dialog.subscribe()
+	return dialog
 }
@@ -0,0 +1,77 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+// SpellingDialog interface for correcting a misspelled word of the draft
+type SpellingDialog interface {
+	layout.Modal
+}
+
+type spellingDialog struct {
+	modal       *modal.Modal
+	list        list.List[list.StringItem]
+	misspelling app.Misspelling
+	suggestions []string
+}
+
+func (s *spellingDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (s *spellingDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			_, idx := s.list.GetSelectedItem()
+			switch {
+			case idx < 0:
+			case idx < len(s.suggestions):
+				return s, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.CorrectWordMsg{Misspelling: s.misspelling, Replacement: s.suggestions[idx]}),
+				)
+			default:
+				return s, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.AddWordMsg{Word: s.misspelling.Word}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := s.list.Update(msg)
+	s.list = listModel.(list.List[list.StringItem])
+	return s, cmd
+}
+
+func (s *spellingDialog) Render(background string) string {
+	return s.modal.Render(s.list.View(), background)
+}
+
+func (s *spellingDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewSpellingDialog creates a dialog listing the corrections of a misspelled
+// word, then adding it to the words of the project
+func NewSpellingDialog(misspelling app.Misspelling, suggestions []string) SpellingDialog {
+	items := append([]string{}, suggestions...)
+	items = append(items, "add \""+misspelling.Word+"\" to the project words")
+	list := list.NewStringList(items, 8, "No suggestions", true)
+	list.SetMaxWidth(40)
+
+	return &spellingDialog{
+		list:        list,
+		misspelling: misspelling,
+		suggestions: suggestions,
+		modal:       modal.New(modal.WithTitle("Spelling: "+misspelling.Word), modal.WithMaxWidth(44)),
+	}
+}
@@ -0,0 +1,130 @@
+package dialog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const (
+	statsDialogWidth = 64
+	statsFilesLimit  = 8
+)
+
+// StatsDialog interface for the session statistics overlay
+type StatsDialog interface {
+	layout.Modal
+}
+
+type statsDialog struct {
+	modal *modal.Modal
+	stats app.SessionStats
+}
+
+func (s *statsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (s *statsDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return s, nil
+}
+
+func (s *statsDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	heading := styles.NewStyle().Foreground(t.Primary()).Background(t.BackgroundElement()).Bold(true).Render
+	label := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Width(16).Render
+	value := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+
+	stats := s.stats
+	lines := []string{
+		label("Messages") + value(fmt.Sprintf("%d (%d from you, %d from the agent)",
+			stats.UserMessages+stats.AssistantMessages, stats.UserMessages, stats.AssistantMessages)),
+		label("Tokens") + value(fmt.Sprintf("%s in · %s out · %s cached",
+			util.FormatTokens(stats.TokensIn), util.FormatTokens(stats.TokensOut), util.FormatTokens(stats.TokensCached))),
+		label("Cost") + value(fmt.Sprintf("$%.4f", stats.Cost)),
+		label("Agent time") + value(formatDuration(stats.AgentTime)),
+	}
+
+	tools := []string{}
+	for tool := range stats.ToolCalls {
+		tools = append(tools, tool)
+	}
+	slices.SortFunc(tools, func(a, b string) int {
+		if stats.ToolCalls[a] != stats.ToolCalls[b] {
+			return stats.ToolCalls[b] - stats.ToolCalls[a]
+		}
+		return strings.Compare(a, b)
+	})
+	lines = append(lines, "", heading("Tool calls"))
+	if len(tools) == 0 {
+		lines = append(lines, muted("none"))
+	}
+	for _, tool := range tools {
+		lines = append(lines, label(tool)+value(fmt.Sprintf("%d", stats.ToolCalls[tool])))
+	}
+
+	if len(stats.Models) > 0 {
+		lines = append(lines, "", heading("Latency and throughput"))
+		for _, model := range stats.Models {
+			line := value(model.Model) + muted(fmt.Sprintf(" %.1fs to first token", model.FirstToken.Seconds()))
+			if model.TokensPerSecond > 0 {
+				line += muted(fmt.Sprintf(" · %.0f tok/s", model.TokensPerSecond))
+			}
+			line += muted(fmt.Sprintf(" · %d response(s)", model.Responses))
+			lines = append(lines, ansi.Truncate(line, statsDialogWidth-4, "…"))
+		}
+	}
+
+	if len(stats.LongestTools) > 0 {
+		lines = append(lines, "", heading("Longest tool runs"))
+		for _, run := range stats.LongestTools {
+			title := run.Title
+			if title == "" {
+				title = run.Tool
+			}
+			line := label(formatDuration(run.Duration)) + value(run.Tool) + muted(" "+title)
+			lines = append(lines, ansi.Truncate(line, statsDialogWidth-4, "…"))
+		}
+	}
+
+	lines = append(lines, "", heading(fmt.Sprintf("Files touched (%d)", len(stats.FilesTouched))))
+	for i, file := range stats.FilesTouched {
+		if i == statsFilesLimit {
+			lines = append(lines, muted(fmt.Sprintf("… and %d more", len(stats.FilesTouched)-statsFilesLimit)))
+			break
+		}
+		lines = append(lines, value(file))
+	}
+
+	return s.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (s *statsDialog) Close() tea.Cmd {
+	return nil
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+// NewStatsDialog creates the overlay summarizing a session
+func NewStatsDialog(stats app.SessionStats) StatsDialog {
+	return &statsDialog{
+		stats: stats,
+		modal: modal.New(modal.WithTitle("Session Stats"), modal.WithMaxWidth(statsDialogWidth)),
+	}
+}
@@ -0,0 +1,94 @@
+package dialog
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/textarea"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const (
+	systemDialogWidth  = 72
+	systemDialogHeight = 12
+)
+
+// SystemPromptDialog interface for editing the session's system prompt
+type SystemPromptDialog interface {
+	layout.Modal
+}
+
+type systemPromptDialog struct {
+	modal    *modal.Modal
+	textarea textarea.Model
+}
+
+func (s *systemPromptDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (s *systemPromptDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch msg.String() {
+		case "ctrl+s":
+			return s, s.submit(strings.TrimSpace(s.textarea.Value()))
+		case "ctrl+r":
+			return s, s.submit("")
+		}
+	}
+	var cmd tea.Cmd
+	s.textarea, cmd = s.textarea.Update(msg)
+	return s, cmd
+}
+
+func (s *systemPromptDialog) submit(prompt string) tea.Cmd {
+	return tea.Sequence(
+		util.CmdHandler(modal.CloseModalMsg{}),
+		util.CmdHandler(app.SystemPromptMsg{Prompt: prompt}),
+	)
+}
+
+func (s *systemPromptDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("ctrl+s") + muted(" save  ") + base("ctrl+r") + muted(" reset to default  ") + base("esc") + muted(" cancel")
+	return s.modal.Render(s.textarea.View()+"\n\n"+help, background)
+}
+
+func (s *systemPromptDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewSystemPromptDialog creates an editor for the custom system prompt of
+// the current session
+func NewSystemPromptDialog(prompt string) SystemPromptDialog {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+
+	ta := textarea.New()
+	ta.Styles.Focused.Base = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Focused.CursorLine = styles.NewStyle().Background(bg).Lipgloss()
+	ta.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Lipgloss()
+	ta.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Blurred = ta.Styles.Focused
+	ta.Styles.Cursor.Color = t.Primary()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	ta.CharLimit = -1
+	ta.Placeholder = "The server's default system prompt is used"
+	ta.SetWidth(systemDialogWidth - 4)
+	ta.SetHeight(systemDialogHeight)
+	ta.SetValue(prompt)
+	ta.Focus()
+
+	return &systemPromptDialog{
+		textarea: ta,
+		modal:    modal.New(modal.WithTitle("System Prompt"), modal.WithMaxWidth(systemDialogWidth)),
+	}
+}
@@ -0,0 +1,70 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/list"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/util"
+)
+
+// CancelToolDialog interface for picking the running tool call to cancel
+type CancelToolDialog interface {
+	layout.Modal
+}
+
+type cancelToolDialog struct {
+	modal *modal.Modal
+	list  list.List[list.StringItem]
+	tools []app.PendingTool
+}
+
+func (c *cancelToolDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (c *cancelToolDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter":
+			if _, idx := c.list.GetSelectedItem(); idx >= 0 {
+				return c, tea.Sequence(
+					util.CmdHandler(modal.CloseModalMsg{}),
+					util.CmdHandler(app.CancelToolMsg{Tool: c.tools[idx]}),
+				)
+			}
+		}
+	}
+
+	listModel, cmd := c.list.Update(msg)
+	c.list = listModel.(list.List[list.StringItem])
+	return c, cmd
+}
+
+func (c *cancelToolDialog) Render(background string) string {
+	return c.modal.Render(c.list.View(), background)
+}
+
+func (c *cancelToolDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewCancelToolDialog creates a dialog listing the running tool calls, the
+// picked one is cancelled while the turn continues
+func NewCancelToolDialog(tools []app.PendingTool) CancelToolDialog {
+	items := make([]string, len(tools))
+	for i, tool := range tools {
+		items[i] = ansi.Truncate(tool.Name+"  "+tool.Summary, 56, "…")
+	}
+	list := list.NewStringList(items, min(len(items), 10), "", true)
+	list.SetMaxWidth(56)
+
+	return &cancelToolDialog{
+		list:  list,
+		tools: tools,
+		modal: modal.New(modal.WithTitle("Cancel Tool Call"), modal.WithMaxWidth(60)),
+	}
+}
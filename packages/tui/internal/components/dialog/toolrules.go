@@ -0,0 +1,94 @@
+package dialog
+
+import (
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/textarea"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+const (
+	toolRulesDialogWidth  = 72
+	toolRulesDialogHeight = 12
+)
+
+// ToolRulesDialog interface for editing the auto-approve rules of tools
+type ToolRulesDialog interface {
+	layout.Modal
+}
+
+type toolRulesDialog struct {
+	modal    *modal.Modal
+	textarea textarea.Model
+	err      error
+}
+
+func (r *toolRulesDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (r *toolRulesDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && msg.String() == "ctrl+s" {
+		rules, err := app.ParseToolRules(r.textarea.Value())
+		if err != nil {
+			r.err = err
+			return r, nil
+		}
+		return r, tea.Sequence(
+			util.CmdHandler(modal.CloseModalMsg{}),
+			util.CmdHandler(app.ToolRulesMsg{Rules: rules}),
+		)
+	}
+	r.err = nil
+	var cmd tea.Cmd
+	r.textarea, cmd = r.textarea.Update(msg)
+	return r, cmd
+}
+
+func (r *toolRulesDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement()).Render
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement()).Render
+	help := base("ctrl+s") + muted(" save  ") + base("esc") + muted(" cancel")
+	if r.err != nil {
+		help = styles.NewStyle().Foreground(t.Error()).Background(t.BackgroundElement()).Render(r.err.Error())
+	}
+	return r.modal.Render(r.textarea.View()+"\n\n"+help, background)
+}
+
+func (r *toolRulesDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewToolRulesDialog creates an editor for the auto-approve rules, one
+// "command: <pattern>" or "path: <glob>" per line
+func NewToolRulesDialog(rules config.ToolRules) ToolRulesDialog {
+	t := theme.CurrentTheme()
+	bg := t.BackgroundElement()
+
+	ta := textarea.New()
+	ta.Styles.Focused.Base = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Focused.CursorLine = styles.NewStyle().Background(bg).Lipgloss()
+	ta.Styles.Focused.Placeholder = styles.NewStyle().Foreground(t.TextMuted()).Background(bg).Lipgloss()
+	ta.Styles.Focused.Text = styles.NewStyle().Foreground(t.Text()).Background(bg).Lipgloss()
+	ta.Styles.Blurred = ta.Styles.Focused
+	ta.Styles.Cursor.Color = t.Primary()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	ta.CharLimit = -1
+	ta.Placeholder = "command: go test *\npath: src/**\n\nEvery other tool call asks"
+	ta.SetWidth(toolRulesDialogWidth - 4)
+	ta.SetHeight(toolRulesDialogHeight)
+	ta.SetValue(app.FormatToolRules(rules))
+	ta.Focus()
+
+	return &toolRulesDialog{
+		textarea: ta,
+		modal:    modal.New(modal.WithTitle("Tool Rules"), modal.WithMaxWidth(toolRulesDialogWidth)),
+	}
+}
@@ -0,0 +1,69 @@
+package dialog
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+)
+
+// UndoDialog interface for confirming the undo of the agent's file changes
+type UndoDialog interface {
+	layout.Modal
+}
+
+type undoDialog struct {
+	modal *modal.Modal
+	files []string
+}
+
+func (u *undoDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (u *undoDialog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "enter", "y":
+			return u, tea.Sequence(
+				util.CmdHandler(modal.CloseModalMsg{}),
+				util.CmdHandler(app.UndoConfirmedMsg{}),
+			)
+		case "n":
+			return u, util.CmdHandler(modal.CloseModalMsg{})
+		}
+	}
+	return u, nil
+}
+
+func (u *undoDialog) Render(background string) string {
+	t := theme.CurrentTheme()
+	base := styles.NewStyle().Foreground(t.Text()).Background(t.BackgroundElement())
+	muted := styles.NewStyle().Foreground(t.TextMuted()).Background(t.BackgroundElement())
+
+	lines := []string{base.Render("The following files will be restored:"), ""}
+	for _, file := range u.files {
+		lines = append(lines, muted.Render("  "+strings.TrimPrefix(file, app.RootPath+"/")))
+	}
+	lines = append(lines, "", base.Render("enter")+muted.Render(" confirm  ")+base.Render("esc")+muted.Render(" cancel"))
+
+	return u.modal.Render(strings.Join(lines, "\n"), background)
+}
+
+func (u *undoDialog) Close() tea.Cmd {
+	return nil
+}
+
+// NewUndoDialog creates a dialog listing the files an undo will restore
+func NewUndoDialog(files []string) UndoDialog {
+	return &undoDialog{
+		files: files,
+		modal: modal.New(modal.WithTitle("Undo File Changes")),
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/alecthomas/chroma/v2"
 	"github.com/alecthomas/chroma/v2/formatters"
@@ -243,11 +244,24 @@ func ParseUnifiedDiff(diff string) (DiffResult, error) {
 	return result, nil
 }
 
-// HighlightIntralineChanges updates lines in a hunk to show character-level differences
+// wordDiffMaxLines is the number of changed lines up to which a hunk is
+// diffed word by word instead of character by character
+const wordDiffMaxLines = 12
+
+// HighlightIntralineChanges updates lines in a hunk to show the differences
+// within changed lines, word by word for small edits
 func HighlightIntralineChanges(h *Hunk) {
 	var updated []DiffLine
 	dmp := diffmatchpatch.New()
 
+	changed := 0
+	for _, line := range h.Lines {
+		if line.Kind != LineContext {
+			changed++
+		}
+	}
+	words := changed <= wordDiffMaxLines
+
 	for i := 0; i < len(h.Lines); i++ {
 		// Look for removed line followed by added line
 		if i+1 < len(h.Lines) &&
@@ -257,11 +271,16 @@ func HighlightIntralineChanges(h *Hunk) {
 			oldLine := h.Lines[i]
 			newLine := h.Lines[i+1]
 
-			// Find character-level differences
-			patches := dmp.DiffMain(oldLine.Content, newLine.Content, false)
-			patches = dmp.DiffCleanupSemantic(patches)
-			patches = dmp.DiffCleanupMerge(patches)
-			patches = dmp.DiffCleanupEfficiency(patches)
+			var patches []diffmatchpatch.Diff
+			if words {
+				patches = wordDiff(dmp, oldLine.Content, newLine.Content)
+			} else {
+				// Find character-level differences
+				patches = dmp.DiffMain(oldLine.Content, newLine.Content, false)
+				patches = dmp.DiffCleanupSemantic(patches)
+				patches = dmp.DiffCleanupMerge(patches)
+				patches = dmp.DiffCleanupEfficiency(patches)
+			}
 
 			segments := make([]Segment, 0)
 
@@ -304,6 +323,68 @@ func HighlightIntralineChanges(h *Hunk) {
 	h.Lines = updated
 }
 
+// wordDiff diffs two lines word by word, so a renamed identifier or a
+// tweaked string is highlighted as a whole. Each word is encoded as a single
+// rune to diff and cleaned up at word granularity, then decoded again.
+func wordDiff(dmp *diffmatchpatch.DiffMatchPatch, oldText, newText string) []diffmatchpatch.Diff {
+	runes := map[string]rune{}
+	var words []string
+	encode := func(text string) string {
+		var encoded strings.Builder
+		for _, word := range splitWords(text) {
+			r, ok := runes[word]
+			if !ok {
+				r = rune(len(words) + 1)
+				runes[word] = r
+				words = append(words, word)
+			}
+			encoded.WriteRune(r)
+		}
+		return encoded.String()
+	}
+
+	diffs := dmp.DiffMain(encode(oldText), encode(newText), false)
+	diffs = dmp.DiffCleanupSemantic(diffs)
+	for i, d := range diffs {
+		var decoded strings.Builder
+		for _, r := range d.Text {
+			decoded.WriteString(words[r-1])
+		}
+		diffs[i].Text = decoded.String()
+	}
+	return diffs
+}
+
+// splitWords splits text into identifiers, runs of whitespace and single
+// punctuation characters
+func splitWords(text string) []string {
+	class := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		}
+		return 0
+	}
+
+	var words []string
+	start := 0
+	previous := -1
+	for i, r := range text {
+		current := class(r)
+		if i > 0 && (current != previous || current == 0) {
+			words = append(words, text[start:i])
+			start = i
+		}
+		previous = current
+	}
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+	return words
+}
+
 // pairLines converts a flat list of diff lines to pairs for side-by-side display
 func pairLines(lines []DiffLine) []linePair {
 	var pairs []linePair
@@ -145,14 +145,25 @@ func (c *listComponent[T]) View() string {
 		}
 	}
 
-	endIdx := min(startIdx+maxVisibleItems, len(items))
-
+	// items can span several lines, such as the first of a group with its
+	// header, the lines are what counts against maxVisibleItems
 	listItems := make([]string, 0, maxVisibleItems)
-
-	for i := startIdx; i < endIdx; i++ {
-		item := items[i]
-		title := item.Render(i == c.selectedIdx, maxWidth)
+	heights := make([]int, 0, maxVisibleItems)
+	lines := 0
+	for i := startIdx; i < len(items); i++ {
+		title := items[i].Render(i == c.selectedIdx, maxWidth)
+		height := strings.Count(title, "\n") + 1
+		if i > c.selectedIdx && lines+height > c.maxVisibleItems {
+			break
+		}
 		listItems = append(listItems, title)
+		heights = append(heights, height)
+		lines += height
+		// scroll down while the selected item does not fit yet
+		for lines > c.maxVisibleItems && len(listItems) > 1 {
+			lines -= heights[0]
+			listItems, heights = listItems[1:], heights[1:]
+		}
 	}
 
 	return strings.Join(listItems, "\n")
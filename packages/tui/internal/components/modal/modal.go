@@ -7,6 +7,7 @@ import (
 	"github.com/sst/opencode/internal/layout"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
 )
 
 // CloseModalMsg is a message to signal that the active modal should be closed.
@@ -75,6 +76,48 @@ func (m *Modal) SetTitle(title string) {
 // Render renders the modal centered on the screen
 func (m *Modal) Render(contentView string, background string) string {
 	t := theme.CurrentTheme()
+	modalView := m.frame(contentView)
+	col, row := m.position(modalView, background)
+
+	return layout.PlaceOverlay(
+		col,
+		row,
+		modalView,
+		background,
+		layout.WithOverlayBorder(),
+		layout.WithOverlayBorderColor(t.Primary()),
+	)
+}
+
+// ContentOrigin returns the column and row of the screen where the content
+// starts when the modal is rendered over background, for drawing over it
+func (m *Modal) ContentOrigin(contentView string, background string) (int, int) {
+	col, row := m.position(m.frame(contentView), background)
+	// the left border and padding, then the top padding and the title
+	// with the blank line below it
+	col, row = col+3, row+1
+	if m.title != "" {
+		row += 2
+	}
+	return col, row
+}
+
+// position centers the framed modal on background, where it lands once
+// the overlay keeps it and its borders on the screen
+func (m *Modal) position(modalView string, background string) (int, int) {
+	bgHeight := lipgloss.Height(background)
+	bgWidth := lipgloss.Width(background)
+	modalHeight := lipgloss.Height(modalView)
+	modalWidth := lipgloss.Width(modalView)
+
+	row := (bgHeight - modalHeight) / 2
+	col := (bgWidth - modalWidth) / 2
+	return util.Clamp(col, 0, bgWidth-modalWidth-2), util.Clamp(row, 0, bgHeight-modalHeight)
+}
+
+// frame renders the content with the title and padding of the modal
+func (m *Modal) frame(contentView string) string {
+	t := theme.CurrentTheme()
 
 	outerWidth := layout.Current.Container.Width - 8
 	if m.maxWidth > 0 && outerWidth > m.maxWidth {
@@ -121,25 +164,7 @@ func (m *Modal) Render(contentView string, background string) string {
 		PaddingLeft(2).
 		PaddingRight(2)
 
-	modalView := modalStyle.
+	return modalStyle.
 		Width(outerWidth).
 		Render(finalContent)
-
-	// Calculate position for centering
-	bgHeight := lipgloss.Height(background)
-	bgWidth := lipgloss.Width(background)
-	modalHeight := lipgloss.Height(modalView)
-	modalWidth := lipgloss.Width(modalView)
-
-	row := (bgHeight - modalHeight) / 2
-	col := (bgWidth - modalWidth) / 2
-
-	return layout.PlaceOverlay(
-		col,
-		row,
-		modalView,
-		background,
-		layout.WithOverlayBorder(),
-		layout.WithOverlayBorderColor(t.Primary()),
-	)
 }
@@ -0,0 +1,201 @@
+package sidebar
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/bus"
+	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/styles"
+	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// Width is the width of the sidebar including its border
+const Width = 32
+
+// sidebarHeaderLines are the lines above the first session
+const sidebarHeaderLines = 2
+
+// SessionsLoadedMsg carries the sessions listed in the sidebar
+type SessionsLoadedMsg []client.SessionInfo
+
+// SidebarComponent lists the sessions next to the chat on wide terminals
+type SidebarComponent interface {
+	tea.Model
+	tea.ViewModel
+	layout.Sizeable
+	layout.Focusable
+	Refresh() tea.Cmd
+	SessionAt(y int) *client.SessionInfo
+}
+
+type sidebarComponent struct {
+	app      *app.App
+	width    int
+	height   int
+	sessions []client.SessionInfo
+	cursor   int
+	offset   int
+	focused  bool
+}
+
+func (s *sidebarComponent) Init() tea.Cmd {
+	return s.Refresh()
+}
+
+func (s *sidebarComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SessionsLoadedMsg:
+		s.sessions = []client.SessionInfo{}
+		for _, session := range msg {
+			if session.ParentID == nil && !s.app.IsArchived(session.Id) {
+				s.sessions = append(s.sessions, session)
+			}
+		}
+		s.cursor = min(s.cursor, max(len(s.sessions)-1, 0))
+	case tea.KeyPressMsg:
+		if !s.focused {
+			return s, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			s.cursor = max(s.cursor-1, 0)
+		case "down", "j":
+			s.cursor = min(s.cursor+1, max(len(s.sessions)-1, 0))
+		case "enter":
+			s.focused = false
+			if s.cursor < len(s.sessions) {
+				session := s.sessions[s.cursor]
+				return s, util.CmdHandler(app.SessionSelectedMsg(&session))
+			}
+		case "esc", "ctrl+c":
+			s.focused = false
+		}
+	}
+	return s, nil
+}
+
+// Refresh reloads the list of sessions from the server
+func (s *sidebarComponent) Refresh() tea.Cmd {
+	return func() tea.Msg {
+		sessions, err := s.app.ListSessions(context.Background())
+		if err != nil {
+			slog.Error("Failed to list sessions", "error", err)
+			return nil
+		}
+		return SessionsLoadedMsg(sessions)
+	}
+}
+
+// SessionAt returns the session rendered at line y of the sidebar
+func (s *sidebarComponent) SessionAt(y int) *client.SessionInfo {
+	idx := y - sidebarHeaderLines + s.offset
+	if y < sidebarHeaderLines || idx < 0 || idx >= len(s.sessions) {
+		return nil
+	}
+	return &s.sessions[idx]
+}
+
+func (s *sidebarComponent) View() string {
+	t := theme.CurrentTheme()
+	width := s.width - 1
+	base := styles.NewStyle().Background(t.Background()).Width(width).PaddingLeft(1)
+	heading := base.Foreground(t.TextMuted()).Bold(true)
+
+	visible := max(s.height-sidebarHeaderLines, 1)
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+visible {
+		s.offset = s.cursor - visible + 1
+	}
+
+	title := "Sessions"
+	if s.focused {
+		title += " · enter open · esc back"
+	}
+	lines := []string{heading.Render(ansi.Truncate(title, width-1, "…")), base.Render("")}
+	if len(s.sessions) == 0 {
+		lines = append(lines, base.Foreground(t.TextMuted()).Render("No sessions"))
+	}
+	for i := s.offset; i < len(s.sessions) && i < s.offset+visible; i++ {
+		session := s.sessions[i]
+		style := base.Foreground(t.TextMuted())
+		marker := "  "
+		if s.app.Session != nil && session.Id == s.app.Session.Id {
+			style = base.Foreground(t.Text()).Bold(true)
+			marker = "▌ "
+		}
+		if s.focused && i == s.cursor {
+			style = base.Foreground(t.BackgroundElement()).Background(t.Primary())
+		}
+		lines = append(lines, style.Render(ansi.Truncate(marker+session.Title, width-1, "…")))
+	}
+	for len(lines) < s.height {
+		lines = append(lines, base.Render(""))
+	}
+
+	border := styles.NewStyle().Foreground(t.BorderSubtle()).Background(t.Background()).Render("│")
+	for i, line := range lines {
+		lines[i] = line + border
+	}
+	return strings.Join(lines[:s.height], "\n")
+}
+
+func (s *sidebarComponent) SetSize(width, height int) tea.Cmd {
+	s.width = width
+	s.height = height
+	return nil
+}
+
+func (s *sidebarComponent) GetSize() (int, int) {
+	return s.width, s.height
+}
+
+// Focus moves the cursor to the current session and takes the keyboard
+func (s *sidebarComponent) Focus() tea.Cmd {
+	s.focused = true
+	for i, session := range s.sessions {
+		if s.app.Session != nil && session.Id == s.app.Session.Id {
+			s.cursor = i
+		}
+	}
+	return nil
+}
+
+func (s *sidebarComponent) Blur() tea.Cmd {
+	s.focused = false
+	return nil
+}
+
+func (s *sidebarComponent) IsFocused() bool {
+	return s.focused
+}
+
+// subscribe keeps the listed sessions up to date
+func (s *sidebarComponent) subscribe() {
+	bus.Subscribe(s.app.Bus, func(msg client.EventSessionUpdated) tea.Cmd {
+		for i, session := range s.sessions {
+			if session.Id == msg.Properties.Info.Id {
+				s.sessions[i] = msg.Properties.Info
+				return nil
+			}
+		}
+		return s.Refresh()
+	})
+	bus.Subscribe(s.app.Bus, func(client.EventSessionDeleted) tea.Cmd {
+		return s.Refresh()
+	})
+}
+
+func NewSidebarComponent(app *app.App) SidebarComponent {
+	s := &sidebarComponent{app: app}
+	s.subscribe()
+	return s
+}
@@ -2,27 +2,42 @@ package status
 
 import (
 	"fmt"
+	"log/slog"
+	"os/exec"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/i18n"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
+	"github.com/sst/opencode/internal/util"
 )
 
 type StatusComponent interface {
 	tea.Model
 	tea.ViewModel
+	SegmentAt(x int) string
 }
 
 type statusComponent struct {
-	app   *app.App
-	width int
+	app     *app.App
+	width   int
+	outputs map[int]string
 }
 
 func (m statusComponent) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	for i, segment := range m.segments() {
+		if segment.Type == "git" || segment.Type == "command" {
+			cmds = append(cmds, m.refresh(i, segment, 0))
+		}
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m statusComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -30,6 +45,12 @@ func (m statusComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		return m, nil
+	case segmentOutputMsg:
+		m.outputs[msg.index] = msg.output
+		segments := m.segments()
+		if msg.index < len(segments) {
+			return m, m.refresh(msg.index, segments[msg.index], msg.interval)
+		}
 	}
 	return m, nil
 }
@@ -49,30 +70,124 @@ func (m statusComponent) logo() string {
 }
 
 func formatTokensAndCost(tokens float32, contextWindow float32, cost float32) string {
-	// Format tokens in human-readable format (e.g., 110K, 1.2M)
-	var formattedTokens string
-	switch {
-	case tokens >= 1_000_000:
-		formattedTokens = fmt.Sprintf("%.1fM", float64(tokens)/1_000_000)
-	case tokens >= 1_000:
-		formattedTokens = fmt.Sprintf("%.1fK", float64(tokens)/1_000)
-	default:
-		formattedTokens = fmt.Sprintf("%d", int(tokens))
-	}
-
-	// Remove .0 suffix if present
-	if strings.HasSuffix(formattedTokens, ".0K") {
-		formattedTokens = strings.Replace(formattedTokens, ".0K", "K", 1)
-	}
-	if strings.HasSuffix(formattedTokens, ".0M") {
-		formattedTokens = strings.Replace(formattedTokens, ".0M", "M", 1)
-	}
+	formattedTokens := util.FormatTokens(tokens)
 
 	// Format cost with $ symbol and 2 decimal places
 	formattedCost := fmt.Sprintf("$%.2f", cost)
 	percentage := (float64(tokens) / float64(contextWindow)) * 100
 
-	return fmt.Sprintf("Context: %s (%d%%), Cost: %s", formattedTokens, int(percentage), formattedCost)
+	return i18n.T("status.context", formattedTokens, int(percentage), formattedCost)
+}
+
+func (m statusComponent) segments() []config.StatusSegment {
+	if len(m.app.State.StatusBar) > 0 {
+		return m.app.State.StatusBar
+	}
+	return config.DefaultStatusBar
+}
+
+func (m statusComponent) renderSegment(index int, segment config.StatusSegment) string {
+	t := theme.CurrentTheme()
+	panel := styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundPanel()).
+		Padding(0, 1)
+	element := styles.NewStyle().
+		Foreground(t.TextMuted()).
+		Background(t.BackgroundElement()).
+		Padding(0, 1)
+
+	switch segment.Type {
+	case "logo":
+		return m.logo()
+	case "mode":
+		mode := m.app.Mode()
+		color := t.Secondary()
+		switch mode {
+		case app.ModePlan:
+			color = t.Warning()
+		case app.ModeAsk:
+			color = t.Info()
+		}
+		return styles.NewStyle().
+			Foreground(t.Background()).
+			Background(color).
+			Bold(true).
+			Padding(0, 1).
+			Render(mode + " ▾")
+	case "system":
+		if m.app.SystemPrompt() == "" {
+			return ""
+		}
+		return styles.NewStyle().
+			Foreground(t.Background()).
+			Background(t.Accent()).
+			Padding(0, 1).
+			Render(i18n.T("status.custom_prompt"))
+	case "parameters":
+		parameters := app.FormatParameters(m.app.Parameters())
+		if parameters == "" {
+			return ""
+		}
+		return element.Render(parameters)
+	case "run":
+		label := m.app.Run().Label()
+		if label == "" {
+			return ""
+		}
+		return element.Render(label)
+	case "cwd":
+		return panel.Render(m.app.ActiveRoot())
+	case "model":
+		if m.app.Model == nil && m.app.LoadingProviders() {
+			return panel.Italic(true).Render(i18n.T("status.loading_models"))
+		}
+		if m.app.Model == nil {
+			return ""
+		}
+		return panel.Render(m.app.Provider.Name + " " + m.app.Model.Name)
+	case "session":
+		return panel.Render(m.app.Session.Title)
+	case "tokens":
+		if m.app.Model == nil {
+			return ""
+		}
+		return element.Render(m.sessionInfo())
+	case "changes":
+		changes := len(m.app.ExternalChanges())
+		if changes == 0 {
+			return ""
+		}
+		return panel.Render(i18n.N("status.files_changed", changes))
+	case "git", "command":
+		output := m.outputs[index]
+		if output == "" {
+			return ""
+		}
+		return element.Render(output)
+	}
+	return ""
+}
+
+func (m statusComponent) sessionInfo() string {
+	tokens := float32(0)
+	cost := float32(0)
+	contextWindow := m.app.Model.Limit.Context
+
+	for _, message := range m.app.Messages {
+		if message.Metadata.Assistant != nil {
+			cost += message.Metadata.Assistant.Cost
+			usage := message.Metadata.Assistant.Tokens
+			if usage.Output > 0 {
+				tokens = (usage.Input +
+					usage.Cache.Write +
+					usage.Cache.Read +
+					usage.Output +
+					usage.Reasoning)
+			}
+		}
+	}
+	return formatTokensAndCost(tokens, contextWindow, cost)
 }
 
 func (m statusComponent) View() string {
@@ -85,58 +200,132 @@ func (m statusComponent) View() string {
 			Render("")
 	}
 
-	logo := m.logo()
+	line, _ := m.render()
+	if remaining := m.width - lipgloss.Width(line); remaining > 0 {
+		line += styles.NewStyle().Background(t.BackgroundPanel()).Width(remaining).Render("")
+	}
 
-	cwd := styles.NewStyle().
-		Foreground(t.TextMuted()).
-		Background(t.BackgroundPanel()).
-		Padding(0, 1).
-		Render(m.app.Info.Path.Cwd)
-
-	sessionInfo := ""
-	if m.app.Session.Id != "" {
-		tokens := float32(0)
-		cost := float32(0)
-		contextWindow := m.app.Model.Limit.Context
-
-		for _, message := range m.app.Messages {
-			if message.Metadata.Assistant != nil {
-				cost += message.Metadata.Assistant.Cost
-				usage := message.Metadata.Assistant.Tokens
-				if usage.Output > 0 {
-					tokens = (usage.Input +
-						usage.Cache.Write +
-						usage.Cache.Read +
-						usage.Output +
-						usage.Reasoning)
-				}
+	blank := styles.NewStyle().Background(t.Background()).Width(m.width).Render("")
+	return blank + "\n" + line
+}
+
+type segmentSpan struct {
+	segment    string
+	start, end int
+}
+
+// render lays out the status line, returning the cells each segment covers
+func (m statusComponent) render() (string, []segmentSpan) {
+	t := theme.CurrentTheme()
+	segments := m.segments()
+	rendered := make([]string, len(segments))
+	for i, segment := range segments {
+		rendered[i] = m.renderSegment(i, segment)
+	}
+
+	// Drop segments in order of priority until everything fits
+	for {
+		total := 0
+		drop := -1
+		for i, segment := range segments {
+			total += lipgloss.Width(rendered[i])
+			if rendered[i] != "" && segment.Priority > 0 &&
+				(drop == -1 || segment.Priority > segments[drop].Priority) {
+				drop = i
 			}
 		}
+		if total <= m.width || drop == -1 {
+			break
+		}
+		rendered[drop] = ""
+	}
 
-		sessionInfo = styles.NewStyle().
-			Foreground(t.TextMuted()).
-			Background(t.BackgroundElement()).
-			Padding(0, 1).
-			Render(formatTokensAndCost(tokens, contextWindow, cost))
+	used := 0
+	spacers := 0
+	for i, segment := range segments {
+		used += lipgloss.Width(rendered[i])
+		if segment.Type == "spacer" {
+			spacers++
+		}
 	}
+	space := max(0, m.width-used)
 
-	// diagnostics := styles.Padded().Background(t.BackgroundElement()).Render(m.projectDiagnostics())
+	var status strings.Builder
+	spans := []segmentSpan{}
+	offset := 0
+	for i, segment := range segments {
+		if segment.Type == "spacer" {
+			width := space / spacers
+			if spacers == 1 {
+				width = space
+			}
+			space -= width
+			spacers--
+			status.WriteString(styles.NewStyle().Background(t.BackgroundPanel()).Width(width).Render(""))
+			offset += width
+			continue
+		}
+		status.WriteString(rendered[i])
+		width := lipgloss.Width(rendered[i])
+		spans = append(spans, segmentSpan{segment: segment.Type, start: offset, end: offset + width})
+		offset += width
+	}
+	return ansi.Truncate(status.String(), m.width, "…"), spans
+}
 
-	space := max(
-		0,
-		m.width-lipgloss.Width(logo)-lipgloss.Width(cwd)-lipgloss.Width(sessionInfo),
-	)
-	spacer := styles.NewStyle().Background(t.BackgroundPanel()).Width(space).Render("")
+// SegmentAt returns the type of the status segment under column x
+func (m statusComponent) SegmentAt(x int) string {
+	if m.app.Session.Id == "" {
+		return ""
+	}
+	_, spans := m.render()
+	for _, span := range spans {
+		if x >= span.start && x < span.end {
+			return span.segment
+		}
+	}
+	return ""
+}
 
-	status := logo + cwd + spacer + sessionInfo
+// refresh runs the shell command backing a segment and schedules the next run
+func (m statusComponent) refresh(index int, segment config.StatusSegment, delay time.Duration) tea.Cmd {
+	command := segment.Command
+	interval := time.Duration(segment.Interval) * time.Second
+	if segment.Type == "git" {
+		command = "git rev-parse --abbrev-ref HEAD 2>/dev/null"
+		if interval == 0 {
+			interval = 10 * time.Second
+		}
+	}
+	if command == "" {
+		return nil
+	}
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+	cwd := m.app.ActiveRoot()
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		c := exec.Command("sh", "-c", command)
+		c.Dir = cwd
+		output, err := c.Output()
+		if err != nil {
+			slog.Debug("Status segment command failed", "command", command, "error", err)
+		}
+		line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+		return segmentOutputMsg{index: index, output: line, interval: interval}
+	})
+}
 
-	blank := styles.NewStyle().Background(t.Background()).Width(m.width).Render("")
-	return blank + "\n" + status
+type segmentOutputMsg struct {
+	index    int
+	output   string
+	interval time.Duration
 }
 
 func NewStatusCmp(app *app.App) StatusComponent {
 	statusComponent := &statusComponent{
-		app: app,
+		app:     app,
+		outputs: map[int]string{},
 	}
 
 	return statusComponent
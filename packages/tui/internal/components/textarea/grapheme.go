@@ -0,0 +1,57 @@
+package textarea
+
+import (
+	"github.com/rivo/uniseg"
+)
+
+// graphemes splits runes into grapheme clusters, the characters a user sees,
+// so that combining marks, emoji sequences and wide glyphs are moved over,
+// deleted and wrapped as a whole
+func graphemes(runes []rune) [][]rune {
+	clusters := [][]rune{}
+	state := -1
+	rest := string(runes)
+	for len(rest) > 0 {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		clusters = append(clusters, []rune(cluster))
+	}
+	return clusters
+}
+
+// graphemeAfter returns the number of runes of the grapheme cluster starting
+// at col
+func graphemeAfter(runes []rune, col int) int {
+	if col >= len(runes) {
+		return 0
+	}
+	start := 0
+	for _, cluster := range graphemes(runes) {
+		if start+len(cluster) > col {
+			return start + len(cluster) - col
+		}
+		start += len(cluster)
+	}
+	return 1
+}
+
+// graphemeBefore returns the number of runes of the grapheme cluster ending
+// at col
+func graphemeBefore(runes []rune, col int) int {
+	if col <= 0 {
+		return 0
+	}
+	start := 0
+	for _, cluster := range graphemes(runes[:min(col, len(runes))]) {
+		if start+len(cluster) >= col {
+			return col - start
+		}
+		start += len(cluster)
+	}
+	return 1
+}
+
+// runesWidth returns the number of cells the runes take on screen
+func runesWidth(runes []rune) int {
+	return uniseg.StringWidth(string(runes))
+}
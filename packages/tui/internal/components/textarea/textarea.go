@@ -15,7 +15,6 @@ import (
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
-	rw "github.com/mattn/go-runewidth"
 	"github.com/rivo/uniseg"
 	"slices"
 )
@@ -181,6 +180,9 @@ type StyleState struct {
 	EndOfBuffer      lipgloss.Style
 	Placeholder      lipgloss.Style
 	Prompt           lipgloss.Style
+	// Marked styles the spans returned by [Model.Marks], on top of the style
+	// of the line.
+	Marked lipgloss.Style
 }
 
 func (s StyleState) computedCursorLine() lipgloss.Style {
@@ -279,6 +281,10 @@ type Model struct {
 	// there's no limit.
 	MaxWidth int
 
+	// Marks, if set, returns the spans of a line rendered in the Marked style,
+	// such as misspelled words, as [start, end) rune offsets in order.
+	Marks func(row int, line []rune) [][2]int
+
 	// If promptFunc is set, it replaces Prompt as a generator for
 	// prompt strings at the beginning of each line.
 	promptFunc func(line int) string
@@ -591,8 +597,9 @@ func (m *Model) CursorDown() {
 		if m.row >= len(m.value) || m.col >= len(m.value[m.row]) || offset >= nli.CharWidth-1 {
 			break
 		}
-		offset += rw.RuneWidth(m.value[m.row][m.col])
-		m.col++
+		n := max(graphemeAfter(m.value[m.row], m.col), 1)
+		offset += runesWidth(m.value[m.row][m.col : m.col+n])
+		m.col += n
 	}
 }
 
@@ -626,8 +633,9 @@ func (m *Model) CursorUp() {
 		if m.col >= len(m.value[m.row]) || offset >= nli.CharWidth-1 {
 			break
 		}
-		offset += rw.RuneWidth(m.value[m.row][m.col])
-		m.col++
+		n := max(graphemeAfter(m.value[m.row], m.col), 1)
+		offset += runesWidth(m.value[m.row][m.col : m.col+n])
+		m.col += n
 	}
 }
 
@@ -640,6 +648,13 @@ func (m *Model) SetCursorColumn(col int) {
 	m.lastCharOffset = 0
 }
 
+// SetCursor moves the cursor to the given row and column. If the position is
+// out of bounds the cursor will be moved to the nearest valid position.
+func (m *Model) SetCursor(row, col int) {
+	m.row = clamp(row, 0, len(m.value)-1)
+	m.SetCursorColumn(col)
+}
+
 // CursorStart moves the cursor to the start of the input field.
 func (m *Model) CursorStart() {
 	m.SetCursorColumn(0)
@@ -798,10 +813,11 @@ func (m *Model) deleteWordRight() {
 	m.SetCursorColumn(oldCol)
 }
 
-// characterRight moves the cursor one character to the right.
+// characterRight moves the cursor one character to the right, over a whole
+// grapheme cluster.
 func (m *Model) characterRight() {
 	if m.col < len(m.value[m.row]) {
-		m.SetCursorColumn(m.col + 1)
+		m.SetCursorColumn(m.col + graphemeAfter(m.value[m.row], m.col))
 	} else {
 		if m.row < len(m.value)-1 {
 			m.row++
@@ -810,7 +826,8 @@ func (m *Model) characterRight() {
 	}
 }
 
-// characterLeft moves the cursor one character to the left.
+// characterLeft moves the cursor one character to the left, over a whole
+// grapheme cluster.
 // If insideLine is set, the cursor is moved to the last
 // character in the previous line, instead of one past that.
 func (m *Model) characterLeft(insideLine bool) {
@@ -822,7 +839,7 @@ func (m *Model) characterLeft(insideLine bool) {
 		}
 	}
 	if m.col > 0 {
-		m.SetCursorColumn(m.col - 1)
+		m.SetCursorColumn(m.col - graphemeBefore(m.value[m.row], m.col))
 	}
 }
 
@@ -1094,14 +1111,15 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				break
 			}
 			if len(m.value[m.row]) > 0 {
-				m.value[m.row] = append(m.value[m.row][:max(0, m.col-1)], m.value[m.row][m.col:]...)
+				n := graphemeBefore(m.value[m.row], m.col)
+				m.value[m.row] = append(m.value[m.row][:max(0, m.col-n)], m.value[m.row][m.col:]...)
 				if m.col > 0 {
-					m.SetCursorColumn(m.col - 1)
+					m.SetCursorColumn(m.col - n)
 				}
 			}
 		case key.Matches(msg, m.KeyMap.DeleteCharacterForward):
 			if len(m.value[m.row]) > 0 && m.col < len(m.value[m.row]) {
-				m.value[m.row] = slices.Delete(m.value[m.row], m.col, m.col+1)
+				m.value[m.row] = slices.Delete(m.value[m.row], m.col, m.col+graphemeAfter(m.value[m.row], m.col))
 			}
 			if m.col >= len(m.value[m.row]) {
 				m.mergeLineBelow(m.row)
@@ -1202,8 +1220,15 @@ func (m Model) View() string {
 		} else {
 			style = styles.computedText()
 		}
+		var marks [][2]int
+		if m.Marks != nil {
+			marks = m.Marks(l, line)
+		}
 
+		offset := 0
 		for wl, wrappedLine := range wrappedLines {
+			start := offset
+			offset += len(wrappedLine)
 			prompt := m.promptView(displayLine)
 			prompt = styles.computedPrompt().Render(prompt)
 			s.WriteString(style.Render(prompt))
@@ -1240,17 +1265,20 @@ func (m Model) View() string {
 				padding -= m.width - strwidth
 			}
 			if m.row == l && lineInfo.RowOffset == wl {
-				s.WriteString(style.Render(string(wrappedLine[:lineInfo.ColumnOffset])))
+				s.WriteString(m.renderMarked(style, wrappedLine[:lineInfo.ColumnOffset], start, marks))
 				if m.col >= len(line) && lineInfo.CharOffset >= m.width {
 					m.virtualCursor.SetChar(" ")
 					s.WriteString(m.virtualCursor.View())
 				} else {
-					m.virtualCursor.SetChar(string(wrappedLine[lineInfo.ColumnOffset]))
+					// the cursor covers the whole cluster, or combining marks
+					// would be drawn apart from the character they modify
+					end := lineInfo.ColumnOffset + max(graphemeAfter(wrappedLine, lineInfo.ColumnOffset), 1)
+					m.virtualCursor.SetChar(string(wrappedLine[lineInfo.ColumnOffset:end]))
 					s.WriteString(style.Render(m.virtualCursor.View()))
-					s.WriteString(style.Render(string(wrappedLine[lineInfo.ColumnOffset+1:])))
+					s.WriteString(m.renderMarked(style, wrappedLine[end:], start+end, marks))
 				}
 			} else {
-				s.WriteString(style.Render(string(wrappedLine)))
+				s.WriteString(m.renderMarked(style, wrappedLine, start, marks))
 			}
 			s.WriteString(style.Render(strings.Repeat(" ", max(0, padding))))
 			s.WriteRune('\n')
@@ -1267,6 +1295,29 @@ func (m Model) View() string {
 	return styles.Base.Render(result)
 }
 
+// renderMarked renders runes found at offset in their line, the parts inside
+// marks in the Marked style.
+func (m Model) renderMarked(style lipgloss.Style, runes []rune, offset int, marks [][2]int) string {
+	if len(marks) == 0 {
+		return style.Render(string(runes))
+	}
+	marked := m.activeStyle().Marked.Inherit(style)
+	var s strings.Builder
+	pos := 0
+	for _, mark := range marks {
+		from := clamp(mark[0]-offset, pos, len(runes))
+		to := clamp(mark[1]-offset, from, len(runes))
+		if from == to {
+			continue
+		}
+		s.WriteString(style.Render(string(runes[pos:from])))
+		s.WriteString(marked.Render(string(runes[from:to])))
+		pos = to
+	}
+	s.WriteString(style.Render(string(runes[pos:])))
+	return s.String()
+}
+
 // promptView renders a single line of the prompt.
 func (m Model) promptView(displayLine int) (prompt string) {
 	prompt = m.Prompt
@@ -1543,16 +1594,28 @@ func wrap(runes []rune, width int) [][]rune {
 		spaces int
 	)
 
-	// Word wrap the runes
-	for _, r := range runes {
-		if unicode.IsSpace(r) {
+	// Word wrap the grapheme clusters, so that wide glyphs are measured by
+	// the cells they take and combining marks stay with their character
+	for _, cluster := range graphemes(runes) {
+		if len(cluster) == 1 && unicode.IsSpace(cluster[0]) {
 			spaces++
 		} else {
-			word = append(word, r)
+			// If a wide character does not fit in what is left of the line,
+			// break the word before it rather than overflowing.
+			clusterWidth := runesWidth(cluster)
+			if len(word) > 0 && runesWidth(word)+clusterWidth > width {
+				if len(lines[row]) > 0 {
+					row++
+					lines = append(lines, []rune{})
+				}
+				lines[row] = append(lines[row], word...)
+				word = nil
+			}
+			word = append(word, cluster...)
 		}
 
 		if spaces > 0 { //nolint:nestif
-			if uniseg.StringWidth(string(lines[row]))+uniseg.StringWidth(string(word))+spaces > width {
+			if runesWidth(lines[row])+runesWidth(word)+spaces > width {
 				row++
 				lines = append(lines, []rune{})
 				lines[row] = append(lines[row], word...)
@@ -1568,8 +1631,8 @@ func wrap(runes []rune, width int) [][]rune {
 		} else {
 			// If the last character is a double-width rune, then we may not be able to add it to this line
 			// as it might cause us to go past the width.
-			lastCharLen := rw.RuneWidth(word[len(word)-1])
-			if uniseg.StringWidth(string(word))+lastCharLen > width {
+			lastCharLen := runesWidth(cluster)
+			if runesWidth(word)+lastCharLen > width {
 				// If the current line has any content, let's move to the next
 				// line because the current word fills up the entire line.
 				if len(lines[row]) > 0 {
@@ -1582,7 +1645,7 @@ func wrap(runes []rune, width int) [][]rune {
 		}
 	}
 
-	if uniseg.StringWidth(string(lines[row]))+uniseg.StringWidth(string(word))+spaces >= width {
+	if runesWidth(lines[row])+runesWidth(word)+spaces >= width {
 		lines = append(lines, []rune{})
 		lines[row+1] = append(lines[row+1], word...)
 		// We add an extra space at the end of the line to account for the
@@ -2,6 +2,7 @@ package toast
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,12 +14,36 @@ import (
 	"github.com/sst/opencode/internal/theme"
 )
 
+// Level is the severity of a toast
+type Level string
+
+const (
+	LevelInfo    Level = "info"
+	LevelSuccess Level = "success"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+// DefaultDuration is how long a toast is shown when its level has no
+// duration in Durations
+const DefaultDuration = 5 * time.Second
+
+// historyLimit is the number of toasts kept in the history
+const historyLimit = 50
+
+// Durations is how long the toasts of each level are shown, set from the
+// state on startup
+var Durations = map[Level]time.Duration{}
+
 // ShowToastMsg is a message to display a toast notification
 type ShowToastMsg struct {
 	Message  string
 	Title    *string
 	Color    compat.AdaptiveColor
 	Duration time.Duration
+	Level    Level
+	// Error is set for error toasts
+	Error bool
 }
 
 // DismissToastMsg is a message to dismiss a specific toast
@@ -32,19 +57,64 @@ type Toast struct {
 	Message   string
 	Title     *string
 	Color     compat.AdaptiveColor
+	Level     Level
 	CreatedAt time.Time
 	Duration  time.Duration
+	// Count is the number of times the same toast was shown while visible
+	Count int
 }
 
 // ToastManager manages multiple toast notifications
 type ToastManager struct {
-	toasts []Toast
+	toasts  []Toast
+	history []Toast
+	// rects are the screen areas of the toasts last rendered, by ID
+	rects map[string]toastRect
+}
+
+type toastRect struct {
+	x, y, width, height int
 }
 
 // NewToastManager creates a new toast manager
 func NewToastManager() *ToastManager {
 	return &ToastManager{
 		toasts: []Toast{},
+		rects:  map[string]toastRect{},
+	}
+}
+
+// History returns the last toasts shown, the most recent first
+func (tm *ToastManager) History() []Toast {
+	history := make([]Toast, len(tm.history))
+	for i, toast := range tm.history {
+		history[len(tm.history)-1-i] = toast
+	}
+	return history
+}
+
+// DismissAll hides the visible toasts, they stay in the history
+func (tm *ToastManager) DismissAll() bool {
+	dismissed := len(tm.toasts) > 0
+	tm.toasts = []Toast{}
+	tm.rects = map[string]toastRect{}
+	return dismissed
+}
+
+// Clicked returns the ID of the toast rendered at x, y
+func (tm *ToastManager) Clicked(x, y int) (string, bool) {
+	for id, rect := range tm.rects {
+		if x >= rect.x && x < rect.x+rect.width && y >= rect.y && y < rect.y+rect.height {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func (tm *ToastManager) record(toast Toast) {
+	tm.history = append(tm.history, toast)
+	if len(tm.history) > historyLimit {
+		tm.history = tm.history[len(tm.history)-historyLimit:]
 	}
 }
 
@@ -57,15 +127,34 @@ func (tm *ToastManager) Init() tea.Cmd {
 func (tm *ToastManager) Update(msg tea.Msg) (*ToastManager, tea.Cmd) {
 	switch msg := msg.(type) {
 	case ShowToastMsg:
+		if msg.Level == "" {
+			msg.Level = LevelInfo
+			if msg.Error {
+				msg.Level = LevelError
+			}
+		}
 		toast := Toast{
 			ID:        fmt.Sprintf("toast-%d", time.Now().UnixNano()),
 			Title:     msg.Title,
 			Message:   msg.Message,
 			Color:     msg.Color,
+			Level:     msg.Level,
 			CreatedAt: time.Now(),
 			Duration:  msg.Duration,
+			Count:     1,
+		}
+		tm.record(toast)
+
+		// The same toast shown again while visible is counted instead of
+		// stacked, and stays for its full duration from now
+		for i, shown := range tm.toasts {
+			if shown.Message == toast.Message && shown.Level == toast.Level && sameTitle(shown.Title, toast.Title) {
+				toast.Count = shown.Count + 1
+				delete(tm.rects, shown.ID)
+				tm.toasts = append(tm.toasts[:i], tm.toasts[i+1:]...)
+				break
+			}
 		}
-
 		tm.toasts = append(tm.toasts, toast)
 
 		// Return command to dismiss after duration
@@ -81,11 +170,19 @@ func (tm *ToastManager) Update(msg tea.Msg) (*ToastManager, tea.Cmd) {
 			}
 		}
 		tm.toasts = newToasts
+		delete(tm.rects, msg.ID)
 	}
 
 	return tm, nil
 }
 
+func sameTitle(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // renderSingleToast renders a single toast notification
 func (tm *ToastManager) renderSingleToast(toast Toast) string {
 	t := theme.CurrentTheme()
@@ -100,11 +197,16 @@ func (tm *ToastManager) renderSingleToast(toast Toast) string {
 
 	// Build content with wrapping
 	var content strings.Builder
+	count := ""
+	if toast.Count > 1 {
+		count = styles.NewStyle().Foreground(t.TextMuted()).Render(fmt.Sprintf(" ×%d", toast.Count))
+	}
 	if toast.Title != nil {
 		titleStyle := styles.NewStyle().Foreground(toast.Color).
 			Bold(true)
-		content.WriteString(titleStyle.Render(*toast.Title))
+		content.WriteString(titleStyle.Render(*toast.Title) + count)
 		content.WriteString("\n")
+		count = ""
 	}
 
 	// Wrap message text
@@ -113,7 +215,7 @@ func (tm *ToastManager) renderSingleToast(toast Toast) string {
 	if contentWidth > contentMaxWidth {
 		messageStyle = messageStyle.Width(contentMaxWidth)
 	}
-	content.WriteString(messageStyle.Render(toast.Message))
+	content.WriteString(messageStyle.Render(toast.Message) + count)
 
 	// Render toast with max width
 	return baseStyle.MaxWidth(maxWidth).Render(content.String())
@@ -143,12 +245,15 @@ func (tm *ToastManager) RenderOverlay(background string) string {
 	bgWidth := lipgloss.Width(background)
 	bgHeight := lipgloss.Height(background)
 	result := background
+	tm.rects = map[string]toastRect{}
 
 	// Start from top with 2 character padding
 	currentY := 2
 
-	// Render each toast individually
-	for _, toast := range tm.toasts {
+	// Render each toast individually, the most recent ones once they do
+	// not all fit
+	shown := 0
+	for i, toast := range slices.Backward(tm.toasts) {
 		// Render individual toast
 		toastView := tm.renderSingleToast(toast)
 		toastWidth := lipgloss.Width(toastView)
@@ -157,8 +262,12 @@ func (tm *ToastManager) RenderOverlay(background string) string {
 		// Position at top-right with 2 character padding from right edge
 		x := max(bgWidth-toastWidth-4, 0)
 
-		// Check if toast fits vertically
-		if currentY+toastHeight > bgHeight-2 {
+		// Check if toast fits vertically, keeping a line for the hidden ones
+		reserved := 0
+		if i > 0 {
+			reserved = 2
+		}
+		if currentY+toastHeight+reserved > bgHeight-2 {
 			// No more room for toasts
 			break
 		}
@@ -172,11 +281,24 @@ func (tm *ToastManager) RenderOverlay(background string) string {
 			layout.WithOverlayBorder(),
 			layout.WithOverlayBorderColor(toast.Color),
 		)
+		// the border adds a column on each side
+		tm.rects[toast.ID] = toastRect{x: x, y: currentY, width: toastWidth + 2, height: toastHeight}
+		shown++
 
 		// Move down for next toast (add 1 for spacing between toasts)
 		currentY += toastHeight + 1
 	}
 
+	if hidden := len(tm.toasts) - shown; hidden > 0 && currentY < bgHeight-2 {
+		t := theme.CurrentTheme()
+		more := styles.NewStyle().
+			Foreground(t.TextMuted()).
+			Background(t.BackgroundElement()).
+			Padding(0, 1).
+			Render(fmt.Sprintf("+%d more", hidden))
+		result = layout.PlaceOverlay(max(bgWidth-lipgloss.Width(more)-4, 0), currentY, more, result)
+	}
+
 	return result
 }
 
@@ -189,6 +311,8 @@ type toastOptions struct {
 	title    *string
 	duration *time.Duration
 	color    *compat.AdaptiveColor
+	level    Level
+	error    bool
 }
 
 type ToastOption func(*toastOptions)
@@ -210,18 +334,31 @@ func WithColor(color compat.AdaptiveColor) ToastOption {
 	}
 }
 
+func withLevel(level Level) ToastOption {
+	return func(t *toastOptions) {
+		t.level = level
+		t.error = level == LevelError
+	}
+}
+
 func NewToast(message string, options ...ToastOption) tea.Cmd {
 	t := theme.CurrentTheme()
-	duration := 5 * time.Second
 	color := t.Primary()
 
 	opts := toastOptions{
-		duration: &duration,
-		color:    &color,
+		color: &color,
+		level: LevelInfo,
 	}
 	for _, option := range options {
 		option(&opts)
 	}
+	if opts.duration == nil {
+		duration, ok := Durations[opts.level]
+		if !ok || duration <= 0 {
+			duration = DefaultDuration
+		}
+		opts.duration = &duration
+	}
 
 	return func() tea.Msg {
 		return ShowToastMsg{
@@ -229,12 +366,14 @@ func NewToast(message string, options ...ToastOption) tea.Cmd {
 			Title:    opts.title,
 			Duration: *opts.duration,
 			Color:    *opts.color,
+			Level:    opts.level,
+			Error:    opts.error,
 		}
 	}
 }
 
 func NewInfoToast(message string, options ...ToastOption) tea.Cmd {
-	options = append(options, WithColor(theme.CurrentTheme().Info()))
+	options = append(options, WithColor(theme.CurrentTheme().Info()), withLevel(LevelInfo))
 	return NewToast(
 		message,
 		options...,
@@ -242,7 +381,7 @@ func NewInfoToast(message string, options ...ToastOption) tea.Cmd {
 }
 
 func NewSuccessToast(message string, options ...ToastOption) tea.Cmd {
-	options = append(options, WithColor(theme.CurrentTheme().Success()))
+	options = append(options, WithColor(theme.CurrentTheme().Success()), withLevel(LevelSuccess))
 	return NewToast(
 		message,
 		options...,
@@ -250,7 +389,7 @@ func NewSuccessToast(message string, options ...ToastOption) tea.Cmd {
 }
 
 func NewWarningToast(message string, options ...ToastOption) tea.Cmd {
-	options = append(options, WithColor(theme.CurrentTheme().Warning()))
+	options = append(options, WithColor(theme.CurrentTheme().Warning()), withLevel(LevelWarning))
 	return NewToast(
 		message,
 		options...,
@@ -258,7 +397,7 @@ func NewWarningToast(message string, options ...ToastOption) tea.Cmd {
 }
 
 func NewErrorToast(message string, options ...ToastOption) tea.Cmd {
-	options = append(options, WithColor(theme.CurrentTheme().Error()))
+	options = append(options, WithColor(theme.CurrentTheme().Error()), withLevel(LevelError))
 	return NewToast(
 		message,
 		options...,
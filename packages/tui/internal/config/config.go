@@ -2,23 +2,332 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/sst/opencode/pkg/client"
 )
 
+// ProjectDefault is the model and agent mode last used in a project
+type ProjectDefault struct {
+	Provider string `toml:"provider"`
+	Model    string `toml:"model"`
+	Mode     string `toml:"mode"`
+}
+
+// ToolRules are the tool calls approved without asking, Commands are shell
+// command patterns where * matches anything and Paths are globs of the files
+// writes and edits are approved for, relative to the project root
+type ToolRules struct {
+	Commands []string `toml:"commands"`
+	Paths    []string `toml:"paths"`
+}
+
+// RedactionRules adjust the secrets masked in the prompts of a project, Deny
+// and Allow are regular expressions of the text masked or left alone
+type RedactionRules struct {
+	Deny     []string `toml:"deny"`
+	Allow    []string `toml:"allow"`
+	Disabled bool     `toml:"disabled"`
+}
+
 type State struct {
 	Theme    string `toml:"theme"`
 	Provider string `toml:"provider"`
 	Model    string `toml:"model"`
+	// Locale is the language of the interface, such as "es", detected from
+	// the environment when empty
+	Locale string `toml:"locale"`
+	// ProjectDefaults maps project roots to the model and agent mode last
+	// used in them, selected over Provider and Model in that project
+	ProjectDefaults map[string]ProjectDefault `toml:"project_defaults"`
+	// SessionDirectories maps session IDs to the cwd they were started from
+	SessionDirectories map[string]string `toml:"session_directories"`
+	// ArchivedSessions are hidden from the session list
+	ArchivedSessions map[string]bool `toml:"archived_sessions"`
+	// PinnedSessions are listed above the others in the session list
+	PinnedSessions map[string]bool `toml:"pinned_sessions"`
+	// PinnedMessages stay visible when the history before a summary is
+	// collapsed
+	PinnedMessages map[string]bool `toml:"pinned_messages"`
+	// CleanupDays proposes deleting unarchived sessions untouched for this
+	// many days, only empty ones unless CleanupNonEmpty is set. Zero
+	// disables the policy. KeptSessions were kept in a review.
+	CleanupDays     int             `toml:"cleanup_days"`
+	CleanupNonEmpty bool            `toml:"cleanup_non_empty"`
+	KeptSessions    map[string]bool `toml:"kept_sessions"`
+	// SkipConfirmations are the destructive actions run without asking,
+	// after the user chose not to be asked again
+	SkipConfirmations map[string]bool `toml:"skip_confirmations"`
+	// SessionTags maps session IDs to the tags given to them with /tag
+	SessionTags map[string][]string `toml:"session_tags"`
+	// SessionModes maps session IDs to the agent mode last used in them
+	SessionModes map[string]string `toml:"session_modes"`
+	// SystemPrompts maps session IDs to a custom system prompt
+	SystemPrompts map[string]string `toml:"system_prompts"`
+	StatusBar     []StatusSegment   `toml:"status_bar"`
+	Density       string            `toml:"density"`
+	// Drafts holds the unsent editor content per session ID, the empty key is
+	// the draft for a new session
+	Drafts map[string]string `toml:"drafts"`
+	// PinnedContext maps session IDs to the files and snippets sent with
+	// every prompt of the session, the empty key holds them for a new session
+	PinnedContext map[string][]PinnedContext `toml:"pinned_context"`
+	// Workspaces maps session IDs to the roots attached to them, the empty
+	// key holds them for a new session
+	Workspaces map[string]Workspace `toml:"workspaces"`
+	// Checkpoints maps session IDs to their named restore points, oldest
+	// first
+	Checkpoints map[string][]Checkpoint `toml:"checkpoints"`
+	// SessionParameters maps session IDs to the model parameters sent with
+	// their prompts
+	SessionParameters map[string]ModelParameters `toml:"session_parameters"`
+	// RelativeTimestamps shows message times as "3m ago" instead of the
+	// TimestampFormat, a Go time layout, in the Timezone (local by default)
+	RelativeTimestamps bool   `toml:"relative_timestamps"`
+	TimestampFormat    string `toml:"timestamp_format"`
+	Timezone           string `toml:"timezone"`
+	// RecentModels are the "provider/model" IDs last selected, most recent
+	// first, listed at the top of the model palette
+	RecentModels     []string `toml:"recent_models"`
+	RecentModelLimit int      `toml:"recent_model_limit"`
+	// FavoriteModels are the starred "provider/model" IDs, the first one is
+	// the default model
+	FavoriteModels []string `toml:"favorite_models"`
+	// DraftInterval is how often drafts are saved in seconds, a negative
+	// value disables saving drafts
+	DraftInterval int `toml:"draft_interval"`
+	// SplitLayoutWidth is the terminal width from which the session list is
+	// shown as a sidebar, a negative value disables the sidebar
+	SplitLayoutWidth int `toml:"split_layout_width"`
+	// ContentWidth is the maximum width of the chat column, a negative value
+	// uses the full width of the terminal
+	ContentWidth int `toml:"content_width"`
+	// LayoutPresets are the layouts saved with /layout-save by name, Layout
+	// is the one last switched to
+	LayoutPresets map[string]LayoutPreset `toml:"layout_presets"`
+	Layout        string                  `toml:"layout"`
+	// CodeWrap is how code blocks wider than the chat column are laid out,
+	// an empty CodeWrap is soft
+	CodeWrap string `toml:"code_wrap"`
+	// Reasoning is how the reasoning of models is shown, an empty Reasoning
+	// is collapsed
+	Reasoning string `toml:"reasoning"`
+	// ScreenReader renders the chat linearly without decorations and
+	// announces state changes, for terminal screen readers
+	ScreenReader bool `toml:"screen_reader"`
+	// FallbackModels are the "provider/model" IDs a prompt is resent to, in
+	// order, when its provider fails. AutoFallback resends without asking.
+	FallbackModels []string `toml:"fallback_models"`
+	AutoFallback   bool     `toml:"auto_fallback"`
+	// Fallbacks maps session IDs to the messages a fallback model wrote and
+	// the "provider/model" ID that failed before it
+	Fallbacks map[string]map[string]string `toml:"fallbacks"`
+	// ReportFileChanges tells the model which files changed outside of the
+	// agent since the last turn, with the next message
+	ReportFileChanges bool `toml:"report_file_changes"`
+	// SendRetries is how many times a request failing on a network error is
+	// sent again, waiting SendBackoff milliseconds before the first retry and
+	// twice as long before each next one. A negative value disables retries.
+	SendRetries int `toml:"send_retries"`
+	SendBackoff int `toml:"send_backoff"`
+	// NewlineOnEnter makes Enter insert a newline and Shift+Enter send,
+	// the opposite of the default
+	NewlineOnEnter bool `toml:"newline_on_enter"`
+	// Snippets maps abbreviations to the templates they expand to with tab
+	// in the editor, ${1:text} and $1 are tab stops and $0 is the last one
+	Snippets map[string]string `toml:"snippets"`
+	// SpellCheck underlines the misspelled words of the draft, checked
+	// against a bundled wordlist and the words.txt of the user and project
+	SpellCheck bool `toml:"spell_check"`
+	// WindowTitle is the template of the terminal title, with {project},
+	// {session}, {model}, {cwd} and {busy}. "-" leaves the title alone.
+	WindowTitle string `toml:"window_title"`
+	// OpenCommand is the shell command mentioned files are opened with, with
+	// {file}, {line} and {editor}, such as "tmux split-window nvim +{line}
+	// {file}". ProjectOpenCommands overrides it by project root.
+	OpenCommand         string            `toml:"open_command"`
+	ProjectOpenCommands map[string]string `toml:"project_open_commands"`
+	// AttachmentLimit is the estimated prompt size in tokens, with pasted
+	// content, outputs and attachments, from which sending asks whether to
+	// truncate, chunk or summarize it. A negative value disables the limit.
+	AttachmentLimit int `toml:"attachment_limit"`
+	// PasteLines is the number of lines from which a paste is collapsed to a
+	// label in the editor and sent as its own part, a negative value
+	// inserts pastes as they are
+	PasteLines int `toml:"paste_lines"`
+	// TokenWarning is the estimated draft size in tokens from which the
+	// editor warns, a negative value disables the warning
+	TokenWarning int `toml:"token_warning"`
+	// LogLevels sets the log level of the app, client, renderer and sse
+	// subsystems, the "default" key applies to the others
+	LogLevels map[string]string `toml:"log_levels"`
+	// ToastDurations is how many seconds the toasts of the info, success,
+	// warning and error levels are shown, 5 by default
+	ToastDurations map[string]int `toml:"toast_durations"`
+	// ToolRules auto-approves the matching tool calls, the other ones ask
+	ToolRules ToolRules `toml:"tool_rules"`
+	// Redaction maps project roots to the patterns of the secrets masked in
+	// prompts there, on top of the builtin ones
+	Redaction map[string]RedactionRules `toml:"redaction"`
+	// CACert is a PEM file of extra certificate authorities and Proxy the
+	// HTTP(S) proxy URL used to reach the server, unless set by flags or the
+	// environment
+	CACert string `toml:"ca_cert"`
+	Proxy  string `toml:"proxy"`
+}
+
+// Message rendering densities, an empty Density is comfortable
+const (
+	DensityCompact     = "compact"
+	DensityComfortable = "comfortable"
+	DensityVerbose     = "verbose"
+)
+
+// Densities lists the rendering densities in toggle order
+var Densities = []string{DensityCompact, DensityComfortable, DensityVerbose}
+
+// DefaultContentWidth is the maximum width of the chat column unless
+// configured otherwise
+const DefaultContentWidth = 80
+
+// Code block wrapping: soft wraps at word boundaries, hard breaks lines at
+// the column edge and scroll keeps long lines intact and scrolls them
+const (
+	CodeWrapSoft   = "soft"
+	CodeWrapHard   = "hard"
+	CodeWrapScroll = "scroll"
+)
+
+// CodeWraps lists the code block wrapping modes in toggle order
+var CodeWraps = []string{CodeWrapSoft, CodeWrapHard, CodeWrapScroll}
+
+// Reasoning display: collapsed shows a line that expands on click, expanded
+// shows the whole reasoning and hidden leaves it out
+const (
+	ReasoningCollapsed = "collapsed"
+	ReasoningExpanded  = "expanded"
+	ReasoningHidden    = "hidden"
+)
+
+// ReasoningModes lists the reasoning display modes in toggle order
+var ReasoningModes = []string{ReasoningCollapsed, ReasoningExpanded, ReasoningHidden}
+
+// PinnedContext is a file, read again for every prompt, or a snippet of
+// text included with the prompts of a session
+type PinnedContext struct {
+	Path string `toml:"path,omitempty"`
+	Text string `toml:"text,omitempty"`
+}
+
+// Workspace is the roots attached to a session besides the working
+// directory, and the one file completion, git status and the agent use
+type Workspace struct {
+	Roots  []string `toml:"roots"`
+	Active string   `toml:"active,omitempty"`
+}
+
+// Checkpoint is a named restore point of a session, after MessageID or
+// before the first message when it is empty
+type Checkpoint struct {
+	Name      string    `toml:"name"`
+	MessageID string    `toml:"message_id,omitempty"`
+	Created   time.Time `toml:"created"`
+}
+
+// LayoutPreset is a named set of the layout settings, an empty Theme keeps
+// the current theme
+type LayoutPreset struct {
+	Theme            string `toml:"theme,omitempty"`
+	Density          string `toml:"density,omitempty"`
+	SplitLayoutWidth int    `toml:"split_layout_width,omitempty"`
+	ContentWidth     int    `toml:"content_width,omitempty"`
+	CodeWrap         string `toml:"code_wrap,omitempty"`
+	Reasoning        string `toml:"reasoning,omitempty"`
+}
+
+// ModelParameters override the sampling of the model, the zero values use
+// the defaults of the server
+type ModelParameters struct {
+	Temperature     *float64 `toml:"temperature,omitempty"`
+	TopP            *float64 `toml:"top_p,omitempty"`
+	MaxTokens       int      `toml:"max_tokens,omitempty"`
+	ReasoningEffort string   `toml:"reasoning_effort,omitempty"`
+}
+
+// IsZero reports whether no parameter is overridden
+func (p ModelParameters) IsZero() bool {
+	return p.Temperature == nil && p.TopP == nil && p.MaxTokens == 0 && p.ReasoningEffort == ""
+}
+
+// Equal reports whether both override the same parameters with the same
+// values
+func (p ModelParameters) Equal(other ModelParameters) bool {
+	equal := func(a, b *float64) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return *a == *b
+	}
+	return equal(p.Temperature, other.Temperature) &&
+		equal(p.TopP, other.TopP) &&
+		p.MaxTokens == other.MaxTokens &&
+		p.ReasoningEffort == other.ReasoningEffort
+}
+
+// ReasoningEfforts lists the reasoning efforts a model can be asked for
+var ReasoningEfforts = []string{"low", "medium", "high"}
+
+// StatusSegment configures one segment of the status bar. Type is one of
+// logo, mode, system, parameters, run, cwd, model, session, git, tokens, changes, command or spacer. Segments with a
+// higher Priority are dropped first when the terminal is too narrow, a
+// Priority of zero is never dropped.
+type StatusSegment struct {
+	Type     string `toml:"type"`
+	Command  string `toml:"command,omitempty"`
+	Interval int    `toml:"interval,omitempty"` // refresh interval in seconds
+	Priority int    `toml:"priority,omitempty"`
+}
+
+// DefaultStatusBar is used when no segments are configured
+var DefaultStatusBar = []StatusSegment{
+	{Type: "logo"},
+	{Type: "mode"},
+	{Type: "system"},
+	{Type: "parameters", Priority: 1},
+	{Type: "cwd", Priority: 2},
+	{Type: "spacer"},
+	{Type: "changes", Priority: 2},
+	{Type: "run", Priority: 1},
+	{Type: "tokens", Priority: 1},
 }
 
 func NewState() *State {
 	return &State{
-		Theme: "opencode",
+		Theme:              "opencode",
+		ProjectDefaults:    map[string]ProjectDefault{},
+		SessionDirectories: map[string]string{},
+		SessionModes:       map[string]string{},
+		SystemPrompts:      map[string]string{},
+		SessionParameters:  map[string]ModelParameters{},
+		LayoutPresets:      map[string]LayoutPreset{},
+		PinnedContext:      map[string][]PinnedContext{},
+		Redaction:          map[string]RedactionRules{},
+		Workspaces:         map[string]Workspace{},
+		Checkpoints:        map[string][]Checkpoint{},
+		Fallbacks:          map[string]map[string]string{},
+		Drafts:             map[string]string{},
+		ArchivedSessions:   map[string]bool{},
+		PinnedSessions:     map[string]bool{},
+		PinnedMessages:     map[string]bool{},
+		KeptSessions:       map[string]bool{},
+		SessionTags:        map[string][]string{},
+		SkipConfirmations:  map[string]bool{},
 	}
 }
 
@@ -29,13 +338,20 @@ func MergeState(state *State, config *client.ConfigInfo) *client.ConfigInfo {
 	return config
 }
 
+// ErrStateCorrupt is returned by LoadState when the state file exists but is
+// not valid TOML
+var ErrStateCorrupt = errors.New("state file is corrupt")
+
 // SaveState writes the provided Config struct to the specified TOML file.
 // It will create the file if it doesn't exist, or overwrite it if it does.
+// The file is replaced at once so other instances never read it half written,
+// and the version it replaces is kept as a backup when it was valid.
 func SaveState(filePath string, state *State) error {
-	file, err := os.Create(filePath)
+	file, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("failed to create/open config file %s: %w", filePath, err)
 	}
+	defer os.Remove(file.Name())
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
@@ -46,6 +362,18 @@ func SaveState(filePath string, state *State) error {
 	if err := writer.Flush(); err != nil {
 		return fmt.Errorf("failed to flush writer for state file %s: %w", filePath, err)
 	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", filePath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", filePath, err)
+	}
+	if err := backupState(filePath); err != nil {
+		slog.Warn("Failed to back up state file", "file", filePath, "error", err)
+	}
+	if err := os.Rename(file.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to replace state file %s: %w", filePath, err)
+	}
 
 	slog.Debug("State saved to file", "file", filePath)
 	return nil
@@ -59,7 +387,56 @@ func LoadState(filePath string) (*State, error) {
 		if _, statErr := os.Stat(filePath); os.IsNotExist(statErr) {
 			return nil, fmt.Errorf("state file not found at %s: %w", filePath, statErr)
 		}
-		return nil, fmt.Errorf("failed to decode TOML from file %s: %w", filePath, err)
+		return nil, fmt.Errorf("%w, failed to decode TOML from file %s: %w", ErrStateCorrupt, filePath, err)
 	}
 	return &state, nil
 }
+
+// BackupPath is where the last valid version of the state file is kept
+func BackupPath(filePath string) string {
+	return filePath + ".bak"
+}
+
+// CorruptPath is where a state file that could not be decoded is moved aside
+func CorruptPath(filePath string) string {
+	return filePath + ".corrupt"
+}
+
+// backupState copies the state file to its backup, unless it is missing or
+// corrupt so that a bad write never replaces a good backup
+func backupState(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var state State
+	if _, err := toml.Decode(string(data), &state); err != nil {
+		return nil
+	}
+	backup := BackupPath(filePath)
+	temp := backup + ".tmp"
+	if err := os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, backup)
+}
+
+// RecoverState replaces a corrupt state file with its backup, keeping the
+// corrupt one at CorruptPath. It returns an error when there is no valid
+// backup, the corrupt file is moved aside all the same.
+func RecoverState(filePath string) (*State, error) {
+	if err := os.Rename(filePath, CorruptPath(filePath)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to move corrupt state file %s: %w", filePath, err)
+	}
+	state, err := LoadState(BackupPath(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover state file %s: %w", filePath, err)
+	}
+	if err := SaveState(filePath, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// SyncState saves state while holding a lock on the state file, so that TUIs
+// running at the same time do not overwrite each other. The changes other
+// instances saved since base was read are merged in: the fields and map
+// entries changed in state since base keep their value, the others take the
+// saved one. state is updated with the merge and the new base is returned.
+func SyncState(filePath string, base, state *State) (*State, error) {
+	lock, err := os.OpenFile(filePath+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return base, fmt.Errorf("failed to open state lock %s: %w", filePath, err)
+	}
+	defer lock.Close()
+	if err := lockFile(lock); err != nil {
+		return base, fmt.Errorf("failed to lock state file %s: %w", filePath, err)
+	}
+	defer unlockFile(lock)
+
+	if saved, err := LoadState(filePath); err == nil && base != nil {
+		mergeState(reflect.ValueOf(state).Elem(), reflect.ValueOf(base).Elem(), reflect.ValueOf(saved).Elem())
+	}
+	if err := SaveState(filePath, state); err != nil {
+		return base, err
+	}
+	return LoadState(filePath)
+}
+
+// mergeState applies the saved values of the fields of state left unchanged
+// since base, entry by entry for maps
+func mergeState(state, base, saved reflect.Value) {
+	for i := range state.NumField() {
+		mine, original, theirs := state.Field(i), base.Field(i), saved.Field(i)
+		if mine.Kind() != reflect.Map {
+			if sameValue(mine, original) {
+				mine.Set(theirs)
+			}
+			continue
+		}
+		merged := reflect.MakeMap(mine.Type())
+		for _, key := range theirs.MapKeys() {
+			merged.SetMapIndex(key, theirs.MapIndex(key))
+		}
+		keys := append(mine.MapKeys(), original.MapKeys()...)
+		for _, key := range keys {
+			value, before := mine.MapIndex(key), original.MapIndex(key)
+			switch {
+			case value.IsValid() && before.IsValid() && sameValue(value, before):
+			case value.IsValid():
+				merged.SetMapIndex(key, value)
+			case before.IsValid():
+				// deleted by this instance
+				merged.SetMapIndex(key, reflect.Value{})
+			}
+		}
+		mine.Set(merged)
+	}
+}
+
+// sameValue compares two values, a nil and an empty slice or map are equal
+// since decoding the state does not tell them apart
+func sameValue(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Slice, reflect.Map:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,228 @@
+// Package i18n translates the user facing strings of the TUI. The strings
+// are looked up by key in the catalogs under locales, formatted with fmt, and
+// fall back to English when a locale does not translate a key.
+//
+// The chat view, the status bar, the toasts and the confirmation and prompt
+// dialogs are translated. The titles and hints of the other dialogs, command
+// names and descriptions, and errors passed through from the server are
+// not, they stay in English for now.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale of the source strings
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// message is a string of a catalog, or its plural forms by category
+type message struct {
+	text   string
+	plural map[string]string
+}
+
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &m.plural)
+}
+
+type catalog map[string]message
+
+var (
+	loadOnce sync.Once
+	catalogs map[string]catalog
+	loadErr  error
+
+	mu      sync.RWMutex
+	current = DefaultLocale
+)
+
+func load() {
+	catalogs = map[string]catalog{}
+	entries, err := localesFS.ReadDir("locales")
+	if err != nil {
+		loadErr = fmt.Errorf("failed to read locales directory: %w", err)
+		return
+	}
+	for _, entry := range entries {
+		locale := strings.TrimSuffix(entry.Name(), ".json")
+		data, err := localesFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			loadErr = fmt.Errorf("failed to read locale %s: %w", locale, err)
+			return
+		}
+		messages := catalog{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			loadErr = fmt.Errorf("failed to parse locale %s: %w", locale, err)
+			return
+		}
+		catalogs[locale] = messages
+	}
+}
+
+func loaded() map[string]catalog {
+	loadOnce.Do(load)
+	return catalogs
+}
+
+// Locales lists the locales with a catalog, sorted
+func Locales() []string {
+	locales := []string{}
+	for locale := range loaded() {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Detect picks the locale to use: the configured one, else the first of the
+// OPENCODE_LOCALE, LC_ALL, LC_MESSAGES and LANG variables that names a
+// locale with a catalog, else DefaultLocale
+func Detect(configured string) string {
+	candidates := []string{configured}
+	for _, name := range []string{"OPENCODE_LOCALE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		candidates = append(candidates, os.Getenv(name))
+	}
+	for _, candidate := range candidates {
+		if locale, ok := supported(candidate); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// supported matches a locale such as "es_ES.UTF-8" to a catalog, the one of
+// its region first and the one of its language next
+func supported(locale string) (string, bool) {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.ReplaceAll(locale, "_", "-")
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return "", false
+	}
+	language, region, _ := strings.Cut(locale, "-")
+	language = strings.ToLower(language)
+	catalogs := loaded()
+	if region != "" {
+		if _, ok := catalogs[language+"-"+strings.ToUpper(region)]; ok {
+			return language + "-" + strings.ToUpper(region), true
+		}
+	}
+	if _, ok := catalogs[language]; ok {
+		return language, true
+	}
+	return "", false
+}
+
+// SetLocale translates the strings into locale from now on, falling back to
+// DefaultLocale when it has no catalog
+func SetLocale(locale string) error {
+	if loaded(); loadErr != nil {
+		return loadErr
+	}
+	matched, ok := supported(locale)
+	if !ok {
+		matched = DefaultLocale
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = matched
+	if !ok {
+		return fmt.Errorf("no translation for locale %q", locale)
+	}
+	return nil
+}
+
+// Locale returns the locale the strings are translated into
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// lookup finds the message of key in the current locale, its language or
+// DefaultLocale
+func lookup(key string) (message, bool) {
+	locale := Locale()
+	language, _, _ := strings.Cut(locale, "-")
+	catalogs := loaded()
+	for _, candidate := range []string{locale, language, DefaultLocale} {
+		if message, ok := catalogs[candidate][key]; ok {
+			return message, true
+		}
+	}
+	return message{}, false
+}
+
+// T translates the string of key, formatted with args
+func T(key string, args ...any) string {
+	message, ok := lookup(key)
+	if !ok {
+		return key
+	}
+	text := message.text
+	if message.plural != nil {
+		text = message.plural["other"]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// N translates the string of key in the plural form for count, formatted
+// with count followed by args
+func N(key string, count int, args ...any) string {
+	message, ok := lookup(key)
+	if !ok {
+		return key
+	}
+	text := message.text
+	if message.plural != nil {
+		text, ok = message.plural[pluralCategory(Locale(), count)]
+		if !ok {
+			text = message.plural["other"]
+		}
+	}
+	return fmt.Sprintf(text, append([]any{count}, args...)...)
+}
+
+// pluralCategory returns the CLDR plural category of count in locale, for
+// the languages with a catalog and the ones close to them
+func pluralCategory(locale string, count int) string {
+	language, _, _ := strings.Cut(locale, "-")
+	n := max(count, -count)
+	switch language {
+	case "fr", "pt":
+		if n <= 1 {
+			return "one"
+		}
+	case "ru", "uk":
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "ja", "ko", "zh":
+	default:
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
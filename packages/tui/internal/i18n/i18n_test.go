@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%(?:\[\d+\])?[a-zA-Z%]`)
+
+// verbs lists the formatting verbs of a string without their argument
+// indexes, so that translations may reorder the arguments
+func verbs(text string) []string {
+	found := []string{}
+	for _, verb := range verbPattern.FindAllString(text, -1) {
+		found = append(found, verb[len(verb)-1:])
+	}
+	slices.Sort(found)
+	return found
+}
+
+func TestCatalogsMatchDefault(t *testing.T) {
+	catalogs := loaded()
+	if loadErr != nil {
+		t.Fatalf("Failed to load catalogs: %v", loadErr)
+	}
+	source, ok := catalogs[DefaultLocale]
+	if !ok {
+		t.Fatal("No catalog for the default locale")
+	}
+	for locale, catalog := range catalogs {
+		for key, translated := range catalog {
+			original, ok := source[key]
+			if !ok {
+				t.Errorf("%s: key %s is not in the default catalog", locale, key)
+				continue
+			}
+			if (original.plural == nil) != (translated.plural == nil) {
+				t.Errorf("%s: key %s should be plural only if the default is", locale, key)
+				continue
+			}
+			if original.plural == nil {
+				if !slices.Equal(verbs(original.text), verbs(translated.text)) {
+					t.Errorf("%s: key %s has different verbs than the default", locale, key)
+				}
+				continue
+			}
+			if _, ok := translated.plural["other"]; !ok {
+				t.Errorf("%s: key %s has no other form", locale, key)
+			}
+			for category, text := range translated.plural {
+				if !slices.Equal(verbs(original.plural["other"]), verbs(text)) {
+					t.Errorf("%s: key %s has different verbs in its %s form", locale, key, category)
+				}
+			}
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+	if err := SetLocale("es_ES.UTF-8"); err != nil {
+		t.Fatal(err)
+	}
+	if Locale() != "es" {
+		t.Errorf("Expected es, got %s", Locale())
+	}
+	if got := N("toast.imported", 1); got != "Se importó 1 sesión" {
+		t.Errorf("Unexpected singular: %s", got)
+	}
+	if got := N("toast.restored_checkpoint", 2, "v1"); got != "Restaurado v1, 2 archivos revertidos" {
+		t.Errorf("Unexpected plural: %s", got)
+	}
+	if got := T("missing.key"); got != "missing.key" {
+		t.Errorf("Expected the key for a missing string, got %s", got)
+	}
+
+	for count, expected := range map[int]string{1: "one", 3: "few", 5: "many", 11: "many", 21: "one"} {
+		if got := pluralCategory("ru", count); got != expected {
+			t.Errorf("ru %d: expected %s, got %s", count, expected, got)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/ansi/iterm2"
+	"github.com/charmbracelet/x/ansi/kitty"
+	"github.com/disintegration/imaging"
+)
+
+// Protocol is a terminal graphics protocol images can be drawn with
+type Protocol string
+
+const (
+	ProtocolNone   Protocol = ""
+	ProtocolKitty  Protocol = "kitty"
+	ProtocolITerm2 Protocol = "iterm2"
+	ProtocolSixel  Protocol = "sixel"
+)
+
+// cellWidth and cellHeight are the assumed size of a terminal cell in
+// pixels, on the small side so images never overflow the cells kept for them
+const (
+	cellWidth  = 8
+	cellHeight = 16
+)
+
+// DetectProtocol guesses the graphics protocol of the terminal from its
+// environment, OPENCODE_GRAPHICS set to kitty, iterm2, sixel or none
+// overrides the guess
+func DetectProtocol() Protocol {
+	switch value := os.Getenv("OPENCODE_GRAPHICS"); value {
+	case "kitty", "iterm2", "sixel":
+		return Protocol(value)
+	case "none":
+		return ProtocolNone
+	}
+	// multiplexers only forward graphics when configured to
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return ProtocolNone
+	}
+	term := os.Getenv("TERM")
+	program := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty", program == "ghostty":
+		return ProtocolKitty
+	case program == "iTerm.app", program == "WezTerm", os.Getenv("LC_TERMINAL") == "iTerm2":
+		return ProtocolITerm2
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"), strings.Contains(term, "sixel"), program == "contour":
+		return ProtocolSixel
+	}
+	return ProtocolNone
+}
+
+// Fit returns the cells an image of width by height pixels takes at most
+// maxCols by maxRows, keeping its aspect ratio
+func Fit(width, height, maxCols, maxRows int) (int, int) {
+	cols := max((width+cellWidth-1)/cellWidth, 1)
+	rows := max((height+cellHeight-1)/cellHeight, 1)
+	if cols > maxCols {
+		rows = max(rows*maxCols/cols, 1)
+		cols = maxCols
+	}
+	if rows > maxRows {
+		cols = max(cols*maxRows/rows, 1)
+		rows = maxRows
+	}
+	return cols, rows
+}
+
+// Placeholder describes an image that cannot be drawn by its size and type
+func Placeholder(width, height int, mediaType string) string {
+	return fmt.Sprintf("▣ image %d×%d · %s", width, height, mediaType)
+}
+
+// KittyTransmit returns the sequence sending an image to a kitty terminal
+// with a virtual placement of cols by rows cells, which KittyPlaceholder
+// then shows wherever its text is drawn
+func KittyTransmit(id int, img image.Image, cols, rows int) (string, error) {
+	var b strings.Builder
+	err := ansi.WriteKittyGraphics(&b, img, &kitty.Options{
+		Action:           kitty.TransmitAndPut,
+		Transmission:     kitty.Direct,
+		Format:           kitty.PNG,
+		Quite:            2,
+		ID:               id,
+		VirtualPlacement: true,
+		Columns:          cols,
+		Rows:             rows,
+		Chunk:            true,
+	})
+	return b.String(), err
+}
+
+// KittyDelete returns the sequence freeing an image sent with KittyTransmit
+func KittyDelete(id int) string {
+	return ansi.KittyGraphics(nil, "a=d", "d=I", fmt.Sprintf("i=%d", id), "q=2")
+}
+
+// KittyPlaceholder returns the text showing the image with the id sent by
+// KittyTransmit, one line per row. The image id is the foreground color
+// and each cell holds its row and column as diacritics.
+func KittyPlaceholder(id, cols, rows int) string {
+	color := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", (id>>16)&0xff, (id>>8)&0xff, id&0xff)
+	lines := make([]string, rows)
+	for row := range rows {
+		var b strings.Builder
+		b.WriteString(color)
+		for col := range cols {
+			b.WriteRune(kitty.Placeholder)
+			b.WriteRune(kitty.Diacritic(row))
+			b.WriteRune(kitty.Diacritic(col))
+		}
+		b.WriteString("\x1b[39m")
+		lines[row] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Draw returns the sequence drawing an image over cols by rows cells from
+// column x and row y of the screen with the iTerm2 or sixel protocol, which
+// draw at the cursor
+func Draw(protocol Protocol, img image.Image, x, y, cols, rows int) (string, error) {
+	var seq string
+	switch protocol {
+	case ProtocolITerm2:
+		var data bytes.Buffer
+		if err := imaging.Encode(&data, img, imaging.PNG); err != nil {
+			return "", err
+		}
+		seq = ansi.ITerm2(iterm2.File{
+			Inline:  true,
+			Size:    int64(data.Len()),
+			Width:   iterm2.Cells(cols),
+			Height:  iterm2.Cells(rows),
+			Content: []byte(base64.StdEncoding.EncodeToString(data.Bytes())),
+		})
+	case ProtocolSixel:
+		seq = EncodeSixel(imaging.Fit(img, cols*cellWidth, rows*cellHeight, imaging.Lanczos))
+	default:
+		return "", fmt.Errorf("cannot draw at the cursor with %q", protocol)
+	}
+	return ansi.SaveCursor + ansi.CursorPosition(x+1, y+1) + seq + ansi.RestoreCursor, nil
+}
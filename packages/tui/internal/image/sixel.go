@@ -0,0 +1,78 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"slices"
+	"strings"
+)
+
+// EncodeSixel encodes an image as sixels, dithered to the 216 web-safe
+// colors
+func EncodeSixel(img image.Image) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	paletted := image.NewPaletted(image.Rect(0, 0, width, height), palette.WebSafe)
+	draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), img, bounds.Min)
+
+	var b strings.Builder
+	b.WriteString("\x1bPq")
+	fmt.Fprintf(&b, "\"1;1;%d;%d", width, height)
+	for i, c := range paletted.Palette {
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, bl*100/0xffff)
+	}
+
+	// each band of six rows is drawn once per color it uses
+	for top := 0; top < height; top += 6 {
+		colors := []uint8{}
+		for y := top; y < min(top+6, height); y++ {
+			for x := range width {
+				if index := paletted.ColorIndexAt(x, y); !slices.Contains(colors, index) {
+					colors = append(colors, index)
+				}
+			}
+		}
+		for i, index := range colors {
+			if i > 0 {
+				b.WriteByte('$')
+			}
+			fmt.Fprintf(&b, "#%d", index)
+			var run byte
+			count := 0
+			for x := range width {
+				var bits byte
+				for dy := range 6 {
+					if y := top + dy; y < height && paletted.ColorIndexAt(x, y) == index {
+						bits |= 1 << dy
+					}
+				}
+				sixel := 63 + bits
+				if count > 0 && sixel != run {
+					writeSixelRun(&b, run, count)
+					count = 0
+				}
+				run = sixel
+				count++
+			}
+			writeSixelRun(&b, run, count)
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+// writeSixelRun writes a sixel repeated count times, with the repeat
+// introducer when that is shorter
+func writeSixelRun(b *strings.Builder, sixel byte, count int) {
+	if count > 3 {
+		fmt.Fprintf(b, "!%d%c", count, sixel)
+		return
+	}
+	for range count {
+		b.WriteByte(sixel)
+	}
+}
@@ -13,6 +13,7 @@ type Container interface {
 	Sizeable
 	Focusable
 	Alignable
+	SetMaxWidth(maxWidth int)
 }
 
 type container struct {
@@ -148,6 +149,10 @@ func (c *container) MaxWidth() int {
 	return c.maxWidth
 }
 
+func (c *container) SetMaxWidth(maxWidth int) {
+	c.maxWidth = maxWidth
+}
+
 func (c *container) Alignment() lipgloss.Position {
 	return c.align
 }
@@ -0,0 +1,155 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Subsystems that can be given their own log level, records logged through
+// the default logger belong to App
+const (
+	App      = "app"
+	Client   = "client"
+	Renderer = "renderer"
+	SSE      = "sse"
+)
+
+// Modules lists the subsystems in the order they are shown
+var Modules = []string{App, Client, Renderer, SSE}
+
+// moduleKey is the attribute carrying the subsystem of a record
+const moduleKey = "module"
+
+var levels = struct {
+	sync.RWMutex
+	fallback slog.Level
+	modules  map[string]slog.Level
+}{fallback: slog.LevelDebug, modules: map[string]slog.Level{}}
+
+// Path returns the log file under the state directory
+func Path(state string) string {
+	return filepath.Join(state, "log", "tui.log")
+}
+
+// Setup makes the default logger write JSON records to w, filtered by the
+// level of their subsystem
+func Setup(w io.Writer) {
+	json := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug})
+	slog.SetDefault(slog.New(&moduleHandler{Handler: json, module: App}))
+}
+
+// For returns a logger for a subsystem. It writes through the default
+// logger of the time of each call, so it can be created before Setup
+func For(module string) *slog.Logger {
+	return slog.New(lazyHandler{module: module})
+}
+
+// SetLevels configures the level of each subsystem, the "default" key sets
+// the level of subsystems that are not listed
+func SetLevels(config map[string]string) error {
+	modules := map[string]slog.Level{}
+	fallback := slog.LevelDebug
+	for module, name := range config {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			return fmt.Errorf("invalid log level %q for %s", name, module)
+		}
+		if module == "default" {
+			fallback = level
+			continue
+		}
+		modules[strings.ToLower(module)] = level
+	}
+	levels.Lock()
+	defer levels.Unlock()
+	levels.fallback = fallback
+	levels.modules = modules
+	return nil
+}
+
+func levelFor(module string) slog.Level {
+	levels.RLock()
+	defer levels.RUnlock()
+	if level, ok := levels.modules[module]; ok {
+		return level
+	}
+	return levels.fallback
+}
+
+// moduleHandler drops the records below the level of their subsystem
+type moduleHandler struct {
+	slog.Handler
+	module string
+	tagged bool
+}
+
+func (h *moduleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= levelFor(h.module) && h.Handler.Enabled(ctx, level)
+}
+
+func (h *moduleHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.tagged {
+		r.AddAttrs(slog.String(moduleKey, h.module))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *moduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := &moduleHandler{Handler: h.Handler.WithAttrs(attrs), module: h.module, tagged: h.tagged}
+	for _, attr := range attrs {
+		if attr.Key == moduleKey {
+			handler.module = attr.Value.String()
+			handler.tagged = true
+		}
+	}
+	return handler
+}
+
+func (h *moduleHandler) WithGroup(name string) slog.Handler {
+	return &moduleHandler{Handler: h.Handler.WithGroup(name), module: h.module, tagged: h.tagged}
+}
+
+// lazyHandler resolves the default handler when a record is logged
+type lazyHandler struct {
+	module string
+	with   func(slog.Handler) slog.Handler
+}
+
+func (h lazyHandler) handler() slog.Handler {
+	handler := slog.Default().Handler().WithAttrs([]slog.Attr{slog.String(moduleKey, h.module)})
+	if h.with != nil {
+		handler = h.with(handler)
+	}
+	return handler
+}
+
+func (h lazyHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler().Enabled(ctx, level)
+}
+
+func (h lazyHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler().Handle(ctx, r)
+}
+
+func (h lazyHandler) wrap(fn func(slog.Handler) slog.Handler) lazyHandler {
+	previous := h.with
+	return lazyHandler{module: h.module, with: func(handler slog.Handler) slog.Handler {
+		if previous != nil {
+			handler = previous(handler)
+		}
+		return fn(handler)
+	}}
+}
+
+func (h lazyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.wrap(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h lazyHandler) WithGroup(name string) slog.Handler {
+	return h.wrap(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
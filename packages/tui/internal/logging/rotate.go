@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// maxLogSize is the size from which the log file is rotated
+	maxLogSize = 5 * 1024 * 1024
+	// keptLogs is how many rotated files are kept next to the log file
+	keptLogs = 3
+)
+
+// File is a log file that is rotated once it grows past maxLogSize, the
+// rotated files are named tui.log.1 (newest) to tui.log.3 (oldest)
+type File struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	size int64
+}
+
+// Open opens the log file for appending, creating its directory if needed
+func Open(path string) (*File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f := &File{path: path}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size = file, info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files by one and starts a new log file
+func (f *File) rotate() error {
+	f.file.Close()
+	for i := keptLogs - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+	// keep logging to the same file when it cannot be renamed
+	os.Rename(f.path, f.path+".1")
+	return f.open()
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.size+int64(len(p)) > maxLogSize && f.size > 0 {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
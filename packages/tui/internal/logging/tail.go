@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// tailBytes is how much of the end of the log file Tail reads
+const tailBytes = 256 * 1024
+
+// Tail returns the last n lines of the log file
+func Tail(path string, n int) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := max(info.Size()-tailBytes, 0)
+	data := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(data, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if offset > 0 && len(lines) > 1 {
+		// the first line was cut
+		lines = lines[1:]
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
+// Format renders a JSON record as "15:04:05 INFO  app  message key=value"
+func Format(line string) string {
+	var record map[string]any
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return line
+	}
+	timestamp := ""
+	if value, ok := record["time"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			timestamp = t.Local().Format("15:04:05")
+		}
+	}
+	level, _ := record["level"].(string)
+	module, _ := record[moduleKey].(string)
+	message, _ := record["msg"].(string)
+	delete(record, "time")
+	delete(record, "level")
+	delete(record, moduleKey)
+	delete(record, "msg")
+
+	keys := make([]string, 0, len(record))
+	for key := range record {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	fields := []string{fmt.Sprintf("%s %-5s %-8s %s", timestamp, level, module, message)}
+	for _, key := range keys {
+		fields = append(fields, fmt.Sprintf("%s=%v", key, record[key]))
+	}
+	return strings.Join(fields, " ")
+}
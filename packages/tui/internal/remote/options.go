@@ -0,0 +1,136 @@
+package remote
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/logging"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// Options configures the connection to the server. Flags take precedence
+// over the environment, which takes precedence over the ca_cert and proxy
+// of the state file:
+//
+//	--server URL     OPENCODE_SERVER                  http(s):// or ssh://host:port
+//	--token TOKEN    OPENCODE_SERVER_TOKEN            bearer token
+//	--token-file F   OPENCODE_SERVER_TOKEN_FILE       file holding the bearer token, re-read on 401
+//	--ca-cert FILE   OPENCODE_CA_CERT                 extra CA certificates (PEM)
+//	--insecure       OPENCODE_INSECURE_SKIP_VERIFY=1  skip TLS verification
+//	--proxy URL      OPENCODE_PROXY                   HTTP(S) proxy, HTTPS_PROXY and NO_PROXY otherwise
+//	--debug          OPENCODE_DEBUG=1                 record client calls for the debug inspector
+type Options struct {
+	Server    string
+	Token     string
+	TokenFile string
+	CACert    string
+	Insecure  bool
+	Proxy     string
+	Debug     bool
+}
+
+// ParseOptions reads the connection options from args and the environment
+func ParseOptions(args []string) Options {
+	insecure := os.Getenv("OPENCODE_INSECURE_SKIP_VERIFY")
+	debug := os.Getenv("OPENCODE_DEBUG")
+	return Options{
+		Server:    flagValue(args, "--server", os.Getenv("OPENCODE_SERVER")),
+		Token:     flagValue(args, "--token", os.Getenv("OPENCODE_SERVER_TOKEN")),
+		TokenFile: flagValue(args, "--token-file", os.Getenv("OPENCODE_SERVER_TOKEN_FILE")),
+		CACert:    flagValue(args, "--ca-cert", os.Getenv("OPENCODE_CA_CERT")),
+		Insecure:  slices.Contains(args, "--insecure") || insecure == "1" || insecure == "true",
+		Proxy:     flagValue(args, "--proxy", os.Getenv("OPENCODE_PROXY")),
+		Debug:     slices.Contains(args, "--debug") || debug == "1" || debug == "true",
+	}
+}
+
+// Connection returns the client connection options
+func (o Options) Connection(onUnauthorized func()) client.ConnectionOptions {
+	connection := client.ConnectionOptions{
+		Credentials:    client.NewCredentials(o.Token, o.TokenFile),
+		CACert:         o.CACert,
+		Insecure:       o.Insecure,
+		Proxy:          o.Proxy,
+		OnUnauthorized: onUnauthorized,
+		Logger:         logging.For(logging.Client),
+	}
+	if o.Debug {
+		connection.Inspector = client.NewInspector()
+	}
+	return connection
+}
+
+// ApplyState fills the CA certificate and proxy left unset by flags and the
+// environment from the state file, which is read before connecting
+func (o *Options) ApplyState(path string) {
+	state, err := config.LoadState(path)
+	if err != nil {
+		return
+	}
+	if o.CACert == "" {
+		o.CACert = state.CACert
+	}
+	if o.Proxy == "" {
+		o.Proxy = state.Proxy
+	}
+}
+
+// StatePath returns the state file of the TUI, known before connecting to
+// the server: the one of the local server that launched it, or the local one
+// used for remote servers
+func StatePath() (string, error) {
+	if appInfo := os.Getenv("OPENCODE_APP_INFO"); appInfo != "" {
+		var info client.AppInfo
+		if err := json.Unmarshal([]byte(appInfo), &info); err != nil {
+			return "", err
+		}
+		return filepath.Join(info.Path.State, "tui"), nil
+	}
+	dir, err := remoteDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state", "tui"), nil
+}
+
+// remoteDir is where the state and data of remote servers are kept locally
+func remoteDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "opencode", "remote"), nil
+}
+
+// UseLocalPaths points the state and data directories of a remote server's
+// app info at the local machine, so TUI state and logs stay local
+func UseLocalPaths(info *client.AppInfo) error {
+	dir, err := remoteDir()
+	if err != nil {
+		return err
+	}
+	info.Path.State = filepath.Join(dir, "state")
+	info.Path.Data = filepath.Join(dir, "data")
+	for _, path := range []string{info.Path.State, info.Path.Data} {
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flagValue(args []string, name string, fallback string) string {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, name+"="); ok {
+			return value
+		}
+		if arg == name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,80 @@
+// Package remote connects the TUI to an opencode server on another machine.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+const tunnelTimeout = 10 * time.Second
+
+// Tunnel forwards a local port to an opencode server reachable over SSH,
+// eg ssh://user@devbox:4096 for a server listening on port 4096 of devbox.
+// It returns the local URL of the server and a function that closes the
+// tunnel.
+func Tunnel(ctx context.Context, target *url.URL) (string, func(), error) {
+	remotePort := target.Port()
+	if remotePort == "" {
+		return "", nil, fmt.Errorf("ssh server url must include the server port, eg ssh://host:4096")
+	}
+	host := target.Hostname()
+	if target.User != nil {
+		host = target.User.Username() + "@" + host
+	}
+
+	localPort, err := freePort()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	local := net.JoinHostPort("127.0.0.1", strconv.Itoa(localPort))
+
+	cmd := exec.CommandContext(ctx,
+		"ssh", "-N",
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", fmt.Sprintf("%d:127.0.0.1:%s", localPort, remotePort),
+		host,
+	)
+	if err := cmd.Start(); err != nil {
+		return "", nil, fmt.Errorf("failed to start ssh: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	cleanup := func() {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+		}
+	}
+
+	deadline := time.Now().Add(tunnelTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			return "", nil, fmt.Errorf("ssh tunnel to %s exited: %v", host, err)
+		default:
+		}
+		conn, err := net.DialTimeout("tcp", local, 200*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			slog.Debug("SSH tunnel established", "host", host, "local", local)
+			return "http://" + local + "/", cleanup, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	cleanup()
+	return "", nil, fmt.Errorf("timed out waiting for ssh tunnel to %s", host)
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
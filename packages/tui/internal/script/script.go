@@ -0,0 +1,280 @@
+// Package script implements a non-interactive mode where commands are read
+// as JSON lines from stdin and events are written as JSON lines to stdout.
+package script
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/pkg/client"
+)
+
+// Permission requests are answered without asking: a tool call is granted
+// when the auto-approve rules or the tools passed with --allow cover it, a
+// single * allowing every tool, and rejected otherwise. Each answer is
+// reported as a script.permission event.
+//
+// Command is a single instruction read from stdin, eg:
+//
+//	{"command": "prompt", "text": "fix the failing test"}
+//	{"command": "model", "model": "anthropic/claude-sonnet-4"}
+//	{"command": "export", "path": "session.json"}
+type Command struct {
+	Command string `json:"command"`
+	Text    string `json:"text,omitempty"`
+	Model   string `json:"model,omitempty"`
+	Session string `json:"session,omitempty"`
+	Path    string `json:"path,omitempty"`
+}
+
+// Event is a single JSON line written to stdout
+type Event struct {
+	Type       string `json:"type"`
+	Properties any    `json:"properties,omitempty"`
+}
+
+type result struct {
+	Command string `json:"command"`
+	Ok      bool   `json:"ok"`
+	Error   string `json:"error,omitempty"`
+	Data    any    `json:"data,omitempty"`
+}
+
+type runner struct {
+	app   *app.App
+	allow []string
+	mu    sync.Mutex
+	out   *json.Encoder
+}
+
+// Run processes commands from in until EOF or a quit command, forwarding
+// server events to out as they arrive. allow lists the tools granted
+// permission besides those the auto-approve rules cover.
+func Run(ctx context.Context, a *app.App, events <-chan any, in io.Reader, out io.Writer, allow []string) error {
+	r := &runner{app: a, allow: allow, out: json.NewEncoder(out)}
+
+	go func() {
+		for event := range events {
+			r.emitServerEvent(event)
+			if event, ok := event.(client.EventPermissionUpdated); ok {
+				r.answerPermission(ctx, event.Properties)
+			}
+		}
+	}()
+
+	if err := r.selectDefaultModel(); err != nil {
+		r.emit(Event{Type: "script.error", Properties: map[string]string{"message": err.Error()}})
+	}
+	r.emit(Event{Type: "script.ready"})
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var command Command
+		if err := json.Unmarshal([]byte(line), &command); err != nil {
+			r.emit(Event{Type: "script.result", Properties: result{Error: "invalid command: " + err.Error()}})
+			continue
+		}
+		if command.Command == "quit" {
+			break
+		}
+		data, err := r.execute(ctx, command)
+		res := result{Command: command.Command, Ok: err == nil, Data: data}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		r.emit(Event{Type: "script.result", Properties: res})
+	}
+	return scanner.Err()
+}
+
+func (r *runner) execute(ctx context.Context, command Command) (any, error) {
+	switch command.Command {
+	case "prompt":
+		return nil, r.prompt(ctx, command.Text)
+	case "model":
+		return nil, r.selectModel(ctx, command.Model)
+	case "new":
+		r.app.Session = &client.SessionInfo{}
+		r.app.SetMessages([]client.MessageInfo{})
+		return nil, nil
+	case "session":
+		return nil, r.selectSession(ctx, command.Session)
+	case "export":
+		return r.export(ctx, command.Path)
+	case "abort":
+		if r.app.Session.Id == "" {
+			return nil, nil
+		}
+		return nil, r.app.Cancel(ctx, r.app.Session.Id)
+	}
+	return nil, fmt.Errorf("unknown command: %s", command.Command)
+}
+
+func (r *runner) prompt(ctx context.Context, text string) error {
+	if text == "" {
+		return fmt.Errorf("prompt text is empty")
+	}
+	if r.app.Provider == nil || r.app.Model == nil {
+		return fmt.Errorf("no model selected")
+	}
+	if r.app.Session.Id == "" {
+		session, err := r.app.CreateSession(ctx)
+		if err != nil {
+			return err
+		}
+		r.app.Session = session
+		r.emit(Event{Type: "script.session", Properties: session})
+	}
+
+	part := client.MessagePart{}
+	part.FromMessagePartText(client.MessagePartText{
+		Type: "text",
+		Text: text,
+	})
+	response, err := r.app.Client.PostSessionChat(ctx, client.PostSessionChatJSONRequestBody{
+		SessionID:  r.app.Session.Id,
+		Parts:      []client.MessagePart{part},
+		ProviderID: r.app.Provider.Id,
+		ModelID:    r.app.Model.Id,
+	})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return fmt.Errorf("failed to send message: %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (r *runner) selectDefaultModel() error {
+	msg := r.app.InitializeProvider()()
+	selected, ok := msg.(app.ModelSelectedMsg)
+	if !ok {
+		return fmt.Errorf("no providers configured")
+	}
+	r.app.Provider = &selected.Provider
+	r.app.Model = &selected.Model
+	return nil
+}
+
+func (r *runner) selectModel(ctx context.Context, model string) error {
+	providerID, modelID, found := strings.Cut(model, "/")
+	if !found {
+		return fmt.Errorf("model must be in the format provider/model")
+	}
+	providers, err := r.app.ListProviders(ctx)
+	if err != nil {
+		return err
+	}
+	for _, provider := range providers {
+		if provider.Id != providerID {
+			continue
+		}
+		if info, ok := provider.Models[modelID]; ok {
+			r.app.Provider = &provider
+			r.app.Model = &info
+			return nil
+		}
+	}
+	return fmt.Errorf("model not found: %s", model)
+}
+
+func (r *runner) selectSession(ctx context.Context, sessionID string) error {
+	sessions, err := r.app.ListSessions(ctx)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.Id == sessionID {
+			messages, err := r.app.ListMessages(ctx, session.Id)
+			if err != nil {
+				return err
+			}
+			r.app.Session = &session
+			r.app.SetMessages(messages)
+			return nil
+		}
+	}
+	return fmt.Errorf("session not found: %s", sessionID)
+}
+
+func (r *runner) export(ctx context.Context, path string) (any, error) {
+	if r.app.Session.Id == "" {
+		return nil, fmt.Errorf("no active session")
+	}
+	messages, err := r.app.ListMessages(ctx, r.app.Session.Id)
+	if err != nil {
+		return nil, err
+	}
+	export := map[string]any{
+		"session":  r.app.Session,
+		"messages": messages,
+	}
+	if path == "" {
+		return export, nil
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return map[string]string{"path": path}, nil
+}
+
+// answerPermission grants or rejects a permission request, the server waits
+// for an answer and nobody can be asked
+func (r *runner) answerPermission(ctx context.Context, permission client.PermissionInfo) {
+	tool, _ := permission.Metadata["tool"].(string)
+	response := app.PermissionReject
+	if r.app.AutoApproves(permission) || slices.Contains(r.allow, "*") || slices.Contains(r.allow, tool) {
+		response = app.PermissionOnce
+	}
+	if err := r.app.RespondPermission(ctx, permission, response); err != nil {
+		r.emit(Event{Type: "script.error", Properties: map[string]string{"message": err.Error()}})
+		return
+	}
+	r.emit(Event{Type: "script.permission", Properties: map[string]string{
+		"id":       permission.Id,
+		"tool":     tool,
+		"title":    permission.Title,
+		"response": response,
+	}})
+}
+
+func (r *runner) emitServerEvent(event any) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("Failed to marshal event", "error", err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.out.Encode(json.RawMessage(data)); err != nil {
+		slog.Error("Failed to write event", "error", err)
+	}
+}
+
+func (r *runner) emit(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.out.Encode(event); err != nil {
+		slog.Error("Failed to write event", "error", err)
+	}
+}
@@ -0,0 +1,210 @@
+// Package spell checks the words of a draft against a bundled wordlist and
+// the words added by the user and the project. Nothing leaves the machine.
+package spell
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// words.txt lists English and programming words from the most common
+//
+//go:embed words.txt
+var bundled string
+
+const (
+	// minWordLength is the length from which words are checked, shorter ones
+	// are mostly abbreviations
+	minWordLength = 3
+	// maxDistance is the number of edits up to which a word is suggested
+	maxDistance = 2
+)
+
+// Word is a misspelled word of a line, as [Start, End) rune offsets
+type Word struct {
+	Start int
+	End   int
+}
+
+// Dictionary is the set of known words, ranked by how common they are
+type Dictionary struct {
+	words map[string]int
+}
+
+// New returns the bundled dictionary extended by words
+func New(words ...string) *Dictionary {
+	d := &Dictionary{words: map[string]int{}}
+	for _, word := range strings.Fields(bundled) {
+		d.Add(word)
+	}
+	for _, word := range words {
+		d.Add(word)
+	}
+	return d
+}
+
+// Load returns the bundled dictionary extended by the word files in the
+// usual override order:
+// 1. USER_CONFIG/opencode/words.txt
+// 2. PROJECT_ROOT/.opencode/words.txt
+// 3. CWD/.opencode/words.txt
+func Load(userConfig, projectRoot, cwd string) *Dictionary {
+	d := New()
+	files := []string{
+		filepath.Join(userConfig, "words.txt"),
+		filepath.Join(projectRoot, ".opencode", "words.txt"),
+	}
+	if cwd != projectRoot {
+		files = append(files, filepath.Join(cwd, ".opencode", "words.txt"))
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, word := range strings.Fields(string(data)) {
+			d.Add(word)
+		}
+	}
+	return d
+}
+
+// Add makes a word known, such as a project term
+func (d *Dictionary) Add(word string) {
+	word = strings.ToLower(word)
+	if _, ok := d.words[word]; !ok {
+		d.words[word] = len(d.words)
+	}
+}
+
+// Known reports whether a word is spelled correctly. Words too short, in
+// capitals or mixed case, such as identifiers and acronyms, always are.
+func (d *Dictionary) Known(word string) bool {
+	if !checked(word) {
+		return true
+	}
+	word = strings.ToLower(word)
+	if _, ok := d.words[word]; ok {
+		return true
+	}
+	_, ok := d.words[strings.TrimSuffix(word, "'s")]
+	return ok
+}
+
+// checked reports whether a word is prose to check, plain letters with at
+// most a capital first one
+func checked(word string) bool {
+	runes := []rune(word)
+	if len(runes) < minWordLength {
+		return false
+	}
+	for i, r := range runes {
+		switch {
+		case r > unicode.MaxASCII:
+			return false
+		case r == '\'' && i > 0 && i < len(runes)-1:
+		case unicode.IsUpper(r) && i > 0, !unicode.IsLetter(r):
+			return false
+		}
+	}
+	return true
+}
+
+// Misspelled returns the unknown words of a line. Tokens with digits,
+// symbols or inner punctuation, such as paths, URLs and code, are skipped.
+func (d *Dictionary) Misspelled(line []rune) []Word {
+	words := []Word{}
+	for start := 0; start < len(line); {
+		if unicode.IsSpace(line[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(line) && !unicode.IsSpace(line[end]) {
+			end++
+		}
+		from, to := start, end
+		for from < to && strings.ContainsRune(`([{"'<*_`, line[from]) {
+			from++
+		}
+		for to > from && strings.ContainsRune(`.,;:!?)]}"'>*_`, line[to-1]) {
+			to--
+		}
+		if !d.Known(string(line[from:to])) {
+			words = append(words, Word{Start: from, End: to})
+		}
+		start = end
+	}
+	return words
+}
+
+// Suggest returns up to n known words close to word, closest and most
+// common first, in the case of word
+func (d *Dictionary) Suggest(word string, n int) []string {
+	lower := strings.ToLower(word)
+	type candidate struct {
+		word     string
+		distance int
+		rank     int
+	}
+	candidates := []candidate{}
+	for known, rank := range d.words {
+		if abs(len(known)-len(lower)) > maxDistance {
+			continue
+		}
+		if distance := editDistance(lower, known); distance <= maxDistance {
+			candidates = append(candidates, candidate{known, distance, rank})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return a.rank - b.rank
+	})
+	suggestions := []string{}
+	for _, c := range candidates[:min(n, len(candidates))] {
+		if unicode.IsUpper([]rune(word)[0]) {
+			c.word = strings.ToUpper(c.word[:1]) + c.word[1:]
+		}
+		suggestions = append(suggestions, c.word)
+	}
+	return suggestions
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of
+// adjacent letters turning a into b
+func editDistance(a, b string) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,143 @@
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/lipgloss/v2/compat"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ThemeColor is a semantic color of a theme, Key is its name in theme JSON
+type ThemeColor struct {
+	Key string
+	Get func(Theme) compat.AdaptiveColor
+}
+
+// Colors lists every semantic color in the order of the theme JSON files
+var Colors = []ThemeColor{
+	{"primary", Theme.Primary},
+	{"secondary", Theme.Secondary},
+	{"accent", Theme.Accent},
+	{"error", Theme.Error},
+	{"warning", Theme.Warning},
+	{"success", Theme.Success},
+	{"info", Theme.Info},
+	{"text", Theme.Text},
+	{"textMuted", Theme.TextMuted},
+	{"background", Theme.Background},
+	{"backgroundPanel", Theme.BackgroundPanel},
+	{"backgroundElement", Theme.BackgroundElement},
+	{"border", Theme.Border},
+	{"borderActive", Theme.BorderActive},
+	{"borderSubtle", Theme.BorderSubtle},
+	{"diffAdded", Theme.DiffAdded},
+	{"diffRemoved", Theme.DiffRemoved},
+	{"diffContext", Theme.DiffContext},
+	{"diffHunkHeader", Theme.DiffHunkHeader},
+	{"diffHighlightAdded", Theme.DiffHighlightAdded},
+	{"diffHighlightRemoved", Theme.DiffHighlightRemoved},
+	{"diffAddedBg", Theme.DiffAddedBg},
+	{"diffRemovedBg", Theme.DiffRemovedBg},
+	{"diffContextBg", Theme.DiffContextBg},
+	{"diffLineNumber", Theme.DiffLineNumber},
+	{"diffAddedLineNumberBg", Theme.DiffAddedLineNumberBg},
+	{"diffRemovedLineNumberBg", Theme.DiffRemovedLineNumberBg},
+	{"markdownText", Theme.MarkdownText},
+	{"markdownHeading", Theme.MarkdownHeading},
+	{"markdownLink", Theme.MarkdownLink},
+	{"markdownLinkText", Theme.MarkdownLinkText},
+	{"markdownCode", Theme.MarkdownCode},
+	{"markdownBlockQuote", Theme.MarkdownBlockQuote},
+	{"markdownEmph", Theme.MarkdownEmph},
+	{"markdownStrong", Theme.MarkdownStrong},
+	{"markdownHorizontalRule", Theme.MarkdownHorizontalRule},
+	{"markdownListItem", Theme.MarkdownListItem},
+	{"markdownListEnumeration", Theme.MarkdownListEnumeration},
+	{"markdownImage", Theme.MarkdownImage},
+	{"markdownImageText", Theme.MarkdownImageText},
+	{"markdownCodeBlock", Theme.MarkdownCodeBlock},
+	{"syntaxComment", Theme.SyntaxComment},
+	{"syntaxKeyword", Theme.SyntaxKeyword},
+	{"syntaxFunction", Theme.SyntaxFunction},
+	{"syntaxVariable", Theme.SyntaxVariable},
+	{"syntaxString", Theme.SyntaxString},
+	{"syntaxNumber", Theme.SyntaxNumber},
+	{"syntaxType", Theme.SyntaxType},
+	{"syntaxOperator", Theme.SyntaxOperator},
+	{"syntaxPunctuation", Theme.SyntaxPunctuation},
+}
+
+// NewCustomTheme creates an editable copy of a theme's palette
+func NewCustomTheme(name string, base Theme) *LoadedTheme {
+	theme := &LoadedTheme{name: name}
+	for _, c := range Colors {
+		setThemeColor(theme, c.Key, c.Get(base))
+	}
+	return theme
+}
+
+// SetColor changes a semantic color by its JSON key
+func (t *LoadedTheme) SetColor(key string, color compat.AdaptiveColor) error {
+	return setThemeColor(t, key, color)
+}
+
+// UnregisterTheme removes a theme from the registry, it is a no-op for the
+// active theme
+func UnregisterTheme(name string) {
+	globalManager.mu.Lock()
+	defer globalManager.mu.Unlock()
+
+	if globalManager.currentName != name {
+		delete(globalManager.themes, name)
+	}
+}
+
+// ColorValue returns the theme JSON value of a color: a hex string, an ANSI
+// color number or "none"
+func ColorValue(c color.Color) any {
+	switch c := c.(type) {
+	case nil, lipgloss.NoColor:
+		return "none"
+	case ansi.BasicColor:
+		return int(c)
+	case ansi.ExtendedColor:
+		return int(c)
+	}
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// MarshalTheme encodes a theme in the JSON format read by the theme loader
+func MarshalTheme(t Theme) ([]byte, error) {
+	jsonTheme := JSONTheme{Theme: map[string]any{}}
+	for _, c := range Colors {
+		color := c.Get(t)
+		jsonTheme.Theme[c.Key] = map[string]any{
+			"dark":  ColorValue(color.Dark),
+			"light": ColorValue(color.Light),
+		}
+	}
+	return json.MarshalIndent(jsonTheme, "", "  ")
+}
+
+// SaveTheme writes a theme to dir/<name>.json and registers it
+func SaveTheme(dir, name string, t Theme) (string, error) {
+	data, err := MarshalTheme(t)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", err
+	}
+	RegisterTheme(name, t)
+	return path, nil
+}
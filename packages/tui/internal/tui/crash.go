@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/sst/opencode/internal/app"
+)
+
+var lastCrash atomic.Pointer[app.Crash]
+
+// LastCrash returns the panic recorded by the model returned by Guard, nil
+// when the program did not panic in it
+func LastCrash() *app.Crash {
+	return lastCrash.Load()
+}
+
+// crashGuard records the panics of a model with the session and draft it
+// had, then panics again for the program to restore the terminal
+type crashGuard struct {
+	model tea.Model
+}
+
+// Guard wraps the model returned by NewModel to record its panics, in
+// Update, View and the commands it returns directly
+func Guard(model tea.Model) tea.Model {
+	return crashGuard{model: model}
+}
+
+func (g crashGuard) record(r any) {
+	crash := &app.Crash{
+		Time:  time.Now(),
+		Panic: fmt.Sprint(r),
+		Stack: string(debug.Stack()),
+	}
+	if model, ok := g.model.(appModel); ok {
+		crash.Version = model.app.Version
+		crash.SessionID = model.app.Session.Id
+		// the editor may be what panicked
+		func() {
+			defer func() { recover() }()
+			crash.Draft = model.editor.Value()
+		}()
+	}
+	lastCrash.CompareAndSwap(nil, crash)
+}
+
+func (g crashGuard) catch() {
+	if r := recover(); r != nil {
+		g.record(r)
+		panic(r)
+	}
+}
+
+func (g crashGuard) guard(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer g.catch()
+		return cmd()
+	}
+}
+
+func (g crashGuard) Init() tea.Cmd {
+	defer g.catch()
+	return g.guard(g.model.Init())
+}
+
+func (g crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.catch()
+	model, cmd := g.model.Update(msg)
+	return crashGuard{model: model}, g.guard(cmd)
+}
+
+func (g crashGuard) View() string {
+	defer g.catch()
+	return g.model.(tea.ViewModel).View()
+}
@@ -2,25 +2,37 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/v2/key"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/sst/opencode/internal/app"
+	"github.com/sst/opencode/internal/clipboard"
 	"github.com/sst/opencode/internal/commands"
 	"github.com/sst/opencode/internal/completions"
 	"github.com/sst/opencode/internal/components/chat"
+	commandsComponent "github.com/sst/opencode/internal/components/commands"
 	"github.com/sst/opencode/internal/components/dialog"
 	"github.com/sst/opencode/internal/components/modal"
+	"github.com/sst/opencode/internal/components/sidebar"
 	"github.com/sst/opencode/internal/components/status"
 	"github.com/sst/opencode/internal/components/toast"
+	"github.com/sst/opencode/internal/config"
+	"github.com/sst/opencode/internal/i18n"
 	"github.com/sst/opencode/internal/layout"
+	"github.com/sst/opencode/internal/logging"
 	"github.com/sst/opencode/internal/styles"
 	"github.com/sst/opencode/internal/theme"
 	"github.com/sst/opencode/internal/util"
@@ -40,6 +52,16 @@ const (
 
 const interruptDebounceTimeout = 1 * time.Second
 
+// LeaderSequenceTimeoutMsg is sent when no key continued a leader sequence
+// that is bound itself, such as <leader>g while <leader>gd is bound too
+type LeaderSequenceTimeoutMsg struct {
+	Keys []string
+}
+
+// leaderSequenceTimeout is how long a bound leader sequence waits for a key
+// continuing it before its command runs
+const leaderSequenceTimeout = 1 * time.Second
+
 type appModel struct {
 	width, height        int
 	app                  *app.App
@@ -54,10 +76,37 @@ type appModel struct {
 	showCompletionDialog bool
 	leaderBinding        *key.Binding
 	isLeaderSequence     bool
+	leaderKeys           []string
 	toastManager         *toast.ToastManager
 	interruptKeyState    InterruptKeyState
+	sidebar              sidebar.SidebarComponent
+	split                bool
+	busy                 bool
+	switcher             sessionSwitch
+}
+
+// sessionSwitch is the session last switched to with the next and previous
+// session keys, its title is shown until the switch expires
+type sessionSwitch struct {
+	title           string
+	position, total int
+	seq             int
+}
+
+// sessionSwitchExpiredMsg hides the title of a session switched to, unless
+// another switch followed
+type sessionSwitchExpiredMsg struct {
+	seq int
 }
 
+// sessionSwitchDuration is how long the title of a session switched to is
+// shown
+const sessionSwitchDuration = 1200 * time.Millisecond
+
+// defaultSplitLayoutWidth is the terminal width from which the session list
+// is shown as a sidebar unless configured otherwise
+const defaultSplitLayoutWidth = 140
+
 func (a appModel) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	// https://github.com/charmbracelet/bubbletea/issues/1440
@@ -65,12 +114,19 @@ func (a appModel) Init() tea.Cmd {
 	if !util.IsWsl() {
 		cmds = append(cmds, tea.RequestBackgroundColor)
 	}
+	cmds = append(cmds, a.app.LoadConfig())
 	cmds = append(cmds, a.app.InitializeProvider())
 	cmds = append(cmds, a.editor.Init())
 	cmds = append(cmds, a.messages.Init())
 	cmds = append(cmds, a.status.Init())
 	cmds = append(cmds, a.completions.Init())
 	cmds = append(cmds, a.toastManager.Init())
+	cmds = append(cmds, a.sidebar.Init())
+	cmds = append(cmds, a.app.ResumePrompt())
+	cmds = append(cmds, a.app.RunCleanup())
+	cmds = append(cmds, a.app.WatchFiles())
+	cmds = append(cmds, a.app.StateRecovery())
+	cmds = append(cmds, a.app.CrashRecovery())
 
 	// Check if we should show the init dialog
 	cmds = append(cmds, func() tea.Msg {
@@ -82,6 +138,13 @@ func (a appModel) Init() tea.Cmd {
 }
 
 func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// subscribers see every message, also the ones handled with an early
+	// return, after the app state was updated for it
+	model, cmd := a.update(msg)
+	return model, tea.Batch(cmd, a.app.Bus.Publish(msg), a.app.UpdateWindowTitle())
+}
+
+func (a appModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -95,6 +158,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "esc", "ctrl+c":
 				cmd := a.modal.Close()
 				a.modal = nil
+				a.showPermission()
 				return a, cmd
 			}
 
@@ -106,14 +170,45 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// 2. Check for commands that require leader
 		if a.isLeaderSequence {
-			matches := a.app.Commands.Matches(msg, a.isLeaderSequence)
+			keys := append(slices.Clone(a.leaderKeys), keyString)
+			matches, next := a.app.Commands.LeaderSequence(keys)
+			if len(next) > 0 {
+				// wait for the rest of a multi-key sequence, or run the
+				// commands of the sequence so far when none follows
+				a.leaderKeys = keys
+				if len(matches) == 0 {
+					return a, nil
+				}
+				return a, tea.Tick(leaderSequenceTimeout, func(time.Time) tea.Msg {
+					return LeaderSequenceTimeoutMsg{Keys: keys}
+				})
+			}
 			a.isLeaderSequence = false
+			a.leaderKeys = nil
 			if len(matches) > 0 {
 				return a, util.CmdHandler(commands.ExecuteCommandsMsg(matches))
 			}
 		}
 
-		// 3. Handle completions trigger
+		// Route keys to the session sidebar while it is focused
+		if a.sidebar.IsFocused() {
+			updated, cmd := a.sidebar.Update(msg)
+			a.sidebar = updated.(sidebar.SidebarComponent)
+			return a, cmd
+		}
+
+		// 3. Handle the in-session search bar
+		if handled, cmd := a.messages.HandleSearchKey(msg); handled {
+			return a, cmd
+		}
+		if handled, cmd := a.messages.HandleReplayKey(msg); handled {
+			return a, cmd
+		}
+		if handled, cmd := a.messages.HandleNavigationKey(msg); handled {
+			return a, cmd
+		}
+
+		// 4. Handle completions trigger
 		if keyString == "/" && !a.showCompletionDialog {
 			a.showCompletionDialog = true
 
@@ -171,7 +266,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Batch(cmds...)
 		}
 
-		// 4. Maximize editor responsiveness for printable characters
+		// 5. Maximize editor responsiveness for printable characters
 		if msg.Text != "" {
 			updated, cmd := a.editor.Update(msg)
 			a.editor = updated.(chat.EditorComponent)
@@ -179,7 +274,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Batch(cmds...)
 		}
 
-		// 5. Check for leader key activation
+		// 6. Check for leader key activation
 		if a.leaderBinding != nil &&
 			!a.isLeaderSequence &&
 			key.Matches(msg, *a.leaderBinding) {
@@ -187,7 +282,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 
-		// 6. Handle interrupt key debounce for session interrupt
+		// 7. Handle interrupt key debounce for session interrupt
 		interruptCommand := a.app.Commands[commands.SessionInterruptCommand]
 		if interruptCommand.Matches(msg, a.isLeaderSequence) && a.app.IsBusy() {
 			switch a.interruptKeyState {
@@ -206,7 +301,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// 7. Check again for commands that don't require leader (excluding interrupt when busy)
+		// 8. Check again for commands that don't require leader (excluding interrupt when busy)
 		matches := a.app.Commands.Matches(msg, a.isLeaderSequence)
 		if len(matches) > 0 {
 			// Skip interrupt key if we're in debounce mode and app is busy
@@ -216,7 +311,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, util.CmdHandler(commands.ExecuteCommandsMsg(matches))
 		}
 
-		// 7. Fallback to editor. This is for other characters
+		// 9. Fallback to editor. This is for other characters
 		// like backspace, tab, etc.
 		updatedEditor, cmd := a.editor.Update(msg)
 		a.editor = updatedEditor.(chat.EditorComponent)
@@ -229,6 +324,43 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.messages = updated.(chat.MessagesComponent)
 		cmds = append(cmds, cmd)
 		return a, tea.Batch(cmds...)
+	case tea.MouseClickMsg:
+		// toasts are drawn over the modals
+		if id, ok := a.toastManager.Clicked(msg.X, msg.Y); ok {
+			return a, util.CmdHandler(toast.DismissToastMsg{ID: id})
+		}
+		if a.modal != nil {
+			return a, nil
+		}
+		_, editorY := a.editorContainer.GetPosition()
+		if a.split && msg.X < sidebar.Width && msg.Y < a.height {
+			if session := a.sidebar.SessionAt(msg.Y); session != nil {
+				return a, util.CmdHandler(app.SessionSelectedMsg(session))
+			}
+			return a, nil
+		}
+		switch {
+		case msg.Y > a.height:
+			// the status bar sits below the layout, after a blank line
+			switch a.status.SegmentAt(msg.X) {
+			case "model":
+				return a.executeCommand(a.app.Commands[commands.ModelListCommand])
+			case "mode":
+				return a.executeCommand(a.app.Commands[commands.AgentModeListCommand])
+			case "system":
+				return a.executeCommand(a.app.Commands[commands.SystemPromptCommand])
+			case "parameters":
+				return a.executeCommand(a.app.Commands[commands.ModelParametersCommand])
+			}
+		case msg.Y < editorY-a.editor.Lines()+1:
+			if a.split {
+				msg.X -= sidebar.Width
+			}
+			updated, cmd := a.messages.Update(msg)
+			a.messages = updated.(chat.MessagesComponent)
+			return a, cmd
+		}
+		return a, nil
 	case tea.BackgroundColorMsg:
 		styles.Terminal = &styles.TerminalInfo{
 			Background:       msg.Color,
@@ -250,6 +382,7 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd = a.modal.Close()
 		}
 		a.modal = nil
+		a.showPermission()
 		return a, cmd
 	case commands.ExecuteCommandMsg:
 		updated, cmd := a.executeCommand(commands.Command(msg))
@@ -261,29 +394,553 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return updated, cmd
 			}
 		}
+	case commands.ExecuteCommandWithArgsMsg:
+		return a.executeCommandWithArgs(msg.Command, msg.Args)
 	case app.SendMsg:
 		a.showCompletionDialog = false
+		if command, args, ok := a.app.Commands.FindTrigger(msg.Text); ok {
+			return a.executeCommandWithArgs(command, args)
+		}
+		if a.app.RateLimit != nil {
+			a.app.QueuePrompt(msg.Text, msg.Attachments)
+			return a, toast.NewInfoToast(i18n.T("toast.queued_rate_limit"))
+		}
+		if a.app.Comparison != nil && !a.app.Comparison.Sent {
+			cmds = append(cmds, a.app.SendComparison(context.Background(), msg.Text, msg.Attachments))
+			break
+		}
+		if msg.Oversize != "" && msg.Oversize != app.OversizeSend {
+			// fitting the prompt works on its text, pastes included
+			msg.Text, msg.Attachments = app.InlinePastes(msg.Text, msg.Attachments)
+		}
+		switch msg.Oversize {
+		case app.OversizeTruncate:
+			msg.Text = a.app.TruncatePrompt(msg.Text)
+		case app.OversizeChunk:
+			cmds = append(cmds, a.app.SendChunked(context.Background(), msg.Text))
+			return a, tea.Batch(cmds...)
+		case app.OversizeSummarize:
+			return a, tea.Batch(
+				toast.NewInfoToast(i18n.T("toast.summarizing_prompt")),
+				a.app.SummarizePrompt(context.Background(), msg.Text, msg.Attachments),
+			)
+		}
 		cmd := a.app.SendChatMessage(context.Background(), msg.Text, msg.Attachments)
 		cmds = append(cmds, cmd)
+	case app.OversizedPromptMsg:
+		a.modal = dialog.NewOversizeDialog(msg.Tokens, msg.Limit, a.app.ChunkCount(msg.Tokens))
+		return a, nil
+	case app.ResolveOversizedMsg:
+		updated, cmd := a.editor.SubmitOversized(msg.Action)
+		a.editor = updated.(chat.EditorComponent)
+		return a, cmd
+	case app.CancelToolMsg:
+		if err := a.app.CancelTool(context.Background(), a.app.Session.Id, msg.Tool.ID); err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.cancel_failed", msg.Tool.Name, err.Error()))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.tool_cancelled", msg.Tool.Name))
+	case app.PromptSummarizedMsg:
+		if msg.Err != nil {
+			slog.Error("Failed to summarize the prompt", "error", msg.Err)
+			return a, toast.NewErrorToast(i18n.T("toast.summarize_failed", msg.Err.Error()),
+				toast.WithTitle(i18n.T("toast.summarize_title")))
+		}
+		return a, a.app.SendChatMessage(context.Background(), msg.Text, msg.Attachments)
+	case app.SteerMsg:
+		if !a.app.IsBusy() {
+			return a, a.app.SendChatMessage(context.Background(), msg.Text, nil)
+		}
+		a.app.Steer(msg.Text)
+		return a, toast.NewInfoToast(i18n.T("toast.steer_queued"))
+	case app.RunScriptMsg:
+		if msg.Ask {
+			return a, util.CmdHandler(app.SendMsg{Text: "Run `" + msg.Script.Command + "` and tell me how it went"})
+		}
+		return a.executeCommandWithArgs(a.app.Commands[commands.RunShellCommand], msg.Script.Command)
+	case app.CommandOutputMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.run_failed", msg.Output.Command, msg.Err.Error()))
+		}
+		a.app.AttachOutput(msg.Output)
+		a.modal = dialog.NewCommandOutputDialog(msg.Output)
+		return a, nil
+	case app.ResumePromptMsg:
+		// the prompt from startup is stale once a session was opened
+		if msg.Decline == nil && (a.app.Session.Id != "" || a.modal != nil) {
+			return a, nil
+		}
+		a.modal = dialog.NewResumeDialog(msg)
+		return a, nil
+	case app.CrashRecoveryMsg:
+		message := i18n.T("dialog.crash_restore_draft", msg.Crash.Time.Format("15:04"))
+		if msg.Crash.SessionID != "" {
+			message = i18n.T("dialog.crash_restore_session", msg.Crash.Time.Format("15:04"))
+		}
+		a.modal = dialog.NewConfirmDialog(i18n.T("dialog.crash_title"), message, app.RestoreCrashMsg{Crash: msg.Crash})
+		return a, nil
+	case app.RestoreCrashMsg:
+		return a, a.app.RestoreCrash(context.Background(), msg.Crash)
+	case app.InitializeProjectMsg:
+		return a, a.app.InitializeNewSession(context.Background())
+	case app.ProjectContextMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(msg.Err.Error(), toast.WithTitle(i18n.T("toast.init_title")))
+		}
+		a.modal = dialog.NewProjectContextDialog(msg)
+		return a, nil
+	case app.ApproveProjectContextMsg:
+		if err := a.app.ApproveProjectContext(context.Background(), msg.Context, msg.Content); err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.init_failed", err.Error()))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.initialized", filepath.Base(msg.Context.Path)))
+	case app.DiscardProjectContextMsg:
+		if err := a.app.DiscardProjectContext(context.Background(), msg.Context); err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.restore_failed", filepath.Base(msg.Context.Path), err.Error()))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.init_discarded", filepath.Base(msg.Context.Path)))
+	case app.ApplyContextMsg:
+		entries := a.app.ContextEntries()
+		tokens := msg.Plan.Tokens(entries)
+		return a, tea.Batch(
+			toast.NewInfoToast(i18n.T("toast.trimming")),
+			func() tea.Msg {
+				session, err := a.app.TrimContext(context.Background(), entries, msg.Plan)
+				return app.ContextTrimmedMsg{Session: session, Tokens: tokens, Err: err}
+			},
+		)
+	case app.ContextTrimmedMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.trim_failed", msg.Err.Error()))
+		}
+		a.app.AdoptSessions([]client.SessionInfo{*msg.Session})
+		a.app.CarrySettings(msg.Session.Id)
+		return a, tea.Batch(
+			util.CmdHandler(app.SessionSelectedMsg(msg.Session)),
+			toast.NewSuccessToast(i18n.N("toast.trimmed", msg.Tokens)),
+		)
+	case app.SessionsImportedMsg:
+		a.app.AdoptSessions(msg.Sessions)
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.N("toast.imported_failed", len(msg.Sessions), msg.Err))
+		}
+		if len(msg.Sessions) == 0 {
+			return a, toast.NewWarningToast(i18n.T("toast.no_sessions_to_import"))
+		}
+		session := msg.Sessions[len(msg.Sessions)-1]
+		return a, tea.Batch(
+			util.CmdHandler(app.SessionSelectedMsg(&session)),
+			toast.NewSuccessToast(i18n.N("toast.imported", len(msg.Sessions))),
+		)
+	case app.SessionForkedMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.fork_failed", msg.Err))
+		}
+		a.app.AdoptSessions([]client.SessionInfo{msg.Session})
+		return a, tea.Batch(
+			util.CmdHandler(app.SessionSelectedMsg(&msg.Session)),
+			toast.NewSuccessToast(i18n.T("toast.forked", msg.Session.Title)),
+		)
+	case chat.InspectMessageMsg:
+		a.modal = dialog.NewJSONDialog("Message "+msg.Message.Id, msg.Message)
+		return a, nil
+	case app.SessionMergedMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.merge_failed", msg.Err))
+		}
+		return a, toast.NewSuccessToast(i18n.N("toast.merged", msg.Messages, msg.Source.Title))
+	case app.AuditLogMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.audit_failed", msg.Err.Error()))
+		}
+		if msg.Export {
+			return a, util.CmdHandler(app.ExportAuditMsg{Entries: msg.Entries})
+		}
+		a.modal = dialog.NewAuditDialog(msg.Entries)
+		return a, nil
+	case app.ExportAuditMsg:
+		path, err := a.app.ExportAudit(msg.Entries)
+		if err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.audit_export_failed", err.Error()))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.audit_exported", filepath.Base(path)))
+	case sessionSwitchExpiredMsg:
+		if msg.seq == a.switcher.seq {
+			a.switcher = sessionSwitch{seq: a.switcher.seq}
+		}
+		return a, nil
+	case app.BugReportMsg:
+		if msg.Err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.bug_report_failed", msg.Err.Error()))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.bug_report_saved", msg.Path))
+	case app.PreviewAttachmentMsg:
+		attachments := a.editor.Attachments()
+		if msg.Index < 0 || msg.Index >= len(attachments) {
+			return a, nil
+		}
+		preview := dialog.NewAttachmentPreviewDialog(attachments[msg.Index], a.app.Graphics)
+		a.modal = preview
+		return a, preview.Init()
+	case app.RemoveAttachmentMsg:
+		removed, ok := a.editor.RemoveAttachment(msg.Index)
+		if !ok {
+			return a, nil
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.attachment_removed", removed.FileName))
+	case app.FilePreviewMsg:
+		a.modal = dialog.NewFilePreviewDialog(msg.Reference)
+		return a, nil
+	case app.OpenFileMsg:
+		cmd, err := a.app.OpenFile(msg.Reference)
+		if err != nil {
+			return a, toast.NewErrorToast(err.Error())
+		}
+		return a, cmd
+	case app.OpenLinkMsg:
+		return a, app.OpenLink(msg.URL)
+	case app.SwitchRootMsg:
+		if !a.app.SetActiveRoot(msg.Root) {
+			return a, toast.NewErrorToast(i18n.T("toast.not_a_root", msg.Root))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.root_switched", a.app.RootLabel(msg.Root)))
+	case app.CompareModelSelectedMsg:
+		if a.app.Provider == nil || a.app.Model == nil {
+			return a, nil
+		}
+		a.app.StartComparison(msg.Provider, msg.Model)
+		return a, toast.NewInfoToast(
+			i18n.T("toast.compare_started", a.app.Model.Name, msg.Model.Name),
+			toast.WithTitle(i18n.T("toast.compare_title")),
+		)
+	case app.CompareFailedMsg:
+		return a, a.app.ComparisonFailed(msg)
+	case app.CompareWinnerSelectedMsg:
+		if a.app.Comparison == nil {
+			return a, nil
+		}
+		side := a.app.Comparison.Sides[msg.Side]
+		a.app.Comparison = nil
+		return a, tea.Sequence(
+			util.CmdHandler(app.ModelSelectedMsg{Provider: side.Provider, Model: side.Model}),
+			util.CmdHandler(app.SessionSelectedMsg(side.Session)),
+		)
+	case app.FilesChangedMsg:
+		a.app.NoteFileChanges(msg.Paths)
+		cmds = append(cmds, a.app.WatchFiles())
+	case app.SendRetryMsg:
+		cmds = append(cmds, a.app.RetrySend(msg))
+	case app.SendFailedMsg:
+		a.app.FailSend(msg)
+		cmds = append(cmds, toast.NewErrorToast(
+			i18n.T("toast.send_failed", msg.Err),
+			toast.WithTitle(i18n.T("toast.connection_lost_title")),
+		))
+	case app.RateLimitedMsg:
+		a.app.RemoveOptimistic()
+		waiting := a.app.RateLimit != nil
+		if !a.app.QueueRateLimited(msg) {
+			return a, toast.NewErrorToast(i18n.T("toast.rate_limit_stopped"), toast.WithTitle(i18n.T("toast.rate_limited_title")))
+		}
+		cmds = append(cmds, toast.NewWarningToast(
+			i18n.T("toast.rate_limit_retrying", int(a.app.RateLimit.Remaining().Seconds())),
+			toast.WithTitle(i18n.T("toast.rate_limited_title")),
+		))
+		if !waiting {
+			cmds = append(cmds, app.TickRateLimit())
+		}
+		return a, tea.Batch(cmds...)
+	case app.ProviderFailedMsg:
+		a.app.RemoveOptimistic()
+		from := msg.ProviderID + "/" + msg.ModelID
+		slog.Error("Provider failed", "model", from, "error", msg.Error)
+		provider, model, ok := a.app.NextFallback(context.Background(), msg.ProviderID, msg.ModelID)
+		if !ok {
+			return a, toast.NewErrorToast(msg.Error, toast.WithTitle(i18n.T("toast.provider_failed_title", from)))
+		}
+		fallback := app.FallbackMsg{Turn: msg.Turn, From: from, Provider: *provider, Model: *model}
+		if a.app.State.AutoFallback {
+			return a, util.CmdHandler(fallback)
+		}
+		a.modal = dialog.NewConfirmDialog(
+			i18n.T("dialog.provider_failed_title"),
+			i18n.T("dialog.provider_failed", from, provider.Id+"/"+model.Id),
+			fallback,
+		)
+		return a, nil
+	case app.FallbackMsg:
+		return a, tea.Batch(
+			toast.NewInfoToast(i18n.T("toast.retrying_with", msg.Provider.Id, msg.Model.Id)),
+			a.app.SendFallback(context.Background(), msg),
+		)
+	case app.RateLimitTickMsg:
+		if a.app.RateLimit == nil {
+			return a, nil
+		}
+		if a.app.RateLimit.Remaining() > 0 {
+			return a, app.TickRateLimit()
+		}
+		queued := a.app.RateLimit
+		a.app.RateLimit = nil
+		if queued.Turn != nil {
+			return a, a.app.RetryTurn(context.Background(), *queued.Turn, nil, nil)
+		}
+		return a, a.app.SendChatMessage(context.Background(), queued.Text, queued.Attachments)
+	case app.TurnRevertedMsg:
+		return a, a.app.SendTurn(context.Background(), msg)
+	case app.DeleteSessionsPromptMsg:
+		return a, a.app.Confirm(
+			app.ConfirmDeleteSessions,
+			i18n.T("dialog.delete_sessions_title"),
+			i18n.N("dialog.delete_sessions", len(msg.SessionIDs))+" "+i18n.N("dialog.delete_sessions_messages", msg.Messages),
+			app.DeleteSessionsMsg{SessionIDs: msg.SessionIDs},
+		)
+	case app.ConfirmMsg:
+		a.modal = dialog.NewConfirmDialog(msg.Title, msg.Message, msg.Confirm,
+			dialog.WithRemember(app.SkipConfirmationMsg{Action: msg.Action}),
+		)
+		return a, nil
+	case app.ClearInputMsg:
+		updated, cmd := a.editor.Clear()
+		a.editor = updated.(chat.EditorComponent)
+		return a, cmd
+	case app.SkipConfirmationMsg:
+		a.app.SkipConfirmation(msg.Action)
+		return a, toast.NewInfoToast(i18n.T("toast.confirmation_skipped"))
+	case app.CleanupTickMsg:
+		return a, a.app.RunCleanup()
+	case app.CleanupCandidatesMsg:
+		switch {
+		case msg.Err != nil:
+			slog.Error("Failed to look for sessions to clean up", "error", msg.Err)
+			if msg.Requested {
+				return a, toast.NewErrorToast(i18n.T("toast.cleanup_failed"))
+			}
+		case len(msg.Candidates) == 0:
+			if msg.Requested {
+				return a, toast.NewInfoToast(i18n.N("toast.cleanup_none", msg.Days))
+			}
+		case a.modal != nil && !msg.Requested:
+			// the review never interrupts another dialog
+			return a, toast.NewInfoToast(i18n.N("toast.cleanup_candidates", len(msg.Candidates)))
+		default:
+			a.modal = dialog.NewCleanupDialog(msg.Candidates, msg.Days)
+		}
+		return a, nil
+	case app.CleanupReviewedMsg:
+		a.app.KeepSessions(msg.Keep)
+		if len(msg.Delete) == 0 {
+			return a, nil
+		}
+		return a, tea.Batch(
+			util.CmdHandler(app.DeleteSessionsMsg{SessionIDs: msg.Delete}),
+			toast.NewInfoToast(i18n.N("toast.cleanup_deleting", len(msg.Delete))),
+		)
+	case app.DeleteSessionsMsg:
+		return a, func() tea.Msg {
+			if err := a.app.DeleteSessions(context.Background(), msg.SessionIDs); err != nil {
+				slog.Error("Failed to delete sessions", "error", err)
+				return toast.NewErrorToast(i18n.T("toast.cleanup_delete_failed"))()
+			}
+			return nil
+		}
+	case app.SystemPromptMsg:
+		a.app.SetSystemPrompt(msg.Prompt)
+		if msg.Prompt == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.system_prompt_default"))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.system_prompt_saved"))
+	case app.SaveLayoutMsg:
+		if !a.app.SaveLayout(msg.Name) {
+			return a, toast.NewErrorToast(i18n.T("toast.layout_name_missing"))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.layout_saved", strings.TrimSpace(msg.Name)))
+	case app.ApplyLayoutMsg:
+		return a.applyLayout(msg.Name)
+	case app.ParametersMsg:
+		a.app.SetParameters(msg.Parameters)
+		if msg.Parameters.IsZero() {
+			return a, toast.NewInfoToast(i18n.T("toast.parameters_default"))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.parameters_set", app.FormatParameters(msg.Parameters)))
+	case app.ModeSelectedMsg:
+		a.app.SetMode(msg.Mode)
+		return a, toast.NewInfoToast(i18n.T("toast.agent_mode", msg.Mode))
+	case app.CheckpointNamedMsg:
+		if err := a.app.CreateCheckpoint(msg.Name); err != nil {
+			return a, toast.NewErrorToast(err.Error())
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.checkpoint_created", msg.Name))
+	case app.CorrectWordMsg:
+		a.editor.Correct(msg.Misspelling, msg.Replacement)
+		return a, nil
+	case app.AddWordMsg:
+		if err := a.app.AddWord(msg.Word); err != nil {
+			slog.Error("Failed to add word", "error", err)
+			return a, toast.NewErrorToast(err.Error())
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.word_added", msg.Word))
+	case app.RestoreCheckpointMsg:
+		if !msg.Confirmed {
+			return a, a.app.Confirm(
+				app.ConfirmRestoreCheckpoint,
+				i18n.T("dialog.restore_checkpoint_title"),
+				i18n.T("dialog.restore_checkpoint", msg.Name),
+				app.RestoreCheckpointMsg{Name: msg.Name, Confirmed: true},
+			)
+		}
+		return a, a.app.RestoreCheckpoint(context.Background(), msg.Name)
+	case app.CheckpointRestoredMsg:
+		if msg.Err != nil {
+			slog.Error("Failed to restore checkpoint", "error", msg.Err)
+			return a, toast.NewErrorToast(msg.Err.Error(), toast.WithTitle(i18n.T("toast.restore_title")))
+		}
+		a.app.CheckpointRestored(msg)
+		if msg.SessionID != a.app.Session.Id {
+			return a, nil
+		}
+		a.app.SetMessages(msg.Messages)
+		a.app.CacheMessages(msg.SessionID, msg.Messages)
+		return a, tea.Batch(
+			util.CmdHandler(app.SessionClearedMsg{}),
+			toast.NewSuccessToast(i18n.N("toast.restored_checkpoint", len(msg.Files), msg.Name)),
+		)
+	case app.ConfigLoadedMsg:
+		cmd := a.app.ApplyConfig(msg)
+		a.leaderBinding = nil
+		if a.app.Config.Keybinds.Leader != nil {
+			binding := key.NewBinding(key.WithKeys(*a.app.Config.Keybinds.Leader))
+			a.leaderBinding = &binding
+		}
+		return a, cmd
+	case app.AuthFailedMsg:
+		return a, a.app.Reauthenticate()
+	case app.AuthPromptMsg:
+		title := i18n.T("dialog.auth_title")
+		if msg.Retry {
+			title = i18n.T("dialog.auth_retry_title")
+		}
+		a.modal = dialog.NewPromptDialog(title, i18n.T("dialog.auth_placeholder"), func(value string) tea.Msg {
+			return app.AuthTokenMsg{Token: value}
+		}).OnCancel(func() tea.Msg { return app.AuthCancelledMsg{} })
+		return a, nil
+	case app.AuthCancelledMsg:
+		a.app.CancelReauthentication()
+		return a, toast.NewErrorToast(
+			i18n.T("toast.auth_rejected"),
+			toast.WithTitle(i18n.T("toast.auth_failed_title")),
+		)
+	case app.AuthTokenMsg:
+		return a, a.app.UseToken(msg.Token)
+	case app.AuthRestoredMsg:
+		cmds = append(cmds, toast.NewSuccessToast(i18n.T("toast.reconnected"), toast.WithTitle(i18n.T("toast.auth_title"))))
+		cmds = append(cmds, a.app.RetryConfig(context.Background()))
+		cmds = append(cmds, a.app.InitializeProvider())
+		if a.app.Session.Id != "" {
+			cmds = append(cmds, util.CmdHandler(app.SessionSelectedMsg(a.app.Session)))
+		}
+		return a, tea.Batch(cmds...)
+	case app.NoProvidersMsg:
+		a.modal = dialog.NewProviderSetupDialog()
+		return a, nil
+	case app.ProviderCredentialsMsg:
+		return a, a.app.SaveCredentials(context.Background(), msg.ProviderID, msg.Key)
+	case app.RegenerateMsg:
+		return a, a.app.Regenerate(context.Background(), msg)
+	case app.RegenerateModelMsg:
+		a.modal = dialog.NewRegenerateModelDialog(a.app)
+		return a, nil
+	case app.FileChangesMsg:
+		if msg.Err != nil {
+			slog.Error("Failed to list file changes", "error", msg.Err)
+			return a, toast.NewErrorToast(msg.Err.Error(), toast.WithTitle(i18n.T("toast.undo_title")))
+		}
+		if len(msg.Files) == 0 {
+			return a, toast.NewInfoToast(i18n.T("toast.no_file_changes_to_undo"))
+		}
+		a.modal = dialog.NewUndoDialog(msg.Files)
+		return a, nil
+	case app.UndoConfirmedMsg:
+		return a, a.app.UndoFileChanges(context.Background())
+	case app.FilesRestoredMsg:
+		if msg.Err != nil {
+			slog.Error("Failed to undo file changes", "error", msg.Err)
+			return a, toast.NewErrorToast(i18n.T("toast.undo_failed"), toast.WithTitle(i18n.T("toast.undo_title")))
+		}
+		return a, toast.NewSuccessToast(i18n.N("toast.restored_files", len(msg.Files)))
 	case dialog.CompletionDialogCloseMsg:
 		a.showCompletionDialog = false
 	case client.EventInstallationUpdated:
 		return a, toast.NewSuccessToast(
-			"opencode updated to "+msg.Properties.Version+", restart to apply.",
-			toast.WithTitle("New version installed"),
+			i18n.T("toast.updated", msg.Properties.Version),
+			toast.WithTitle(i18n.T("toast.updated_title")),
 		)
 	case client.EventSessionDeleted:
+		_, directory := a.app.State.SessionDirectories[msg.Properties.Info.Id]
+		_, tagged := a.app.State.SessionTags[msg.Properties.Info.Id]
+		_, checkpointed := a.app.State.Checkpoints[msg.Properties.Info.Id]
+		_, fellBack := a.app.State.Fallbacks[msg.Properties.Info.Id]
+		if directory || tagged || checkpointed || fellBack {
+			delete(a.app.State.SessionDirectories, msg.Properties.Info.Id)
+			delete(a.app.State.SessionTags, msg.Properties.Info.Id)
+			delete(a.app.State.Checkpoints, msg.Properties.Info.Id)
+			delete(a.app.State.Fallbacks, msg.Properties.Info.Id)
+			a.app.SaveState()
+		}
+		a.app.SaveDraft(msg.Properties.Info.Id, "")
+		a.app.DropCachedMessages(msg.Properties.Info.Id)
 		if a.app.Session != nil && msg.Properties.Info.Id == a.app.Session.Id {
 			a.app.Session = &client.SessionInfo{}
-			a.app.Messages = []client.MessageInfo{}
+			a.app.SetMessages([]client.MessageInfo{})
 		}
-		return a, toast.NewSuccessToast("Session deleted successfully")
+		return a, tea.Batch(toast.NewSuccessToast(i18n.T("toast.session_deleted")), a.sidebar.Refresh())
 	case client.EventSessionUpdated:
 		if msg.Properties.Info.Id == a.app.Session.Id {
 			a.app.Session = &msg.Properties.Info
 		}
+	case client.EventPermissionUpdated:
+		permission := msg.Properties
+		if a.app.AutoApproves(permission) {
+			return a, a.respondPermission(permission, app.PermissionOnce)
+		}
+		a.app.QueuePermission(permission)
+		a.showPermission()
+		return a, nil
+	case app.PermissionRespondMsg:
+		if msg.Remember {
+			a.app.RememberPermission(msg.Permission)
+		}
+		return a, a.respondPermission(msg.Permission, msg.Response)
+	case app.ToolRulesMsg:
+		a.app.SetToolRules(msg.Rules)
+		return a, toast.NewSuccessToast(i18n.T("toast.tool_rules_saved"))
+	case app.RedactionRulesMsg:
+		a.app.SetRedactionRules(msg.Rules)
+		return a, toast.NewSuccessToast(i18n.T("toast.redaction_saved"))
+	case client.EventMessagePartUpdated:
+		a.app.TrackTimingPart(msg)
+		a.app.TrackRunPart(msg)
+		return a, a.announce()
 	case client.EventMessageUpdated:
+		a.app.TrackTimingMessage(msg.Properties.Info)
+		a.app.TrackRunMessage(msg.Properties.Info)
+		if a.app.Comparison != nil {
+			if side := a.app.Comparison.Side(msg.Properties.Info.Metadata.SessionID); side != nil {
+				exists := false
+				for i, m := range side.Messages {
+					if m.Id == msg.Properties.Info.Id {
+						side.Messages[i] = msg.Properties.Info
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					side.Messages = append(side.Messages, msg.Properties.Info)
+				}
+			}
+		}
 		if msg.Properties.Info.Metadata.SessionID == a.app.Session.Id {
+			msg.Properties.Info = a.app.ApplyRetained(msg.Properties.Info)
+			a.app.TrackRecentFiles(msg.Properties.Info)
 			exists := false
 			optimisticReplaced := false
 
@@ -315,6 +972,30 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if !exists {
 				a.app.Messages = append(a.app.Messages, msg.Properties.Info)
 			}
+
+			// Retry the prompt automatically when the provider rate limited it
+			info := msg.Properties.Info
+			isLast := a.app.Messages[len(a.app.Messages)-1].Id == info.Id
+			if isLast && a.app.RateLimit == nil {
+				if limited, ok := a.app.RateLimitedTurn(info); ok {
+					cmds = append(cmds, util.CmdHandler(limited))
+				}
+			}
+			a.app.TrackFallback(info)
+			if isLast && info.Metadata.Time.Completed != nil {
+				if failed, ok := a.app.FailedPrompt(info); ok {
+					cmds = append(cmds, util.CmdHandler(failed))
+				}
+			}
+			if info.Metadata.Time.Completed != nil {
+				a.app.CacheMessages(a.app.Session.Id, a.app.Messages)
+			}
+			if cmd := a.app.FlushChunks(context.Background()); cmd != nil {
+				cmds = append(cmds, cmd)
+			} else {
+				cmds = append(cmds, a.app.FlushSteering(context.Background()))
+			}
+			cmds = append(cmds, a.announce())
 		}
 	case client.EventSessionError:
 		unknownError, err := msg.Properties.Error.AsUnknownError()
@@ -325,34 +1006,73 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		msg.Height -= 2 // Make space for the status bar
 		a.width, a.height = msg.Width, msg.Height
-		layout.Current = &layout.LayoutInfo{
-			Viewport: layout.Dimensions{
-				Width:  a.width,
-				Height: a.height,
-			},
-			Container: layout.Dimensions{
-				Width: min(a.width, 80),
-			},
-		}
-		a.layout.SetSize(a.width, a.height)
+		a.resize()
 	case app.SessionSelectedMsg:
-		messages, err := a.app.ListMessages(context.Background(), msg.Id)
-		if err != nil {
-			slog.Error("Failed to list messages", "error", err)
-			return a, toast.NewErrorToast("Failed to open session")
+		// Render a previously opened session from the cache right away and
+		// catch up with the server in the background
+		messages, cached := a.app.CachedMessages(msg.Id)
+		if cached {
+			cmds = append(cmds, a.app.ReconcileMessages(msg.Id))
+		} else {
+			var err error
+			messages, err = a.app.ListMessages(context.Background(), msg.Id)
+			if err != nil {
+				slog.Error("Failed to list messages", "error", err)
+				return a, toast.NewErrorToast(i18n.T("toast.open_session_failed"))
+			}
+			a.app.CacheMessages(msg.Id, messages)
+		}
+		for i, message := range messages {
+			messages[i] = a.app.ApplyRetained(message)
 		}
 		a.app.Session = msg
-		a.app.Messages = messages
+		a.app.SetMessages(messages)
+		a.app.LoadMode()
+		a.app.LoadSystemPrompt()
+		a.app.LoadParameters()
+		a.app.LoadRun()
+		a.app.LoadWorkspace()
+	case app.MessagesReconciledMsg:
+		if msg.SessionID != a.app.Session.Id {
+			return a, nil
+		}
+		messages := msg.Messages
+		for i, message := range messages {
+			messages[i] = a.app.ApplyRetained(message)
+		}
+		for _, message := range a.app.Messages {
+			if strings.HasPrefix(message.Id, "optimistic-") {
+				messages = append(messages, message)
+			}
+		}
+		a.app.SetMessages(messages)
+		a.app.CacheMessages(msg.SessionID, messages)
 	case app.ModelSelectedMsg:
 		a.app.Provider = &msg.Provider
 		a.app.Model = &msg.Model
 		a.app.State.Provider = msg.Provider.Id
 		a.app.State.Model = msg.Model.Id
-		a.app.SaveState()
+		a.app.RememberProjectModel(msg.Provider.Id, msg.Model.Id)
+		a.app.RecordRecentModel(msg.Provider, msg.Model)
 	case dialog.ThemeSelectedMsg:
 		a.app.State.Theme = msg.ThemeName
 		a.app.SaveState()
+	case dialog.ThemeDesignedMsg:
+		path, err := theme.SaveTheme(filepath.Join(a.app.Info.Path.Config, "themes"), msg.Name, msg.Theme)
+		if err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.theme_save_failed", err.Error()))
+		}
+		theme.SetTheme(msg.Name)
+		return a, tea.Batch(
+			util.CmdHandler(dialog.ThemeSelectedMsg{ThemeName: msg.Name}),
+			toast.NewSuccessToast(i18n.T("toast.theme_saved", path)),
+		)
 	case toast.ShowToastMsg:
+		// The failures caused by rejected credentials are reported by the
+		// re-authentication flow instead
+		if msg.Error && a.app.Reauthenticating() {
+			return a, nil
+		}
 		tm, cmd := a.toastManager.Update(msg)
 		a.toastManager = tm
 		cmds = append(cmds, cmd)
@@ -364,6 +1084,17 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reset interrupt key state after timeout
 		a.interruptKeyState = InterruptKeyIdle
 		a.editor.SetInterruptKeyInDebounce(false)
+	case LeaderSequenceTimeoutMsg:
+		// the timer of a sequence that was continued or ended since is stale
+		if !a.isLeaderSequence || !slices.Equal(a.leaderKeys, msg.Keys) {
+			return a, nil
+		}
+		a.isLeaderSequence = false
+		a.leaderKeys = nil
+		if matches, _ := a.app.Commands.LeaderSequence(msg.Keys); len(matches) > 0 {
+			return a, util.CmdHandler(commands.ExecuteCommandsMsg(matches))
+		}
+		return a, nil
 	}
 
 	// update status bar
@@ -381,6 +1112,11 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	a.messages = u.(chat.MessagesComponent)
 	cmds = append(cmds, cmd)
 
+	// update sidebar
+	u, cmd = a.sidebar.Update(msg)
+	a.sidebar = u.(sidebar.SidebarComponent)
+	cmds = append(cmds, cmd)
+
 	// update modal
 	if a.modal != nil {
 		u, cmd := a.modal.Update(msg)
@@ -397,7 +1133,91 @@ func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return a, tea.Batch(cmds...)
 }
 
+// resize lays out the sidebar and the chat column for the terminal size
+func (a *appModel) resize() {
+	threshold := a.app.State.SplitLayoutWidth
+	if threshold == 0 {
+		threshold = defaultSplitLayoutWidth
+	}
+	a.split = threshold > 0 && a.width >= threshold
+	if !a.split {
+		a.sidebar.Blur()
+	}
+	contentWidth := a.app.State.ContentWidth
+	if contentWidth == 0 {
+		contentWidth = config.DefaultContentWidth
+	}
+	if contentWidth < 0 {
+		contentWidth = a.mainWidth()
+	}
+	layout.Current = &layout.LayoutInfo{
+		Viewport: layout.Dimensions{
+			Width:  a.mainWidth(),
+			Height: a.height,
+		},
+		Container: layout.Dimensions{
+			Width: min(a.mainWidth(), contentWidth),
+		},
+	}
+	a.editorContainer.SetMaxWidth(layout.Current.Container.Width)
+	a.layout.SetSize(a.mainWidth(), a.height)
+	a.sidebar.SetSize(sidebar.Width, a.height)
+}
+
+// showPermission opens the dialog of the oldest permission request waiting,
+// once no other dialog is open
+func (a *appModel) showPermission() {
+	if a.modal != nil {
+		return
+	}
+	permission, ok := a.app.NextPermission()
+	if !ok {
+		return
+	}
+	rule, _ := a.app.PermissionRule(permission)
+	a.modal = dialog.NewApproveDialog(permission, rule)
+}
+
+// respondPermission answers a permission request in the background
+func (a appModel) respondPermission(permission client.PermissionInfo, response string) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.app.RespondPermission(context.Background(), permission, response); err != nil {
+			return toast.NewErrorToast(i18n.T("toast.permission_failed", err.Error()))()
+		}
+		return nil
+	}
+}
+
+// announce reports when the assistant starts and stops working, in screen
+// reader mode the spinner showing it is hidden
+func (a *appModel) announce() tea.Cmd {
+	busy := a.app.IsBusy()
+	if busy == a.busy {
+		return nil
+	}
+	a.busy = busy
+	if !a.app.ScreenReader {
+		return nil
+	}
+	if busy {
+		return toast.NewInfoToast(i18n.T("toast.assistant_working"))
+	}
+	return toast.NewInfoToast(i18n.T("toast.assistant_finished"))
+}
+
+// mainWidth is the width left for the chat next to the sidebar
+func (a appModel) mainWidth() int {
+	if a.split {
+		return a.width - sidebar.Width
+	}
+	return a.width
+}
+
+// firstFrame records when the first frame was rendered
+var firstFrame sync.Once
+
 func (a appModel) View() string {
+	defer firstFrame.Do(func() { app.StartupStep("first frame") })
 	layoutView := a.layout.View()
 	editorWidth, _ := a.editorContainer.GetSize()
 	editorX, editorY := a.editorContainer.GetPosition()
@@ -412,6 +1232,10 @@ func (a appModel) View() string {
 		)
 	}
 
+	if strip := chat.RenderAttachmentStrip(a.app, a.editor.Attachments(), editorWidth); strip != "" {
+		layoutView = layout.PlaceOverlay(editorX, editorY, strip, layoutView)
+	}
+
 	if a.showCompletionDialog {
 		a.completions.SetWidth(editorWidth)
 		overlay := a.completions.View()
@@ -423,6 +1247,38 @@ func (a appModel) View() string {
 		)
 	}
 
+	if a.split {
+		layoutView = lipgloss.JoinHorizontal(lipgloss.Top, a.sidebar.View(), layoutView)
+	}
+
+	if a.isLeaderSequence {
+		leader := ""
+		if a.app.Config.Keybinds.Leader != nil {
+			leader = *a.app.Config.Keybinds.Leader
+		}
+		popup := commandsComponent.WhichKey(a.app.Commands, leader, a.leaderKeys)
+		if popup != "" {
+			layoutView = layout.PlaceOverlay(
+				max(a.width-lipgloss.Width(popup)-2, 0),
+				max(editorY-lipgloss.Height(popup), 0),
+				popup,
+				layoutView,
+			)
+		}
+	}
+
+	if a.switcher.title != "" {
+		popup := a.renderSessionSwitch()
+		layoutView = layout.PlaceOverlay(
+			max((a.width-lipgloss.Width(popup))/2-1, 0),
+			1,
+			popup,
+			layoutView,
+			layout.WithOverlayBorder(),
+			layout.WithOverlayBorderColor(theme.CurrentTheme().Primary()),
+		)
+	}
+
 	components := []string{
 		layoutView,
 		a.status.View(),
@@ -441,7 +1297,321 @@ func (a appModel) View() string {
 	return appView
 }
 
+// switchSession opens the session created after the current one, or before
+// it when offset is negative, and briefly shows its title like switching
+// buffers in an editor
+func (a appModel) switchSession(offset int) (tea.Model, tea.Cmd) {
+	session, position, total, err := a.app.AdjacentSession(context.Background(), offset)
+	if err != nil {
+		return a, toast.NewErrorToast(i18n.T("toast.switch_session_failed", err.Error()))
+	}
+	if session == nil || session.Id == a.app.Session.Id {
+		return a, toast.NewInfoToast(i18n.T("toast.no_other_sessions"))
+	}
+	seq := a.switcher.seq + 1
+	a.switcher = sessionSwitch{title: session.Title, position: position, total: total, seq: seq}
+	return a, tea.Batch(
+		util.CmdHandler(app.SessionSelectedMsg(session)),
+		tea.Tick(sessionSwitchDuration, func(time.Time) tea.Msg {
+			return sessionSwitchExpiredMsg{seq: seq}
+		}),
+	)
+}
+
+// renderSessionSwitch renders the title of the session switched to
+func (a appModel) renderSessionSwitch() string {
+	t := theme.CurrentTheme()
+	style := styles.NewStyle().Background(t.BackgroundElement())
+	position := style.Foreground(t.TextMuted()).Render(fmt.Sprintf("%d/%d  ", a.switcher.position, a.switcher.total))
+	maxTitle := max(a.width/2-lipgloss.Width(position), 10)
+	title := style.Foreground(t.Text()).Bold(true).Render(ansi.Truncate(a.switcher.title, maxTitle, "…"))
+	return style.Padding(0, 2).Render(position + title)
+}
+
+// codeScrollStep is how many columns code blocks scroll horizontally
+const codeScrollStep = 8
+
+func (a appModel) setContentWidth(width int) (tea.Model, tea.Cmd) {
+	a.app.State.ContentWidth = width
+	a.app.SaveState()
+	a.resize()
+	label := i18n.T("toast.width_full")
+	if width > 0 {
+		label = i18n.N("toast.width_columns", width)
+	}
+	return a, tea.Batch(
+		util.CmdHandler(chat.LayoutChangedMsg{}),
+		toast.NewInfoToast(i18n.T("toast.content_width", label)),
+	)
+}
+
+// applyLayout switches to a layout preset, re-rendering the messages and
+// changing the theme when the preset has one
+func (a appModel) applyLayout(name string) (tea.Model, tea.Cmd) {
+	preset, ok := a.app.ApplyLayout(name)
+	if !ok {
+		return a, toast.NewErrorToast(i18n.T("toast.layout_unknown", name))
+	}
+	a.resize()
+	cmds := []tea.Cmd{
+		util.CmdHandler(chat.LayoutChangedMsg{}),
+		util.CmdHandler(chat.DensityChangedMsg{}),
+		util.CmdHandler(chat.ReasoningChangedMsg{}),
+		toast.NewInfoToast(i18n.T("toast.layout_applied", name)),
+	}
+	if preset.Theme != "" && preset.Theme != a.app.State.Theme {
+		if err := theme.SetTheme(preset.Theme); err != nil {
+			cmds = append(cmds, toast.NewWarningToast(i18n.T("toast.layout_theme_failed", preset.Theme, err.Error())))
+		} else {
+			cmds = append(cmds, util.CmdHandler(dialog.ThemeSelectedMsg{ThemeName: preset.Theme}))
+		}
+	}
+	return a, tea.Batch(cmds...)
+}
+
+func (a appModel) setCodeWrap(wrap string) (tea.Model, tea.Cmd) {
+	a.app.State.CodeWrap = wrap
+	a.app.SaveState()
+	return a, tea.Batch(
+		util.CmdHandler(chat.LayoutChangedMsg{}),
+		toast.NewInfoToast(i18n.T("toast.code_wrap", wrap)),
+	)
+}
+
+func (a appModel) setReasoning(mode string) (tea.Model, tea.Cmd) {
+	a.app.State.Reasoning = mode
+	a.app.SaveState()
+	return a, tea.Batch(
+		util.CmdHandler(chat.ReasoningChangedMsg{}),
+		toast.NewInfoToast(i18n.T("toast.reasoning", mode)),
+	)
+}
+
+// enter sends the editor content or inserts a newline
+func (a appModel) enter(send bool) (tea.Model, tea.Cmd) {
+	var updated tea.Model
+	var cmd tea.Cmd
+	if send {
+		updated, cmd = a.editor.Submit()
+	} else {
+		updated, cmd = a.editor.Newline()
+	}
+	a.editor = updated.(chat.EditorComponent)
+	return a, cmd
+}
+
+// tagSession runs /tag: "add <tag>" or a bare tag adds it, "remove <tag>"
+// removes it and no arguments lists the tags of the session
+func (a appModel) tagSession(args string) (tea.Model, tea.Cmd) {
+	if a.app.Session.Id == "" {
+		return a, toast.NewInfoToast(i18n.T("toast.tag_no_session"))
+	}
+	action, tag, _ := strings.Cut(strings.TrimSpace(args), " ")
+	switch action {
+	case "":
+		tags := a.app.Tags(a.app.Session.Id)
+		if len(tags) == 0 {
+			return a, toast.NewInfoToast(i18n.T("toast.no_tags"))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.tags", strings.Join(tags, ", ")))
+	case "remove", "rm":
+		if !a.app.RemoveTag(a.app.Session.Id, tag) {
+			return a, toast.NewWarningToast(i18n.T("toast.not_tagged", tag))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.tag_removed", tag))
+	case "add":
+	default:
+		tag = strings.TrimSpace(action + " " + tag)
+	}
+	added, err := a.app.AddTag(a.app.Session.Id, tag)
+	if err != nil {
+		return a, toast.NewErrorToast(err.Error())
+	}
+	return a, toast.NewSuccessToast(i18n.T("toast.tagged", added))
+}
+
+// importSessions recreates the sessions of an export file in the background,
+// relative paths are resolved against the working directory
+func (a appModel) importSessions(path string) tea.Cmd {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(a.app.Info.Path.Cwd, path)
+	}
+	return func() tea.Msg {
+		sessions, err := a.app.ImportSessions(context.Background(), path)
+		return app.SessionsImportedMsg{Sessions: sessions, Err: err}
+	}
+}
+
+// mergeSession appends the history of the session matching args to the
+// current session
+func (a appModel) mergeSession(args string) (tea.Model, tea.Cmd) {
+	if a.app.Session.Id == "" {
+		return a, toast.NewInfoToast(i18n.T("toast.merge_no_session"))
+	}
+	if a.app.IsBusy() {
+		return a, toast.NewWarningToast(i18n.T("toast.agent_busy"))
+	}
+	query, summarize := app.ParseMergeArgs(args)
+	if query == "" {
+		return a, toast.NewErrorToast(i18n.T("toast.merge_name_missing"))
+	}
+	message := i18n.T("toast.merging", query)
+	if summarize {
+		message = i18n.T("toast.merging_summarized", query)
+	}
+	return a, tea.Batch(
+		toast.NewInfoToast(message),
+		a.app.MergeSession(context.Background(), query, summarize),
+	)
+}
+
+// executeCommandWithArgs runs commands that accept the text typed after
+// their trigger, falling back to executeCommand when there is none
+func (a appModel) executeCommandWithArgs(command commands.Command, args string) (tea.Model, tea.Cmd) {
+	if args == "" {
+		return a.executeCommand(command)
+	}
+	switch command.Name {
+	case commands.SessionListCommand:
+		sessionDialog := dialog.NewSessionDialog(a.app)
+		sessionDialog.SetTag(args)
+		a.modal = sessionDialog
+		return a, nil
+	case commands.SessionTagCommand:
+		return a.tagSession(args)
+	case commands.CheckpointCreateCommand:
+		return a, util.CmdHandler(app.CheckpointNamedMsg{Name: args})
+	case commands.CheckpointRestoreCommand:
+		return a, util.CmdHandler(app.RestoreCheckpointMsg{Name: args})
+	case commands.ContextPinCommand:
+		if err := a.app.PinFile(args); err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.pin_failed", args, err.Error()))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.pinned_context", strings.TrimPrefix(args, "@")))
+	case commands.WorkspaceRootsCommand:
+		root, err := a.app.AttachRoot(args)
+		if err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.attach_failed", args, err.Error()))
+		}
+		return a, util.CmdHandler(app.SwitchRootMsg{Root: root})
+	case commands.LayoutSaveCommand:
+		return a, util.CmdHandler(app.SaveLayoutMsg{Name: args})
+	case commands.LayoutSwitchCommand:
+		return a, util.CmdHandler(app.ApplyLayoutMsg{Name: args})
+	case commands.InputSteerCommand:
+		return a, util.CmdHandler(app.SteerMsg{Text: args})
+	case commands.SessionAuditCommand:
+		if args != "export" {
+			return a, toast.NewErrorToast(i18n.T("toast.audit_usage"))
+		}
+		if a.app.Session.Id == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.no_active_session"))
+		}
+		return a, a.app.AuditLog(context.Background(), true)
+	case commands.SessionCleanupCommand:
+		days, err := strconv.Atoi(args)
+		if err != nil || days < 1 {
+			return a, toast.NewErrorToast(i18n.T("toast.cleanup_usage"))
+		}
+		return a, a.app.FindCleanup(days, true)
+	case commands.SessionImportCommand:
+		return a, tea.Batch(
+			toast.NewInfoToast(i18n.T("toast.importing", filepath.Base(args))),
+			a.importSessions(args),
+		)
+	case commands.SessionMergeCommand:
+		return a.mergeSession(args)
+	case commands.RunShellCommand:
+		return a, tea.Batch(
+			toast.NewInfoToast(i18n.T("toast.running", args)),
+			a.app.RunCommand(args),
+		)
+	case commands.FilePreviewCommand:
+		reference, ok := a.app.ResolveFileReference(args)
+		if !ok {
+			return a, toast.NewErrorToast(i18n.T("toast.no_such_file", args))
+		}
+		return a, util.CmdHandler(app.FilePreviewMsg{Reference: reference})
+	case commands.FileOpenCommand:
+		reference, ok := a.app.ResolveFileReference(args)
+		if !ok {
+			return a, toast.NewErrorToast(i18n.T("toast.no_such_file", args))
+		}
+		return a, util.CmdHandler(app.OpenFileMsg{Reference: reference})
+	case commands.LinkOpenCommand:
+		links := app.FindLinks(args)
+		if len(links) == 0 {
+			return a, toast.NewErrorToast(i18n.T("toast.not_a_link", args))
+		}
+		return a, util.CmdHandler(app.OpenLinkMsg{URL: links[0]})
+	case commands.MessagesWidthCommand:
+		if args == "full" {
+			return a.setContentWidth(-1)
+		}
+		width, err := strconv.Atoi(args)
+		if err != nil || width < 40 {
+			return a, toast.NewErrorToast(i18n.T("toast.width_usage"))
+		}
+		return a.setContentWidth(width)
+	case commands.MessagesWrapCommand:
+		if !slices.Contains(config.CodeWraps, args) {
+			return a, toast.NewErrorToast(i18n.T("toast.wrap_unknown", args, strings.Join(config.CodeWraps, ", ")))
+		}
+		return a.setCodeWrap(args)
+	case commands.MessagesReasoningCommand:
+		if !slices.Contains(config.ReasoningModes, args) {
+			return a, toast.NewErrorToast(i18n.T("toast.reasoning_unknown", args, strings.Join(config.ReasoningModes, ", ")))
+		}
+		return a.setReasoning(args)
+	case commands.ModelListCommand:
+		provider, model, err := a.app.FindModel(context.Background(), args)
+		if err != nil {
+			return a, toast.NewErrorToast(err.Error())
+		}
+		return a, util.CmdHandler(app.ModelSelectedMsg{Provider: *provider, Model: *model})
+	case commands.AgentModeListCommand:
+		if !slices.Contains(app.Modes, args) {
+			return a, toast.NewErrorToast(i18n.T("toast.mode_unknown", args, strings.Join(app.Modes, ", ")))
+		}
+		return a, util.CmdHandler(app.ModeSelectedMsg{Mode: args})
+	}
+	return a.executeCommand(command)
+}
+
+// rateMessage rates the selected message, or the latest response when none
+// is selected
+func (a appModel) rateMessage(feedback client.MessageMetadataFeedback) (tea.Model, tea.Cmd) {
+	message, ok := a.messages.SelectedMessage()
+	if ok && message.Role != client.Assistant {
+		ok = false
+		for i := len(a.app.Messages) - 1; i >= 0 && !ok; i-- {
+			message, ok = a.app.Messages[i], a.app.Messages[i].Role == client.Assistant
+		}
+	}
+	if !ok {
+		return a, toast.NewInfoToast(i18n.T("toast.no_response_to_rate"))
+	}
+	rated, err := a.app.RateMessage(context.Background(), message, feedback)
+	if err != nil {
+		return a, toast.NewErrorToast(i18n.T("toast.rate_failed", err.Error()))
+	}
+	if rated.Metadata.Feedback == nil {
+		return a, toast.NewInfoToast(i18n.T("toast.rating_cleared"))
+	}
+	if *rated.Metadata.Feedback == client.MessageMetadataFeedbackDown {
+		return a, toast.NewInfoToast(i18n.T("toast.rated_bad"))
+	}
+	return a, toast.NewInfoToast(i18n.T("toast.rated_good"))
+}
+
 func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd) {
+	if command.Expansion != "" {
+		target, args, ok := a.app.Commands.Resolve(command, "")
+		if !ok {
+			return a, toast.NewErrorToast(i18n.T("toast.command_unknown", command.Expansion))
+		}
+		return a.executeCommandWithArgs(target, args)
+	}
 	cmds := []tea.Cmd{
 		util.CmdHandler(commands.CommandExecutedMsg(command)),
 	}
@@ -456,7 +1626,7 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		}
 		editor := os.Getenv("EDITOR")
 		if editor == "" {
-			return a, toast.NewErrorToast("No EDITOR set, can't open editor")
+			return a, toast.NewErrorToast(i18n.T("toast.no_editor"))
 		}
 
 		value := a.editor.Value()
@@ -468,7 +1638,7 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		tmpfile.WriteString(value)
 		if err != nil {
 			slog.Error("Failed to create temp file", "error", err)
-			return a, toast.NewErrorToast("Something went wrong, couldn't open editor")
+			return a, toast.NewErrorToast(i18n.T("toast.editor_failed"))
 		}
 		tmpfile.Close()
 		c := exec.Command(editor, tmpfile.Name()) //nolint:gosec
@@ -499,15 +1669,40 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		})
 		cmds = append(cmds, cmd)
 	case commands.SessionNewCommand:
+		if a.app.Comparison != nil {
+			a.app.CancelComparison(context.Background())
+			a.app.Comparison = nil
+			cmds = append(cmds, util.CmdHandler(app.ComparisonClearedMsg{}))
+		}
 		if a.app.Session.Id == "" {
-			return a, nil
+			return a, tea.Batch(cmds...)
 		}
 		a.app.Session = &client.SessionInfo{}
-		a.app.Messages = []client.MessageInfo{}
+		a.app.SetMessages([]client.MessageInfo{})
+		a.app.LoadWorkspace()
 		cmds = append(cmds, util.CmdHandler(app.SessionClearedMsg{}))
+	case commands.SessionNextCommand:
+		return a.switchSession(1)
+	case commands.SessionPreviousCommand:
+		return a.switchSession(-1)
 	case commands.SessionListCommand:
+		if a.split && !a.sidebar.IsFocused() {
+			return a, a.sidebar.Focus()
+		}
 		sessionDialog := dialog.NewSessionDialog(a.app)
 		a.modal = sessionDialog
+	case commands.SessionTagCommand:
+		return a.tagSession("")
+	case commands.SessionPinCommand:
+		if a.app.Session.Id == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.pin_no_session"))
+		}
+		pinned := !a.app.IsPinned(a.app.Session.Id)
+		a.app.PinSessions([]string{a.app.Session.Id}, pinned)
+		if pinned {
+			return a, toast.NewSuccessToast(i18n.T("toast.session_pinned", a.app.Session.Title))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.session_unpinned", a.app.Session.Title))
 	case commands.SessionShareCommand:
 		if a.app.Session.Id == "" {
 			return a, nil
@@ -520,59 +1715,388 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		)
 		if err != nil {
 			slog.Error("Failed to share session", "error", err)
-			return a, toast.NewErrorToast("Failed to share session")
+			return a, toast.NewErrorToast(i18n.T("toast.share_failed"))
 		}
 		if response.JSON200 != nil && response.JSON200.Share != nil {
 			shareUrl := response.JSON200.Share.Url
-			cmds = append(cmds, tea.SetClipboard(shareUrl))
-			cmds = append(cmds, toast.NewSuccessToast("Share URL copied to clipboard!"))
+			cmds = append(cmds, clipboard.Copy(shareUrl, "Share URL"))
 		}
 	case commands.SessionInterruptCommand:
+		if a.app.IsComparing() {
+			a.app.CancelComparison(context.Background())
+			return a, nil
+		}
 		if a.app.Session.Id == "" {
 			return a, nil
 		}
 		a.app.Cancel(context.Background(), a.app.Session.Id)
 		return a, nil
+	case commands.SessionStopCommand:
+		if a.app.Session.Id == "" || !a.app.IsBusy() {
+			return a, nil
+		}
+		if err := a.app.StopAndRetain(context.Background()); err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.stop_failed"))
+		}
+		cmds = append(cmds, toast.NewInfoToast(i18n.T("toast.stopped")))
+	case commands.SessionCancelToolCommand:
+		message, ok := a.messages.SelectedMessage()
+		tools := []app.PendingTool{}
+		if ok {
+			tools = app.PendingTools(message)
+		}
+		switch len(tools) {
+		case 0:
+			return a, toast.NewInfoToast(i18n.T("toast.no_tool_running"))
+		case 1:
+			return a, util.CmdHandler(app.CancelToolMsg{Tool: tools[0]})
+		}
+		a.modal = dialog.NewCancelToolDialog(tools)
 	case commands.SessionCompactCommand:
 		if a.app.Session.Id == "" {
 			return a, nil
 		}
 		// TODO: block until compaction is complete
-		a.app.CompactSession(context.Background())
+		cmds = append(cmds, a.app.CompactSession(context.Background()))
+	case commands.SessionContextCommand:
+		if a.app.Session.Id == "" || len(a.app.Messages) == 0 {
+			return a, toast.NewInfoToast(i18n.T("toast.no_messages"))
+		}
+		if a.app.IsBusy() {
+			return a, toast.NewWarningToast(i18n.T("toast.trim_busy"))
+		}
+		a.modal = dialog.NewContextDialog(a.app.ContextEntries())
+	case commands.ContextPinCommand:
+		a.modal = dialog.NewPinnedContextDialog(a.app)
+	case commands.WorkspaceRootsCommand:
+		a.modal = dialog.NewRootsDialog(a.app)
+	case commands.SessionCompareCommand:
+		switch {
+		case a.app.Comparison == nil:
+			if a.app.IsBusy() {
+				return a, toast.NewWarningToast(i18n.T("toast.agent_busy"))
+			}
+			a.modal = dialog.NewCompareModelDialog(a.app)
+		case !a.app.Comparison.Sent:
+			a.app.Comparison = nil
+			cmds = append(cmds, toast.NewInfoToast(i18n.T("toast.compare_disabled")))
+		case a.app.Comparison.Busy():
+			cmds = append(cmds, toast.NewWarningToast(i18n.T("toast.compare_waiting")))
+		default:
+			a.modal = dialog.NewCompareDialog(a.app.Comparison)
+		}
+	case commands.UndoCommand:
+		cmds = append(cmds, a.app.FileChanges(context.Background()))
+	case commands.RegenerateCommand:
+		if a.app.IsBusy() {
+			return a, toast.NewWarningToast(i18n.T("toast.agent_busy"))
+		}
+		if !a.app.CanRegenerate() {
+			return a, toast.NewInfoToast(i18n.T("toast.nothing_to_regenerate"))
+		}
+		a.modal = dialog.NewRegenerateDialog()
+	case commands.ProviderSetupCommand:
+		a.modal = dialog.NewProviderSetupDialog()
+	case commands.SessionSearchCommand:
+		if len(a.app.Messages) == 0 {
+			return a, nil
+		}
+		cmds = append(cmds, util.CmdHandler(chat.OpenSearchMsg{}))
+	case commands.SessionReplayCommand:
+		if len(a.app.Messages) == 0 {
+			return a, toast.NewInfoToast(i18n.T("toast.no_messages_to_replay"))
+		}
+		cmds = append(cmds, util.CmdHandler(chat.OpenReplayMsg{}))
+	case commands.MessagesDensityCommand:
+		density := a.app.State.Density
+		if density == "" {
+			density = config.DensityComfortable
+		}
+		next := config.Densities[(slices.Index(config.Densities, density)+1)%len(config.Densities)]
+		a.app.State.Density = next
+		a.app.SaveState()
+		cmds = append(cmds, util.CmdHandler(chat.DensityChangedMsg{}))
+		cmds = append(cmds, toast.NewInfoToast(i18n.T("toast.density", next)))
+	case commands.MessagesWidthCommand:
+		// cycle through the preset widths
+		widths := []int{config.DefaultContentWidth, 100, 120, -1}
+		current := a.app.State.ContentWidth
+		if current == 0 {
+			current = config.DefaultContentWidth
+		}
+		next := widths[(slices.Index(widths, current)+1)%len(widths)]
+		return a.setContentWidth(next)
+	case commands.MessagesWrapCommand:
+		wrap := a.app.State.CodeWrap
+		if wrap == "" {
+			wrap = config.CodeWrapSoft
+		}
+		next := config.CodeWraps[(slices.Index(config.CodeWraps, wrap)+1)%len(config.CodeWraps)]
+		return a.setCodeWrap(next)
+	case commands.FileChangesCommand:
+		a.app.State.ReportFileChanges = !a.app.State.ReportFileChanges
+		a.app.SaveState()
+		if a.app.State.ReportFileChanges {
+			return a, toast.NewInfoToast(i18n.T("toast.file_changes_on"))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.file_changes_off"))
+	case commands.MessagesReasoningCommand:
+		mode := a.app.State.Reasoning
+		if mode == "" {
+			mode = config.ReasoningCollapsed
+		}
+		next := config.ReasoningModes[(slices.Index(config.ReasoningModes, mode)+1)%len(config.ReasoningModes)]
+		return a.setReasoning(next)
+	case commands.MessagesScrollLeftCommand:
+		cmds = append(cmds, util.CmdHandler(chat.ScrollCodeMsg{Offset: -codeScrollStep}))
+	case commands.MessagesScrollRightCommand:
+		cmds = append(cmds, util.CmdHandler(chat.ScrollCodeMsg{Offset: codeScrollStep}))
+	case commands.ModelPaletteCommand:
+		a.modal = dialog.NewModelPaletteDialog(a.app)
+	case commands.SessionStatsCommand:
+		if a.app.Session.Id == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.no_active_session"))
+		}
+		a.modal = dialog.NewStatsDialog(a.app.Stats())
+	case commands.SessionAuditCommand:
+		if a.app.Session.Id == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.no_active_session"))
+		}
+		cmds = append(cmds, a.app.AuditLog(context.Background(), false))
+	case commands.SystemPromptCommand:
+		a.modal = dialog.NewSystemPromptDialog(a.app.SystemPrompt())
+	case commands.ModelParametersCommand:
+		a.modal = dialog.NewParametersDialog(a.app.Parameters())
+	case commands.ToolRulesCommand:
+		a.modal = dialog.NewToolRulesDialog(a.app.State.ToolRules)
+	case commands.RedactionRulesCommand:
+		a.modal = dialog.NewRedactionDialog(a.app.RedactionRules())
+	case commands.FilePreviewCommand:
+		reference, ok := a.app.ResolveFileReference(a.editor.MentionAtCursor())
+		if !ok {
+			reference, ok = a.messages.FileReference()
+		}
+		if !ok {
+			return a, toast.NewInfoToast(i18n.T("toast.no_mention"))
+		}
+		return a, util.CmdHandler(app.FilePreviewMsg{Reference: reference})
+	case commands.FileOpenCommand:
+		reference, ok := a.app.ResolveFileReference(a.editor.MentionAtCursor())
+		if !ok {
+			reference, ok = a.messages.FileReference()
+		}
+		if !ok {
+			return a, toast.NewInfoToast(i18n.T("toast.no_mention"))
+		}
+		return a, util.CmdHandler(app.OpenFileMsg{Reference: reference})
+	case commands.LinkOpenCommand:
+		links := a.messages.Links()
+		switch len(links) {
+		case 0:
+			return a, toast.NewInfoToast(i18n.T("toast.no_links"))
+		case 1:
+			return a, util.CmdHandler(app.OpenLinkMsg{URL: links[0]})
+		}
+		a.modal = dialog.NewLinksDialog(links)
+	case commands.SessionCleanupCommand:
+		return a, a.app.FindCleanup(a.app.CleanupDays(), true)
+	case commands.SessionImportCommand:
+		a.modal = dialog.NewPromptDialog(i18n.T("dialog.import_title"), i18n.T("dialog.import_placeholder"), func(path string) tea.Msg {
+			return commands.ExecuteCommandWithArgsMsg{Command: a.app.Commands[commands.SessionImportCommand], Args: path}
+		})
+	case commands.SessionMergeCommand:
+		if a.app.Session.Id == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.merge_no_session"))
+		}
+		a.modal = dialog.NewPromptDialog(i18n.T("dialog.merge_title"), i18n.T("dialog.merge_placeholder"), func(query string) tea.Msg {
+			return commands.ExecuteCommandWithArgsMsg{Command: a.app.Commands[commands.SessionMergeCommand], Args: query}
+		})
+	case commands.RunShellCommand:
+		a.modal = dialog.NewPromptDialog(i18n.T("dialog.run_title"), i18n.T("dialog.run_placeholder"), func(command string) tea.Msg {
+			return commands.ExecuteCommandWithArgsMsg{Command: a.app.Commands[commands.RunShellCommand], Args: command}
+		})
+	case commands.ProjectScriptsCommand:
+		a.modal = dialog.NewScriptsDialog(a.app.ProjectScripts())
+	case commands.MessageInspectCommand:
+		message, ok := a.messages.SelectedMessage()
+		if !ok {
+			return a, toast.NewInfoToast(i18n.T("toast.no_message_to_inspect"))
+		}
+		a.modal = dialog.NewJSONDialog("Message "+message.Id, message)
+	case commands.MessagesNavigateCommand:
+		// esc interrupts a busy session or a comparison instead
+		if a.app.IsBusy() || a.app.IsComparing() {
+			return a, nil
+		}
+		if !a.messages.StartNavigation() {
+			return a, nil
+		}
+	case commands.MessageRateUpCommand:
+		return a.rateMessage(client.MessageMetadataFeedbackUp)
+	case commands.MessageRateDownCommand:
+		return a.rateMessage(client.MessageMetadataFeedbackDown)
+	case commands.MessagesRatedDownCommand:
+		if len(a.app.RatedMessages(client.MessageMetadataFeedbackDown)) == 0 {
+			return a, toast.NewInfoToast(i18n.T("toast.no_bad_ratings"))
+		}
+		cmds = append(cmds, util.CmdHandler(chat.JumpToRatedMsg{Feedback: client.MessageMetadataFeedbackDown}))
+	case commands.LogsCommand:
+		logs := dialog.NewLogsDialog(logging.Path(a.app.Info.Path.State))
+		a.modal = logs
+		return a, logs.Tick()
+	case commands.NotificationsCommand:
+		a.modal = dialog.NewNotificationsDialog(a.toastManager.History())
+	case commands.ToastDismissCommand:
+		if !a.toastManager.DismissAll() {
+			return a, nil
+		}
+	case commands.BugReportCommand:
+		return a, a.app.BugReport()
+	case commands.DebugInspectorCommand:
+		if a.app.Inspector == nil {
+			return a, toast.NewInfoToast(i18n.T("toast.inspector_off"))
+		}
+		a.modal = dialog.NewInspectorDialog(a.app.Inspector)
+	case commands.MessagesTimestampsCommand:
+		a.app.State.RelativeTimestamps = !a.app.State.RelativeTimestamps
+		a.app.SaveState()
+		cmds = append(cmds, util.CmdHandler(chat.ToggleTimestampsMsg{}))
+	case commands.AgentModeCycleCommand:
+		cmds = append(cmds, toast.NewInfoToast(i18n.T("toast.agent_mode", a.app.CycleMode())))
+	case commands.AgentModeListCommand:
+		a.modal = dialog.NewModeDialog(a.app.Mode())
+	case commands.CheckpointCreateCommand:
+		if a.app.Session.Id == "" {
+			return a, toast.NewInfoToast(i18n.T("toast.checkpoint_no_session"))
+		}
+		a.modal = dialog.NewPromptDialog(i18n.T("dialog.checkpoint_title"), i18n.T("dialog.checkpoint_placeholder"), func(name string) tea.Msg {
+			return app.CheckpointNamedMsg{Name: name}
+		})
+	case commands.CheckpointRestoreCommand:
+		checkpoints := a.app.Checkpoints()
+		if len(checkpoints) == 0 {
+			return a, toast.NewInfoToast(i18n.T("toast.no_checkpoints"))
+		}
+		a.modal = dialog.NewCheckpointDialog(checkpoints)
 	case commands.ToolDetailsCommand:
-		message := "Tool details are now visible"
+		message := i18n.T("toast.tool_details_visible")
 		if a.messages.ToolDetailsVisible() {
-			message = "Tool details are now hidden"
+			message = i18n.T("toast.tool_details_hidden")
 		}
 		cmds = append(cmds, util.CmdHandler(chat.ToggleToolDetailsMsg{}))
 		cmds = append(cmds, toast.NewInfoToast(message))
+	case commands.ConfirmationsResetCommand:
+		a.app.ResetConfirmations()
+		return a, toast.NewInfoToast(i18n.T("toast.confirmations_reset"))
+	case commands.ToolOutputMoreCommand:
+		if !a.messages.ShowMore() {
+			return a, toast.NewInfoToast(i18n.T("toast.no_output_more"))
+		}
+	case commands.ToolOutputExportCommand:
+		messageID, toolCallID, ok := a.messages.PagedOutput()
+		if !ok {
+			return a, toast.NewInfoToast(i18n.T("toast.no_output_save"))
+		}
+		path, err := a.app.ExportToolOutput(messageID, toolCallID)
+		if err != nil {
+			return a, toast.NewErrorToast(i18n.T("toast.output_save_failed", err.Error()))
+		}
+		return a, toast.NewSuccessToast(i18n.T("toast.output_saved", filepath.Base(path)))
 	case commands.ModelListCommand:
 		modelDialog := dialog.NewModelDialog(a.app)
 		a.modal = modelDialog
 	case commands.ThemeListCommand:
 		themeDialog := dialog.NewThemeDialog()
 		a.modal = themeDialog
+	case commands.ThemeDesignCommand:
+		a.modal = dialog.NewThemeDesignerDialog()
+	case commands.LayoutSaveCommand:
+		a.modal = dialog.NewPromptDialog(i18n.T("dialog.layout_title"), i18n.T("dialog.layout_placeholder"), func(name string) tea.Msg {
+			return app.SaveLayoutMsg{Name: name}
+		})
+	case commands.LayoutSwitchCommand:
+		a.modal = dialog.NewLayoutDialog(a.app.Layouts(), a.app.State.Layout)
+	case commands.ScreenReaderCommand:
+		a.app.ScreenReader = !a.app.ScreenReader
+		a.app.State.ScreenReader = a.app.ScreenReader
+		a.app.SaveState()
+		status := i18n.T("toast.screen_reader_off")
+		if a.app.ScreenReader {
+			status = i18n.T("toast.screen_reader_on")
+		}
+		return a, tea.Batch(
+			util.CmdHandler(chat.LayoutChangedMsg{}),
+			toast.NewInfoToast(status),
+		)
 	case commands.ProjectInitCommand:
 		cmds = append(cmds, a.app.InitializeProject(context.Background()))
 	case commands.InputClearCommand:
 		if a.editor.Value() == "" {
 			return a, nil
 		}
+		// a single line is quick to type again, longer drafts ask first
+		if a.editor.Lines() > 1 {
+			return a, a.app.Confirm(
+				app.ConfirmClearInput,
+				i18n.T("dialog.clear_input_title"),
+				i18n.N("dialog.clear_input", a.editor.Lines()),
+				app.ClearInputMsg{},
+			)
+		}
 		updated, cmd := a.editor.Clear()
 		a.editor = updated.(chat.EditorComponent)
 		cmds = append(cmds, cmd)
+	case commands.AttachmentPreviewCommand, commands.AttachmentRemoveCommand:
+		attachments := a.editor.Attachments()
+		remove := command.Name == commands.AttachmentRemoveCommand
+		switch {
+		case len(attachments) == 0:
+			return a, toast.NewInfoToast(i18n.T("toast.no_attachments"))
+		case len(attachments) == 1 && remove:
+			return a, util.CmdHandler(app.RemoveAttachmentMsg{Index: 0})
+		case len(attachments) == 1:
+			return a, util.CmdHandler(app.PreviewAttachmentMsg{Index: 0})
+		}
+		a.modal = dialog.NewAttachmentsDialog(attachments, remove)
 	case commands.InputPasteCommand:
 		updated, cmd := a.editor.Paste()
 		a.editor = updated.(chat.EditorComponent)
 		cmds = append(cmds, cmd)
 	case commands.InputSubmitCommand:
-		updated, cmd := a.editor.Submit()
+		return a.enter(!a.app.State.NewlineOnEnter)
+	case commands.InputSteerCommand:
+		if !a.app.IsBusy() {
+			return a.enter(a.app.State.NewlineOnEnter)
+		}
+		text := strings.TrimSpace(a.editor.Value())
+		if text == "" {
+			return a, nil
+		}
+		updated, cmd := a.editor.Clear()
 		a.editor = updated.(chat.EditorComponent)
-		cmds = append(cmds, cmd)
+		cmds = append(cmds, cmd, util.CmdHandler(app.SteerMsg{Text: text}))
 	case commands.InputNewlineCommand:
-		updated, cmd := a.editor.Newline()
-		a.editor = updated.(chat.EditorComponent)
-		cmds = append(cmds, cmd)
+		return a.enter(a.app.State.NewlineOnEnter)
+	case commands.InputEnterModeCommand:
+		a.app.State.NewlineOnEnter = !a.app.State.NewlineOnEnter
+		a.app.SaveState()
+		if a.app.State.NewlineOnEnter {
+			return a, toast.NewInfoToast(i18n.T("toast.enter_newline"))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.enter_sends"))
+	case commands.InputSpellCheckCommand:
+		a.app.State.SpellCheck = !a.app.State.SpellCheck
+		a.app.SaveState()
+		if a.app.State.SpellCheck {
+			return a, toast.NewInfoToast(i18n.T("toast.spell_check_on"))
+		}
+		return a, toast.NewInfoToast(i18n.T("toast.spell_check_off"))
+	case commands.InputCorrectCommand:
+		misspelling, ok := a.editor.Misspelling()
+		if !ok {
+			return a, toast.NewInfoToast(i18n.T("toast.no_misspelling"))
+		}
+		a.modal = dialog.NewSpellingDialog(misspelling, a.app.Dictionary().Suggest(misspelling.Word, 7))
 	case commands.HistoryPreviousCommand:
 		if a.showCompletionDialog {
 			return a, nil
@@ -612,6 +2136,7 @@ func (a appModel) executeCommand(command commands.Command) (tea.Model, tea.Cmd)
 		a.messages = updated.(chat.MessagesComponent)
 		cmds = append(cmds, cmd)
 	case commands.AppExitCommand:
+		a.editor.SaveDraft()
 		return a, tea.Quit
 	}
 	return a, tea.Batch(cmds...)
@@ -660,6 +2185,7 @@ func NewModel(app *app.App) tea.Model {
 		editorContainer:      editorContainer,
 		toastManager:         toast.NewToastManager(),
 		interruptKeyState:    InterruptKeyIdle,
+		sidebar:              sidebar.NewSidebarComponent(app),
 		layout: layout.NewFlexLayout(
 			[]tea.ViewModel{messagesContainer, editorContainer},
 			layout.WithDirection(layout.FlexDirectionVertical),
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -35,3 +36,21 @@ func IsWsl() bool {
 
 	return false
 }
+
+// FormatTokens formats a token count in human-readable form (e.g. 110K, 1.2M)
+func FormatTokens(tokens float32) string {
+	var formatted string
+	switch {
+	case tokens >= 1_000_000:
+		formatted = fmt.Sprintf("%.1fM", float64(tokens)/1_000_000)
+	case tokens >= 1_000:
+		formatted = fmt.Sprintf("%.1fK", float64(tokens)/1_000)
+	default:
+		formatted = fmt.Sprintf("%d", int(tokens))
+	}
+
+	// Remove .0 suffix if present
+	formatted = strings.Replace(formatted, ".0K", "K", 1)
+	formatted = strings.Replace(formatted, ".0M", "M", 1)
+	return formatted
+}
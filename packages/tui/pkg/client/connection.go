@@ -0,0 +1,215 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnauthorized is returned when the server rejects the bearer token
+var ErrUnauthorized = errors.New("unauthorized: check the server token")
+
+// Credentials hold the bearer token sent with every request, so it can be
+// replaced after the server rejects it
+type Credentials struct {
+	mu    sync.Mutex
+	token string
+	file  string
+}
+
+// NewCredentials creates credentials from a token, or from the token stored
+// in file when token is empty
+func NewCredentials(token string, file string) *Credentials {
+	c := &Credentials{token: token, file: file}
+	if token == "" {
+		c.Refresh()
+	}
+	return c
+}
+
+// Token returns the current bearer token
+func (c *Credentials) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// SetToken replaces the bearer token
+func (c *Credentials) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// Refresh re-reads the token file and reports whether the token changed
+func (c *Credentials) Refresh() bool {
+	if c.file == "" {
+		return false
+	}
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		return false
+	}
+	token := strings.TrimSpace(string(data))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token == "" || token == c.token {
+		return false
+	}
+	c.token = token
+	return true
+}
+
+// ConnectionOptions configures authentication and TLS for a remote server
+type ConnectionOptions struct {
+	// Credentials hold the token sent as a bearer token with every request
+	Credentials *Credentials
+	// CACert is a PEM file with additional certificate authorities to trust
+	CACert string
+	// Insecure disables TLS certificate verification
+	Insecure bool
+	// Proxy is the URL of the HTTP(S) proxy requests go through, when empty
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY apply
+	Proxy string
+	// OnUnauthorized is called whenever the server answers with a 401 or 403
+	OnUnauthorized func()
+	// Inspector, when set, records every request made by the client
+	Inspector *Inspector
+	// Logger, when set, logs every request at debug level
+	Logger *slog.Logger
+}
+
+// ClientOptions builds the client options for the connection
+func (o ConnectionOptions) ClientOptions() ([]ClientOption, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if o.CACert != "" || o.Insecure {
+		tlsConfig := &tls.Config{InsecureSkipVerify: o.Insecure}
+		if o.CACert != "" {
+			pem, err := os.ReadFile(o.CACert)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in %s", o.CACert)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if o.Proxy != "" {
+		proxy, err := url.Parse(o.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q, use a URL such as http://proxy:8080", o.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	var base http.RoundTripper = &tlsErrorTransport{base: transport}
+	if o.Inspector != nil {
+		base = &inspectorTransport{base: base, inspector: o.Inspector}
+	}
+	if o.Logger != nil {
+		base = &logTransport{base: base, logger: o.Logger}
+	}
+	opts := []ClientOption{
+		WithHTTPClient(&http.Client{
+			Transport: &authTransport{
+				base:           base,
+				credentials:    o.Credentials,
+				onUnauthorized: o.OnUnauthorized,
+			},
+		}),
+	}
+	return opts, nil
+}
+
+// tlsErrorTransport explains TLS failures, which behind a proxy inspecting
+// traffic usually mean its certificate authority is not trusted
+type tlsErrorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *tlsErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, explainTLSError(err)
+	}
+	return resp, nil
+}
+
+// explainTLSError adds what to do about certificate errors to err
+func explainTLSError(err error) error {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	var recordHeader tls.RecordHeaderError
+	switch {
+	case errors.As(err, &unknownAuthority):
+		return fmt.Errorf("%w: the server certificate is signed by an untrusted authority, behind a proxy inspecting TLS pass its CA certificate with --ca-cert or OPENCODE_CA_CERT", err)
+	case errors.As(err, &invalid):
+		return fmt.Errorf("%w: the server certificate is invalid or expired, check the system clock or pass --insecure to skip verification", err)
+	case errors.As(err, &hostname):
+		return fmt.Errorf("%w: the server certificate does not match the host name, check --server", err)
+	case errors.As(err, &recordHeader):
+		return fmt.Errorf("%w: the server does not speak TLS, use an http:// URL", err)
+	}
+	return err
+}
+
+// logTransport logs the requests made by the client
+type logTransport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+	response, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.Warn("Request failed", "method", req.Method, "path", req.URL.Path, "error", err, "duration", time.Since(started))
+		return response, err
+	}
+	t.logger.Debug("Request", "method", req.Method, "path", req.URL.Path, "status", response.StatusCode, "duration", time.Since(started))
+	return response, nil
+}
+
+// authTransport adds the bearer token to requests and turns rejections into
+// ErrUnauthorized, so every caller can tell them apart from other failures
+type authTransport struct {
+	base           http.RoundTripper
+	credentials    *Credentials
+	onUnauthorized func()
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.credentials != nil {
+		if token := t.credentials.Token(); token != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		resp.Body.Close()
+		if t.onUnauthorized != nil {
+			t.onUnauthorized()
+		}
+		return nil, ErrUnauthorized
+	}
+	return resp, nil
+}
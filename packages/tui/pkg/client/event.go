@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 )
@@ -15,10 +16,20 @@ func (c *Client) Event(ctx context.Context) (<-chan any, error) {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	for _, editor := range c.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to subscribe to events: %d", resp.StatusCode)
+	}
 
 	go func() {
 		defer close(events)
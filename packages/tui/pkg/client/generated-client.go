@@ -23,6 +23,39 @@ const (
 	User      MessageInfoRole = "user"
 )
 
+// Defines values for MessageMetadataFeedback.
+const (
+	MessageMetadataFeedbackDown MessageMetadataFeedback = "down"
+	MessageMetadataFeedbackUp   MessageMetadataFeedback = "up"
+)
+
+// Defines values for PostPermissionRespondJSONBodyResponse.
+const (
+	Always PostPermissionRespondJSONBodyResponse = "always"
+	Once   PostPermissionRespondJSONBodyResponse = "once"
+	Reject PostPermissionRespondJSONBodyResponse = "reject"
+)
+
+// Defines values for PostSessionChatJSONBodyMode.
+const (
+	Ask   PostSessionChatJSONBodyMode = "ask"
+	Build PostSessionChatJSONBodyMode = "build"
+	Plan  PostSessionChatJSONBodyMode = "plan"
+)
+
+// Defines values for PostSessionChatJSONBodyReasoningEffort.
+const (
+	High   PostSessionChatJSONBodyReasoningEffort = "high"
+	Low    PostSessionChatJSONBodyReasoningEffort = "low"
+	Medium PostSessionChatJSONBodyReasoningEffort = "medium"
+)
+
+// Defines values for PostSessionFeedbackJSONBodyFeedback.
+const (
+	PostSessionFeedbackJSONBodyFeedbackDown PostSessionFeedbackJSONBodyFeedback = "down"
+	PostSessionFeedbackJSONBodyFeedbackUp   PostSessionFeedbackJSONBodyFeedback = "up"
+)
+
 // AppInfo defines model for App.Info.
 type AppInfo struct {
 	Git      bool   `json:"git"`
@@ -46,6 +79,9 @@ type ConfigInfo struct {
 	// Schema JSON schema reference for configuration validation
 	Schema *string `json:"$schema,omitempty"`
 
+	// Aliases Slash command aliases, such as c for compact, arguments typed after an alias are appended to its expansion
+	Aliases *map[string]string `json:"aliases,omitempty"`
+
 	// Autoshare Share newly created sessions automatically
 	Autoshare *bool `json:"autoshare,omitempty"`
 
@@ -345,8 +381,9 @@ type MessageMetadata struct {
 			Reasoning float32 `json:"reasoning"`
 		} `json:"tokens"`
 	} `json:"assistant,omitempty"`
-	Error     *MessageMetadata_Error `json:"error,omitempty"`
-	SessionID string                 `json:"sessionID"`
+	Error     *MessageMetadata_Error   `json:"error,omitempty"`
+	Feedback  *MessageMetadataFeedback `json:"feedback,omitempty"`
+	SessionID string                   `json:"sessionID"`
 	Time      struct {
 		Completed *float32 `json:"completed,omitempty"`
 		Created   float32  `json:"created"`
@@ -359,6 +396,9 @@ type MessageMetadata_Error struct {
 	union json.RawMessage
 }
 
+// MessageMetadataFeedback defines model for MessageMetadata.Feedback.
+type MessageMetadataFeedback string
+
 // MessageMetadata_Tool_AdditionalProperties defines model for Message.Metadata.tool.AdditionalProperties.
 type MessageMetadata_Tool_AdditionalProperties struct {
 	Time struct {
@@ -502,6 +542,29 @@ type UnknownError struct {
 	Name string `json:"name"`
 }
 
+// AuditEntry defines model for audit.entry.
+type AuditEntry struct {
+	Args      *interface{} `json:"args,omitempty"`
+	Error     *string      `json:"error,omitempty"`
+	Exit      *int         `json:"exit,omitempty"`
+	Files     []AuditFile  `json:"files"`
+	MessageID string       `json:"messageID"`
+	SessionID string       `json:"sessionID"`
+	Time      struct {
+		End   int `json:"end"`
+		Start int `json:"start"`
+	} `json:"time"`
+	Tool       string `json:"tool"`
+	ToolCallID string `json:"toolCallID"`
+}
+
+// AuditFile defines model for audit.file.
+type AuditFile struct {
+	After  *string `json:"after,omitempty"`
+	Before *string `json:"before,omitempty"`
+	Path   string  `json:"path"`
+}
+
 // PermissionInfo defines model for permission.info.
 type PermissionInfo struct {
 	Id        string                 `json:"id"`
@@ -528,34 +591,121 @@ type SessionInfo struct {
 	Version string `json:"version"`
 }
 
+// PutAppContextJSONBody defines parameters for PutAppContext.
+type PutAppContextJSONBody struct {
+	// Content The file is removed when left out
+	Content *string `json:"content,omitempty"`
+}
+
 // PostFileSearchJSONBody defines parameters for PostFileSearch.
 type PostFileSearchJSONBody struct {
 	Query string `json:"query"`
+
+	// Root The directory to search instead of the working directory
+	Root *string `json:"root,omitempty"`
+}
+
+// PostPermissionRespondJSONBody defines parameters for PostPermissionRespond.
+type PostPermissionRespondJSONBody struct {
+	PermissionID string                                `json:"permissionID"`
+	Response     PostPermissionRespondJSONBodyResponse `json:"response"`
+	SessionID    string                                `json:"sessionID"`
+}
+
+// PostPermissionRespondJSONBodyResponse defines parameters for PostPermissionRespond.
+type PostPermissionRespondJSONBodyResponse string
+
+// PostProviderAuthJSONBody defines parameters for PostProviderAuth.
+type PostProviderAuthJSONBody struct {
+	Key        string `json:"key"`
+	ProviderID string `json:"providerID"`
 }
 
 // PostSessionAbortJSONBody defines parameters for PostSessionAbort.
 type PostSessionAbortJSONBody struct {
 	SessionID string `json:"sessionID"`
+
+	// ToolCallID Abort only this tool call, the turn continues
+	ToolCallID *string `json:"toolCallID,omitempty"`
+}
+
+// PostSessionAuditJSONBody defines parameters for PostSessionAudit.
+type PostSessionAuditJSONBody struct {
+	SessionID string `json:"sessionID"`
+}
+
+// PostSessionChangesJSONBody defines parameters for PostSessionChanges.
+type PostSessionChangesJSONBody struct {
+	// MessageID Changes of this message and later ones, the latest message with changes when left out
+	MessageID *string `json:"messageID,omitempty"`
+	SessionID string  `json:"sessionID"`
 }
 
 // PostSessionChatJSONBody defines parameters for PostSessionChat.
 type PostSessionChatJSONBody struct {
-	ModelID    string        `json:"modelID"`
-	Parts      []MessagePart `json:"parts"`
-	ProviderID string        `json:"providerID"`
-	SessionID  string        `json:"sessionID"`
+	MaxTokens *int `json:"maxTokens,omitempty"`
+
+	// Mode The agent mode, plan and ask leave out the tools that change files
+	Mode            *PostSessionChatJSONBodyMode            `json:"mode,omitempty"`
+	ModelID         string                                  `json:"modelID"`
+	Parts           []MessagePart                           `json:"parts"`
+	ProviderID      string                                  `json:"providerID"`
+	ReasoningEffort *PostSessionChatJSONBodyReasoningEffort `json:"reasoningEffort,omitempty"`
+
+	// Root The workspace root the user is working in
+	Root      *string `json:"root,omitempty"`
+	SessionID string  `json:"sessionID"`
+
+	// System Replaces the default system prompt of the provider
+	System      *string  `json:"system,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	TopP        *float32 `json:"topP,omitempty"`
 }
 
+// PostSessionChatJSONBodyMode defines parameters for PostSessionChat.
+type PostSessionChatJSONBodyMode string
+
+// PostSessionChatJSONBodyReasoningEffort defines parameters for PostSessionChat.
+type PostSessionChatJSONBodyReasoningEffort string
+
 // PostSessionDeleteJSONBody defines parameters for PostSessionDelete.
 type PostSessionDeleteJSONBody struct {
 	SessionID string `json:"sessionID"`
 }
 
+// PostSessionFeedbackJSONBody defines parameters for PostSessionFeedback.
+type PostSessionFeedbackJSONBody struct {
+	Feedback  *PostSessionFeedbackJSONBodyFeedback `json:"feedback,omitempty"`
+	MessageID string                               `json:"messageID"`
+	SessionID string                               `json:"sessionID"`
+}
+
+// PostSessionFeedbackJSONBodyFeedback defines parameters for PostSessionFeedback.
+type PostSessionFeedbackJSONBodyFeedback string
+
+// PostSessionForkJSONBody defines parameters for PostSessionFork.
+type PostSessionForkJSONBody struct {
+	MessageID string `json:"messageID"`
+	SessionID string `json:"sessionID"`
+}
+
 // PostSessionInitializeJSONBody defines parameters for PostSessionInitialize.
 type PostSessionInitializeJSONBody struct {
 	ModelID    string `json:"modelID"`
 	ProviderID string `json:"providerID"`
+
+	// Review Leave the app uninitialized until the client approves the file with /app_initialize
+	Review    *bool  `json:"review,omitempty"`
+	SessionID string `json:"sessionID"`
+}
+
+// PostSessionMergeJSONBody defines parameters for PostSessionMerge.
+type PostSessionMergeJSONBody struct {
+	ModelID    string `json:"modelID"`
+	ProviderID string `json:"providerID"`
 	SessionID  string `json:"sessionID"`
+	SourceID   string `json:"sourceID"`
+	Summarize  *bool  `json:"summarize,omitempty"`
 }
 
 // PostSessionMessagesJSONBody defines parameters for PostSessionMessages.
@@ -563,6 +713,13 @@ type PostSessionMessagesJSONBody struct {
 	SessionID string `json:"sessionID"`
 }
 
+// PostSessionRevertJSONBody defines parameters for PostSessionRevert.
+type PostSessionRevertJSONBody struct {
+	// MessageID The last message kept, every message is dropped when left out
+	MessageID *string `json:"messageID,omitempty"`
+	SessionID string  `json:"sessionID"`
+}
+
 // PostSessionShareJSONBody defines parameters for PostSessionShare.
 type PostSessionShareJSONBody struct {
 	SessionID string `json:"sessionID"`
@@ -575,35 +732,90 @@ type PostSessionSummarizeJSONBody struct {
 	SessionID  string `json:"sessionID"`
 }
 
+// PostSessionTrimJSONBody defines parameters for PostSessionTrim.
+type PostSessionTrimJSONBody struct {
+	// Collapse Ranges of messages replaced by a summary
+	Collapse []struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"collapse"`
+
+	// Exclude Messages left out
+	Exclude    []string `json:"exclude"`
+	ModelID    string   `json:"modelID"`
+	ProviderID string   `json:"providerID"`
+	SessionID  string   `json:"sessionID"`
+}
+
+// PostSessionUndoJSONBody defines parameters for PostSessionUndo.
+type PostSessionUndoJSONBody struct {
+	// MessageID Undo the changes of this message and later ones, the latest message with changes when left out
+	MessageID *string `json:"messageID,omitempty"`
+	SessionID string  `json:"sessionID"`
+}
+
 // PostSessionUnshareJSONBody defines parameters for PostSessionUnshare.
 type PostSessionUnshareJSONBody struct {
 	SessionID string `json:"sessionID"`
 }
 
+// PutAppContextJSONRequestBody defines body for PutAppContext for application/json ContentType.
+type PutAppContextJSONRequestBody PutAppContextJSONBody
+
 // PostFileSearchJSONRequestBody defines body for PostFileSearch for application/json ContentType.
 type PostFileSearchJSONRequestBody PostFileSearchJSONBody
 
+// PostPermissionRespondJSONRequestBody defines body for PostPermissionRespond for application/json ContentType.
+type PostPermissionRespondJSONRequestBody PostPermissionRespondJSONBody
+
+// PostProviderAuthJSONRequestBody defines body for PostProviderAuth for application/json ContentType.
+type PostProviderAuthJSONRequestBody PostProviderAuthJSONBody
+
 // PostSessionAbortJSONRequestBody defines body for PostSessionAbort for application/json ContentType.
 type PostSessionAbortJSONRequestBody PostSessionAbortJSONBody
 
+// PostSessionAuditJSONRequestBody defines body for PostSessionAudit for application/json ContentType.
+type PostSessionAuditJSONRequestBody PostSessionAuditJSONBody
+
+// PostSessionChangesJSONRequestBody defines body for PostSessionChanges for application/json ContentType.
+type PostSessionChangesJSONRequestBody PostSessionChangesJSONBody
+
 // PostSessionChatJSONRequestBody defines body for PostSessionChat for application/json ContentType.
 type PostSessionChatJSONRequestBody PostSessionChatJSONBody
 
 // PostSessionDeleteJSONRequestBody defines body for PostSessionDelete for application/json ContentType.
 type PostSessionDeleteJSONRequestBody PostSessionDeleteJSONBody
 
+// PostSessionFeedbackJSONRequestBody defines body for PostSessionFeedback for application/json ContentType.
+type PostSessionFeedbackJSONRequestBody PostSessionFeedbackJSONBody
+
+// PostSessionForkJSONRequestBody defines body for PostSessionFork for application/json ContentType.
+type PostSessionForkJSONRequestBody PostSessionForkJSONBody
+
 // PostSessionInitializeJSONRequestBody defines body for PostSessionInitialize for application/json ContentType.
 type PostSessionInitializeJSONRequestBody PostSessionInitializeJSONBody
 
+// PostSessionMergeJSONRequestBody defines body for PostSessionMerge for application/json ContentType.
+type PostSessionMergeJSONRequestBody PostSessionMergeJSONBody
+
 // PostSessionMessagesJSONRequestBody defines body for PostSessionMessages for application/json ContentType.
 type PostSessionMessagesJSONRequestBody PostSessionMessagesJSONBody
 
+// PostSessionRevertJSONRequestBody defines body for PostSessionRevert for application/json ContentType.
+type PostSessionRevertJSONRequestBody PostSessionRevertJSONBody
+
 // PostSessionShareJSONRequestBody defines body for PostSessionShare for application/json ContentType.
 type PostSessionShareJSONRequestBody PostSessionShareJSONBody
 
 // PostSessionSummarizeJSONRequestBody defines body for PostSessionSummarize for application/json ContentType.
 type PostSessionSummarizeJSONRequestBody PostSessionSummarizeJSONBody
 
+// PostSessionTrimJSONRequestBody defines body for PostSessionTrim for application/json ContentType.
+type PostSessionTrimJSONRequestBody PostSessionTrimJSONBody
+
+// PostSessionUndoJSONRequestBody defines body for PostSessionUndo for application/json ContentType.
+type PostSessionUndoJSONRequestBody PostSessionUndoJSONBody
+
 // PostSessionUnshareJSONRequestBody defines body for PostSessionUnshare for application/json ContentType.
 type PostSessionUnshareJSONRequestBody PostSessionUnshareJSONBody
 
@@ -1713,6 +1925,14 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetAppContext request
+	GetAppContext(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAppContextWithBody request with any body
+	PutAppContextWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAppContext(ctx context.Context, body PutAppContextJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostAppInfo request
 	PostAppInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -1736,6 +1956,16 @@ type ClientInterface interface {
 	// PostPathGet request
 	PostPathGet(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostPermissionRespondWithBody request with any body
+	PostPermissionRespondWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostPermissionRespond(ctx context.Context, body PostPermissionRespondJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostProviderAuthWithBody request with any body
+	PostProviderAuthWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostProviderAuth(ctx context.Context, body PostProviderAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostProviderList request
 	PostProviderList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -1744,6 +1974,16 @@ type ClientInterface interface {
 
 	PostSessionAbort(ctx context.Context, body PostSessionAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSessionAuditWithBody request with any body
+	PostSessionAuditWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionAudit(ctx context.Context, body PostSessionAuditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSessionChangesWithBody request with any body
+	PostSessionChangesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionChanges(ctx context.Context, body PostSessionChangesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostSessionChatWithBody request with any body
 	PostSessionChatWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -1757,6 +1997,16 @@ type ClientInterface interface {
 
 	PostSessionDelete(ctx context.Context, body PostSessionDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSessionFeedbackWithBody request with any body
+	PostSessionFeedbackWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionFeedback(ctx context.Context, body PostSessionFeedbackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSessionForkWithBody request with any body
+	PostSessionForkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionFork(ctx context.Context, body PostSessionForkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostSessionInitializeWithBody request with any body
 	PostSessionInitializeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -1765,11 +2015,21 @@ type ClientInterface interface {
 	// PostSessionList request
 	PostSessionList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSessionMergeWithBody request with any body
+	PostSessionMergeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionMerge(ctx context.Context, body PostSessionMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostSessionMessagesWithBody request with any body
 	PostSessionMessagesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostSessionMessages(ctx context.Context, body PostSessionMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSessionRevertWithBody request with any body
+	PostSessionRevertWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionRevert(ctx context.Context, body PostSessionRevertJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostSessionShareWithBody request with any body
 	PostSessionShareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -1780,12 +2040,58 @@ type ClientInterface interface {
 
 	PostSessionSummarize(ctx context.Context, body PostSessionSummarizeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostSessionTrimWithBody request with any body
+	PostSessionTrimWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionTrim(ctx context.Context, body PostSessionTrimJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostSessionUndoWithBody request with any body
+	PostSessionUndoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostSessionUndo(ctx context.Context, body PostSessionUndoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostSessionUnshareWithBody request with any body
 	PostSessionUnshareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PostSessionUnshare(ctx context.Context, body PostSessionUnshareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) GetAppContext(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAppContextRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAppContextWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAppContextRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAppContext(ctx context.Context, body PutAppContextJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAppContextRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostAppInfo(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostAppInfoRequest(c.Server)
 	if err != nil {
@@ -1882,8 +2188,8 @@ func (c *Client) PostPathGet(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostProviderList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostProviderListRequest(c.Server)
+func (c *Client) PostPermissionRespondWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPermissionRespondRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1894,8 +2200,8 @@ func (c *Client) PostProviderList(ctx context.Context, reqEditors ...RequestEdit
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionAbortWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionAbortRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostPermissionRespond(ctx context.Context, body PostPermissionRespondJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostPermissionRespondRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1906,8 +2212,8 @@ func (c *Client) PostSessionAbortWithBody(ctx context.Context, contentType strin
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionAbort(ctx context.Context, body PostSessionAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionAbortRequest(c.Server, body)
+func (c *Client) PostProviderAuthWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProviderAuthRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1918,8 +2224,8 @@ func (c *Client) PostSessionAbort(ctx context.Context, body PostSessionAbortJSON
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionChatWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionChatRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostProviderAuth(ctx context.Context, body PostProviderAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProviderAuthRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1930,8 +2236,8 @@ func (c *Client) PostSessionChatWithBody(ctx context.Context, contentType string
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionChat(ctx context.Context, body PostSessionChatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionChatRequest(c.Server, body)
+func (c *Client) PostProviderList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostProviderListRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -1942,8 +2248,8 @@ func (c *Client) PostSessionChat(ctx context.Context, body PostSessionChatJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionCreate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionCreateRequest(c.Server)
+func (c *Client) PostSessionAbortWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionAbortRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1954,8 +2260,8 @@ func (c *Client) PostSessionCreate(ctx context.Context, reqEditors ...RequestEdi
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionDeleteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionDeleteRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostSessionAbort(ctx context.Context, body PostSessionAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionAbortRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1966,8 +2272,8 @@ func (c *Client) PostSessionDeleteWithBody(ctx context.Context, contentType stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionDelete(ctx context.Context, body PostSessionDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionDeleteRequest(c.Server, body)
+func (c *Client) PostSessionAuditWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionAuditRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1978,8 +2284,8 @@ func (c *Client) PostSessionDelete(ctx context.Context, body PostSessionDeleteJS
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionInitializeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionInitializeRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostSessionAudit(ctx context.Context, body PostSessionAuditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionAuditRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1990,8 +2296,8 @@ func (c *Client) PostSessionInitializeWithBody(ctx context.Context, contentType
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionInitialize(ctx context.Context, body PostSessionInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionInitializeRequest(c.Server, body)
+func (c *Client) PostSessionChangesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionChangesRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2002,8 +2308,8 @@ func (c *Client) PostSessionInitialize(ctx context.Context, body PostSessionInit
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionListRequest(c.Server)
+func (c *Client) PostSessionChanges(ctx context.Context, body PostSessionChangesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionChangesRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2014,8 +2320,8 @@ func (c *Client) PostSessionList(ctx context.Context, reqEditors ...RequestEdito
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionMessagesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionMessagesRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostSessionChatWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionChatRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2026,8 +2332,8 @@ func (c *Client) PostSessionMessagesWithBody(ctx context.Context, contentType st
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionMessages(ctx context.Context, body PostSessionMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionMessagesRequest(c.Server, body)
+func (c *Client) PostSessionChat(ctx context.Context, body PostSessionChatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionChatRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2038,8 +2344,8 @@ func (c *Client) PostSessionMessages(ctx context.Context, body PostSessionMessag
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionShareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionShareRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostSessionCreate(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionCreateRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -2050,8 +2356,8 @@ func (c *Client) PostSessionShareWithBody(ctx context.Context, contentType strin
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionShare(ctx context.Context, body PostSessionShareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionShareRequest(c.Server, body)
+func (c *Client) PostSessionDeleteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionDeleteRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2062,8 +2368,8 @@ func (c *Client) PostSessionShare(ctx context.Context, body PostSessionShareJSON
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionSummarizeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionSummarizeRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostSessionDelete(ctx context.Context, body PostSessionDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionDeleteRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2074,8 +2380,8 @@ func (c *Client) PostSessionSummarizeWithBody(ctx context.Context, contentType s
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionSummarize(ctx context.Context, body PostSessionSummarizeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionSummarizeRequest(c.Server, body)
+func (c *Client) PostSessionFeedbackWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionFeedbackRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2086,8 +2392,8 @@ func (c *Client) PostSessionSummarize(ctx context.Context, body PostSessionSumma
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionUnshareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionUnshareRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostSessionFeedback(ctx context.Context, body PostSessionFeedbackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionFeedbackRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2098,8 +2404,8 @@ func (c *Client) PostSessionUnshareWithBody(ctx context.Context, contentType str
 	return c.Client.Do(req)
 }
 
-func (c *Client) PostSessionUnshare(ctx context.Context, body PostSessionUnshareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPostSessionUnshareRequest(c.Server, body)
+func (c *Client) PostSessionForkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionForkRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -2110,89 +2416,248 @@ func (c *Client) PostSessionUnshare(ctx context.Context, body PostSessionUnshare
 	return c.Client.Do(req)
 }
 
-// NewPostAppInfoRequest generates requests for PostAppInfo
-func NewPostAppInfoRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostSessionFork(ctx context.Context, body PostSessionForkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionForkRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/app_info")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostSessionInitializeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionInitializeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPostAppInitializeRequest generates requests for PostAppInitialize
-func NewPostAppInitializeRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostSessionInitialize(ctx context.Context, body PostSessionInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionInitializeRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/app_initialize")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostSessionList(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionListRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewPostConfigGetRequest generates requests for PostConfigGet
-func NewPostConfigGetRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) PostSessionMergeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionMergeRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/config_get")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostSessionMerge(ctx context.Context, body PostSessionMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionMergeRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetEventRequest generates requests for GetEvent
-func NewGetEventRequest(server string) (*http.Request, error) {
+func (c *Client) PostSessionMessagesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionMessagesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionMessages(ctx context.Context, body PostSessionMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionMessagesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionRevertWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionRevertRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionRevert(ctx context.Context, body PostSessionRevertJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionRevertRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionShareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionShareRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionShare(ctx context.Context, body PostSessionShareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionShareRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionSummarizeWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionSummarizeRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionSummarize(ctx context.Context, body PostSessionSummarizeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionSummarizeRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionTrimWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionTrimRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionTrim(ctx context.Context, body PostSessionTrimJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionTrimRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionUndoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionUndoRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionUndo(ctx context.Context, body PostSessionUndoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionUndoRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionUnshareWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionUnshareRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostSessionUnshare(ctx context.Context, body PostSessionUnshareJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostSessionUnshareRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAppContextRequest generates requests for GetAppContext
+func NewGetAppContextRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2200,7 +2665,7 @@ func NewGetEventRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/event")
+	operationPath := fmt.Sprintf("/app_context")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2218,19 +2683,19 @@ func NewGetEventRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostFileSearchRequest calls the generic PostFileSearch builder with application/json body
-func NewPostFileSearchRequest(server string, body PostFileSearchJSONRequestBody) (*http.Request, error) {
+// NewPutAppContextRequest calls the generic PutAppContext builder with application/json body
+func NewPutAppContextRequest(server string, body PutAppContextJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostFileSearchRequestWithBody(server, "application/json", bodyReader)
+	return NewPutAppContextRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostFileSearchRequestWithBody generates requests for PostFileSearch with any type of body
-func NewPostFileSearchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPutAppContextRequestWithBody generates requests for PutAppContext with any type of body
+func NewPutAppContextRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2238,7 +2703,7 @@ func NewPostFileSearchRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/file_search")
+	operationPath := fmt.Sprintf("/app_context")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2248,7 +2713,7 @@ func NewPostFileSearchRequestWithBody(server string, contentType string, body io
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -2258,8 +2723,8 @@ func NewPostFileSearchRequestWithBody(server string, contentType string, body io
 	return req, nil
 }
 
-// NewPostInstallationInfoRequest generates requests for PostInstallationInfo
-func NewPostInstallationInfoRequest(server string) (*http.Request, error) {
+// NewPostAppInfoRequest generates requests for PostAppInfo
+func NewPostAppInfoRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2267,7 +2732,7 @@ func NewPostInstallationInfoRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/installation_info")
+	operationPath := fmt.Sprintf("/app_info")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2285,8 +2750,8 @@ func NewPostInstallationInfoRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostPathGetRequest generates requests for PostPathGet
-func NewPostPathGetRequest(server string) (*http.Request, error) {
+// NewPostAppInitializeRequest generates requests for PostAppInitialize
+func NewPostAppInitializeRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2294,7 +2759,7 @@ func NewPostPathGetRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/path_get")
+	operationPath := fmt.Sprintf("/app_initialize")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2312,8 +2777,8 @@ func NewPostPathGetRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostProviderListRequest generates requests for PostProviderList
-func NewPostProviderListRequest(server string) (*http.Request, error) {
+// NewPostConfigGetRequest generates requests for PostConfigGet
+func NewPostConfigGetRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2321,7 +2786,7 @@ func NewPostProviderListRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/provider_list")
+	operationPath := fmt.Sprintf("/config_get")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2339,19 +2804,8 @@ func NewPostProviderListRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostSessionAbortRequest calls the generic PostSessionAbort builder with application/json body
-func NewPostSessionAbortRequest(server string, body PostSessionAbortJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionAbortRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewPostSessionAbortRequestWithBody generates requests for PostSessionAbort with any type of body
-func NewPostSessionAbortRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewGetEventRequest generates requests for GetEvent
+func NewGetEventRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2359,7 +2813,7 @@ func NewPostSessionAbortRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_abort")
+	operationPath := fmt.Sprintf("/event")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2369,29 +2823,27 @@ func NewPostSessionAbortRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewPostSessionChatRequest calls the generic PostSessionChat builder with application/json body
-func NewPostSessionChatRequest(server string, body PostSessionChatJSONRequestBody) (*http.Request, error) {
+// NewPostFileSearchRequest calls the generic PostFileSearch builder with application/json body
+func NewPostFileSearchRequest(server string, body PostFileSearchJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionChatRequestWithBody(server, "application/json", bodyReader)
+	return NewPostFileSearchRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostSessionChatRequestWithBody generates requests for PostSessionChat with any type of body
-func NewPostSessionChatRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostFileSearchRequestWithBody generates requests for PostFileSearch with any type of body
+func NewPostFileSearchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2399,7 +2851,7 @@ func NewPostSessionChatRequestWithBody(server string, contentType string, body i
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_chat")
+	operationPath := fmt.Sprintf("/file_search")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2419,8 +2871,8 @@ func NewPostSessionChatRequestWithBody(server string, contentType string, body i
 	return req, nil
 }
 
-// NewPostSessionCreateRequest generates requests for PostSessionCreate
-func NewPostSessionCreateRequest(server string) (*http.Request, error) {
+// NewPostInstallationInfoRequest generates requests for PostInstallationInfo
+func NewPostInstallationInfoRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2428,7 +2880,7 @@ func NewPostSessionCreateRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_create")
+	operationPath := fmt.Sprintf("/installation_info")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2446,19 +2898,8 @@ func NewPostSessionCreateRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostSessionDeleteRequest calls the generic PostSessionDelete builder with application/json body
-func NewPostSessionDeleteRequest(server string, body PostSessionDeleteJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionDeleteRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewPostSessionDeleteRequestWithBody generates requests for PostSessionDelete with any type of body
-func NewPostSessionDeleteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostPathGetRequest generates requests for PostPathGet
+func NewPostPathGetRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2466,7 +2907,7 @@ func NewPostSessionDeleteRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_delete")
+	operationPath := fmt.Sprintf("/path_get")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2476,29 +2917,27 @@ func NewPostSessionDeleteRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewPostSessionInitializeRequest calls the generic PostSessionInitialize builder with application/json body
-func NewPostSessionInitializeRequest(server string, body PostSessionInitializeJSONRequestBody) (*http.Request, error) {
+// NewPostPermissionRespondRequest calls the generic PostPermissionRespond builder with application/json body
+func NewPostPermissionRespondRequest(server string, body PostPermissionRespondJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionInitializeRequestWithBody(server, "application/json", bodyReader)
+	return NewPostPermissionRespondRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostSessionInitializeRequestWithBody generates requests for PostSessionInitialize with any type of body
-func NewPostSessionInitializeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostPermissionRespondRequestWithBody generates requests for PostPermissionRespond with any type of body
+func NewPostPermissionRespondRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2506,7 +2945,7 @@ func NewPostSessionInitializeRequestWithBody(server string, contentType string,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_initialize")
+	operationPath := fmt.Sprintf("/permission_respond")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2526,16 +2965,56 @@ func NewPostSessionInitializeRequestWithBody(server string, contentType string,
 	return req, nil
 }
 
-// NewPostSessionListRequest generates requests for PostSessionList
-func NewPostSessionListRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
+// NewPostProviderAuthRequest calls the generic PostProviderAuth builder with application/json body
+func NewPostProviderAuthRequest(server string, body PostProviderAuthJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
 		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostProviderAuthRequestWithBody(server, "application/json", bodyReader)
+}
 
-	operationPath := fmt.Sprintf("/session_list")
+// NewPostProviderAuthRequestWithBody generates requests for PostProviderAuth with any type of body
+func NewPostProviderAuthRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/provider_auth")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostProviderListRequest generates requests for PostProviderList
+func NewPostProviderListRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/provider_list")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2553,19 +3032,19 @@ func NewPostSessionListRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewPostSessionMessagesRequest calls the generic PostSessionMessages builder with application/json body
-func NewPostSessionMessagesRequest(server string, body PostSessionMessagesJSONRequestBody) (*http.Request, error) {
+// NewPostSessionAbortRequest calls the generic PostSessionAbort builder with application/json body
+func NewPostSessionAbortRequest(server string, body PostSessionAbortJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionMessagesRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSessionAbortRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostSessionMessagesRequestWithBody generates requests for PostSessionMessages with any type of body
-func NewPostSessionMessagesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSessionAbortRequestWithBody generates requests for PostSessionAbort with any type of body
+func NewPostSessionAbortRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2573,7 +3052,7 @@ func NewPostSessionMessagesRequestWithBody(server string, contentType string, bo
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_messages")
+	operationPath := fmt.Sprintf("/session_abort")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2593,19 +3072,19 @@ func NewPostSessionMessagesRequestWithBody(server string, contentType string, bo
 	return req, nil
 }
 
-// NewPostSessionShareRequest calls the generic PostSessionShare builder with application/json body
-func NewPostSessionShareRequest(server string, body PostSessionShareJSONRequestBody) (*http.Request, error) {
+// NewPostSessionAuditRequest calls the generic PostSessionAudit builder with application/json body
+func NewPostSessionAuditRequest(server string, body PostSessionAuditJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionShareRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSessionAuditRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostSessionShareRequestWithBody generates requests for PostSessionShare with any type of body
-func NewPostSessionShareRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSessionAuditRequestWithBody generates requests for PostSessionAudit with any type of body
+func NewPostSessionAuditRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2613,7 +3092,7 @@ func NewPostSessionShareRequestWithBody(server string, contentType string, body
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_share")
+	operationPath := fmt.Sprintf("/session_audit")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2633,19 +3112,19 @@ func NewPostSessionShareRequestWithBody(server string, contentType string, body
 	return req, nil
 }
 
-// NewPostSessionSummarizeRequest calls the generic PostSessionSummarize builder with application/json body
-func NewPostSessionSummarizeRequest(server string, body PostSessionSummarizeJSONRequestBody) (*http.Request, error) {
+// NewPostSessionChangesRequest calls the generic PostSessionChanges builder with application/json body
+func NewPostSessionChangesRequest(server string, body PostSessionChangesJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionSummarizeRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSessionChangesRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostSessionSummarizeRequestWithBody generates requests for PostSessionSummarize with any type of body
-func NewPostSessionSummarizeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSessionChangesRequestWithBody generates requests for PostSessionChanges with any type of body
+func NewPostSessionChangesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2653,7 +3132,7 @@ func NewPostSessionSummarizeRequestWithBody(server string, contentType string, b
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_summarize")
+	operationPath := fmt.Sprintf("/session_changes")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2673,19 +3152,19 @@ func NewPostSessionSummarizeRequestWithBody(server string, contentType string, b
 	return req, nil
 }
 
-// NewPostSessionUnshareRequest calls the generic PostSessionUnshare builder with application/json body
-func NewPostSessionUnshareRequest(server string, body PostSessionUnshareJSONRequestBody) (*http.Request, error) {
+// NewPostSessionChatRequest calls the generic PostSessionChat builder with application/json body
+func NewPostSessionChatRequest(server string, body PostSessionChatJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostSessionUnshareRequestWithBody(server, "application/json", bodyReader)
+	return NewPostSessionChatRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostSessionUnshareRequestWithBody generates requests for PostSessionUnshare with any type of body
-func NewPostSessionUnshareRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostSessionChatRequestWithBody generates requests for PostSessionChat with any type of body
+func NewPostSessionChatRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2693,7 +3172,7 @@ func NewPostSessionUnshareRequestWithBody(server string, contentType string, bod
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/session_unshare")
+	operationPath := fmt.Sprintf("/session_chat")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -2713,122 +3192,761 @@ func NewPostSessionUnshareRequestWithBody(server string, contentType string, bod
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewPostSessionCreateRequest generates requests for PostSessionCreate
+func NewPostSessionCreateRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+
+	operationPath := fmt.Sprintf("/session_create")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+
+	return req, nil
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+// NewPostSessionDeleteRequest calls the generic PostSessionDelete builder with application/json body
+func NewPostSessionDeleteRequest(server string, body PostSessionDeleteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionDeleteRequestWithBody(server, "application/json", bodyReader)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostAppInfoWithResponse request
-	PostAppInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAppInfoResponse, error)
-
-	// PostAppInitializeWithResponse request
-	PostAppInitializeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAppInitializeResponse, error)
-
-	// PostConfigGetWithResponse request
-	PostConfigGetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostConfigGetResponse, error)
-
-	// GetEventWithResponse request
-	GetEventWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetEventResponse, error)
+// NewPostSessionDeleteRequestWithBody generates requests for PostSessionDelete with any type of body
+func NewPostSessionDeleteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	// PostFileSearchWithBodyWithResponse request with any body
-	PostFileSearchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFileSearchResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	PostFileSearchWithResponse(ctx context.Context, body PostFileSearchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFileSearchResponse, error)
+	operationPath := fmt.Sprintf("/session_delete")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// PostInstallationInfoWithResponse request
-	PostInstallationInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostInstallationInfoResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostPathGetWithResponse request
-	PostPathGetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostPathGetResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostProviderListWithResponse request
-	PostProviderListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostProviderListResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	// PostSessionAbortWithBodyWithResponse request with any body
-	PostSessionAbortWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionAbortResponse, error)
+	return req, nil
+}
 
-	PostSessionAbortWithResponse(ctx context.Context, body PostSessionAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionAbortResponse, error)
+// NewPostSessionFeedbackRequest calls the generic PostSessionFeedback builder with application/json body
+func NewPostSessionFeedbackRequest(server string, body PostSessionFeedbackJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionFeedbackRequestWithBody(server, "application/json", bodyReader)
+}
 
-	// PostSessionChatWithBodyWithResponse request with any body
-	PostSessionChatWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionChatResponse, error)
+// NewPostSessionFeedbackRequestWithBody generates requests for PostSessionFeedback with any type of body
+func NewPostSessionFeedbackRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	PostSessionChatWithResponse(ctx context.Context, body PostSessionChatJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionChatResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostSessionCreateWithResponse request
-	PostSessionCreateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSessionCreateResponse, error)
+	operationPath := fmt.Sprintf("/session_feedback")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// PostSessionDeleteWithBodyWithResponse request with any body
-	PostSessionDeleteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionDeleteResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	PostSessionDeleteWithResponse(ctx context.Context, body PostSessionDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionDeleteResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostSessionInitializeWithBodyWithResponse request with any body
-	PostSessionInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionInitializeResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	PostSessionInitializeWithResponse(ctx context.Context, body PostSessionInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionInitializeResponse, error)
+	return req, nil
+}
 
-	// PostSessionListWithResponse request
-	PostSessionListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSessionListResponse, error)
+// NewPostSessionForkRequest calls the generic PostSessionFork builder with application/json body
+func NewPostSessionForkRequest(server string, body PostSessionForkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionForkRequestWithBody(server, "application/json", bodyReader)
+}
 
-	// PostSessionMessagesWithBodyWithResponse request with any body
-	PostSessionMessagesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionMessagesResponse, error)
+// NewPostSessionForkRequestWithBody generates requests for PostSessionFork with any type of body
+func NewPostSessionForkRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-	PostSessionMessagesWithResponse(ctx context.Context, body PostSessionMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionMessagesResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostSessionShareWithBodyWithResponse request with any body
-	PostSessionShareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionShareResponse, error)
+	operationPath := fmt.Sprintf("/session_fork")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	PostSessionShareWithResponse(ctx context.Context, body PostSessionShareJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionShareResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// PostSessionSummarizeWithBodyWithResponse request with any body
-	PostSessionSummarizeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionSummarizeResponse, error)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 
-	PostSessionSummarizeWithResponse(ctx context.Context, body PostSessionSummarizeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionSummarizeResponse, error)
+	req.Header.Add("Content-Type", contentType)
 
-	// PostSessionUnshareWithBodyWithResponse request with any body
-	PostSessionUnshareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionUnshareResponse, error)
+	return req, nil
+}
+
+// NewPostSessionInitializeRequest calls the generic PostSessionInitialize builder with application/json body
+func NewPostSessionInitializeRequest(server string, body PostSessionInitializeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionInitializeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionInitializeRequestWithBody generates requests for PostSessionInitialize with any type of body
+func NewPostSessionInitializeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_initialize")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionListRequest generates requests for PostSessionList
+func NewPostSessionListRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_list")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostSessionMergeRequest calls the generic PostSessionMerge builder with application/json body
+func NewPostSessionMergeRequest(server string, body PostSessionMergeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionMergeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionMergeRequestWithBody generates requests for PostSessionMerge with any type of body
+func NewPostSessionMergeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_merge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionMessagesRequest calls the generic PostSessionMessages builder with application/json body
+func NewPostSessionMessagesRequest(server string, body PostSessionMessagesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionMessagesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionMessagesRequestWithBody generates requests for PostSessionMessages with any type of body
+func NewPostSessionMessagesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_messages")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionRevertRequest calls the generic PostSessionRevert builder with application/json body
+func NewPostSessionRevertRequest(server string, body PostSessionRevertJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionRevertRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionRevertRequestWithBody generates requests for PostSessionRevert with any type of body
+func NewPostSessionRevertRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_revert")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionShareRequest calls the generic PostSessionShare builder with application/json body
+func NewPostSessionShareRequest(server string, body PostSessionShareJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionShareRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionShareRequestWithBody generates requests for PostSessionShare with any type of body
+func NewPostSessionShareRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_share")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionSummarizeRequest calls the generic PostSessionSummarize builder with application/json body
+func NewPostSessionSummarizeRequest(server string, body PostSessionSummarizeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionSummarizeRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionSummarizeRequestWithBody generates requests for PostSessionSummarize with any type of body
+func NewPostSessionSummarizeRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_summarize")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionTrimRequest calls the generic PostSessionTrim builder with application/json body
+func NewPostSessionTrimRequest(server string, body PostSessionTrimJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionTrimRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionTrimRequestWithBody generates requests for PostSessionTrim with any type of body
+func NewPostSessionTrimRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_trim")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionUndoRequest calls the generic PostSessionUndo builder with application/json body
+func NewPostSessionUndoRequest(server string, body PostSessionUndoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionUndoRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionUndoRequestWithBody generates requests for PostSessionUndo with any type of body
+func NewPostSessionUndoRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_undo")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostSessionUnshareRequest calls the generic PostSessionUnshare builder with application/json body
+func NewPostSessionUnshareRequest(server string, body PostSessionUnshareJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostSessionUnshareRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostSessionUnshareRequestWithBody generates requests for PostSessionUnshare with any type of body
+func NewPostSessionUnshareRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/session_unshare")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAppContextWithResponse request
+	GetAppContextWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAppContextResponse, error)
+
+	// PutAppContextWithBodyWithResponse request with any body
+	PutAppContextWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAppContextResponse, error)
+
+	PutAppContextWithResponse(ctx context.Context, body PutAppContextJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAppContextResponse, error)
+
+	// PostAppInfoWithResponse request
+	PostAppInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAppInfoResponse, error)
+
+	// PostAppInitializeWithResponse request
+	PostAppInitializeWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAppInitializeResponse, error)
+
+	// PostConfigGetWithResponse request
+	PostConfigGetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostConfigGetResponse, error)
+
+	// GetEventWithResponse request
+	GetEventWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetEventResponse, error)
+
+	// PostFileSearchWithBodyWithResponse request with any body
+	PostFileSearchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostFileSearchResponse, error)
+
+	PostFileSearchWithResponse(ctx context.Context, body PostFileSearchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostFileSearchResponse, error)
+
+	// PostInstallationInfoWithResponse request
+	PostInstallationInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostInstallationInfoResponse, error)
+
+	// PostPathGetWithResponse request
+	PostPathGetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostPathGetResponse, error)
+
+	// PostPermissionRespondWithBodyWithResponse request with any body
+	PostPermissionRespondWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPermissionRespondResponse, error)
+
+	PostPermissionRespondWithResponse(ctx context.Context, body PostPermissionRespondJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPermissionRespondResponse, error)
+
+	// PostProviderAuthWithBodyWithResponse request with any body
+	PostProviderAuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostProviderAuthResponse, error)
+
+	PostProviderAuthWithResponse(ctx context.Context, body PostProviderAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*PostProviderAuthResponse, error)
+
+	// PostProviderListWithResponse request
+	PostProviderListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostProviderListResponse, error)
+
+	// PostSessionAbortWithBodyWithResponse request with any body
+	PostSessionAbortWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionAbortResponse, error)
+
+	PostSessionAbortWithResponse(ctx context.Context, body PostSessionAbortJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionAbortResponse, error)
+
+	// PostSessionAuditWithBodyWithResponse request with any body
+	PostSessionAuditWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionAuditResponse, error)
+
+	PostSessionAuditWithResponse(ctx context.Context, body PostSessionAuditJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionAuditResponse, error)
+
+	// PostSessionChangesWithBodyWithResponse request with any body
+	PostSessionChangesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionChangesResponse, error)
+
+	PostSessionChangesWithResponse(ctx context.Context, body PostSessionChangesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionChangesResponse, error)
+
+	// PostSessionChatWithBodyWithResponse request with any body
+	PostSessionChatWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionChatResponse, error)
+
+	PostSessionChatWithResponse(ctx context.Context, body PostSessionChatJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionChatResponse, error)
+
+	// PostSessionCreateWithResponse request
+	PostSessionCreateWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSessionCreateResponse, error)
+
+	// PostSessionDeleteWithBodyWithResponse request with any body
+	PostSessionDeleteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionDeleteResponse, error)
+
+	PostSessionDeleteWithResponse(ctx context.Context, body PostSessionDeleteJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionDeleteResponse, error)
+
+	// PostSessionFeedbackWithBodyWithResponse request with any body
+	PostSessionFeedbackWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionFeedbackResponse, error)
+
+	PostSessionFeedbackWithResponse(ctx context.Context, body PostSessionFeedbackJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionFeedbackResponse, error)
+
+	// PostSessionForkWithBodyWithResponse request with any body
+	PostSessionForkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionForkResponse, error)
+
+	PostSessionForkWithResponse(ctx context.Context, body PostSessionForkJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionForkResponse, error)
+
+	// PostSessionInitializeWithBodyWithResponse request with any body
+	PostSessionInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionInitializeResponse, error)
+
+	PostSessionInitializeWithResponse(ctx context.Context, body PostSessionInitializeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionInitializeResponse, error)
+
+	// PostSessionListWithResponse request
+	PostSessionListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostSessionListResponse, error)
+
+	// PostSessionMergeWithBodyWithResponse request with any body
+	PostSessionMergeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionMergeResponse, error)
+
+	PostSessionMergeWithResponse(ctx context.Context, body PostSessionMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionMergeResponse, error)
+
+	// PostSessionMessagesWithBodyWithResponse request with any body
+	PostSessionMessagesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionMessagesResponse, error)
+
+	PostSessionMessagesWithResponse(ctx context.Context, body PostSessionMessagesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionMessagesResponse, error)
+
+	// PostSessionRevertWithBodyWithResponse request with any body
+	PostSessionRevertWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionRevertResponse, error)
+
+	PostSessionRevertWithResponse(ctx context.Context, body PostSessionRevertJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionRevertResponse, error)
+
+	// PostSessionShareWithBodyWithResponse request with any body
+	PostSessionShareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionShareResponse, error)
+
+	PostSessionShareWithResponse(ctx context.Context, body PostSessionShareJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionShareResponse, error)
+
+	// PostSessionSummarizeWithBodyWithResponse request with any body
+	PostSessionSummarizeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionSummarizeResponse, error)
+
+	PostSessionSummarizeWithResponse(ctx context.Context, body PostSessionSummarizeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionSummarizeResponse, error)
+
+	// PostSessionTrimWithBodyWithResponse request with any body
+	PostSessionTrimWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionTrimResponse, error)
+
+	PostSessionTrimWithResponse(ctx context.Context, body PostSessionTrimJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionTrimResponse, error)
+
+	// PostSessionUndoWithBodyWithResponse request with any body
+	PostSessionUndoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionUndoResponse, error)
+
+	PostSessionUndoWithResponse(ctx context.Context, body PostSessionUndoJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionUndoResponse, error)
+
+	// PostSessionUnshareWithBodyWithResponse request with any body
+	PostSessionUnshareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionUnshareResponse, error)
 
 	PostSessionUnshareWithResponse(ctx context.Context, body PostSessionUnshareJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionUnshareResponse, error)
 }
 
+type GetAppContextResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Content *string `json:"content,omitempty"`
+		Path    string  `json:"path"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAppContextResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAppContextResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAppContextResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *bool
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAppContextResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAppContextResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type PostAppInfoResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2844,21 +3962,250 @@ func (r PostAppInfoResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostAppInfoResponse) StatusCode() int {
+func (r PostAppInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAppInitializeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *bool
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAppInitializeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAppInitializeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostConfigGetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConfigInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostConfigGetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostConfigGetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEventResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Event
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEventResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEventResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostFileSearchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]string
+}
+
+// Status returns HTTPResponse.Status
+func (r PostFileSearchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostFileSearchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostInstallationInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InstallationInfo
+}
+
+// Status returns HTTPResponse.Status
+func (r PostInstallationInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostInstallationInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPathGetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Config string `json:"config"`
+		Cwd    string `json:"cwd"`
+		Data   string `json:"data"`
+		Root   string `json:"root"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPathGetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPathGetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostPermissionRespondResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *bool
+}
+
+// Status returns HTTPResponse.Status
+func (r PostPermissionRespondResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostPermissionRespondResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostProviderAuthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *bool
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostProviderAuthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostProviderAuthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostProviderListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Default   map[string]string `json:"default"`
+		Providers []ProviderInfo    `json:"providers"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostProviderListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostProviderListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostSessionAbortResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *bool
+}
+
+// Status returns HTTPResponse.Status
+func (r PostSessionAbortResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostSessionAbortResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostAppInitializeResponse struct {
+type PostSessionAuditResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *bool
+	JSON200      *[]AuditEntry
 }
 
 // Status returns HTTPResponse.Status
-func (r PostAppInitializeResponse) Status() string {
+func (r PostSessionAuditResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2866,21 +4213,21 @@ func (r PostAppInitializeResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostAppInitializeResponse) StatusCode() int {
+func (r PostSessionAuditResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostConfigGetResponse struct {
+type PostSessionChangesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *ConfigInfo
+	JSON200      *[]string
 }
 
 // Status returns HTTPResponse.Status
-func (r PostConfigGetResponse) Status() string {
+func (r PostSessionChangesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2888,21 +4235,21 @@ func (r PostConfigGetResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostConfigGetResponse) StatusCode() int {
+func (r PostSessionChangesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetEventResponse struct {
+type PostSessionChatResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Event
+	JSON200      *MessageInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r GetEventResponse) Status() string {
+func (r PostSessionChatResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2910,21 +4257,22 @@ func (r GetEventResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetEventResponse) StatusCode() int {
+func (r PostSessionChatResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostFileSearchResponse struct {
+type PostSessionCreateResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *[]string
+	JSON200      *SessionInfo
+	JSON400      *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r PostFileSearchResponse) Status() string {
+func (r PostSessionCreateResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2932,21 +4280,21 @@ func (r PostFileSearchResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostFileSearchResponse) StatusCode() int {
+func (r PostSessionCreateResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostInstallationInfoResponse struct {
+type PostSessionDeleteResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *InstallationInfo
+	JSON200      *bool
 }
 
 // Status returns HTTPResponse.Status
-func (r PostInstallationInfoResponse) Status() string {
+func (r PostSessionDeleteResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2954,26 +4302,21 @@ func (r PostInstallationInfoResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostInstallationInfoResponse) StatusCode() int {
+func (r PostSessionDeleteResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostPathGetResponse struct {
+type PostSessionFeedbackResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *struct {
-		Config string `json:"config"`
-		Cwd    string `json:"cwd"`
-		Data   string `json:"data"`
-		Root   string `json:"root"`
-	}
+	JSON200      *MessageInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostPathGetResponse) Status() string {
+func (r PostSessionFeedbackResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -2981,24 +4324,21 @@ func (r PostPathGetResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostPathGetResponse) StatusCode() int {
+func (r PostSessionFeedbackResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostProviderListResponse struct {
+type PostSessionForkResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *struct {
-		Default   map[string]string `json:"default"`
-		Providers []ProviderInfo    `json:"providers"`
-	}
+	JSON200      *SessionInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostProviderListResponse) Status() string {
+func (r PostSessionForkResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3006,21 +4346,21 @@ func (r PostProviderListResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostProviderListResponse) StatusCode() int {
+func (r PostSessionForkResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionAbortResponse struct {
+type PostSessionInitializeResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *bool
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionAbortResponse) Status() string {
+func (r PostSessionInitializeResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3028,21 +4368,21 @@ func (r PostSessionAbortResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionAbortResponse) StatusCode() int {
+func (r PostSessionInitializeResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionChatResponse struct {
+type PostSessionListResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *MessageInfo
+	JSON200      *[]SessionInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionChatResponse) Status() string {
+func (r PostSessionListResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3050,22 +4390,21 @@ func (r PostSessionChatResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionChatResponse) StatusCode() int {
+func (r PostSessionListResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionCreateResponse struct {
+type PostSessionMergeResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *SessionInfo
-	JSON400      *Error
+	JSON200      *[]MessageInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionCreateResponse) Status() string {
+func (r PostSessionMergeResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3073,21 +4412,21 @@ func (r PostSessionCreateResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionCreateResponse) StatusCode() int {
+func (r PostSessionMergeResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionDeleteResponse struct {
+type PostSessionMessagesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *bool
+	JSON200      *[]MessageInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionDeleteResponse) Status() string {
+func (r PostSessionMessagesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3095,21 +4434,21 @@ func (r PostSessionDeleteResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionDeleteResponse) StatusCode() int {
+func (r PostSessionMessagesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionInitializeResponse struct {
+type PostSessionRevertResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *bool
+	JSON200      *[]string
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionInitializeResponse) Status() string {
+func (r PostSessionRevertResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3117,21 +4456,21 @@ func (r PostSessionInitializeResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionInitializeResponse) StatusCode() int {
+func (r PostSessionRevertResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionListResponse struct {
+type PostSessionShareResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *[]SessionInfo
+	JSON200      *SessionInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionListResponse) Status() string {
+func (r PostSessionShareResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3139,21 +4478,21 @@ func (r PostSessionListResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionListResponse) StatusCode() int {
+func (r PostSessionShareResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionMessagesResponse struct {
+type PostSessionSummarizeResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *[]MessageInfo
+	JSON200      *bool
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionMessagesResponse) Status() string {
+func (r PostSessionSummarizeResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3161,21 +4500,21 @@ func (r PostSessionMessagesResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionMessagesResponse) StatusCode() int {
+func (r PostSessionSummarizeResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionShareResponse struct {
+type PostSessionTrimResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *SessionInfo
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionShareResponse) Status() string {
+func (r PostSessionTrimResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3183,21 +4522,21 @@ func (r PostSessionShareResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionShareResponse) StatusCode() int {
+func (r PostSessionTrimResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostSessionSummarizeResponse struct {
+type PostSessionUndoResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *bool
+	JSON200      *[]string
 }
 
 // Status returns HTTPResponse.Status
-func (r PostSessionSummarizeResponse) Status() string {
+func (r PostSessionUndoResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -3205,7 +4544,7 @@ func (r PostSessionSummarizeResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostSessionSummarizeResponse) StatusCode() int {
+func (r PostSessionUndoResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -3234,6 +4573,32 @@ func (r PostSessionUnshareResponse) StatusCode() int {
 	return 0
 }
 
+// GetAppContextWithResponse request returning *GetAppContextResponse
+func (c *ClientWithResponses) GetAppContextWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAppContextResponse, error) {
+	rsp, err := c.GetAppContext(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAppContextResponse(rsp)
+}
+
+// PutAppContextWithBodyWithResponse request with arbitrary body returning *PutAppContextResponse
+func (c *ClientWithResponses) PutAppContextWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAppContextResponse, error) {
+	rsp, err := c.PutAppContextWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAppContextResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAppContextWithResponse(ctx context.Context, body PutAppContextJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAppContextResponse, error) {
+	rsp, err := c.PutAppContext(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAppContextResponse(rsp)
+}
+
 // PostAppInfoWithResponse request returning *PostAppInfoResponse
 func (c *ClientWithResponses) PostAppInfoWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostAppInfoResponse, error) {
 	rsp, err := c.PostAppInfo(ctx, reqEditors...)
@@ -3305,6 +4670,40 @@ func (c *ClientWithResponses) PostPathGetWithResponse(ctx context.Context, reqEd
 	return ParsePostPathGetResponse(rsp)
 }
 
+// PostPermissionRespondWithBodyWithResponse request with arbitrary body returning *PostPermissionRespondResponse
+func (c *ClientWithResponses) PostPermissionRespondWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostPermissionRespondResponse, error) {
+	rsp, err := c.PostPermissionRespondWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPermissionRespondResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostPermissionRespondWithResponse(ctx context.Context, body PostPermissionRespondJSONRequestBody, reqEditors ...RequestEditorFn) (*PostPermissionRespondResponse, error) {
+	rsp, err := c.PostPermissionRespond(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostPermissionRespondResponse(rsp)
+}
+
+// PostProviderAuthWithBodyWithResponse request with arbitrary body returning *PostProviderAuthResponse
+func (c *ClientWithResponses) PostProviderAuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostProviderAuthResponse, error) {
+	rsp, err := c.PostProviderAuthWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostProviderAuthResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostProviderAuthWithResponse(ctx context.Context, body PostProviderAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*PostProviderAuthResponse, error) {
+	rsp, err := c.PostProviderAuth(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostProviderAuthResponse(rsp)
+}
+
 // PostProviderListWithResponse request returning *PostProviderListResponse
 func (c *ClientWithResponses) PostProviderListWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostProviderListResponse, error) {
 	rsp, err := c.PostProviderList(ctx, reqEditors...)
@@ -3331,6 +4730,40 @@ func (c *ClientWithResponses) PostSessionAbortWithResponse(ctx context.Context,
 	return ParsePostSessionAbortResponse(rsp)
 }
 
+// PostSessionAuditWithBodyWithResponse request with arbitrary body returning *PostSessionAuditResponse
+func (c *ClientWithResponses) PostSessionAuditWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionAuditResponse, error) {
+	rsp, err := c.PostSessionAuditWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionAuditResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionAuditWithResponse(ctx context.Context, body PostSessionAuditJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionAuditResponse, error) {
+	rsp, err := c.PostSessionAudit(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionAuditResponse(rsp)
+}
+
+// PostSessionChangesWithBodyWithResponse request with arbitrary body returning *PostSessionChangesResponse
+func (c *ClientWithResponses) PostSessionChangesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionChangesResponse, error) {
+	rsp, err := c.PostSessionChangesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionChangesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionChangesWithResponse(ctx context.Context, body PostSessionChangesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionChangesResponse, error) {
+	rsp, err := c.PostSessionChanges(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionChangesResponse(rsp)
+}
+
 // PostSessionChatWithBodyWithResponse request with arbitrary body returning *PostSessionChatResponse
 func (c *ClientWithResponses) PostSessionChatWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionChatResponse, error) {
 	rsp, err := c.PostSessionChatWithBody(ctx, contentType, body, reqEditors...)
@@ -3374,6 +4807,40 @@ func (c *ClientWithResponses) PostSessionDeleteWithResponse(ctx context.Context,
 	return ParsePostSessionDeleteResponse(rsp)
 }
 
+// PostSessionFeedbackWithBodyWithResponse request with arbitrary body returning *PostSessionFeedbackResponse
+func (c *ClientWithResponses) PostSessionFeedbackWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionFeedbackResponse, error) {
+	rsp, err := c.PostSessionFeedbackWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionFeedbackResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionFeedbackWithResponse(ctx context.Context, body PostSessionFeedbackJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionFeedbackResponse, error) {
+	rsp, err := c.PostSessionFeedback(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionFeedbackResponse(rsp)
+}
+
+// PostSessionForkWithBodyWithResponse request with arbitrary body returning *PostSessionForkResponse
+func (c *ClientWithResponses) PostSessionForkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionForkResponse, error) {
+	rsp, err := c.PostSessionForkWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionForkResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionForkWithResponse(ctx context.Context, body PostSessionForkJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionForkResponse, error) {
+	rsp, err := c.PostSessionFork(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionForkResponse(rsp)
+}
+
 // PostSessionInitializeWithBodyWithResponse request with arbitrary body returning *PostSessionInitializeResponse
 func (c *ClientWithResponses) PostSessionInitializeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionInitializeResponse, error) {
 	rsp, err := c.PostSessionInitializeWithBody(ctx, contentType, body, reqEditors...)
@@ -3400,6 +4867,23 @@ func (c *ClientWithResponses) PostSessionListWithResponse(ctx context.Context, r
 	return ParsePostSessionListResponse(rsp)
 }
 
+// PostSessionMergeWithBodyWithResponse request with arbitrary body returning *PostSessionMergeResponse
+func (c *ClientWithResponses) PostSessionMergeWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionMergeResponse, error) {
+	rsp, err := c.PostSessionMergeWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionMergeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionMergeWithResponse(ctx context.Context, body PostSessionMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionMergeResponse, error) {
+	rsp, err := c.PostSessionMerge(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionMergeResponse(rsp)
+}
+
 // PostSessionMessagesWithBodyWithResponse request with arbitrary body returning *PostSessionMessagesResponse
 func (c *ClientWithResponses) PostSessionMessagesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionMessagesResponse, error) {
 	rsp, err := c.PostSessionMessagesWithBody(ctx, contentType, body, reqEditors...)
@@ -3417,6 +4901,23 @@ func (c *ClientWithResponses) PostSessionMessagesWithResponse(ctx context.Contex
 	return ParsePostSessionMessagesResponse(rsp)
 }
 
+// PostSessionRevertWithBodyWithResponse request with arbitrary body returning *PostSessionRevertResponse
+func (c *ClientWithResponses) PostSessionRevertWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionRevertResponse, error) {
+	rsp, err := c.PostSessionRevertWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionRevertResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionRevertWithResponse(ctx context.Context, body PostSessionRevertJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionRevertResponse, error) {
+	rsp, err := c.PostSessionRevert(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionRevertResponse(rsp)
+}
+
 // PostSessionShareWithBodyWithResponse request with arbitrary body returning *PostSessionShareResponse
 func (c *ClientWithResponses) PostSessionShareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionShareResponse, error) {
 	rsp, err := c.PostSessionShareWithBody(ctx, contentType, body, reqEditors...)
@@ -3451,6 +4952,40 @@ func (c *ClientWithResponses) PostSessionSummarizeWithResponse(ctx context.Conte
 	return ParsePostSessionSummarizeResponse(rsp)
 }
 
+// PostSessionTrimWithBodyWithResponse request with arbitrary body returning *PostSessionTrimResponse
+func (c *ClientWithResponses) PostSessionTrimWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionTrimResponse, error) {
+	rsp, err := c.PostSessionTrimWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionTrimResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionTrimWithResponse(ctx context.Context, body PostSessionTrimJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionTrimResponse, error) {
+	rsp, err := c.PostSessionTrim(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionTrimResponse(rsp)
+}
+
+// PostSessionUndoWithBodyWithResponse request with arbitrary body returning *PostSessionUndoResponse
+func (c *ClientWithResponses) PostSessionUndoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionUndoResponse, error) {
+	rsp, err := c.PostSessionUndoWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionUndoResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostSessionUndoWithResponse(ctx context.Context, body PostSessionUndoJSONRequestBody, reqEditors ...RequestEditorFn) (*PostSessionUndoResponse, error) {
+	rsp, err := c.PostSessionUndo(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostSessionUndoResponse(rsp)
+}
+
 // PostSessionUnshareWithBodyWithResponse request with arbitrary body returning *PostSessionUnshareResponse
 func (c *ClientWithResponses) PostSessionUnshareWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostSessionUnshareResponse, error) {
 	rsp, err := c.PostSessionUnshareWithBody(ctx, contentType, body, reqEditors...)
@@ -3465,7 +5000,62 @@ func (c *ClientWithResponses) PostSessionUnshareWithResponse(ctx context.Context
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostSessionUnshareResponse(rsp)
+	return ParsePostSessionUnshareResponse(rsp)
+}
+
+// ParseGetAppContextResponse parses an HTTP response from a GetAppContextWithResponse call
+func ParseGetAppContextResponse(rsp *http.Response) (*GetAppContextResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAppContextResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Content *string `json:"content,omitempty"`
+			Path    string  `json:"path"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutAppContextResponse parses an HTTP response from a PutAppContextWithResponse call
+func ParsePutAppContextResponse(rsp *http.Response) (*PutAppContextResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutAppContextResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest bool
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
 // ParsePostAppInfoResponse parses an HTTP response from a PostAppInfoWithResponse call
@@ -3655,6 +5245,65 @@ func ParsePostPathGetResponse(rsp *http.Response) (*PostPathGetResponse, error)
 	return response, nil
 }
 
+// ParsePostPermissionRespondResponse parses an HTTP response from a PostPermissionRespondWithResponse call
+func ParsePostPermissionRespondResponse(rsp *http.Response) (*PostPermissionRespondResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostPermissionRespondResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest bool
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostProviderAuthResponse parses an HTTP response from a PostProviderAuthWithResponse call
+func ParsePostProviderAuthResponse(rsp *http.Response) (*PostProviderAuthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostProviderAuthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest bool
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostProviderListResponse parses an HTTP response from a PostProviderListWithResponse call
 func ParsePostProviderListResponse(rsp *http.Response) (*PostProviderListResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3710,6 +5359,58 @@ func ParsePostSessionAbortResponse(rsp *http.Response) (*PostSessionAbortRespons
 	return response, nil
 }
 
+// ParsePostSessionAuditResponse parses an HTTP response from a PostSessionAuditWithResponse call
+func ParsePostSessionAuditResponse(rsp *http.Response) (*PostSessionAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSessionChangesResponse parses an HTTP response from a PostSessionChangesWithResponse call
+func ParsePostSessionChangesResponse(rsp *http.Response) (*PostSessionChangesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionChangesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostSessionChatResponse parses an HTTP response from a PostSessionChatWithResponse call
 func ParsePostSessionChatResponse(rsp *http.Response) (*PostSessionChatResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3795,6 +5496,58 @@ func ParsePostSessionDeleteResponse(rsp *http.Response) (*PostSessionDeleteRespo
 	return response, nil
 }
 
+// ParsePostSessionFeedbackResponse parses an HTTP response from a PostSessionFeedbackWithResponse call
+func ParsePostSessionFeedbackResponse(rsp *http.Response) (*PostSessionFeedbackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionFeedbackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSessionForkResponse parses an HTTP response from a PostSessionForkWithResponse call
+func ParsePostSessionForkResponse(rsp *http.Response) (*PostSessionForkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionForkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SessionInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostSessionInitializeResponse parses an HTTP response from a PostSessionInitializeWithResponse call
 func ParsePostSessionInitializeResponse(rsp *http.Response) (*PostSessionInitializeResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3847,6 +5600,32 @@ func ParsePostSessionListResponse(rsp *http.Response) (*PostSessionListResponse,
 	return response, nil
 }
 
+// ParsePostSessionMergeResponse parses an HTTP response from a PostSessionMergeWithResponse call
+func ParsePostSessionMergeResponse(rsp *http.Response) (*PostSessionMergeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionMergeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []MessageInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostSessionMessagesResponse parses an HTTP response from a PostSessionMessagesWithResponse call
 func ParsePostSessionMessagesResponse(rsp *http.Response) (*PostSessionMessagesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3873,6 +5652,32 @@ func ParsePostSessionMessagesResponse(rsp *http.Response) (*PostSessionMessagesR
 	return response, nil
 }
 
+// ParsePostSessionRevertResponse parses an HTTP response from a PostSessionRevertWithResponse call
+func ParsePostSessionRevertResponse(rsp *http.Response) (*PostSessionRevertResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionRevertResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostSessionShareResponse parses an HTTP response from a PostSessionShareWithResponse call
 func ParsePostSessionShareResponse(rsp *http.Response) (*PostSessionShareResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -3925,6 +5730,58 @@ func ParsePostSessionSummarizeResponse(rsp *http.Response) (*PostSessionSummariz
 	return response, nil
 }
 
+// ParsePostSessionTrimResponse parses an HTTP response from a PostSessionTrimWithResponse call
+func ParsePostSessionTrimResponse(rsp *http.Response) (*PostSessionTrimResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionTrimResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SessionInfo
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostSessionUndoResponse parses an HTTP response from a PostSessionUndoWithResponse call
+func ParsePostSessionUndoResponse(rsp *http.Response) (*PostSessionUndoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostSessionUndoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostSessionUnshareResponse parses an HTTP response from a PostSessionUnshareWithResponse call
 func ParsePostSessionUnshareResponse(rsp *http.Response) (*PostSessionUnshareResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
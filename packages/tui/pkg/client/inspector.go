@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	inspectorMaxCalls = 200
+	inspectorMaxBody  = 2048
+)
+
+// Call is a request made by the client as recorded by an Inspector
+type Call struct {
+	Operation string
+	Method    string
+	Path      string
+	Status    int
+	Started   time.Time
+	Duration  time.Duration
+	Request   string
+	Response  string
+	Error     error
+}
+
+// Inspector records the most recent client calls with truncated bodies, to
+// debug disagreements between the TUI and the server
+type Inspector struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func NewInspector() *Inspector {
+	return &Inspector{}
+}
+
+// Calls returns the recorded calls, oldest first
+func (i *Inspector) Calls() []Call {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	calls := make([]Call, len(i.calls))
+	copy(calls, i.calls)
+	return calls
+}
+
+func (i *Inspector) record(call Call) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.calls = append(i.calls, call)
+	if len(i.calls) > inspectorMaxCalls {
+		i.calls = i.calls[len(i.calls)-inspectorMaxCalls:]
+	}
+}
+
+// operationName returns the generated client method for a request path,
+// e.g. POST /session_chat is PostSessionChat
+func operationName(method, path string) string {
+	var name strings.Builder
+	name.WriteString(strings.ToUpper(method[:1]) + strings.ToLower(method[1:]))
+	for part := range strings.FieldsFuncSeq(path, func(r rune) bool { return r == '/' || r == '_' }) {
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return name.String()
+}
+
+// redactBody hides the values of secret looking keys of a JSON body, such as
+// the API key sent to /provider_auth
+func redactBody(body []byte) []byte {
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return body
+	}
+	redacted, err := json.Marshal(redactValue(decoded, false))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func truncateBody(body []byte) string {
+	if len(body) > inspectorMaxBody {
+		return string(body[:inspectorMaxBody]) + "…"
+	}
+	return string(body)
+}
+
+type inspectorTransport struct {
+	base      http.RoundTripper
+	inspector *Inspector
+}
+
+func (t *inspectorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := Call{
+		Operation: operationName(req.Method, req.URL.Path),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Started:   time.Now(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		call.Request = truncateBody(redactBody(body))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	call.Duration = time.Since(call.Started)
+	if err != nil {
+		call.Error = err
+		t.inspector.record(call)
+		return resp, err
+	}
+	call.Status = resp.StatusCode
+	// The event stream never ends, so only the headers are recorded
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		call.Response = "(event stream)"
+		t.inspector.record(call)
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	call.Duration = time.Since(call.Started)
+	call.Response = truncateBody(body)
+	call.Error = err
+	t.inspector.record(call)
+	return resp, nil
+}
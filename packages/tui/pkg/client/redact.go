@@ -0,0 +1,60 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// secretKeys are the parts of config keys whose values are redacted
+var secretKeys = []string{"key", "token", "secret", "password", "auth", "credential"}
+
+// Redact returns a value encoded as JSON with the values of secret looking
+// keys, environments and headers replaced
+func Redact(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return redactValue(decoded, false)
+}
+
+func redactValue(value any, secret bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			lower := strings.ToLower(key)
+			hidden := secret || lower == "environment" || lower == "env" || lower == "headers"
+			for _, part := range secretKeys {
+				hidden = hidden || (strings.Contains(lower, part) && lower != "keybinds")
+			}
+			value[key] = redactValue(child, hidden)
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = redactValue(child, secret)
+		}
+		return value
+	case string:
+		if secret && value != "" {
+			return "[redacted]"
+		}
+		return RedactURL(value)
+	}
+	return value
+}
+
+// RedactURL hides the credentials of a URL such as a proxy
+func RedactURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.User == nil || parsed.Host == "" {
+		return value
+	}
+	parsed.User = url.User("redacted")
+	return parsed.String()
+}